@@ -12,15 +12,17 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/elementsproject/glightning/gbitcoin"
 	"github.com/elementsproject/glightning/glightning"
 	"github.com/elementsproject/glightning/jrpc2"
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/elementsproject/peerswap/lightning"
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/messages"
 	"github.com/elementsproject/peerswap/onchain"
+	"github.com/elementsproject/peerswap/peerswaprpc"
 	"github.com/elementsproject/peerswap/peersync"
 	"github.com/elementsproject/peerswap/premium"
+	"github.com/elementsproject/peerswap/scheduler"
 	"github.com/elementsproject/peerswap/swap"
 	"github.com/elementsproject/peerswap/wallet"
 	goerrors "github.com/go-errors/errors"
@@ -32,6 +34,7 @@ var methods = []peerswaprpcMethod{
 	&ListPeers{},
 	&LiquidSendToAddress{},
 	&GetSwap{},
+	&GetSwapHistory{},
 	&ListActiveSwaps{},
 	&AllowSwapRequests{},
 	&AddPeer{},
@@ -39,7 +42,19 @@ var methods = []peerswaprpcMethod{
 	&AddSuspiciousPeer{},
 	&RemoveSuspiciousPeer{},
 	&SwapIn{},
+	&SwapInBatch{},
 	&SwapOut{},
+	&RetrySwap{},
+	&FinalizeSwapFunding{},
+	&ListQueuedSwaps{},
+	&RemoveQueuedSwap{},
+	&ListDeferredSwaps{},
+	&CreateSwapSchedule{},
+	&ListSwapSchedules{},
+	&PauseSwapSchedule{},
+	&DeleteSwapSchedule{},
+	&Drain{},
+	&GetStatus{},
 	&ListSwaps{},
 	&LiquidGetAddress{},
 	&LiquidGetBalance{},
@@ -65,22 +80,32 @@ type SendPayPartWaiter interface {
 
 // ClightningClient is the main driver behind c-lightnings plugins system
 // it handles rpc calls and messages
+// RawTxSender broadcasts bitcoin transactions. It is fulfilled by bitcoind
+// and by an electrum server.
+type RawTxSender interface {
+	SendRawTx(txHex string) (string, error)
+}
+
 type ClightningClient struct {
 	version    string
 	glightning *glightning.Lightning
 	Plugin     *glightning.Plugin
 
 	liquidWallet   wallet.Wallet
+	liquidElectrum electrum.RPC
 	swaps          *swap.SwapService
 	requestedSwaps *swap.RequestedSwapsPrinter
 	policy         PolicyReloader
 	peerSync       *peersync.PeerSync
 	peerStore      *peersync.Store
 	ps             *premium.Setting
+	scheduler      *scheduler.Scheduler
+	drainer        *peerswaprpc.Drainer
 
-	gbitcoin       *gbitcoin.Bitcoin
-	bitcoinChain   *onchain.BitcoinOnChain
-	bitcoinNetwork *chaincfg.Params
+	bitcoinTxSender RawTxSender
+	bitcoinElectrum electrum.RPC
+	bitcoinChain    *onchain.BitcoinOnChain
+	bitcoinNetwork  *chaincfg.Params
 
 	msgHandlers     []func(peerId string, messageType string, payload []byte) error
 	paymenthandlers []func(swapId string, invoiceType swap.InvoiceType)
@@ -295,6 +320,23 @@ func (cl *ClightningClient) ReceivableMsat(scid string) (uint64, error) {
 	return 0, fmt.Errorf("could not find a channel with scid: %s", scid)
 }
 
+// ChannelPeer returns the node id of the remote peer of the channel with the
+// given scid.
+func (cl *ClightningClient) ChannelPeer(scid string) (string, error) {
+	scid = lightning.Scid(scid).ClnStyle()
+	var res ListPeerChannelsResponse
+	err := cl.glightning.Request(ListPeerChannelsRequest{}, &res)
+	if err != nil {
+		return "", err
+	}
+	for _, ch := range res.Channels {
+		if ch.ShortChannelId == scid {
+			return ch.PeerId, nil
+		}
+	}
+	return "", fmt.Errorf("could not find a channel with scid: %s", scid)
+}
+
 // checkChannel performs a set of sanity checks id the channel is eligible for
 // a swap of amtSat
 func (cl *ClightningClient) checkChannel(ch PeerChannel) error {
@@ -323,20 +365,24 @@ func (cl *ClightningClient) GetPreimage() (lightning.Preimage, error) {
 }
 
 // SetupClients injects the required services
-func (cl *ClightningClient) SetupClients(liquidWallet wallet.Wallet,
+func (cl *ClightningClient) SetupClients(liquidWallet wallet.Wallet, liquidElectrum electrum.RPC,
 	swaps *swap.SwapService,
 	policy PolicyReloader, requestedSwaps *swap.RequestedSwapsPrinter,
-	bitcoin *gbitcoin.Bitcoin, bitcoinChain *onchain.BitcoinOnChain, peerSync *peersync.PeerSync, peerStore *peersync.Store,
-	ps *premium.Setting) {
+	bitcoinTxSender RawTxSender, bitcoinElectrum electrum.RPC, bitcoinChain *onchain.BitcoinOnChain, peerSync *peersync.PeerSync, peerStore *peersync.Store,
+	ps *premium.Setting, scheduler *scheduler.Scheduler) {
 	cl.liquidWallet = liquidWallet
+	cl.liquidElectrum = liquidElectrum
 	cl.requestedSwaps = requestedSwaps
 	cl.swaps = swaps
 	cl.policy = policy
-	cl.gbitcoin = bitcoin
+	cl.bitcoinTxSender = bitcoinTxSender
+	cl.bitcoinElectrum = bitcoinElectrum
 	cl.peerSync = peerSync
 	cl.peerStore = peerStore
 	cl.bitcoinChain = bitcoinChain
 	cl.ps = ps
+	cl.scheduler = scheduler
+	cl.drainer = peerswaprpc.NewDrainer(swaps, cl.Plugin.Stop)
 	if cl.bitcoinChain != nil {
 		cl.bitcoinNetwork = bitcoinChain.GetChain()
 	}
@@ -540,13 +586,80 @@ func buildDirectClaimRoute(
 }
 
 // RebalancePayment handles the lightning payment that should re-balance the
-// channel.
+// channels. An invoice that is paid over several channels is sent as a
+// multi-part payment with one part per channel.
 func (cl *ClightningClient) RebalancePayment(
 	payreq string,
-	channel string,
+	channels []string,
 	maxTotalCLTVDelta uint32,
 ) (preimage string, err error) {
-	return cl.payInvoiceViaChannel(payreq, channel, maxTotalCLTVDelta)
+	if len(channels) == 1 {
+		return cl.payInvoiceViaChannel(payreq, channels[0], maxTotalCLTVDelta)
+	}
+	return cl.payInvoiceViaChannels(payreq, channels, maxTotalCLTVDelta)
+}
+
+// payInvoiceViaChannels splits the invoice amount over the direct channels to
+// the peer and sends each part with `sendpay` along its channel.
+func (cl *ClightningClient) payInvoiceViaChannels(
+	payreq string,
+	scids []string,
+	maxTotalCLTVDelta uint32,
+) (preimage string, err error) {
+	bolt11, err := cl.glightning.DecodeBolt11(payreq)
+	if err != nil {
+		return "", err
+	}
+
+	spendable := make([]uint64, len(scids))
+	for i, scid := range scids {
+		spendable[i], err = cl.SpendableMsat(scid)
+		if err != nil {
+			return "", err
+		}
+	}
+	parts, err := swap.SplitAmountMsat(bolt11.AmountMsat.MSat(), spendable)
+	if err != nil {
+		return "", err
+	}
+
+	label := randomString()
+	var partIds []uint64
+	for i, part := range parts {
+		if part == 0 {
+			continue
+		}
+		route, err := buildDirectClaimRoute(bolt11, scids[i], maxTotalCLTVDelta)
+		if err != nil {
+			return "", err
+		}
+		route[0].AmountMsat = glightning.AmountFromMSat(part)
+
+		// Part ids start at 1, 0 is used by single part payments.
+		partId := uint64(i + 1)
+		_, err = cl.glightning.SendPay(
+			route,
+			bolt11.PaymentHash,
+			label,
+			bolt11.AmountMsat.MSat(),
+			payreq,
+			bolt11.PaymentSecret,
+			partId,
+		)
+		if err != nil {
+			return "", err
+		}
+		partIds = append(partIds, partId)
+	}
+
+	for _, partId := range partIds {
+		res, err := cl.glightning.WaitSendPayPart(bolt11.PaymentHash, 0, partId)
+		if err != nil {
+			return "", err
+		}
+		preimage = res.PaymentPreimage
+	}
+	return preimage, nil
 }
 
 // RecoverClaimPayment waits for an already-created outgoing payment without
@@ -608,8 +721,12 @@ func (cl *ClightningClient) onInit(plugin *glightning.Plugin, options map[string
 
 // OnConnect is called after the connect event. The
 // handler sends out a poll to the peer it connected
-// to.
+// to and starts the deferred swaps with the peer.
 func (cl *ClightningClient) OnConnect(connectEvent *glightning.ConnectEvent) {
+	if cl.swaps != nil {
+		cl.swaps.OnPeerOnline(lo.Ternary(connectEvent.PeerId != "",
+			connectEvent.PeerId, connectEvent.Conn.PeerId))
+	}
 	go func() {
 		for {
 			time.Sleep(10 * time.Second)
@@ -633,6 +750,21 @@ func (cl *ClightningClient) OnConnect(connectEvent *glightning.ConnectEvent) {
 	}()
 }
 
+// StartDeferredSwaps starts the deferred swaps with peers that are already
+// connected.
+func (cl *ClightningClient) StartDeferredSwaps() error {
+	peers, err := cl.glightning.ListPeers()
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		if peer.Connected {
+			cl.swaps.OnPeerOnline(peer.Id)
+		}
+	}
+	return nil
+}
+
 func (cl *ClightningClient) OnShutdown() {
 	cl.Plugin.Stop()
 }
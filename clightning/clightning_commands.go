@@ -16,6 +16,7 @@ import (
 	"github.com/elementsproject/peerswap/peersync"
 	"github.com/elementsproject/peerswap/peersync/format"
 	"github.com/elementsproject/peerswap/premium"
+	"github.com/elementsproject/peerswap/scheduler"
 
 	"github.com/elementsproject/glightning/glightning"
 	"github.com/elementsproject/glightning/jrpc2"
@@ -180,7 +181,20 @@ type SwapOut struct {
 	Asset               string            `json:"asset"`
 	PremiumLimitRatePPM int64             `json:"premium_rate_limit_ppm"`
 	Force               bool              `json:"force"`
+	Max                 bool              `json:"max"`
+	LeaveLocalSat       uint64            `json:"leave_local_sat"`
+	DryRun              bool              `json:"dry_run"`
+	PeerId              string            `json:"peer_id"`
+	Queue               bool              `json:"queue"`
+	DeferUntilOnline    bool              `json:"defer_until_online"`
+	DeferExpirySec      uint64            `json:"defer_expiry_sec"`
+	IdempotencyKey      string            `json:"idempotency_key"`
+	ExtraChannelIds     []string          `json:"extra_short_channel_ids"`
+	ClaimAddress        string            `json:"claim_address"`
+	SatPerVbyte         float64           `json:"sat_per_vbyte"`
+	TargetConf          uint32            `json:"target_conf"`
 	cl                  *ClightningClient `json:"-"`
+	opts                []swap.SwapOption
 }
 
 func (l *SwapOut) New() interface{} {
@@ -198,11 +212,46 @@ func (l *SwapOut) Call() (jrpc2.Result, error) {
 		return nil, ErrWaitingForReady
 	}
 
-	if l.SatAmt <= 0 {
+	if l.SatAmt <= 0 && !l.Max {
 		return nil, errors.New("Missing required amt_sat parameter")
 	}
+	if l.ShortChannelId == "" && l.PeerId == "" {
+		return nil, errors.New("Missing required short_channel_id or peer_id parameter")
+	}
+
+	if l.IdempotencyKey != "" && !l.DryRun {
+		defer l.cl.swaps.LockIdempotencyKey(l.IdempotencyKey)()
+		res, err := l.cl.idempotentSwapResponse(l.IdempotencyKey, swap.SWAPTYPE_OUT)
+		if err != nil || res != nil {
+			return res, err
+		}
+		l.opts = append(l.opts, swap.WithIdempotencyKey(l.IdempotencyKey))
+	}
+	if l.ClaimAddress != "" {
+		l.opts = append(l.opts, swap.WithClaimAddress(l.ClaimAddress))
+	}
+	feeRate, err := swap.NewFeeRate(l.SatPerVbyte, l.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	if feeRate != nil {
+		l.opts = append(l.opts, swap.WithFeeRate(feeRate))
+	}
+
+	var selection *peerswaprpc.ChannelSelection
 	if l.ShortChannelId == "" {
-		return nil, errors.New("Missing required short_channel_id parameter")
+		var err error
+		l.ShortChannelId, selection, err = l.cl.selectChannel(l.PeerId,
+			func(candidates []swap.ChannelCandidate) (string, []*swap.ChannelChoice, error) {
+				return l.cl.swaps.SelectSwapOutChannel(l.Asset, candidates, l.SatAmt)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if l.DryRun {
+		return l.cl.preflightSwap(swap.SWAPTYPE_OUT, l.ShortChannelId, l.SatAmt, l.Asset, l.PremiumLimitRatePPM)
 	}
 
 	funds, err := l.cl.glightning.ListFunds()
@@ -219,11 +268,34 @@ func (l *SwapOut) Call() (jrpc2.Result, error) {
 	if fundingChannels == nil {
 		return nil, errors.New("fundingChannels not found")
 	}
+	// The channel of a deferred swap is not connected while the peer is
+	// offline.
+	deferSwap := l.DeferUntilOnline && !l.cl.isPeerConnected(fundingChannels.Id)
 
-	if fundingChannels.AmountMilliSatoshi.MSat() < (l.SatAmt+5000)*1000 {
+	outboundMsat := fundingChannels.AmountMilliSatoshi.MSat()
+	if len(l.ExtraChannelIds) > 0 {
+		if l.Max {
+			return nil, errors.New("max can not be combined with extra channels")
+		}
+		extraMsat, err := extraSwapOutChannels(funds.Channels, fundingChannels, l.ExtraChannelIds, deferSwap)
+		if err != nil {
+			return nil, err
+		}
+		outboundMsat += extraMsat
+		l.opts = append(l.opts, swap.WithExtraChannels(l.ExtraChannelIds...))
+	}
+
+	if l.Max {
+		l.SatAmt, err = l.cl.swaps.MaxSwapOutAmountSat(l.Asset, l.ShortChannelId, l.LeaveLocalSat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if outboundMsat < (l.SatAmt+5000)*1000 {
 		return nil, errors.New("not enough outbound capacity to perform swapOut")
 	}
-	if !fundingChannels.Connected {
+	if !fundingChannels.Connected && !deferSwap {
 		return nil, errors.New("fundingChannels is not connected")
 	}
 
@@ -234,7 +306,7 @@ func (l *SwapOut) Call() (jrpc2.Result, error) {
 		}
 	}
 
-	if !l.cl.isPeerConnected(fundingChannels.Id) {
+	if !deferSwap && !l.cl.isPeerConnected(fundingChannels.Id) {
 		return nil, fmt.Errorf("peer is not connected")
 	}
 
@@ -255,9 +327,35 @@ func (l *SwapOut) Call() (jrpc2.Result, error) {
 	}
 
 	pk := l.cl.GetNodeId()
-	swapOut, err := l.cl.swaps.SwapOut(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM)
-	if err != nil {
-		return nil, err
+	if deferSwap {
+		deferred, err := l.cl.swaps.DeferSwapOut(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM,
+			time.Duration(l.DeferExpirySec)*time.Second, l.opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &peerswaprpc.SwapResponse{
+			QueuedSwap:       peerswaprpc.QueuedSwapFromServiceQueuedSwap(deferred),
+			ChannelSelection: selection,
+		}, nil
+	}
+	var swapOut *swap.SwapStateMachine
+	if l.Queue {
+		var queued *swap.QueuedSwap
+		swapOut, queued, err = l.cl.swaps.QueueSwapOut(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM, l.opts...)
+		if err != nil {
+			return nil, err
+		}
+		if queued != nil {
+			return &peerswaprpc.SwapResponse{
+				QueuedSwap:       peerswaprpc.QueuedSwapFromServiceQueuedSwap(queued),
+				ChannelSelection: selection,
+			}, nil
+		}
+	} else {
+		swapOut, err = l.cl.swaps.SwapOut(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM, l.opts...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// In order to be responsive we wait for the `opening_tx` to be sent before
 	// we return. Time out if we wait too long.
@@ -278,7 +376,10 @@ func (l *SwapOut) Call() (jrpc2.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	return peerswaprpc.PrettyprintFromServiceSwap(swapOut), nil
+	return &peerswaprpc.SwapResponse{
+		Swap:             peerswaprpc.PrettyprintFromServiceSwap(swapOut),
+		ChannelSelection: selection,
+	}, nil
 }
 
 func (l *SwapOut) Description() string {
@@ -295,6 +396,38 @@ func (g *SwapOut) Get(client *ClightningClient) jrpc2.ServerMethod {
 	}
 }
 
+// extraSwapOutChannels returns the summed outbound capacity of the extra
+// channels of a swap-out. They must be further connected channels to the
+// peer of the swap channel.
+func extraSwapOutChannels(channels []*glightning.FundingChannel, swapChannel *glightning.FundingChannel, extraIds []string, allowDisconnected bool) (uint64, error) {
+	seen := map[string]bool{swapChannel.ShortChannelId: true}
+	var outboundMsat uint64
+	for _, id := range extraIds {
+		if seen[id] {
+			return 0, fmt.Errorf("channel %s is used twice", id)
+		}
+		seen[id] = true
+		var extra *glightning.FundingChannel
+		for _, v := range channels {
+			if v.ShortChannelId == id {
+				extra = v
+				break
+			}
+		}
+		if extra == nil {
+			return 0, fmt.Errorf("channel %s not found", id)
+		}
+		if extra.Id != swapChannel.Id {
+			return 0, fmt.Errorf("channel %s is not a channel to peer %s", id, swapChannel.Id)
+		}
+		if !extra.Connected && !allowDisconnected {
+			return 0, fmt.Errorf("channel %s is not connected", id)
+		}
+		outboundMsat += extra.AmountMilliSatoshi.MSat()
+	}
+	return outboundMsat, nil
+}
+
 // SwapIn Starts a new swap in(providing onchain liquidity)
 type SwapIn struct {
 	ShortChannelId      string            `json:"short_channel_id"`
@@ -302,7 +435,22 @@ type SwapIn struct {
 	Asset               string            `json:"asset"`
 	PremiumLimitRatePPM int64             `json:"premium_limit_ppm"`
 	Force               bool              `json:"force"`
+	Max                 bool              `json:"max"`
+	LeaveLocalSat       uint64            `json:"leave_local_sat"`
+	DryRun              bool              `json:"dry_run"`
+	PeerId              string            `json:"peer_id"`
+	Queue               bool              `json:"queue"`
+	DeferUntilOnline    bool              `json:"defer_until_online"`
+	DeferExpirySec      uint64            `json:"defer_expiry_sec"`
+	IdempotencyKey      string            `json:"idempotency_key"`
+	ClaimAddress        string            `json:"claim_address"`
+	SatPerVbyte         float64           `json:"sat_per_vbyte"`
+	TargetConf          uint32            `json:"target_conf"`
+	Utxos               []string          `json:"utxos"`
+	ExcludeUtxos        []string          `json:"exclude_utxos"`
+	ExternalFunding     bool              `json:"external_funding"`
 	cl                  *ClightningClient `json:"-"`
+	opts                []swap.SwapOption
 }
 
 func (l *SwapIn) New() interface{} {
@@ -320,12 +468,57 @@ func (l *SwapIn) Call() (jrpc2.Result, error) {
 		return nil, ErrWaitingForReady
 	}
 
-	if l.SatAmt <= 0 {
+	if l.SatAmt <= 0 && !l.Max {
 		return nil, errors.New("Missing required amt_sat parameter")
 	}
 
+	if l.ShortChannelId == "" && l.PeerId == "" {
+		return nil, errors.New("Missing required short_channel_id or peer_id parameter")
+	}
+
+	if l.IdempotencyKey != "" && !l.DryRun {
+		defer l.cl.swaps.LockIdempotencyKey(l.IdempotencyKey)()
+		res, err := l.cl.idempotentSwapResponse(l.IdempotencyKey, swap.SWAPTYPE_IN)
+		if err != nil || res != nil {
+			return res, err
+		}
+		l.opts = append(l.opts, swap.WithIdempotencyKey(l.IdempotencyKey))
+	}
+	if l.ClaimAddress != "" {
+		l.opts = append(l.opts, swap.WithClaimAddress(l.ClaimAddress))
+	}
+	feeRate, err := swap.NewFeeRate(l.SatPerVbyte, l.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	if feeRate != nil {
+		l.opts = append(l.opts, swap.WithFeeRate(feeRate))
+	}
+	coinSelection, err := swap.NewCoinSelection(l.Utxos, l.ExcludeUtxos)
+	if err != nil {
+		return nil, err
+	}
+	if coinSelection != nil {
+		l.opts = append(l.opts, swap.WithCoinSelection(coinSelection))
+	}
+	if l.ExternalFunding {
+		l.opts = append(l.opts, swap.WithExternalFunding())
+	}
+
+	var selection *peerswaprpc.ChannelSelection
 	if l.ShortChannelId == "" {
-		return nil, errors.New("Missing required short_channel_id parameter")
+		var err error
+		l.ShortChannelId, selection, err = l.cl.selectChannel(l.PeerId,
+			func(candidates []swap.ChannelCandidate) (string, []*swap.ChannelChoice, error) {
+				return l.cl.swaps.SelectSwapInChannel(candidates, l.SatAmt)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if l.DryRun {
+		return l.cl.preflightSwap(swap.SWAPTYPE_IN, l.ShortChannelId, l.SatAmt, l.Asset, l.PremiumLimitRatePPM)
 	}
 
 	funds, err := l.cl.glightning.ListFunds()
@@ -342,10 +535,20 @@ func (l *SwapIn) Call() (jrpc2.Result, error) {
 	if fundingChannels == nil {
 		return nil, errors.New("fundingChannels not found")
 	}
+	// The channel of a deferred swap is not connected while the peer is
+	// offline.
+	deferSwap := l.DeferUntilOnline && !l.cl.isPeerConnected(fundingChannels.Id)
+
+	if l.Max {
+		l.SatAmt, err = l.cl.swaps.MaxSwapInAmountSat(l.Asset, l.ShortChannelId, l.LeaveLocalSat)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if fundingChannels.AmountMilliSatoshi.MSat()-fundingChannels.OurAmountMilliSatoshi.MSat() < (l.SatAmt * 1000) {
 		return nil, errors.New("not enough inbound capacity to perform swap")
 	}
-	if !fundingChannels.Connected {
+	if !fundingChannels.Connected && !deferSwap {
 		return nil, errors.New("fundingChannels is not connected")
 	}
 
@@ -356,7 +559,7 @@ func (l *SwapIn) Call() (jrpc2.Result, error) {
 		}
 	}
 
-	if !l.cl.isPeerConnected(fundingChannels.Id) {
+	if !deferSwap && !l.cl.isPeerConnected(fundingChannels.Id) {
 		return nil, fmt.Errorf("peer is not connected")
 	}
 	switch l.Asset {
@@ -373,9 +576,35 @@ func (l *SwapIn) Call() (jrpc2.Result, error) {
 	}
 
 	pk := l.cl.GetNodeId()
-	swapIn, err := l.cl.swaps.SwapIn(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM)
-	if err != nil {
-		return nil, err
+	if deferSwap {
+		deferred, err := l.cl.swaps.DeferSwapIn(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM,
+			time.Duration(l.DeferExpirySec)*time.Second, l.opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &peerswaprpc.SwapResponse{
+			QueuedSwap:       peerswaprpc.QueuedSwapFromServiceQueuedSwap(deferred),
+			ChannelSelection: selection,
+		}, nil
+	}
+	var swapIn *swap.SwapStateMachine
+	if l.Queue {
+		var queued *swap.QueuedSwap
+		swapIn, queued, err = l.cl.swaps.QueueSwapIn(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM, l.opts...)
+		if err != nil {
+			return nil, err
+		}
+		if queued != nil {
+			return &peerswaprpc.SwapResponse{
+				QueuedSwap:       peerswaprpc.QueuedSwapFromServiceQueuedSwap(queued),
+				ChannelSelection: selection,
+			}, nil
+		}
+	} else {
+		swapIn, err = l.cl.swaps.SwapIn(fundingChannels.Id, l.Asset, l.ShortChannelId, pk, l.SatAmt, l.PremiumLimitRatePPM, l.opts...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// In order to be responsive we wait for the `opening_tx` to be sent before
 	// we return. Time out if we wait too long.
@@ -396,7 +625,10 @@ func (l *SwapIn) Call() (jrpc2.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	return peerswaprpc.PrettyprintFromServiceSwap(swapIn), nil
+	return &peerswaprpc.SwapResponse{
+		Swap:             peerswaprpc.PrettyprintFromServiceSwap(swapIn),
+		ChannelSelection: selection,
+	}, nil
 }
 
 func (l *SwapIn) Description() string {
@@ -413,6 +645,140 @@ func (g *SwapIn) Get(client *ClightningClient) jrpc2.ServerMethod {
 	}
 }
 
+// SwapInBatch starts swap-ins with several peers that share a single opening
+// transaction.
+type SwapInBatch struct {
+	Swaps []*BatchSwapInEntry `json:"swaps"`
+	Asset string              `json:"asset"`
+	Force bool                `json:"force"`
+	cl    *ClightningClient   `json:"-"`
+}
+
+// BatchSwapInEntry is a swap-in of a SwapInBatch call.
+type BatchSwapInEntry struct {
+	ShortChannelId      string `json:"short_channel_id"`
+	SatAmt              uint64 `json:"amt_sat"`
+	PremiumLimitRatePPM int64  `json:"premium_limit_ppm"`
+}
+
+func (l *SwapInBatch) New() interface{} {
+	return &SwapInBatch{
+		cl: l.cl,
+	}
+}
+
+func (l *SwapInBatch) Name() string {
+	return "peerswap-swap-in-batch"
+}
+
+func (l *SwapInBatch) Call() (jrpc2.Result, error) {
+	if !l.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	switch l.Asset {
+	case "lbtc":
+		if !l.cl.swaps.LiquidEnabled {
+			return nil, errors.New("liquid swaps are not enabled")
+		}
+	case "btc":
+		if !l.cl.swaps.BitcoinEnabled {
+			return nil, errors.New("bitcoin swaps are not enabled")
+		}
+	default:
+		return nil, errors.New("invalid asset (btc or lbtc)")
+	}
+
+	funds, err := l.cl.glightning.ListFunds()
+	if err != nil {
+		return nil, err
+	}
+	var swaps []*swap.BatchSwapIn
+	for _, entry := range l.Swaps {
+		if entry.SatAmt <= 0 {
+			return nil, errors.New("Missing required amt_sat parameter")
+		}
+		var fundingChannels *glightning.FundingChannel
+		for _, v := range funds.Channels {
+			if v.ShortChannelId == entry.ShortChannelId {
+				fundingChannels = v
+				break
+			}
+		}
+		if fundingChannels == nil {
+			return nil, fmt.Errorf("fundingChannels %s not found", entry.ShortChannelId)
+		}
+		if fundingChannels.AmountMilliSatoshi.MSat()-fundingChannels.OurAmountMilliSatoshi.MSat() < (entry.SatAmt * 1000) {
+			return nil, fmt.Errorf("not enough inbound capacity on %s to perform swap", entry.ShortChannelId)
+		}
+		if !fundingChannels.Connected {
+			return nil, fmt.Errorf("fundingChannels %s is not connected", entry.ShortChannelId)
+		}
+		// Skip this check when `force` is set.
+		if !l.Force {
+			if l.cl.peerSync == nil || !l.cl.peerSync.HasCompatiblePeer(fundingChannels.Id) {
+				return nil, fmt.Errorf("peer %s does not run peerswap", fundingChannels.Id)
+			}
+		}
+		if !l.cl.isPeerConnected(fundingChannels.Id) {
+			return nil, fmt.Errorf("peer %s is not connected", fundingChannels.Id)
+		}
+		swaps = append(swaps, &swap.BatchSwapIn{
+			PeerId:              fundingChannels.Id,
+			ChannelId:           entry.ShortChannelId,
+			AmountSat:           entry.SatAmt,
+			PremiumLimitRatePpm: entry.PremiumLimitRatePPM,
+		})
+	}
+
+	batchId, results, err := l.cl.swaps.SwapInBatch(l.Asset, l.cl.GetNodeId(), swaps)
+	if err != nil {
+		return nil, err
+	}
+
+	// In order to be responsive we wait for the `opening_tx` to be sent before
+	// we return. Time out if we wait too long.
+	deadline := time.Now().Add(30 * time.Second)
+	resp := &peerswaprpc.SwapInBatchResponse{BatchId: batchId}
+	for _, res := range results {
+		if res.Err != nil {
+			resp.Results = append(resp.Results, &peerswaprpc.BatchSwapInResult{Error: res.Err.Error()})
+			continue
+		}
+		var errMsg string
+		res.Swap.WaitForStateChange(func(st swap.StateType) bool {
+			switch st {
+			case swap.State_SwapInSender_SendTxBroadcastedMessage:
+				return true
+			case swap.State_SwapCanceled:
+				errMsg = res.Swap.Data.GetCancelMessage()
+				return true
+			default:
+				return false
+			}
+		}, time.Until(deadline))
+		resp.Results = append(resp.Results, &peerswaprpc.BatchSwapInResult{
+			Swap:  peerswaprpc.PrettyprintFromServiceSwap(res.Swap),
+			Error: errMsg,
+		})
+	}
+	return resp, nil
+}
+
+func (l *SwapInBatch) Description() string {
+	return "Initiates swap ins with several peers that share one opening transaction"
+}
+
+func (l *SwapInBatch) LongDescription() string {
+	return ""
+}
+
+func (g *SwapInBatch) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &SwapInBatch{
+		cl: client,
+	}
+}
+
 // ListSwaps list all active and finished swaps
 type ListSwaps struct {
 	DetailedPrint bool              `json:"detailed,omitempty"`
@@ -672,6 +1038,737 @@ func (g *GetSwap) LongDescription() string {
 	return ""
 }
 
+// selectChannel chooses one of the channels to the peer with selectFn. It
+// returns the short channel id of the chosen channel and an explanation of
+// the choice.
+func (cl *ClightningClient) selectChannel(peerId string,
+	selectFn func([]swap.ChannelCandidate) (string, []*swap.ChannelChoice, error)) (string, *peerswaprpc.ChannelSelection, error) {
+	funds, err := cl.glightning.ListFunds()
+	if err != nil {
+		return "", nil, err
+	}
+	var candidates []swap.ChannelCandidate
+	for _, v := range funds.Channels {
+		if v.Id != peerId || v.ShortChannelId == "" {
+			continue
+		}
+		candidates = append(candidates, swap.ChannelCandidate{
+			ChannelId: v.ShortChannelId,
+			Active:    v.Connected && channelActive(v.State),
+		})
+	}
+	if len(candidates) == 0 {
+		return "", nil, errors.New("no channel to peer found")
+	}
+
+	chosen, choices, err := selectFn(candidates)
+	if err != nil {
+		return "", nil, peerswaprpc.ChannelSelectionError(err, choices)
+	}
+	return chosen, peerswaprpc.ChannelSelectionFromChoices(chosen, choices), nil
+}
+
+// idempotentSwapResponse returns the result for the swap that was started
+// with the idempotency key, or nil if there is none.
+func (cl *ClightningClient) idempotentSwapResponse(key string, swapType swap.SwapType) (jrpc2.Result, error) {
+	swp, queued, err := cl.swaps.FindByIdempotencyKey(key, swapType)
+	if err != nil {
+		return nil, err
+	}
+	if swp != nil {
+		return &peerswaprpc.SwapResponse{Swap: peerswaprpc.PrettyprintFromServiceSwap(swp)}, nil
+	}
+	if queued != nil {
+		return &peerswaprpc.SwapResponse{QueuedSwap: peerswaprpc.QueuedSwapFromServiceQueuedSwap(queued)}, nil
+	}
+	return nil, nil
+}
+
+// preflightSwap runs the local checks of a swap and estimates its fees without
+// starting the swap or sending any message to the peer.
+func (cl *ClightningClient) preflightSwap(swapType swap.SwapType, scid string, amtSat uint64, asset string, premiumLimitRatePpm int64) (*peerswaprpc.PreflightSwapResponse, error) {
+	funds, err := cl.glightning.ListFunds()
+	if err != nil {
+		return nil, err
+	}
+	var fundingChannels *glightning.FundingChannel
+	for _, v := range funds.Channels {
+		if v.ShortChannelId == scid {
+			fundingChannels = v
+			break
+		}
+	}
+	if fundingChannels == nil {
+		report := &swap.PreflightReport{Type: swapType, Asset: asset, ChannelId: scid, AmountSat: amtSat}
+		report.AddCheck("channel_found", errors.New("fundingChannels not found"))
+		return peerswaprpc.PreflightSwapResponseFromReport(report), nil
+	}
+
+	peerId := fundingChannels.Id
+	var report *swap.PreflightReport
+	if swapType == swap.SWAPTYPE_OUT {
+		report = cl.swaps.PreflightSwapOut(peerId, asset, scid, amtSat, premiumLimitRatePpm)
+	} else {
+		report = cl.swaps.PreflightSwapIn(peerId, asset, scid, amtSat, premiumLimitRatePpm)
+	}
+	report.AddCheck("channel_found", nil)
+
+	err = nil
+	if !fundingChannels.Connected {
+		err = errors.New("fundingChannels is not connected")
+	}
+	report.AddCheck("channel_active", err)
+
+	err = nil
+	if cl.peerSync == nil || !cl.peerSync.HasCompatiblePeer(peerId) {
+		err = errors.New("peer does not run peerswap")
+	}
+	report.AddCheck("peer_compatible", err)
+
+	err = nil
+	if !cl.isPeerConnected(peerId) {
+		err = errors.New("peer is not connected")
+	}
+	report.AddCheck("peer_connected", err)
+
+	if asset == "lbtc" && cl.swaps.LiquidEnabled {
+		if ok, perr := cl.liquidWallet.Ping(); perr != nil || !ok {
+			report.AddCheck("liquid_wallet_reachable", fmt.Errorf("liquid wallet not reachable: %v", perr))
+		} else {
+			report.AddCheck("liquid_wallet_reachable", nil)
+		}
+	}
+
+	return peerswaprpc.PreflightSwapResponseFromReport(report), nil
+}
+
+// RetrySwap starts a new swap with the parameters of a finished swap that did
+// not succeed.
+type RetrySwap struct {
+	SwapId              string            `json:"swap_id"`
+	SatAmt              uint64            `json:"amt_sat,omitempty"`
+	PremiumLimitRatePPM int64             `json:"premium_limit_ppm,omitempty"`
+	Force               bool              `json:"force"`
+	cl                  *ClightningClient `json:"-"`
+}
+
+func (r *RetrySwap) Name() string {
+	return "peerswap-retryswap"
+}
+
+func (r *RetrySwap) New() interface{} {
+	return &RetrySwap{
+		cl: r.cl,
+	}
+}
+
+func (r *RetrySwap) Call() (jrpc2.Result, error) {
+	if !r.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if r.SwapId == "" {
+		return nil, errors.New("swap_id required")
+	}
+	params, err := r.cl.swaps.GetRetryParams(r.SwapId, r.SatAmt, r.PremiumLimitRatePPM)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := params.Options()
+	scid := strings.ReplaceAll(params.Scid, ":", "x")
+	var extraScids []string
+	for _, extraScid := range params.ExtraScids {
+		extraScids = append(extraScids, strings.ReplaceAll(extraScid, ":", "x"))
+	}
+	if params.Type == swap.SWAPTYPE_IN {
+		return (&SwapIn{
+			ShortChannelId:      scid,
+			SatAmt:              params.Amount,
+			Asset:               params.Chain,
+			PremiumLimitRatePPM: params.PremiumLimitRatePpm,
+			Force:               r.Force,
+			cl:                  r.cl,
+			opts:                opts,
+		}).Call()
+	}
+	return (&SwapOut{
+		ShortChannelId:      scid,
+		ExtraChannelIds:     extraScids,
+		SatAmt:              params.Amount,
+		Asset:               params.Chain,
+		PremiumLimitRatePPM: params.PremiumLimitRatePpm,
+		Force:               r.Force,
+		cl:                  r.cl,
+		opts:                opts,
+	}).Call()
+}
+
+func (r *RetrySwap) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &RetrySwap{
+		cl: client,
+	}
+}
+
+func (r *RetrySwap) Description() string {
+	return "Retries a canceled or failed swap"
+}
+
+func (r *RetrySwap) LongDescription() string {
+	return "Starts a new swap with the parameters of a finished swap that did not succeed. " +
+		"The amount and premium limit can be overridden."
+}
+
+// FinalizeSwapFunding submits the signed opening transaction of an externally
+// funded swap-in.
+type FinalizeSwapFunding struct {
+	SwapId   string            `json:"swap_id"`
+	SignedTx string            `json:"signed_tx"`
+	cl       *ClightningClient `json:"-"`
+}
+
+func (f *FinalizeSwapFunding) Name() string {
+	return "peerswap-finalizeswapfunding"
+}
+
+func (f *FinalizeSwapFunding) New() interface{} {
+	return &FinalizeSwapFunding{
+		cl: f.cl,
+	}
+}
+
+func (f *FinalizeSwapFunding) Call() (jrpc2.Result, error) {
+	if !f.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if f.SwapId == "" {
+		return nil, errors.New("swap_id required")
+	}
+	if f.SignedTx == "" {
+		return nil, errors.New("signed_tx required")
+	}
+	swap, err := f.cl.swaps.FinalizeSwapFunding(f.SwapId, f.SignedTx)
+	if err != nil {
+		return nil, err
+	}
+	return MSerializedSwapStateMachine(swap), nil
+}
+
+func (f *FinalizeSwapFunding) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &FinalizeSwapFunding{
+		cl: client,
+	}
+}
+
+func (f *FinalizeSwapFunding) Description() string {
+	return "Submits the signed opening transaction of an externally funded swap-in"
+}
+
+func (f *FinalizeSwapFunding) LongDescription() string {
+	return "Validates and broadcasts the signed opening transaction of a swap-in that was started " +
+		"with external_funding. signed_tx is a finalized PSBT (btc) or PSET (lbtc) in base64, " +
+		"or the signed transaction in hex."
+}
+
+type GetSwapHistory struct {
+	SwapId string `json:"swap_id"`
+	cl     *ClightningClient
+}
+
+func (g *GetSwapHistory) Name() string {
+	return "peerswap-getswaphistory"
+}
+
+func (g *GetSwapHistory) New() interface{} {
+	return &GetSwapHistory{
+		cl:     g.cl,
+		SwapId: g.SwapId,
+	}
+}
+
+type SwapHistoryResponse struct {
+	SwapId  string                  `json:"swap_id"`
+	State   swap.StateType          `json:"state"`
+	History []*swap.StateTransition `json:"history"`
+}
+
+func (g *GetSwapHistory) Call() (jrpc2.Result, error) {
+	if !g.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if g.SwapId == "" {
+		return nil, errors.New("swap_id required")
+	}
+	swap, err := g.cl.swaps.GetSwap(g.SwapId)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapHistoryResponse{
+		SwapId:  swap.SwapId.String(),
+		State:   swap.Current,
+		History: swap.History,
+	}, nil
+}
+
+func (g *GetSwapHistory) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &GetSwapHistory{
+		cl: client,
+	}
+}
+
+func (g *GetSwapHistory) Description() string {
+	return "returns the state transition history of a swap"
+}
+
+func (g *GetSwapHistory) LongDescription() string {
+	return ""
+}
+
+type ListQueuedSwaps struct {
+	cl *ClightningClient
+}
+
+func (l *ListQueuedSwaps) Name() string {
+	return "peerswap-listqueuedswaps"
+}
+
+func (l *ListQueuedSwaps) New() interface{} {
+	return &ListQueuedSwaps{
+		cl: l.cl,
+	}
+}
+
+func (l *ListQueuedSwaps) Call() (jrpc2.Result, error) {
+	if !l.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	queue, err := l.cl.swaps.ListQueuedSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var resSwaps []*peerswaprpc.QueuedSwap
+	for _, v := range queue {
+		resSwaps = append(resSwaps, peerswaprpc.QueuedSwapFromServiceQueuedSwap(v))
+	}
+	return &peerswaprpc.ListQueuedSwapsResponse{QueuedSwaps: resSwaps}, nil
+}
+
+func (l *ListQueuedSwaps) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &ListQueuedSwaps{
+		cl: client,
+	}
+}
+
+func (l *ListQueuedSwaps) Description() string {
+	return "lists the swaps that wait for their channel to become free"
+}
+
+func (l *ListQueuedSwaps) LongDescription() string {
+	return ""
+}
+
+type ListDeferredSwaps struct {
+	cl *ClightningClient
+}
+
+func (l *ListDeferredSwaps) Name() string {
+	return "peerswap-listdeferredswaps"
+}
+
+func (l *ListDeferredSwaps) New() interface{} {
+	return &ListDeferredSwaps{
+		cl: l.cl,
+	}
+}
+
+func (l *ListDeferredSwaps) Call() (jrpc2.Result, error) {
+	if !l.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	deferred, err := l.cl.swaps.ListDeferredSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var resSwaps []*peerswaprpc.QueuedSwap
+	for _, v := range deferred {
+		resSwaps = append(resSwaps, peerswaprpc.QueuedSwapFromServiceQueuedSwap(v))
+	}
+	return &peerswaprpc.ListDeferredSwapsResponse{DeferredSwaps: resSwaps}, nil
+}
+
+func (l *ListDeferredSwaps) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &ListDeferredSwaps{
+		cl: client,
+	}
+}
+
+func (l *ListDeferredSwaps) Description() string {
+	return "lists the swaps that wait for their peer to come online"
+}
+
+func (l *ListDeferredSwaps) LongDescription() string {
+	return ""
+}
+
+type RemoveQueuedSwap struct {
+	Id string `json:"id"`
+	cl *ClightningClient
+}
+
+func (r *RemoveQueuedSwap) Name() string {
+	return "peerswap-removequeuedswap"
+}
+
+func (r *RemoveQueuedSwap) New() interface{} {
+	return &RemoveQueuedSwap{
+		cl: r.cl,
+		Id: r.Id,
+	}
+}
+
+func (r *RemoveQueuedSwap) Call() (jrpc2.Result, error) {
+	if !r.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if r.Id == "" {
+		return nil, errors.New("id required")
+	}
+	err := r.cl.swaps.RemoveQueuedSwap(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &peerswaprpc.RemoveQueuedSwapResponse{}, nil
+}
+
+func (r *RemoveQueuedSwap) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &RemoveQueuedSwap{
+		cl: client,
+	}
+}
+
+func (r *RemoveQueuedSwap) Description() string {
+	return "removes a queued or deferred swap before it is started"
+}
+
+func (r *RemoveQueuedSwap) LongDescription() string {
+	return ""
+}
+
+type CreateSwapSchedule struct {
+	Type                string            `json:"type"`
+	ShortChannelId      string            `json:"short_channel_id"`
+	Asset               string            `json:"asset"`
+	SatAmt              uint64            `json:"amt_sat"`
+	Max                 bool              `json:"max"`
+	PremiumLimitRatePPM int64             `json:"premium_rate_limit_ppm"`
+	RunAt               int64             `json:"run_at"`
+	Cron                string            `json:"cron"`
+	IntervalSec         uint64            `json:"interval_sec"`
+	OnlyIfLocalBelowSat uint64            `json:"only_if_local_below_sat"`
+	OnlyIfLocalAboveSat uint64            `json:"only_if_local_above_sat"`
+	cl                  *ClightningClient `json:"-"`
+}
+
+func (c *CreateSwapSchedule) Name() string {
+	return "peerswap-createschedule"
+}
+
+func (c *CreateSwapSchedule) New() interface{} {
+	return &CreateSwapSchedule{
+		cl: c.cl,
+	}
+}
+
+func (c *CreateSwapSchedule) Call() (jrpc2.Result, error) {
+	if !c.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if c.ShortChannelId == "" {
+		return nil, errors.New("Missing required short_channel_id parameter")
+	}
+	var swapType swap.SwapType
+	switch c.Type {
+	case swap.SWAPTYPE_OUT.String():
+		swapType = swap.SWAPTYPE_OUT
+	case swap.SWAPTYPE_IN.String():
+		swapType = swap.SWAPTYPE_IN
+	default:
+		return nil, errors.New("type must be swap-out or swap-in")
+	}
+
+	funds, err := c.cl.glightning.ListFunds()
+	if err != nil {
+		return nil, err
+	}
+	var fundingChannels *glightning.FundingChannel
+	for _, v := range funds.Channels {
+		if v.ShortChannelId == c.ShortChannelId {
+			fundingChannels = v
+			break
+		}
+	}
+	if fundingChannels == nil {
+		return nil, errors.New("fundingChannels not found")
+	}
+
+	schedule, err := c.cl.scheduler.Create(&scheduler.Schedule{
+		Type:                swapType,
+		PeerId:              fundingChannels.Id,
+		ChannelId:           c.ShortChannelId,
+		Asset:               c.Asset,
+		AmountSat:           c.SatAmt,
+		Max:                 c.Max,
+		PremiumLimitRatePpm: c.PremiumLimitRatePPM,
+		RunAt:               c.RunAt,
+		Cron:                c.Cron,
+		IntervalSec:         c.IntervalSec,
+		OnlyIfLocalBelowSat: c.OnlyIfLocalBelowSat,
+		OnlyIfLocalAboveSat: c.OnlyIfLocalAboveSat,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return peerswaprpc.SwapScheduleFromSchedule(schedule), nil
+}
+
+func (c *CreateSwapSchedule) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &CreateSwapSchedule{
+		cl: client,
+	}
+}
+
+func (c *CreateSwapSchedule) Description() string {
+	return "creates a one-shot or recurring swap schedule"
+}
+
+func (c *CreateSwapSchedule) LongDescription() string {
+	return "Exactly one of run_at (unix time), cron (five fields, evaluated in UTC) " +
+		"and interval_sec is required. Runs are skipped unless the local balance " +
+		"of the channel is below only_if_local_below_sat or above only_if_local_above_sat."
+}
+
+type ListSwapSchedules struct {
+	cl *ClightningClient
+}
+
+func (l *ListSwapSchedules) Name() string {
+	return "peerswap-listschedules"
+}
+
+func (l *ListSwapSchedules) New() interface{} {
+	return &ListSwapSchedules{
+		cl: l.cl,
+	}
+}
+
+func (l *ListSwapSchedules) Call() (jrpc2.Result, error) {
+	if !l.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	schedules, err := l.cl.scheduler.List()
+	if err != nil {
+		return nil, err
+	}
+	var resSchedules []*peerswaprpc.SwapSchedule
+	for _, v := range schedules {
+		resSchedules = append(resSchedules, peerswaprpc.SwapScheduleFromSchedule(v))
+	}
+	return &peerswaprpc.ListSwapSchedulesResponse{Schedules: resSchedules}, nil
+}
+
+func (l *ListSwapSchedules) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &ListSwapSchedules{
+		cl: client,
+	}
+}
+
+func (l *ListSwapSchedules) Description() string {
+	return "lists the swap schedules and their run history"
+}
+
+func (l *ListSwapSchedules) LongDescription() string {
+	return ""
+}
+
+type PauseSwapSchedule struct {
+	Id     string            `json:"id"`
+	Resume bool              `json:"resume"`
+	cl     *ClightningClient `json:"-"`
+}
+
+func (p *PauseSwapSchedule) Name() string {
+	return "peerswap-pauseschedule"
+}
+
+func (p *PauseSwapSchedule) New() interface{} {
+	return &PauseSwapSchedule{
+		cl: p.cl,
+	}
+}
+
+func (p *PauseSwapSchedule) Call() (jrpc2.Result, error) {
+	if !p.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if p.Id == "" {
+		return nil, errors.New("id required")
+	}
+	schedule, err := p.cl.scheduler.SetPaused(p.Id, !p.Resume)
+	if err != nil {
+		return nil, err
+	}
+	return peerswaprpc.SwapScheduleFromSchedule(schedule), nil
+}
+
+func (p *PauseSwapSchedule) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &PauseSwapSchedule{
+		cl: client,
+	}
+}
+
+func (p *PauseSwapSchedule) Description() string {
+	return "pauses a swap schedule, or resumes it if resume is set"
+}
+
+func (p *PauseSwapSchedule) LongDescription() string {
+	return ""
+}
+
+type DeleteSwapSchedule struct {
+	Id string            `json:"id"`
+	cl *ClightningClient `json:"-"`
+}
+
+func (d *DeleteSwapSchedule) Name() string {
+	return "peerswap-deleteschedule"
+}
+
+func (d *DeleteSwapSchedule) New() interface{} {
+	return &DeleteSwapSchedule{
+		cl: d.cl,
+	}
+}
+
+func (d *DeleteSwapSchedule) Call() (jrpc2.Result, error) {
+	if !d.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	if d.Id == "" {
+		return nil, errors.New("id required")
+	}
+	err := d.cl.scheduler.Delete(d.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &peerswaprpc.DeleteSwapScheduleResponse{}, nil
+}
+
+func (d *DeleteSwapSchedule) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &DeleteSwapSchedule{
+		cl: client,
+	}
+}
+
+func (d *DeleteSwapSchedule) Description() string {
+	return "deletes a swap schedule"
+}
+
+func (d *DeleteSwapSchedule) LongDescription() string {
+	return ""
+}
+
+type Drain struct {
+	Stop     bool              `json:"stop"`
+	Shutdown bool              `json:"shutdown"`
+	Status   bool              `json:"status"`
+	cl       *ClightningClient `json:"-"`
+}
+
+func (d *Drain) Name() string {
+	return "peerswap-drain"
+}
+
+func (d *Drain) New() interface{} {
+	return &Drain{
+		cl: d.cl,
+	}
+}
+
+func (d *Drain) Call() (jrpc2.Result, error) {
+	if !d.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	return d.cl.drainer.Drain(&peerswaprpc.DrainRequest{
+		Stop:     d.Stop,
+		Shutdown: d.Shutdown,
+		Status:   d.Status,
+	}), nil
+}
+
+func (d *Drain) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &Drain{
+		cl: client,
+	}
+}
+
+func (d *Drain) Description() string {
+	return "blocks new swaps until the active swaps are done"
+}
+
+func (d *Drain) LongDescription() string {
+	return "Rejects incoming swap requests and blocks new outgoing swaps, " +
+		"so that peerswap can be upgraded once the active swaps are done. " +
+		"Call it with status=true to see the progress. With shutdown=true the plugin " +
+		"stops once the last active swap is done, stop=true ends the drain."
+}
+
+type GetStatus struct {
+	cl *ClightningClient `json:"-"`
+}
+
+func (g *GetStatus) Name() string {
+	return "peerswap-status"
+}
+
+func (g *GetStatus) New() interface{} {
+	return &GetStatus{
+		cl: g.cl,
+	}
+}
+
+func (g *GetStatus) Call() (jrpc2.Result, error) {
+	if !g.cl.isReady {
+		return nil, ErrWaitingForReady
+	}
+
+	return peerswaprpc.GetStatusMessage(g.cl.liquidElectrum, g.cl.bitcoinElectrum), nil
+}
+
+func (g *GetStatus) Get(client *ClightningClient) jrpc2.ServerMethod {
+	return &GetStatus{
+		cl: client,
+	}
+}
+
+func (g *GetStatus) Description() string {
+	return "shows the status of the backends"
+}
+
+func (g *GetStatus) LongDescription() string {
+	return "Shows the configured liquid and bitcoin electrum endpoints, " +
+		"which one is in use and the last error of the others."
+}
+
 type PolicyReloader interface {
 	AddToAllowlist(pubkey string) error
 	RemoveFromAllowlist(pubkey string) error
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/elementsproject/glightning/glightning"
 	"github.com/elementsproject/peerswap/lightning"
@@ -24,40 +25,226 @@ func (cl *ClightningClient) CreateOpeningTransaction(swapParams *swap.OpeningPar
 			Satoshi: swapParams.Amount,
 		},
 	}
-	prepRes, err := cl.glightning.PrepareTx(outputs, &glightning.FeeRate{Directive: glightning.Urgent}, nil)
+	prepRes, fee, err := cl.prepareOpeningTransaction(outputs, swapParams.FeeRate, swapParams.CoinSelection)
 	if err != nil {
 		return "", "", "", 0, 0, err
 	}
 
+	_, vout, err = cl.bitcoinChain.GetVoutAndVerify(prepRes.UnsignedTx, swapParams)
+	if err != nil {
+		return "", "", "", 0, 0, err
+	}
+	sendRes, err := cl.glightning.SendTx(prepRes.TxId)
+	if err != nil {
+		return "", "", "", 0, 0, errors.New(fmt.Sprintf("tx was not prepared %v", err))
+	}
+	return sendRes.SignedTx, addr, sendRes.TxId, fee, vout, nil
+}
+
+// CreateBatchOpeningTransaction funds the opening outputs of several swaps
+// with a single transaction. The transaction is only prepared, it is signed
+// when it is broadcast. Its id does not change as cln only spends segwit
+// outputs.
+func (cl *ClightningClient) CreateBatchOpeningTransaction(swapParams []*swap.OpeningParams) (txHex, txId string, fee uint64, batchOutputs []*swap.BatchOutput, err error) {
+	addrs := make([]string, len(swapParams))
+	var outputs []*glightning.Outputs
+	for i, params := range swapParams {
+		addrs[i], err = cl.bitcoinChain.CreateOpeningAddress(params, onchain.BitcoinCsv)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		outputs = append(outputs, &glightning.Outputs{
+			Address: addrs[i],
+			Satoshi: params.Amount,
+		})
+	}
+	prepRes, fee, err := cl.prepareOpeningTransaction(outputs, swap.OpeningFeeRate(swapParams), swap.OpeningCoinSelection(swapParams))
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	for i, params := range swapParams {
+		ok, vout, err := cl.bitcoinChain.GetVoutAndVerify(prepRes.UnsignedTx, params)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		if !ok {
+			return "", "", 0, nil, fmt.Errorf("opening output for %s not found", addrs[i])
+		}
+		batchOutputs = append(batchOutputs, &swap.BatchOutput{Address: addrs[i], Vout: vout})
+	}
+	return prepRes.UnsignedTx, prepRes.TxId, fee, batchOutputs, nil
+}
+
+// BroadcastBatchOpeningTransaction signs and broadcasts a transaction that
+// was prepared by CreateBatchOpeningTransaction and returns the signed
+// transaction.
+func (cl *ClightningClient) BroadcastBatchOpeningTransaction(txHex, txId string) (string, error) {
+	sendRes, err := cl.glightning.SendTx(txId)
+	if err != nil {
+		return "", fmt.Errorf("tx was not prepared %v", err)
+	}
+	return sendRes.SignedTx, nil
+}
+
+// prepareOpeningTransaction prepares a transaction that pays to the outputs
+// and returns it together with its fee. The transaction pays the fee rate of
+// the swap, or an urgent fee rate if it is nil. It is funded from the utxos
+// that the coin selection allows.
+func (cl *ClightningClient) prepareOpeningTransaction(outputs []*glightning.Outputs, feeRate *swap.FeeRate, selection *swap.CoinSelection) (*glightning.TxResult, uint64, error) {
+	txFeeRate := &glightning.FeeRate{Directive: glightning.Urgent}
+	if feeRate != nil {
+		txFeeRate = glightning.NewFeeRate(glightning.PerKw, uint(cl.bitcoinChain.FeeRateSatPerKw(feeRate)))
+	}
+	var utxos []*glightning.Utxo
+	if selection != nil {
+		var err error
+		utxos, err = cl.selectUtxos(outputs, feeRate, selection)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	prepRes, err := cl.glightning.PrepareTxWithUtxos(outputs, txFeeRate, nil, utxos)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Backwards compatibility layer. Since `v23.05`, `preparetx` returns a
 	// psbt v2 instead of v0. We still want to support `v23.02` so we skip the
 	// conversion of the psbt (from v2 to v0).
 	isV2, err := version.CompareVersionStrings(cl.Version(), "v23.05")
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return nil, 0, err
 	}
 	if isV2 {
 		res, err := cl.glightning.SetPSBTVersion(prepRes.Psbt, 0)
 		if err != nil {
-			return "", "", "", 0, 0, err
+			return nil, 0, err
 		}
 		prepRes.Psbt = res.Psbt
 	}
 
-	fee, err = cl.bitcoinChain.GetFeeSatsFromTx(prepRes.Psbt, prepRes.UnsignedTx)
+	fee, err := cl.bitcoinChain.GetFeeSatsFromTx(prepRes.Psbt, prepRes.UnsignedTx)
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return nil, 0, err
 	}
+	return prepRes, fee, nil
+}
 
-	_, vout, err = cl.bitcoinChain.GetVoutAndVerify(prepRes.UnsignedTx, swapParams)
+// CreateFundingPsbt returns an unsigned PSBT that pays the opening output of
+// a swap-in that is funded outside of cln.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (cl *ClightningClient) CreateFundingPsbt(params *swap.OpeningParams) (string, string, error) {
+	return cl.bitcoinChain.CreateFundingPsbt(params)
+}
+
+// ExtractFundingTx returns the opening transaction of a signed PSBT and the
+// vout of the opening output.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (cl *ClightningClient) ExtractFundingTx(signed string, params *swap.OpeningParams) (string, uint32, error) {
+	return cl.bitcoinChain.ExtractFundingTx(signed, params)
+}
+
+// BroadcastFundingTx broadcasts the opening transaction of a swap-in that is
+// funded outside of cln.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (cl *ClightningClient) BroadcastFundingTx(txHex string) (string, error) {
+	return cl.bitcoinTxSender.SendRawTx(txHex)
+}
+
+// ValidateCoinSelection returns an error if one of the selected utxos is
+// not a confirmed unspent output of the wallet.
+//
+// NOTE: This method is part of the swap.CoinSelectionWallet interface.
+func (cl *ClightningClient) ValidateCoinSelection(selection *swap.CoinSelection) error {
+	if len(selection.Utxos) == 0 {
+		return nil
+	}
+	funds, err := cl.confirmedFunds()
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return err
 	}
-	sendRes, err := cl.glightning.SendTx(prepRes.TxId)
+	for _, u := range selection.Utxos {
+		if _, ok := funds[u]; !ok {
+			return fmt.Errorf("utxo %s is not a confirmed unspent output of the wallet", u)
+		}
+	}
+	return nil
+}
+
+// selectUtxos returns the utxos that fund the opening transaction. These are
+// the selected utxos, or the largest confirmed outputs of the wallet that
+// are not excluded and cover the outputs and the fee.
+func (cl *ClightningClient) selectUtxos(outputs []*glightning.Outputs, feeRate *swap.FeeRate, selection *swap.CoinSelection) ([]*glightning.Utxo, error) {
+	funds, err := cl.confirmedFunds()
 	if err != nil {
-		return "", "", "", 0, 0, errors.New(fmt.Sprintf("tx was not prepared %v", err))
+		return nil, err
+	}
+	if len(selection.Utxos) > 0 {
+		var utxos []*glightning.Utxo
+		for _, u := range selection.Utxos {
+			output, ok := funds[u]
+			if !ok {
+				return nil, fmt.Errorf("utxo %s is not a confirmed unspent output of the wallet", u)
+			}
+			utxos = append(utxos, &glightning.Utxo{TxId: output.TxId, Index: uint(output.Output)})
+		}
+		return utxos, nil
 	}
-	return sendRes.SignedTx, addr, sendRes.TxId, fee, vout, nil
+
+	var target uint64
+	for _, o := range outputs {
+		target += o.Satoshi
+	}
+	candidates := make([]*glightning.FundOutput, 0, len(funds))
+	for outpoint, output := range funds {
+		if selection.Allows(outpoint) {
+			candidates = append(candidates, output)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].AmountMilliSatoshi.MSat() > candidates[j].AmountMilliSatoshi.MSat()
+	})
+
+	var (
+		utxos []*glightning.Utxo
+		total uint64
+	)
+	for _, output := range candidates {
+		utxos = append(utxos, &glightning.Utxo{TxId: output.TxId, Index: uint(output.Output)})
+		total += output.AmountMilliSatoshi.MSat() / 1000
+		fee, err := cl.bitcoinChain.GetFeeForRate(int64(onchain.EstimatedOpeningTxSize+p2wpkhInputSize*len(utxos)), feeRate)
+		if err != nil {
+			return nil, err
+		}
+		if total >= target+fee {
+			return utxos, nil
+		}
+	}
+	return nil, errors.New("not enough confirmed funds without the excluded utxos")
+}
+
+// p2wpkhInputSize is the size of a P2WPKH input in vbyte.
+const p2wpkhInputSize = 68
+
+// confirmedFunds returns the confirmed unspent outputs of the wallet by
+// their outpoint.
+func (cl *ClightningClient) confirmedFunds() (map[string]*glightning.FundOutput, error) {
+	funds, err := cl.glightning.ListFunds()
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[string]*glightning.FundOutput{}
+	for _, output := range funds.Outputs {
+		if output.Status != "confirmed" {
+			continue
+		}
+		outputs[fmt.Sprintf("%s:%d", output.TxId, output.Output)] = output
+	}
+	return outputs, nil
 }
 
 func (cl *ClightningClient) CreatePreimageSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (txId, txHex, address string, err error) {
@@ -67,7 +254,7 @@ func (cl *ClightningClient) CreatePreimageSpendingTransaction(swapParams *swap.O
 		return "", "", "", err
 	}
 
-	newAddr, err := cl.glightning.NewAddr()
+	newAddr, err := cl.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -97,7 +284,34 @@ func (cl *ClightningClient) CreatePreimageSpendingTransaction(swapParams *swap.O
 
 	txHex = hex.EncodeToString(bytesBuffer.Bytes())
 
-	txId, err = cl.gbitcoin.SendRawTx(txHex)
+	txId, err = cl.bitcoinTxSender.SendRawTx(txHex)
+	if err != nil {
+		return "", "", "", err
+	}
+	return txId, txHex, newAddr, nil
+}
+
+// CreateBatchSpendingTransaction claims the outputs of several swaps with a
+// single transaction.
+func (cl *ClightningClient) CreateBatchSpendingTransaction(claims []*swap.BatchClaim, claimAddress string) (txId, txHex, address string, err error) {
+	newAddr, err := cl.claimAddress(&swap.ClaimParams{ClaimAddress: claimAddress})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	tx, err := cl.bitcoinChain.CreateBatchSpendingTransaction(claims, newAddr)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	bytesBuffer := new(bytes.Buffer)
+	err = tx.Serialize(bytesBuffer)
+	if err != nil {
+		return "", "", "", err
+	}
+	txHex = hex.EncodeToString(bytesBuffer.Bytes())
+
+	txId, err = cl.bitcoinTxSender.SendRawTx(txHex)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -105,7 +319,7 @@ func (cl *ClightningClient) CreatePreimageSpendingTransaction(swapParams *swap.O
 }
 
 func (cl *ClightningClient) CreateCsvSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (txId, txHex, address string, error error) {
-	newAddr, err := cl.glightning.NewAddr()
+	newAddr, err := cl.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -136,7 +350,7 @@ func (cl *ClightningClient) CreateCsvSpendingTransaction(swapParams *swap.Openin
 
 	txHex = hex.EncodeToString(bytesBuffer.Bytes())
 
-	txId, err = cl.gbitcoin.SendRawTx(txHex)
+	txId, err = cl.bitcoinTxSender.SendRawTx(txHex)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -144,7 +358,7 @@ func (cl *ClightningClient) CreateCsvSpendingTransaction(swapParams *swap.Openin
 }
 
 func (cl *ClightningClient) CreateCoopSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, takerSigner swap.Signer) (txId, txHex, address string, error error) {
-	refundAddr, err := cl.NewAddress()
+	refundAddr, err := cl.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -181,7 +395,7 @@ func (cl *ClightningClient) CreateCoopSpendingTransaction(swapParams *swap.Openi
 
 	txHex = hex.EncodeToString(bytesBuffer.Bytes())
 
-	txId, err = cl.gbitcoin.SendRawTx(txHex)
+	txId, err = cl.bitcoinTxSender.SendRawTx(txHex)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -195,6 +409,15 @@ func (cl *ClightningClient) SetLabel(txID, address, label string) error {
 	return nil
 }
 
+// claimAddress returns the claim address of the swap, or a new wallet address
+// if the swap has none.
+func (cl *ClightningClient) claimAddress(claimParams *swap.ClaimParams) (string, error) {
+	if claimParams.ClaimAddress != "" {
+		return claimParams.ClaimAddress, nil
+	}
+	return cl.NewAddress()
+}
+
 func (cl *ClightningClient) NewAddress() (string, error) {
 	newAddr, err := cl.glightning.NewAddr()
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/elementsproject/glightning/glightning"
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/lwk"
+	"github.com/elementsproject/peerswap/swap"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -37,6 +38,11 @@ type BitcoinConf struct {
 	Network         string
 	DataDir         string
 	BitcoinSwaps    *bool
+	ZmqPubHashBlock string
+	ZmqPubRawTx     string
+	// ElectrumEndpoints replace bitcoind for bitcoin swaps. They are used
+	// in order if the previous ones are down.
+	ElectrumEndpoints []string
 }
 
 type LiquidConf struct {
@@ -49,6 +55,37 @@ type LiquidConf struct {
 	Network         string
 	DataDir         string
 	LiquidSwaps     *bool
+	ZmqPubHashBlock string
+	ZmqPubRawTx     string
+}
+
+// ClaimBatchConf enables the batching of claim transactions. Claims are held
+// back for up to Window to be claimed together with the claims of other
+// swaps.
+type ClaimBatchConf struct {
+	Window             time.Duration
+	SafetyMarginBlocks uint32
+}
+
+// ClaimAddressConf holds the descriptors that the addresses are derived from
+// that claims are sent to instead of the node wallet.
+type ClaimAddressConf struct {
+	BtcDescriptor  string
+	LbtcDescriptor string
+}
+
+// FeeEstimatesConf adds /fee-estimates endpoints to the bitcoin fee
+// estimation. Mode is max or median.
+type FeeEstimatesConf struct {
+	Urls []string
+	Mode string
+}
+
+// FeeRateConf holds the default fee rates of the opening and claim
+// transactions of swaps. A nil fee rate uses the wallet estimate.
+type FeeRateConf struct {
+	Btc  *swap.FeeRate
+	Lbtc *swap.FeeRate
 }
 
 type Config struct {
@@ -59,6 +96,10 @@ type Config struct {
 	Bitcoin      *BitcoinConf
 	Liquid       *LiquidConf
 	LWK          *lwk.Conf
+	ClaimBatch   *ClaimBatchConf
+	ClaimAddress *ClaimAddressConf
+	FeeEstimates *FeeEstimatesConf
+	FeeRate      *FeeRateConf
 }
 
 func (c Config) String() string {
@@ -194,8 +235,18 @@ func ReadFromFile() Processor {
 		}
 
 		var fileConf struct {
-			Bitcoin *BitcoinConf
-			Liquid  *LiquidConf
+			Bitcoin    *BitcoinConf
+			Liquid     *LiquidConf
+			ClaimBatch *struct {
+				Window             string
+				SafetyMarginBlocks *uint32
+			}
+			ClaimAddress *ClaimAddressConf
+			FeeEstimates *FeeEstimatesConf
+			FeeRate      *struct {
+				Btc  string
+				Lbtc string
+			}
 		}
 
 		err = toml.Unmarshal(data, &fileConf)
@@ -210,6 +261,9 @@ func ReadFromFile() Processor {
 			c.Bitcoin.RpcHost = fileConf.Bitcoin.RpcHost
 			c.Bitcoin.RpcPort = fileConf.Bitcoin.RpcPort
 			c.Bitcoin.BitcoinSwaps = fileConf.Bitcoin.BitcoinSwaps
+			c.Bitcoin.ZmqPubHashBlock = fileConf.Bitcoin.ZmqPubHashBlock
+			c.Bitcoin.ZmqPubRawTx = fileConf.Bitcoin.ZmqPubRawTx
+			c.Bitcoin.ElectrumEndpoints = fileConf.Bitcoin.ElectrumEndpoints
 		}
 
 		if fileConf.Liquid != nil {
@@ -220,6 +274,35 @@ func ReadFromFile() Processor {
 			c.Liquid.RpcPort = fileConf.Liquid.RpcPort
 			c.Liquid.RpcWallet = fileConf.Liquid.RpcWallet
 			c.Liquid.LiquidSwaps = fileConf.Liquid.LiquidSwaps
+			c.Liquid.ZmqPubHashBlock = fileConf.Liquid.ZmqPubHashBlock
+			c.Liquid.ZmqPubRawTx = fileConf.Liquid.ZmqPubRawTx
+		}
+
+		if fileConf.ClaimBatch != nil && fileConf.ClaimBatch.Window != "" {
+			window, err := time.ParseDuration(fileConf.ClaimBatch.Window)
+			if err != nil {
+				return nil, fmt.Errorf("invalid claim batch window: %w", err)
+			}
+			c.ClaimBatch = &ClaimBatchConf{
+				Window:             window,
+				SafetyMarginBlocks: swap.DefaultClaimBatchSafetyMarginBlocks,
+			}
+			if fileConf.ClaimBatch.SafetyMarginBlocks != nil {
+				c.ClaimBatch.SafetyMarginBlocks = *fileConf.ClaimBatch.SafetyMarginBlocks
+			}
+		}
+		c.ClaimAddress = fileConf.ClaimAddress
+		c.FeeEstimates = fileConf.FeeEstimates
+		if fileConf.FeeRate != nil {
+			c.FeeRate = &FeeRateConf{}
+			c.FeeRate.Btc, err = swap.ParseFeeRate(fileConf.FeeRate.Btc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid btc fee rate: %w", err)
+			}
+			c.FeeRate.Lbtc, err = swap.ParseFeeRate(fileConf.FeeRate.Lbtc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid lbtc fee rate: %w", err)
+			}
 		}
 		lc, err := LWKConfigFromToml(filepath.Join(c.PeerswapDir, defaultConfigFileName))
 		if err != nil {
@@ -240,8 +323,11 @@ func LWKConfigFromToml(filePath string) (*lwk.Conf, error) {
 		WalletName       string
 		LWKEndpoint      string
 		ElectrumEndpoint string
-		Network          string
-		LiquidSwaps      *bool
+		// FallbackElectrumEndpoints are used in order if the electrum
+		// endpoint is down.
+		FallbackElectrumEndpoints []string
+		Network                   string
+		LiquidSwaps               *bool
 	}
 	var cfg struct {
 		LWK *LwkConfig
@@ -289,6 +375,13 @@ func LWKConfigFromToml(filePath string) (*lwk.Conf, error) {
 		}
 		c.SetElectrumEndpoint(*electrumEndpoint)
 	}
+	for _, endpoint := range cfg.LWK.FallbackElectrumEndpoints {
+		electrumEndpoint, err := lwk.NewElectrsURL(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		c.AddFallbackElectrumEndpoint(*electrumEndpoint)
+	}
 	return c.SetLiquidSwaps(*cfg.LWK.LiquidSwaps).Build()
 }
 
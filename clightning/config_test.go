@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/elementsproject/peerswap/swap"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,3 +86,125 @@ func Test_ReadFromFile_EmptyFile(t *testing.T) {
 
 	assert.EqualValues(t, expected, actual)
 }
+
+func Test_ReadFromFile_ClaimBatch(t *testing.T) {
+	conf := `
+	[ClaimBatch]
+	window="10m"
+	`
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "peerswap.conf")
+	_ = os.WriteFile(fp, []byte(conf), fs.ModePerm)
+
+	c := &Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}}
+	actual, err := ReadFromFile()(c)
+	if err != nil {
+		t.Fatalf("ERROR: %v", err)
+	}
+
+	expected := &ClaimBatchConf{
+		Window:             10 * time.Minute,
+		SafetyMarginBlocks: swap.DefaultClaimBatchSafetyMarginBlocks,
+	}
+	assert.EqualValues(t, expected, actual.ClaimBatch)
+
+	_ = os.WriteFile(fp, []byte("[ClaimBatch]\nwindow=\"ten minutes\""), fs.ModePerm)
+	_, err = ReadFromFile()(&Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}})
+	assert.Error(t, err)
+}
+
+func Test_ReadFromFile_ClaimAddress(t *testing.T) {
+	conf := `
+	[ClaimAddress]
+	btcdescriptor="wpkh(xpub/0/*)"
+	lbtcdescriptor="ct(slip77(key),elwpkh(xpub/0/*))"
+	`
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "peerswap.conf")
+	_ = os.WriteFile(fp, []byte(conf), fs.ModePerm)
+
+	c := &Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}}
+	actual, err := ReadFromFile()(c)
+	if err != nil {
+		t.Fatalf("ERROR: %v", err)
+	}
+
+	expected := &ClaimAddressConf{
+		BtcDescriptor:  "wpkh(xpub/0/*)",
+		LbtcDescriptor: "ct(slip77(key),elwpkh(xpub/0/*))",
+	}
+	assert.EqualValues(t, expected, actual.ClaimAddress)
+}
+
+func Test_ReadFromFile_BitcoinElectrum(t *testing.T) {
+	conf := `
+	[Bitcoin]
+	electrumendpoints=["ssl://electrum.example.com:50002", "tcp://127.0.0.1:50001"]
+	`
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "peerswap.conf")
+	_ = os.WriteFile(fp, []byte(conf), fs.ModePerm)
+
+	c := &Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}}
+	actual, err := ReadFromFile()(c)
+	if err != nil {
+		t.Fatalf("ERROR: %v", err)
+	}
+
+	assert.Equal(t, []string{"ssl://electrum.example.com:50002", "tcp://127.0.0.1:50001"}, actual.Bitcoin.ElectrumEndpoints)
+}
+
+func Test_ReadFromFile_FeeEstimates(t *testing.T) {
+	conf := `
+	[FeeEstimates]
+	urls=["https://mempool.space/api/fee-estimates"]
+	mode="median"
+	`
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "peerswap.conf")
+	_ = os.WriteFile(fp, []byte(conf), fs.ModePerm)
+
+	c := &Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}}
+	actual, err := ReadFromFile()(c)
+	if err != nil {
+		t.Fatalf("ERROR: %v", err)
+	}
+
+	expected := &FeeEstimatesConf{
+		Urls: []string{"https://mempool.space/api/fee-estimates"},
+		Mode: "median",
+	}
+	assert.EqualValues(t, expected, actual.FeeEstimates)
+}
+
+func Test_ReadFromFile_FeeRate(t *testing.T) {
+	conf := `
+	[FeeRate]
+	btc="12.5satvb"
+	lbtc="2blocks"
+	`
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "peerswap.conf")
+	_ = os.WriteFile(fp, []byte(conf), fs.ModePerm)
+
+	c := &Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}}
+	actual, err := ReadFromFile()(c)
+	if err != nil {
+		t.Fatalf("ERROR: %v", err)
+	}
+
+	expected := &FeeRateConf{
+		Btc:  &swap.FeeRate{SatPerVByte: 12.5},
+		Lbtc: &swap.FeeRate{TargetConf: 2},
+	}
+	assert.EqualValues(t, expected, actual.FeeRate)
+
+	_ = os.WriteFile(fp, []byte("[FeeRate]\nbtc=\"fast\"\n"), fs.ModePerm)
+	_, err = ReadFromFile()(&Config{PeerswapDir: dir, Bitcoin: &BitcoinConf{}, Liquid: &LiquidConf{}})
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/elementsproject/peerswap/clightning"
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/stretchr/testify/assert"
 	"github.com/vulpemventures/go-elements/network"
 )
@@ -20,6 +21,7 @@ func TestLWKConfigFromToml(t *testing.T) {
 		signername="signername"
 		walletname="walletname"
 		lwkendpoint="http://localhost:32110"
+		fallbackelectrumendpoints=["ssl://electrum.example.com:50002", "tcp://localhost:60401"]
 		network="liquid"
 		liquidswaps=true
 		`
@@ -32,6 +34,11 @@ func TestLWKConfigFromToml(t *testing.T) {
 		}
 		assert.Equal(t, got.GetChain(), &network.Liquid)
 		assert.Equal(t, got.GetElectrumEndpoint(), "blockstream.info:995")
+		assert.Equal(t, []electrum.Endpoint{
+			{Address: "blockstream.info:995", TLS: true},
+			{Address: "electrum.example.com:50002", TLS: true},
+			{Address: "localhost:60401"},
+		}, got.GetElectrumEndpoints())
 		assert.Equal(t, got.GetLWKEndpoint(), "http://localhost:32110")
 		assert.Equal(t, got.GetLiquidSwaps(), true)
 		assert.Equal(t, got.GetNetwork(), "liquid")
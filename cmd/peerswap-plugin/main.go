@@ -7,8 +7,10 @@ import (
 	glog "log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/elementsproject/peerswap/elements"
 	"github.com/elementsproject/peerswap/isdev"
 	"github.com/elementsproject/peerswap/log"
@@ -30,6 +32,7 @@ import (
 	"github.com/elementsproject/peerswap/peersync"
 	"github.com/elementsproject/peerswap/policy"
 	"github.com/elementsproject/peerswap/premium"
+	"github.com/elementsproject/peerswap/scheduler"
 	"github.com/elementsproject/peerswap/swap"
 	"github.com/elementsproject/peerswap/txwatcher"
 	"github.com/elementsproject/peerswap/wallet"
@@ -42,6 +45,12 @@ var GitCommit string
 
 const (
 	minClnVersion = "23.11"
+
+	// bitcoinFallbackFeeRateSatPerKw is the fee rate that is used if the
+	// estimator has no estimate. 6250 sat/kw converts to 25 sat/vbyte as
+	// this is the hardcoded fallback fee that lnd uses.
+	// See https://github.com/lightningnetwork/lnd/blob/5c36d96c9cbe8b27c29f9682dcbdab7928ae870f/chainreg/chainregistry.go#L481
+	bitcoinFallbackFeeRateSatPerKw = btcutil.Amount(6250)
 )
 
 func main() {
@@ -170,6 +179,7 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 	var liquidTxWatcher swap.TxWatcher
 	var liquidRpcWallet wallet.Wallet
 	var liquidCli *gelements.Elements
+	var liquidElectrum electrum.RPC
 	var liquidEnabled bool
 
 	if *config.Liquid.LiquidSwaps && elementsWanted(config) {
@@ -197,11 +207,13 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 			return err
 		}
 
-		liquidTxWatcher = txwatcher.NewBlockchainRpcTxWatcher(
+		rpcTxWatcher := txwatcher.NewBlockchainRpcTxWatcher(
 			ctx,
 			txwatcher.NewElementsCli(liquidCli),
 			onchain.LiquidConfs,
 		)
+		rpcTxWatcher.EnableZmq(config.Liquid.ZmqPubHashBlock, config.Liquid.ZmqPubRawTx, txwatcher.ElementsTxId)
+		liquidTxWatcher = rpcTxWatcher
 
 		// LiquidChain
 		liquidChain, err := getLiquidChain(liquidCli)
@@ -218,7 +230,8 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 		if err2 != nil {
 			return err2
 		}
-		liquidTxWatcher, err = lwk.NewElectrumTxWatcher(lc.GetElectrumClient())
+		liquidElectrum = lc.GetElectrumClient()
+		liquidTxWatcher, err = lwk.NewElectrumTxWatcher(liquidElectrum)
 		if err != nil {
 			return err
 		}
@@ -236,31 +249,81 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 	if err != nil {
 		return err
 	}
-	log.Infof(
-		"Starting bitcoin client with chain:%s, rpcuser:%s, rpcpassword:******,, rpchost:%s, rpcport:%d",
-		chain.Name,
-		config.Bitcoin.RpcUser,
-		config.Bitcoin.RpcHost,
-		config.Bitcoin.RpcPort,
-	)
-	bitcoinCli, err := getBitcoinClient(lightningPlugin.GetLightningRpc(), config)
-	if err != nil {
-		return err
+	var bitcoinCli *gbitcoin.Bitcoin
+	if len(config.Bitcoin.ElectrumEndpoints) == 0 {
+		log.Infof(
+			"Starting bitcoin client with chain:%s, rpcuser:%s, rpcpassword:******,, rpchost:%s, rpcport:%d",
+			chain.Name,
+			config.Bitcoin.RpcUser,
+			config.Bitcoin.RpcHost,
+			config.Bitcoin.RpcPort,
+		)
+		bitcoinCli, err = getBitcoinClient(lightningPlugin.GetLightningRpc(), config)
+		if err != nil {
+			return err
+		}
 	}
 
 	bitcoinFeeFloor := onchain.LegacyFeeFloorSatPerKw
-	var bitcoinTxWatcher *txwatcher.BlockchainRpcTxWatcher
+	var bitcoinTxWatcher swap.TxWatcher
 	var bitcoinOnChainService *onchain.BitcoinOnChain
+	var bitcoinTxSender clightning.RawTxSender
+	var bitcoinElectrum electrum.RPC
 	var bitcoinEnabled bool
-	if bitcoinCli != nil && *config.Bitcoin.BitcoinSwaps {
+	if len(config.Bitcoin.ElectrumEndpoints) > 0 && *config.Bitcoin.BitcoinSwaps {
+		supportedAssets = append(supportedAssets, "btc")
+		log.Infof("Bitcoin swaps enabled with electrum: %s", strings.Join(config.Bitcoin.ElectrumEndpoints, ", "))
+		bitcoinEnabled = true
+		endpoints := make([]electrum.Endpoint, 0, len(config.Bitcoin.ElectrumEndpoints))
+		for _, e := range config.Bitcoin.ElectrumEndpoints {
+			endpoint, err := electrum.ParseEndpoint(e)
+			if err != nil {
+				return fmt.Errorf("invalid bitcoin electrum endpoint %s: %w", e, err)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		bitcoinElectrum, err = electrum.NewFailoverElectrumClient(ctx, endpoints)
+		if err != nil {
+			return err
+		}
+		bitcoinTxWatcher = electrum.NewTxWatcher(bitcoinElectrum, onchain.BitcoinMinConfs)
+		bitcoinTxSender = electrum.NewTxBroadcaster(bitcoinElectrum)
+
+		var bitcoinEstimator onchain.Estimator
+		bitcoinEstimator, _ = onchain.NewRegtestFeeEstimator()
+		if chain.Name != "regtest" {
+			log.Infof("Using electrum estimator")
+			bitcoinEstimator = electrum.NewFeeEstimator(
+				bitcoinElectrum,
+				bitcoinFallbackFeeRateSatPerKw,
+				bitcoinFeeFloor,
+			)
+		}
+		bitcoinEstimator, err = withFeeEstimates(bitcoinEstimator, config, bitcoinFeeFloor)
+		if err != nil {
+			return err
+		}
+		if err = bitcoinEstimator.Start(); err != nil {
+			return err
+		}
+		bitcoinOnChainService = onchain.NewBitcoinOnChain(
+			bitcoinEstimator,
+			bitcoinFeeFloor,
+			bitcoinFeeFloor,
+			chain,
+		)
+	} else if bitcoinCli != nil && *config.Bitcoin.BitcoinSwaps {
 		supportedAssets = append(supportedAssets, "btc")
 		log.Infof("Bitcoin swaps enabled")
 		bitcoinEnabled = true
-		bitcoinTxWatcher = txwatcher.NewBlockchainRpcTxWatcher(
+		bitcoinTxSender = bitcoinCli
+		rpcTxWatcher := txwatcher.NewBlockchainRpcTxWatcher(
 			ctx,
 			txwatcher.NewBitcoinRpc(bitcoinCli),
 			onchain.BitcoinMinConfs,
 		)
+		rpcTxWatcher.EnableZmq(config.Bitcoin.ZmqPubHashBlock, config.Bitcoin.ZmqPubRawTx, txwatcher.BitcoinTxId)
+		bitcoinTxWatcher = rpcTxWatcher
 
 		floor, detectedVersion, floorErr := determineBitcoinFeeFloor(bitcoinCli)
 		if floorErr != nil {
@@ -280,14 +343,11 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 			log.Infof("Using gbitcoind estimator")
 
 			// Initiate the GBitcoinEstimator with the "ECONOMICAL" estimation
-			// rule and a fallback fee rate of 6250 sat/kw which converts to
-			// 25 sat/vbyte as this is the hardcoded fallback fee that lnd uses.
-			// See https://github.com/lightningnetwork/lnd/blob/5c36d96c9cbe8b27c29f9682dcbdab7928ae870f/chainreg/chainregistry.go#L481
-			fallbackFeeRateSatPerKw := btcutil.Amount(6250)
+			// rule and the fallback fee rate.
 			bitcoinEstimator, err = onchain.NewGBitcoindEstimator(
 				bitcoinCli,
 				"ECONOMICAL",
-				fallbackFeeRateSatPerKw,
+				bitcoinFallbackFeeRateSatPerKw,
 				bitcoinFeeFloor,
 			)
 			if err != nil {
@@ -295,6 +355,10 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 			}
 		}
 
+		bitcoinEstimator, err = withFeeEstimates(bitcoinEstimator, config, bitcoinFeeFloor)
+		if err != nil {
+			return err
+		}
 		if err = bitcoinEstimator.Start(); err != nil {
 			return err
 		}
@@ -342,6 +406,10 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 	if err != nil {
 		return err
 	}
+	queuedSwapStore, err := swap.NewQueuedSwapsStore(swapDb)
+	if err != nil {
+		return err
+	}
 
 	// Manager for send message retry.
 	mesmgr := messages.NewManager()
@@ -352,6 +420,7 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 
 	swapServices := swap.NewSwapServices(swapStore,
 		requestedSwapStore,
+		queuedSwapStore,
 		lightningPlugin,
 		lightningPlugin,
 		mesmgr,
@@ -367,6 +436,45 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 		ps,
 	)
 	swapService := swap.NewSwapService(swapServices)
+	if config.ClaimBatch != nil {
+		swapService.EnableClaimBatching(config.ClaimBatch.Window, config.ClaimBatch.SafetyMarginBlocks)
+	}
+
+	if config.ClaimAddress != nil {
+		claimAddressIndexStore, err := swap.NewClaimAddressIndexStore(swapDb)
+		if err != nil {
+			return err
+		}
+		if config.ClaimAddress.BtcDescriptor != "" && bitcoinOnChainService != nil {
+			deriver, err := onchain.NewBitcoinClaimAddressDeriver(config.ClaimAddress.BtcDescriptor, bitcoinOnChainService.GetChain())
+			if err != nil {
+				return fmt.Errorf("invalid ClaimAddress btcdescriptor: %w", err)
+			}
+			swapServices.SetClaimAddressDeriver("btc", deriver, claimAddressIndexStore)
+		}
+		if config.ClaimAddress.LbtcDescriptor != "" && liquidOnChainService != nil {
+			deriver, err := onchain.NewLiquidClaimAddressDeriver(config.ClaimAddress.LbtcDescriptor, liquidOnChainService.GetChain())
+			if err != nil {
+				return fmt.Errorf("invalid ClaimAddress lbtcdescriptor: %w", err)
+			}
+			swapServices.SetClaimAddressDeriver("lbtc", deriver, claimAddressIndexStore)
+		}
+	}
+
+	if config.FeeRate != nil {
+		if config.FeeRate.Btc != nil && bitcoinOnChainService != nil {
+			err = swapServices.SetDefaultFeeRate("btc", config.FeeRate.Btc)
+			if err != nil {
+				return fmt.Errorf("invalid FeeRate btc: %w", err)
+			}
+		}
+		if config.FeeRate.Lbtc != nil && liquidOnChainService != nil {
+			err = swapServices.SetDefaultFeeRate("lbtc", config.FeeRate.Lbtc)
+			if err != nil {
+				return fmt.Errorf("invalid FeeRate lbtc: %w", err)
+			}
+		}
+	}
 
 	if liquidTxWatcher != nil && liquidEnabled {
 		err := liquidTxWatcher.StartWatchingTxs()
@@ -408,6 +516,8 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 	// Use the peersync CLN lightning adapter; it self-registers message handler
 	clnAdapter := peersync.NewClnLightningAdapter(lightningPlugin)
 	peerSync := peersync.NewPeerSync(nodeID, peerStore, clnAdapter, pol, supportedAssets, ps)
+	swapServices.SetMessageAckSupport(peerSync)
+	swapServices.SetMultiChannelSwapOutSupport(peerSync)
 
 	psSupervisor := suture.New("peersync", suture.Spec{
 		EventHook: func(e suture.Event) {
@@ -425,17 +535,26 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 		}
 	}()
 
+	scheduleStore, err := scheduler.NewBBoltScheduleStore(swapDb)
+	if err != nil {
+		return err
+	}
+	swapScheduler := scheduler.NewScheduler(scheduleStore, swapService, lightningPlugin.GetNodeId())
+
 	sp := swap.NewRequestedSwapsPrinter(requestedSwapStore)
 	lightningPlugin.SetupClients(
 		liquidRpcWallet,
+		liquidElectrum,
 		swapService,
 		pol,
 		sp,
-		bitcoinCli,
+		bitcoinTxSender,
+		bitcoinElectrum,
 		bitcoinOnChainService,
 		peerSync,
 		peerStore,
 		ps,
+		swapScheduler,
 	)
 
 	// We are ready to accept and handle requests.
@@ -459,6 +578,14 @@ func run(ctx context.Context, lightningPlugin *clightning.ClightningClient) erro
 		return err
 	}
 
+	err = lightningPlugin.StartDeferredSwaps()
+	if err != nil {
+		return err
+	}
+
+	swapScheduler.Start()
+	defer swapScheduler.Stop()
+
 	log.Infof("peerswap initialized")
 
 	// Wait for context to finish up
@@ -534,6 +661,22 @@ type bitcoindNetworkInfo struct {
 	Subversion string `json:"subversion"`
 }
 
+// withFeeEstimates combines the estimator with the configured fee estimates
+// endpoints.
+func withFeeEstimates(estimator onchain.Estimator, config *clightning.Config,
+	feeFloor btcutil.Amount) (onchain.Estimator, error) {
+
+	if config.FeeEstimates == nil {
+		return estimator, nil
+	}
+	return onchain.WithHTTPEstimators(
+		estimator,
+		config.FeeEstimates.Urls,
+		config.FeeEstimates.Mode,
+		feeFloor,
+	)
+}
+
 func determineBitcoinFeeFloor(bitcoinCli *gbitcoin.Bitcoin) (btcutil.Amount, string, error) {
 	var networkInfo bitcoindNetworkInfo
 	if err := bitcoinCli.Request(&getNetworkInfoRequest{}, &networkInfo); err != nil {
@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/elementsproject/peerswap/lwk"
+	"github.com/elementsproject/peerswap/onchain"
+	"github.com/elementsproject/peerswap/swap"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -44,7 +47,11 @@ type PeerSwapConfig struct {
 	DataDir    string   `long:"datadir" description:"peerswap datadir"`
 	LogLevel   LogLevel `long:"loglevel" description:"loglevel (1=Info, 2=Debug)"`
 
-	LogRotation LogRotationConfig `group:"Log rotation" namespace:"logrotation"`
+	LogRotation  LogRotationConfig  `group:"Log rotation" namespace:"logrotation"`
+	ClaimBatch   ClaimBatchConfig   `group:"Claim batching" namespace:"claimbatch"`
+	ClaimAddress ClaimAddressConfig `group:"Claim addresses" namespace:"claimaddress"`
+	FeeEstimates FeeEstimatesConfig `group:"Fee estimates" namespace:"feeestimates"`
+	FeeRate      FeeRateConfig      `group:"Fee rate" namespace:"feerate"`
 
 	LndConfig      *LndConfig     `group:"Lnd Grpc config" namespace:"lnd"`
 	ElementsConfig *OnchainConfig `group:"Elements Rpc Config" namespace:"elementsd"`
@@ -101,6 +108,8 @@ type OnchainConfig struct {
 	RpcPort           uint   `long:"rpcport" description:"port to connect to"`
 	RpcWallet         string `long:"rpcwallet" description:"wallet to use for swaps (elements only)"`
 	LiquidSwaps       bool   `long:"liquidswaps" description:"set to false to disable L-BTC swaps"`
+	ZmqPubHashBlock   string `long:"zmqpubhashblock" description:"zmqpubhashblock endpoint of the daemon to get new blocks pushed instead of polled, e.g. tcp://127.0.0.1:28332"`
+	ZmqPubRawTx       string `long:"zmqpubrawtx" description:"zmqpubrawtx endpoint of the daemon to check swap transactions as soon as they are seen"`
 }
 
 func (o *OnchainConfig) Validate() error {
@@ -166,6 +175,30 @@ func (l LogRotationConfig) Validate() error {
 	return nil
 }
 
+type ClaimBatchConfig struct {
+	Window             time.Duration `long:"window" description:"time to hold back claims to claim them together with the claims of other swaps, 0 disables batching"`
+	SafetyMarginBlocks uint32        `long:"safetymargin" description:"number of blocks before the csv of a swap passes at which its claim is no longer held back"`
+}
+
+type ClaimAddressConfig struct {
+	BtcDescriptor  string `long:"btcdescriptor" description:"descriptor or xpub to derive the addresses from that bitcoin claims are sent to instead of the lnd wallet, e.g. wpkh(xpub/0/*)"`
+	LbtcDescriptor string `long:"lbtcdescriptor" description:"descriptor to derive the addresses from that liquid claims are sent to instead of the liquid wallet, e.g. ct(slip77(<key>),elwpkh(xpub/0/*))"`
+}
+
+// FeeEstimatesConfig adds /fee-estimates endpoints to the fee estimation of
+// lnd.
+type FeeEstimatesConfig struct {
+	Urls []string `long:"url" description:"mempool.space or esplora style fee estimates endpoint, e.g. https://mempool.space/api/fee-estimates, can be set multiple times"`
+	Mode string   `long:"mode" description:"how the fee rates of lnd and the endpoints are combined, max or median"`
+}
+
+// FeeRateConfig sets the default fee rates of the opening and claim
+// transactions of swaps instead of the wallet estimate.
+type FeeRateConfig struct {
+	Btc  string `long:"btc" description:"default fee rate of bitcoin swap transactions, as sat/vb (e.g. 12.5satvb) or as confirmation target (e.g. 6blocks)"`
+	Lbtc string `long:"lbtc" description:"default fee rate of liquid swap transactions, as sat/vb (e.g. 0.1satvb) or as confirmation target (e.g. 2blocks)"`
+}
+
 func DefaultConfig() *PeerSwapConfig {
 	return &PeerSwapConfig{
 		Host:       DefaultPeerswapHost,
@@ -182,6 +215,12 @@ func DefaultConfig() *PeerSwapConfig {
 		ElementsConfig: defaultLiquidConfig(),
 		LogLevel:       DefaultLogLevel,
 		LogRotation:    defaultLogRotationConfig(),
+		ClaimBatch: ClaimBatchConfig{
+			SafetyMarginBlocks: swap.DefaultClaimBatchSafetyMarginBlocks,
+		},
+		FeeEstimates: FeeEstimatesConfig{
+			Mode: onchain.CombineMax,
+		},
 	}
 }
 
@@ -209,12 +248,13 @@ func defaultLogRotationConfig() LogRotationConfig {
 
 func LWKFromIniFileConfig(filePath string) (*lwk.Conf, error) {
 	type LWK struct {
-		SignerName       string `long:"signername" description:"name of the signer"`
-		WalletName       string `long:"walletname" description:"name of the wallet"`
-		LWKEndpoint      string `long:"lwkendpoint" description:"endpoint for the liquid wallet kit"`
-		ElectrumEndpoint string `long:"elementsendpoint" description:"endpoint for the elements rpc"`
-		Network          string `long:"network" description:"network to use"`
-		LiquidSwaps      bool   `long:"liquidswaps" description:"enable liquid swaps"`
+		SignerName                string   `long:"signername" description:"name of the signer"`
+		WalletName                string   `long:"walletname" description:"name of the wallet"`
+		LWKEndpoint               string   `long:"lwkendpoint" description:"endpoint for the liquid wallet kit"`
+		ElectrumEndpoint          string   `long:"elementsendpoint" description:"endpoint for the elements rpc"`
+		FallbackElectrumEndpoints []string `long:"fallbackelectrumendpoint" description:"electrum endpoint that is used if the previous ones are down, can be set multiple times"`
+		Network                   string   `long:"network" description:"network to use"`
+		LiquidSwaps               bool     `long:"liquidswaps" description:"enable liquid swaps"`
 	}
 	type IniConf struct {
 		LWK *LWK `group:"Elements Rpc Config" namespace:"lwk"`
@@ -268,5 +308,12 @@ func LWKFromIniFileConfig(filePath string) (*lwk.Conf, error) {
 		}
 		c.SetElectrumEndpoint(*electrumEndpoint)
 	}
+	for _, endpoint := range cfg.LWK.FallbackElectrumEndpoints {
+		electrumEndpoint, err := lwk.NewElectrsURL(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		c.AddFallbackElectrumEndpoint(*electrumEndpoint)
+	}
 	return c.SetLiquidSwaps(cfg.LWK.LiquidSwaps).Build()
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/elementsproject/peerswap/cmd/peerswaplnd"
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/stretchr/testify/assert"
 	"github.com/vulpemventures/go-elements/network"
 )
@@ -90,6 +91,8 @@ func TestLWKFromIniFileConfig(t *testing.T) {
 		lwk.signername=signername
 		lwk.walletname=walletname
 		lwk.lwkendpoint=http://localhost:32110
+		lwk.fallbackelectrumendpoint=ssl://electrum.example.com:50002
+		lwk.fallbackelectrumendpoint=tcp://localhost:60401
 		lwk.network=liquid
 		lwk.liquidswaps=true
 		`
@@ -103,6 +106,11 @@ func TestLWKFromIniFileConfig(t *testing.T) {
 		}
 		assert.Equal(t, got.GetChain(), &network.Liquid)
 		assert.Equal(t, got.GetElectrumEndpoint(), "blockstream.info:995")
+		assert.Equal(t, []electrum.Endpoint{
+			{Address: "blockstream.info:995", TLS: true},
+			{Address: "electrum.example.com:50002", TLS: true},
+			{Address: "localhost:60401"},
+		}, got.GetElectrumEndpoints())
 		assert.Equal(t, got.GetLWKEndpoint(), "http://localhost:32110")
 		assert.Equal(t, got.GetLiquidSwaps(), true)
 		assert.Equal(t, got.GetNetwork(), "liquid")
@@ -16,12 +16,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/elementsproject/peerswap/elements"
 	"github.com/elementsproject/peerswap/isdev"
 	"github.com/elementsproject/peerswap/lnd"
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/lwk"
 	"github.com/elementsproject/peerswap/premium"
+	"github.com/elementsproject/peerswap/scheduler"
 
 	"github.com/elementsproject/peerswap/version"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -66,7 +68,7 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	shutdown := make(chan struct{})
-	sigChan := make(chan os.Signal)
+	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
 		defer close(shutdown)
@@ -174,13 +176,22 @@ func run() error {
 		if err != nil {
 			return err
 		}
-		if err = lndEstimator.Start(); err != nil {
+		bitcoinEstimator, err := onchain.WithHTTPEstimators(
+			lndEstimator,
+			cfg.FeeEstimates.Urls,
+			cfg.FeeEstimates.Mode,
+			bitcoinFeeFloor,
+		)
+		if err != nil {
+			return err
+		}
+		if err = bitcoinEstimator.Start(); err != nil {
 			return err
 		}
 
 		// Keep estimator and fallback paths aligned by reusing the same fee floor.
 		bitcoinOnChainService = onchain.NewBitcoinOnChain(
-			lndEstimator,
+			bitcoinEstimator,
 			bitcoinFeeFloor,
 			bitcoinFeeFloor,
 			chain,
@@ -195,6 +206,7 @@ func run() error {
 	var liquidTxWatcher swap.TxWatcher
 	var liquidRpcWallet wallet.Wallet
 	var liquidCli *gelements.Elements
+	var liquidElectrum electrum.RPC
 	if cfg.LiquidEnabled {
 		if cfg.ElementsConfig.RpcUser != "" {
 			supportedAssets = append(supportedAssets, "lbtc")
@@ -218,11 +230,13 @@ func run() error {
 			}
 
 			// txwatcher
-			liquidTxWatcher = txwatcher.NewBlockchainRpcTxWatcher(
+			rpcTxWatcher := txwatcher.NewBlockchainRpcTxWatcher(
 				ctx,
 				txwatcher.NewElementsCli(liquidCli),
 				onchain.LiquidConfs,
 			)
+			rpcTxWatcher.EnableZmq(liquidConfig.ZmqPubHashBlock, liquidConfig.ZmqPubRawTx, txwatcher.ElementsTxId)
+			liquidTxWatcher = rpcTxWatcher
 
 			// LiquidChain
 			liquidChain, err := getLiquidChain(liquidCli)
@@ -238,7 +252,8 @@ func run() error {
 				return err2
 			}
 			cfg.LiquidEnabled = true
-			liquidTxWatcher, err = lwk.NewElectrumTxWatcher(lc.GetElectrumClient())
+			liquidElectrum = lc.GetElectrumClient()
+			liquidTxWatcher, err = lwk.NewElectrumTxWatcher(liquidElectrum)
 			if err != nil {
 				return err
 			}
@@ -314,6 +329,10 @@ func run() error {
 	if err != nil {
 		return err
 	}
+	queuedSwapStore, err := swap.NewQueuedSwapsStore(swapDb)
+	if err != nil {
+		return err
+	}
 
 	// Manager for send message retry.
 	mesmgr := messages.NewManager()
@@ -325,6 +344,7 @@ func run() error {
 
 	swapServices := swap.NewSwapServices(swapStore,
 		requestedSwapStore,
+		queuedSwapStore,
 		lndClient,
 		lndClient,
 		mesmgr,
@@ -340,6 +360,51 @@ func run() error {
 		ps,
 	)
 	swapService := swap.NewSwapService(swapServices)
+	if cfg.ClaimBatch.Window > 0 {
+		swapService.EnableClaimBatching(cfg.ClaimBatch.Window, cfg.ClaimBatch.SafetyMarginBlocks)
+	}
+
+	if cfg.ClaimAddress.BtcDescriptor != "" || cfg.ClaimAddress.LbtcDescriptor != "" {
+		claimAddressIndexStore, err := swap.NewClaimAddressIndexStore(swapDb)
+		if err != nil {
+			return err
+		}
+		if cfg.ClaimAddress.BtcDescriptor != "" && bitcoinOnChainService != nil {
+			deriver, err := onchain.NewBitcoinClaimAddressDeriver(cfg.ClaimAddress.BtcDescriptor, bitcoinOnChainService.GetChain())
+			if err != nil {
+				return fmt.Errorf("invalid claimaddress.btcdescriptor: %w", err)
+			}
+			swapServices.SetClaimAddressDeriver("btc", deriver, claimAddressIndexStore)
+		}
+		if cfg.ClaimAddress.LbtcDescriptor != "" && liquidOnChainService != nil {
+			deriver, err := onchain.NewLiquidClaimAddressDeriver(cfg.ClaimAddress.LbtcDescriptor, liquidOnChainService.GetChain())
+			if err != nil {
+				return fmt.Errorf("invalid claimaddress.lbtcdescriptor: %w", err)
+			}
+			swapServices.SetClaimAddressDeriver("lbtc", deriver, claimAddressIndexStore)
+		}
+	}
+
+	if cfg.FeeRate.Btc != "" && bitcoinOnChainService != nil {
+		feeRate, err := swap.ParseFeeRate(cfg.FeeRate.Btc)
+		if err != nil {
+			return fmt.Errorf("invalid feerate.btc: %w", err)
+		}
+		err = swapServices.SetDefaultFeeRate("btc", feeRate)
+		if err != nil {
+			return fmt.Errorf("invalid feerate.btc: %w", err)
+		}
+	}
+	if cfg.FeeRate.Lbtc != "" && liquidOnChainService != nil {
+		feeRate, err := swap.ParseFeeRate(cfg.FeeRate.Lbtc)
+		if err != nil {
+			return fmt.Errorf("invalid feerate.lbtc: %w", err)
+		}
+		err = swapServices.SetDefaultFeeRate("lbtc", feeRate)
+		if err != nil {
+			return fmt.Errorf("invalid feerate.lbtc: %w", err)
+		}
+	}
 
 	if liquidTxWatcher != nil {
 		err := liquidTxWatcher.StartWatchingTxs()
@@ -388,6 +453,8 @@ func run() error {
 	rpcLightningClient := lnrpc.NewLightningClient(cc)
 	lightningAdapter := peersync.NewLightningAdapter(rpcLightningClient)
 	peerSync := peersync.NewPeerSync(nodeID, peerStore, lightningAdapter, pol, supportedAssets, ps)
+	swapServices.SetMessageAckSupport(peerSync)
+	swapServices.SetMultiChannelSwapOutSupport(peerSync)
 
 	psSupervisor := suture.New("peersync", suture.Spec{
 		EventHook: func(e suture.Event) {
@@ -419,11 +486,33 @@ func run() error {
 		return err
 	}
 
+	// Start deferred swaps once their peer is online.
+	err = peerListener.AddHandler(lnrpc.PeerEvent_PEER_ONLINE, swapService.OnPeerOnline)
+	if err != nil {
+		return err
+	}
+	peers, err := rpcLightningClient.ListPeers(ctx, &lnrpc.ListPeersRequest{})
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers.Peers {
+		swapService.OnPeerOnline(peer.PubKey)
+	}
+
 	// Start internal lnd listener.
 	if err := lndClient.StartListening(); err != nil {
 		return err
 	}
 
+	// Start scheduled swaps.
+	scheduleStore, err := scheduler.NewBBoltScheduleStore(swapDb)
+	if err != nil {
+		return err
+	}
+	swapScheduler := scheduler.NewScheduler(scheduleStore, swapService, info.IdentityPubkey)
+	swapScheduler.Start()
+	defer swapScheduler.Stop()
+
 	// setup grpc server
 	sp := swap.NewRequestedSwapsPrinter(requestedSwapStore)
 	peerswaprpcServer := peerswaprpc.NewPeerswapServer(
@@ -434,8 +523,10 @@ func run() error {
 		peerStore,
 		pol,
 		liquidCli,
+		liquidElectrum,
 		rpcLightningClient,
 		ps,
+		swapScheduler,
 		sigChan,
 	)
 
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	log2 "log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elementsproject/peerswap/peerswaprpc"
 	"github.com/urfave/cli"
@@ -28,10 +30,12 @@ func main() {
 		},
 	}
 	app.Commands = []cli.Command{
-		swapOutCommand, swapInCommand, getSwapCommand, listSwapsCommand,
+		swapOutCommand, swapInCommand, swapInBatchCommand, retrySwapCommand, finalizeSwapFundingCommand, getSwapCommand, listSwapsCommand,
+		listQueuedSwapsCommand, removeQueuedSwapCommand, listDeferredSwapsCommand,
+		createScheduleCommand, listSchedulesCommand, pauseScheduleCommand, deleteScheduleCommand,
 		listPeersCommand, reloadPolicyFileCommand, listRequestedSwapsCommand,
 		liquidGetBalanceCommand, liquidGetAddressCommand, liquidSendToAddressCommand,
-		stopCommand, listActiveSwapsCommand, allowSwapRequestsCommand, addPeerCommand, removePeerCommand,
+		stopCommand, drainCommand, statusCommand, listActiveSwapsCommand, allowSwapRequestsCommand, addPeerCommand, removePeerCommand,
 		addSusPeerCommand, removeSusPeerCommand, getGlobalPremiumRateCommand, updateGlobalPremiumRateCommand,
 		getPeerPremiumRateCommand, updatePremiumRateCommand, deletePeerPremiumRateCommand,
 	}
@@ -45,14 +49,16 @@ func main() {
 
 var (
 	satAmountFlag = cli.Uint64Flag{
-		Name:     "sat_amt",
-		Usage:    "Amount of Sats to swap for",
-		Required: true,
+		Name:  "sat_amt",
+		Usage: "Amount of Sats to swap for, not needed with --max",
 	}
 	channelIdFlag = cli.Uint64Flag{
-		Name:     "channel_id",
-		Usage:    "channel id of channel to swap over",
-		Required: true,
+		Name:  "channel_id",
+		Usage: "channel id of channel to swap over, not needed with --peer_pubkey",
+	}
+	swapPeerFlag = cli.StringFlag{
+		Name:  "peer_pubkey",
+		Usage: "pubkey of the peer to swap with, the channel is selected automatically",
 	}
 	assetFlag = cli.StringFlag{
 		Name:     "asset",
@@ -104,6 +110,139 @@ var (
 		Name:  "page_token",
 		Usage: "paging token returned by the previous response",
 	}
+	retryAmountFlag = cli.Uint64Flag{
+		Name:  "sat_amt",
+		Usage: "Amount of Sats to swap for, defaults to the amount of the original swap",
+	}
+	forceFlag = cli.BoolFlag{
+		Name:  "force",
+		Usage: "retry the swap even if the peer is not known to run peerswap",
+	}
+	maxAmountFlag = cli.BoolFlag{
+		Name:  "max",
+		Usage: "swap the maximum possible amount instead of sat_amt",
+	}
+	leaveLocalSatFlag = cli.Uint64Flag{
+		Name:  "leave_local_sat",
+		Usage: "with --max, amount of Sats to keep local (channel balance on swapout, wallet balance on swapin)",
+	}
+	queueFlag = cli.BoolFlag{
+		Name:  "queue",
+		Usage: "queue the swap if the channel already has an active swap",
+	}
+	deferFlag = cli.BoolFlag{
+		Name:  "defer",
+		Usage: "defer the swap until the peer comes online if it is offline",
+	}
+	deferExpiryFlag = cli.Uint64Flag{
+		Name:  "defer_expiry_sec",
+		Usage: "seconds after which a deferred swap is dropped (default 24h)",
+	}
+	idempotencyKeyFlag = cli.StringFlag{
+		Name:  "idempotency_key",
+		Usage: "key to safely retry the request, a repeated request returns the swap of the first one",
+	}
+	extraChannelIdFlag = cli.StringSliceFlag{
+		Name:  "extra_channel_id",
+		Usage: "further channel to the same peer that the swap-out may use, can be given several times",
+	}
+	claimAddressFlag = cli.StringFlag{
+		Name:  "claim_address",
+		Usage: "address the claimed funds are sent to instead of the node wallet",
+	}
+	satPerVbyteFlag = cli.Float64Flag{
+		Name:  "sat_per_vbyte",
+		Usage: "fee rate in sat/vb of our opening and claim transactions",
+	}
+	targetConfFlag = cli.UintFlag{
+		Name:  "target_conf",
+		Usage: "confirmation target in blocks that the fee rate of our opening and claim transactions is estimated for",
+	}
+	utxoFlag = cli.StringSliceFlag{
+		Name:  "utxo",
+		Usage: "outpoint txid:vout that funds the opening transaction, can be given several times",
+	}
+	excludeUtxoFlag = cli.StringSliceFlag{
+		Name:  "exclude_utxo",
+		Usage: "outpoint txid:vout that must not fund the opening transaction, can be given several times",
+	}
+	externalFundingFlag = cli.BoolFlag{
+		Name:  "external_funding",
+		Usage: "fund the opening transaction outside of the node, the swap returns an unsigned PSBT once the peer agreed",
+	}
+	signedTxFlag = cli.StringFlag{
+		Name:     "signed_tx",
+		Usage:    "signed and finalized PSBT (btc) or PSET (lbtc) in base64, or the signed transaction in hex",
+		Required: true,
+	}
+	batchSwapFlag = cli.StringSliceFlag{
+		Name:     "swap",
+		Usage:    "swap-in of the batch as channel_id:amount_sat, has to be given for every swap",
+		Required: true,
+	}
+	queuedSwapIdFlag = cli.StringFlag{
+		Name:     "id",
+		Usage:    "id of the queued swap",
+		Required: true,
+	}
+	dryRunFlag = cli.BoolFlag{
+		Name:  "dry_run",
+		Usage: "only run the local checks and estimate the fees without starting the swap",
+	}
+	verboseFlag = cli.BoolFlag{
+		Name:  "verbose",
+		Usage: "include the state transition history of the swap",
+	}
+	scheduleChannelIdFlag = cli.Uint64Flag{
+		Name:     "channel_id",
+		Usage:    "channel id of channel to swap over",
+		Required: true,
+	}
+	runAtFlag = cli.Int64Flag{
+		Name:  "run_at",
+		Usage: "unix time of a one-shot schedule",
+	}
+	cronFlag = cli.StringFlag{
+		Name:  "cron",
+		Usage: "cron expression of a recurring schedule, evaluated in UTC, e.g. '0 6 * * 1'",
+	}
+	intervalFlag = cli.Uint64Flag{
+		Name:  "interval_sec",
+		Usage: "interval in seconds of a recurring schedule",
+	}
+	onlyIfLocalBelowFlag = cli.Uint64Flag{
+		Name:  "only_if_local_below_sat",
+		Usage: "skip a run unless the local balance of the channel is below this amount",
+	}
+	onlyIfLocalAboveFlag = cli.Uint64Flag{
+		Name:  "only_if_local_above_sat",
+		Usage: "skip a run unless the local balance of the channel is above this amount",
+	}
+	scheduleIdFlag = cli.StringFlag{
+		Name:     "id",
+		Usage:    "id of the schedule",
+		Required: true,
+	}
+	resumeFlag = cli.BoolFlag{
+		Name:  "resume",
+		Usage: "resume the schedule instead of pausing it",
+	}
+	drainStopFlag = cli.BoolFlag{
+		Name:  "stop",
+		Usage: "end the drain and allow new swaps again",
+	}
+	drainShutdownFlag = cli.BoolFlag{
+		Name:  "shutdown",
+		Usage: "shut the daemon down once the last active swap is done",
+	}
+	drainStatusFlag = cli.BoolFlag{
+		Name:  "status",
+		Usage: "only print the progress of the drain",
+	}
+	drainFollowFlag = cli.BoolFlag{
+		Name:  "follow",
+		Usage: "print the progress until the last active swap is done",
+	}
 	listDescendingFlag = cli.BoolTFlag{
 		Name:  "descending",
 		Usage: "order by created_at descending (newest first)",
@@ -115,8 +254,20 @@ var (
 		Flags: []cli.Flag{
 			satAmountFlag,
 			channelIdFlag,
+			swapPeerFlag,
 			assetFlag,
 			PremiumLimitRatePPMFlag,
+			maxAmountFlag,
+			leaveLocalSatFlag,
+			queueFlag,
+			deferFlag,
+			deferExpiryFlag,
+			idempotencyKeyFlag,
+			extraChannelIdFlag,
+			claimAddressFlag,
+			satPerVbyteFlag,
+			targetConfFlag,
+			dryRunFlag,
 		},
 		Action: swapOut,
 	}
@@ -127,17 +278,130 @@ var (
 		Flags: []cli.Flag{
 			satAmountFlag,
 			channelIdFlag,
+			swapPeerFlag,
 			assetFlag,
 			PremiumLimitRatePPMFlag,
+			maxAmountFlag,
+			leaveLocalSatFlag,
+			queueFlag,
+			deferFlag,
+			deferExpiryFlag,
+			idempotencyKeyFlag,
+			claimAddressFlag,
+			satPerVbyteFlag,
+			targetConfFlag,
+			utxoFlag,
+			excludeUtxoFlag,
+			externalFundingFlag,
+			dryRunFlag,
 		},
 		Action: swapIn,
 	}
 
+	swapInBatchCommand = cli.Command{
+		Name:  "swapinbatch",
+		Usage: "Perform swap-ins with several peers that share one opening transaction",
+		Flags: []cli.Flag{
+			batchSwapFlag,
+			assetFlag,
+			PremiumLimitRatePPMFlag,
+		},
+		Action: swapInBatch,
+	}
+
+	retrySwapCommand = cli.Command{
+		Name:  "retryswap",
+		Usage: "Retry a canceled or failed swap with the same parameters",
+		Flags: []cli.Flag{
+			swapIdFlag,
+			retryAmountFlag,
+			PremiumLimitRatePPMFlag,
+			forceFlag,
+		},
+		Action: retrySwap,
+	}
+
+	finalizeSwapFundingCommand = cli.Command{
+		Name:  "finalizeswapfunding",
+		Usage: "Submit the signed opening transaction of an externally funded swap-in",
+		Flags: []cli.Flag{
+			swapIdFlag,
+			signedTxFlag,
+		},
+		Action: finalizeSwapFunding,
+	}
+
+	listQueuedSwapsCommand = cli.Command{
+		Name:   "listqueuedswaps",
+		Usage:  "List the swaps that wait for their channel to become free",
+		Action: listQueuedSwaps,
+	}
+
+	listDeferredSwapsCommand = cli.Command{
+		Name:   "listdeferredswaps",
+		Usage:  "List the swaps that wait for their peer to come online",
+		Action: listDeferredSwaps,
+	}
+
+	removeQueuedSwapCommand = cli.Command{
+		Name:  "removequeuedswap",
+		Usage: "Remove a queued or deferred swap before it is started",
+		Flags: []cli.Flag{
+			queuedSwapIdFlag,
+		},
+		Action: removeQueuedSwap,
+	}
+
+	createScheduleCommand = cli.Command{
+		Name:  "createschedule",
+		Usage: "Create a one-shot or recurring swap schedule, requires one of --run_at, --cron and --interval_sec",
+		Flags: []cli.Flag{
+			operationFlag,
+			scheduleChannelIdFlag,
+			assetFlag,
+			satAmountFlag,
+			maxAmountFlag,
+			PremiumLimitRatePPMFlag,
+			runAtFlag,
+			cronFlag,
+			intervalFlag,
+			onlyIfLocalBelowFlag,
+			onlyIfLocalAboveFlag,
+		},
+		Action: createSchedule,
+	}
+
+	listSchedulesCommand = cli.Command{
+		Name:   "listschedules",
+		Usage:  "List the swap schedules and their run history",
+		Action: listSchedules,
+	}
+
+	pauseScheduleCommand = cli.Command{
+		Name:  "pauseschedule",
+		Usage: "Pause a swap schedule, or resume it with --resume",
+		Flags: []cli.Flag{
+			scheduleIdFlag,
+			resumeFlag,
+		},
+		Action: pauseSchedule,
+	}
+
+	deleteScheduleCommand = cli.Command{
+		Name:  "deleteschedule",
+		Usage: "Delete a swap schedule",
+		Flags: []cli.Flag{
+			scheduleIdFlag,
+		},
+		Action: deleteSchedule,
+	}
+
 	getSwapCommand = cli.Command{
 		Name:  "getswap",
 		Usage: "Get a swap by its id",
 		Flags: []cli.Flag{
 			swapIdFlag,
+			verboseFlag,
 		},
 		Action: getSwap,
 	}
@@ -240,6 +504,23 @@ var (
 		Flags:  []cli.Flag{},
 		Action: stopPeerswap,
 	}
+	drainCommand = cli.Command{
+		Name:  "drain",
+		Usage: "Blocks new swaps until the active swaps are done, e.g. before an upgrade",
+		Flags: []cli.Flag{
+			drainStopFlag,
+			drainShutdownFlag,
+			drainStatusFlag,
+			drainFollowFlag,
+		},
+		Action: drain,
+	}
+	statusCommand = cli.Command{
+		Name:   "status",
+		Usage:  "Shows the status of the backends, e.g. the electrum endpoint in use",
+		Flags:  []cli.Flag{},
+		Action: getStatus,
+	}
 	getGlobalPremiumRateCommand = cli.Command{
 		Name:  "getglobalpremiumrate",
 		Usage: "Get the default premium rate for a specific asset and operation",
@@ -300,7 +581,78 @@ func swapIn(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
+	if ctx.Bool(dryRunFlag.Name) {
+		return preflightSwap(ctx, client, peerswaprpc.OperationType_SWAP_IN)
+	}
+
 	res, err := client.SwapIn(context.Background(), &peerswaprpc.SwapInRequest{
+		ChannelId:           ctx.Uint64(channelIdFlag.Name),
+		PeerPubkey:          ctx.String(swapPeerFlag.Name),
+		SwapAmount:          ctx.Uint64(satAmountFlag.Name),
+		Asset:               ctx.String(assetFlag.Name),
+		PremiumLimitRatePpm: ctx.Int64(PremiumLimitRatePPMFlag.Name),
+		Max:                 ctx.Bool(maxAmountFlag.Name),
+		LeaveLocalSat:       ctx.Uint64(leaveLocalSatFlag.Name),
+		Queue:               ctx.Bool(queueFlag.Name),
+		DeferUntilOnline:    ctx.Bool(deferFlag.Name),
+		DeferExpirySec:      ctx.Uint64(deferExpiryFlag.Name),
+		IdempotencyKey:      ctx.String(idempotencyKeyFlag.Name),
+		ClaimAddress:        ctx.String(claimAddressFlag.Name),
+		SatPerVbyte:         ctx.Float64(satPerVbyteFlag.Name),
+		TargetConf:          uint32(ctx.Uint(targetConfFlag.Name)),
+		Utxos:               ctx.StringSlice(utxoFlag.Name),
+		ExcludeUtxos:        ctx.StringSlice(excludeUtxoFlag.Name),
+		ExternalFunding:     ctx.Bool(externalFundingFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func swapInBatch(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var swaps []*peerswaprpc.BatchSwapIn
+	for _, entry := range ctx.StringSlice(batchSwapFlag.Name) {
+		chanIdStr, amountStr, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("invalid swap %s, expected channel_id:amount_sat", entry)
+		}
+		chanId, err := strconv.ParseUint(chanIdStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid channel_id %s: %v", chanIdStr, err)
+		}
+		amount, err := strconv.ParseUint(amountStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount_sat %s: %v", amountStr, err)
+		}
+		swaps = append(swaps, &peerswaprpc.BatchSwapIn{
+			ChannelId:           chanId,
+			SwapAmount:          amount,
+			PremiumLimitRatePpm: ctx.Int64(PremiumLimitRatePPMFlag.Name),
+		})
+	}
+
+	res, err := client.SwapInBatch(context.Background(), &peerswaprpc.SwapInBatchRequest{
+		Swaps: swaps,
+		Asset: ctx.String(assetFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func preflightSwap(ctx *cli.Context, client peerswaprpc.PeerSwapClient, operation peerswaprpc.OperationType) error {
+	res, err := client.PreflightSwap(context.Background(), &peerswaprpc.PreflightSwapRequest{
+		Operation:           operation,
 		ChannelId:           ctx.Uint64(channelIdFlag.Name),
 		SwapAmount:          ctx.Uint64(satAmountFlag.Name),
 		Asset:               ctx.String(assetFlag.Name),
@@ -321,11 +673,73 @@ func swapOut(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
+	if ctx.Bool(dryRunFlag.Name) {
+		return preflightSwap(ctx, client, peerswaprpc.OperationType_SWAP_OUT)
+	}
+
+	var extraChannelIds []uint64
+	for _, id := range ctx.StringSlice(extraChannelIdFlag.Name) {
+		chanId, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid extra_channel_id %s: %v", id, err)
+		}
+		extraChannelIds = append(extraChannelIds, chanId)
+	}
+
 	res, err := client.SwapOut(context.Background(), &peerswaprpc.SwapOutRequest{
 		ChannelId:           ctx.Uint64(channelIdFlag.Name),
+		PeerPubkey:          ctx.String(swapPeerFlag.Name),
 		SwapAmount:          ctx.Uint64(satAmountFlag.Name),
 		Asset:               ctx.String(assetFlag.Name),
 		PremiumLimitRatePpm: ctx.Int64(PremiumLimitRatePPMFlag.Name),
+		Max:                 ctx.Bool(maxAmountFlag.Name),
+		LeaveLocalSat:       ctx.Uint64(leaveLocalSatFlag.Name),
+		Queue:               ctx.Bool(queueFlag.Name),
+		DeferUntilOnline:    ctx.Bool(deferFlag.Name),
+		DeferExpirySec:      ctx.Uint64(deferExpiryFlag.Name),
+		IdempotencyKey:      ctx.String(idempotencyKeyFlag.Name),
+		ExtraChannelIds:     extraChannelIds,
+		ClaimAddress:        ctx.String(claimAddressFlag.Name),
+		SatPerVbyte:         ctx.Float64(satPerVbyteFlag.Name),
+		TargetConf:          uint32(ctx.Uint(targetConfFlag.Name)),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func retrySwap(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.RetrySwap(context.Background(), &peerswaprpc.RetrySwapRequest{
+		SwapId:              ctx.String(swapIdFlag.Name),
+		SwapAmount:          ctx.Uint64(retryAmountFlag.Name),
+		PremiumLimitRatePpm: ctx.Int64(PremiumLimitRatePPMFlag.Name),
+		Force:               ctx.Bool(forceFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func finalizeSwapFunding(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.FinalizeSwapFunding(context.Background(), &peerswaprpc.FinalizeSwapFundingRequest{
+		SwapId:   ctx.String(swapIdFlag.Name),
+		SignedTx: ctx.String(signedTxFlag.Name),
 	})
 	if err != nil {
 		return err
@@ -341,6 +755,17 @@ func getSwap(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
+	if ctx.Bool(verboseFlag.Name) {
+		res, err := client.GetSwapHistory(context.Background(), &peerswaprpc.GetSwapHistoryRequest{
+			SwapId: ctx.String(swapIdFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		printRespJSON(res)
+		return nil
+	}
+
 	res, err := client.GetSwap(context.Background(), &peerswaprpc.GetSwapRequest{
 		SwapId: ctx.String(swapIdFlag.Name),
 	})
@@ -485,6 +910,130 @@ func listActiveSwaps(ctx *cli.Context) error {
 	return nil
 }
 
+func listQueuedSwaps(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.ListQueuedSwaps(context.Background(), &peerswaprpc.ListQueuedSwapsRequest{})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func listDeferredSwaps(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.ListDeferredSwaps(context.Background(), &peerswaprpc.ListDeferredSwapsRequest{})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func removeQueuedSwap(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.RemoveQueuedSwap(context.Background(), &peerswaprpc.RemoveQueuedSwapRequest{
+		Id: ctx.String(queuedSwapIdFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func createSchedule(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.CreateSwapSchedule(context.Background(), &peerswaprpc.CreateSwapScheduleRequest{
+		Operation:           peerswaprpc.OperationType(peerswaprpc.OperationType_value[strings.ToUpper(ctx.String(operationFlag.Name))]),
+		ChannelId:           ctx.Uint64(scheduleChannelIdFlag.Name),
+		Asset:               ctx.String(assetFlag.Name),
+		SwapAmount:          ctx.Uint64(satAmountFlag.Name),
+		Max:                 ctx.Bool(maxAmountFlag.Name),
+		PremiumLimitRatePpm: ctx.Int64(PremiumLimitRatePPMFlag.Name),
+		RunAt:               ctx.Int64(runAtFlag.Name),
+		Cron:                ctx.String(cronFlag.Name),
+		IntervalSec:         ctx.Uint64(intervalFlag.Name),
+		OnlyIfLocalBelowSat: ctx.Uint64(onlyIfLocalBelowFlag.Name),
+		OnlyIfLocalAboveSat: ctx.Uint64(onlyIfLocalAboveFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func listSchedules(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.ListSwapSchedules(context.Background(), &peerswaprpc.ListSwapSchedulesRequest{})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func pauseSchedule(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.PauseSwapSchedule(context.Background(), &peerswaprpc.PauseSwapScheduleRequest{
+		Id:     ctx.String(scheduleIdFlag.Name),
+		Paused: !ctx.Bool(resumeFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func deleteSchedule(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.DeleteSwapSchedule(context.Background(), &peerswaprpc.DeleteSwapScheduleRequest{
+		Id: ctx.String(scheduleIdFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
 func allowSwaps(ctx *cli.Context) error {
 	client, cleanup, err := getClient(ctx)
 	if err != nil {
@@ -582,6 +1131,76 @@ func stopPeerswap(ctx *cli.Context) error {
 	return nil
 }
 
+func getStatus(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	res, err := client.GetStatus(context.Background(), &peerswaprpc.GetStatusRequest{})
+	if err != nil {
+		return err
+	}
+	printRespJSON(res)
+	return nil
+}
+
+func drain(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	req := &peerswaprpc.DrainRequest{
+		Stop:     ctx.Bool(drainStopFlag.Name),
+		Shutdown: ctx.Bool(drainShutdownFlag.Name),
+		Status:   ctx.Bool(drainStatusFlag.Name),
+	}
+	res, err := client.Drain(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if !ctx.Bool(drainFollowFlag.Name) || req.Stop {
+		printRespJSON(res)
+		return nil
+	}
+
+	for {
+		if !res.Draining {
+			fmt.Println("drain was stopped")
+			return nil
+		}
+		printDrainProgress(res)
+		if len(res.ActiveSwaps) == 0 {
+			fmt.Println("drain done")
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+		res, err = client.Drain(context.Background(), &peerswaprpc.DrainRequest{Status: true})
+		if err != nil {
+			if req.Shutdown {
+				fmt.Println("daemon is not reachable anymore, it probably shut down after the drain")
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func printDrainProgress(res *peerswaprpc.DrainResponse) {
+	fmt.Printf("%s draining, %d active swaps left\n", time.Now().Format(time.TimeOnly), len(res.ActiveSwaps))
+	for _, left := range res.ActiveSwaps {
+		completion := "unknown"
+		if left.ExpectedCompletionHeight > 0 {
+			completion = fmt.Sprintf("%d (current %d)", left.ExpectedCompletionHeight, left.CurrentHeight)
+		}
+		fmt.Printf("  %s %s %s %s, done by height %s\n",
+			left.Swap.Id, left.Swap.Asset, left.Swap.Type, left.Swap.State, completion)
+	}
+}
+
 func getGlobalPremiumRate(ctx *cli.Context) error {
 	client, cleanup, err := getClient(ctx)
 	if err != nil {
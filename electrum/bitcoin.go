@@ -0,0 +1,110 @@
+package electrum
+
+import (
+	"context"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/elementsproject/peerswap/log"
+	"github.com/elementsproject/peerswap/onchain"
+)
+
+const (
+	// requestTimeout is the timeout of the fee and broadcast requests.
+	requestTimeout = 20 * time.Second
+
+	// witnessScaleFactor is the discount for witness data.
+	witnessScaleFactor = 4
+)
+
+// FeeEstimator estimates the fee rate with the blockchain.estimatefee call
+// of an electrum server. It is used for Bitcoin swaps without a bitcoind.
+type FeeEstimator struct {
+	rpc RPC
+
+	// fallbackFeeRate is returned in case that the server has an error or
+	// no estimate. This value is in sat/kw.
+	fallbackFeeRate btcutil.Amount
+
+	// feeFloorSatPerKw is the minimum fee rate that is returned, even if the
+	// relay fee of the server is lower.
+	feeFloorSatPerKw btcutil.Amount
+}
+
+var _ onchain.Estimator = (*FeeEstimator)(nil)
+
+func NewFeeEstimator(rpc RPC, fallbackFeeRate, feeFloorSatPerKw btcutil.Amount) *FeeEstimator {
+	return &FeeEstimator{
+		rpc:              rpc,
+		fallbackFeeRate:  fallbackFeeRate,
+		feeFloorSatPerKw: feeFloorSatPerKw,
+	}
+}
+
+// Start is necessary to implement the Estimator interface but is noop for
+// the FeeEstimator.
+func (e *FeeEstimator) Start() error {
+	return nil
+}
+
+// EstimateFeePerKW returns the estimated fee in sat/kw for a transaction
+// that should be confirmed in targetBlocks. The fee is at least the relay fee
+// of the server.
+func (e *FeeEstimator) EstimateFeePerKW(targetBlocks uint32) (btcutil.Amount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	floor := e.feeFloor(ctx)
+	feeRate, err := e.rpc.GetFee(ctx, targetBlocks)
+	if err == nil && feeRate > 0 {
+		var satPerKw btcutil.Amount
+		satPerKw, err = btcPerKbToSatPerKw(feeRate)
+		if err == nil {
+			return max(satPerKw, floor), nil
+		}
+	}
+	if err != nil {
+		log.Infof("Could not fetch on-chain fee from electrum: %v", err)
+	}
+	fallback := max(e.fallbackFeeRate, floor)
+	log.Debugf("No fee estimate from electrum, using fallback fee %d", fallback)
+	return fallback, nil
+}
+
+// feeFloor returns the relay fee of the server, but at least the fee floor.
+func (e *FeeEstimator) feeFloor(ctx context.Context) btcutil.Amount {
+	relayFee, err := e.rpc.GetRelayFee(ctx)
+	if err != nil {
+		log.Debugf("Could not fetch relay fee from electrum: %v", err)
+		return e.feeFloorSatPerKw
+	}
+	satPerKw, err := btcPerKbToSatPerKw(relayFee)
+	if err != nil {
+		return e.feeFloorSatPerKw
+	}
+	return max(satPerKw, e.feeFloorSatPerKw)
+}
+
+func btcPerKbToSatPerKw(feeRate float32) (btcutil.Amount, error) {
+	satPerKb, err := btcutil.NewAmount(float64(feeRate))
+	if err != nil {
+		return 0, err
+	}
+	return satPerKb / witnessScaleFactor, nil
+}
+
+// TxBroadcaster sends raw transactions through an electrum server.
+type TxBroadcaster struct {
+	rpc RPC
+}
+
+func NewTxBroadcaster(rpc RPC) *TxBroadcaster {
+	return &TxBroadcaster{rpc: rpc}
+}
+
+// SendRawTx broadcasts the transaction and returns its txid.
+func (b *TxBroadcaster) SendRawTx(txHex string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return b.rpc.BroadcastTransaction(ctx, txHex)
+}
@@ -0,0 +1,52 @@
+package electrum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+func TestFeeEstimator(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fee      float32
+		feeErr   error
+		relayFee float32
+		want     btcutil.Amount
+	}{
+		{name: "estimate", fee: 0.0002, relayFee: 0.00001, want: 5000},
+		{name: "estimate below relay fee", fee: 0.00001, relayFee: 0.00004, want: 1000},
+		{name: "estimate below floor", fee: 0.000004, relayFee: 0.000001, want: 253},
+		{name: "no estimate", fee: -1, relayFee: 0.00001, want: 6250},
+		{name: "error", feeErr: errors.New("timeout"), relayFee: 0.00001, want: 6250},
+		{name: "relay fee above fallback", fee: -1, relayFee: 0.0004, want: 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rpc := &observerRPC{fee: tt.fee, feeErr: tt.feeErr, relayFee: tt.relayFee}
+			estimator := NewFeeEstimator(rpc, 6250, 253)
+			got, err := estimator.EstimateFeePerKW(6)
+			if err != nil {
+				t.Fatalf("EstimateFeePerKW() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("EstimateFeePerKW() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxBroadcaster(t *testing.T) {
+	t.Parallel()
+	rpc := &observerRPC{}
+	txid, err := NewTxBroadcaster(rpc).SendRawTx("rawtx")
+	if err != nil {
+		t.Fatalf("SendRawTx() error = %v", err)
+	}
+	if txid != "txid" || len(rpc.broadcast) != 1 || rpc.broadcast[0] != "rawtx" {
+		t.Fatalf("SendRawTx() = %s, broadcast %v", txid, rpc.broadcast)
+	}
+}
@@ -26,24 +26,24 @@ type BlockHeaderSubscriber interface {
 	Update(ctx context.Context, blockHeight BlockHeight) error
 }
 
-type liquidBlockHeaderSubscriber struct {
+type blockHeaderSubscriber struct {
 	txObservers []TXObserver
 	mu          sync.Mutex
 }
 
-func NewLiquidBlockHeaderSubscriber() *liquidBlockHeaderSubscriber {
-	return &liquidBlockHeaderSubscriber{}
+func NewBlockHeaderSubscriber() *blockHeaderSubscriber {
+	return &blockHeaderSubscriber{}
 }
 
-var _ BlockHeaderSubscriber = (*liquidBlockHeaderSubscriber)(nil)
+var _ BlockHeaderSubscriber = (*blockHeaderSubscriber)(nil)
 
-func (h *liquidBlockHeaderSubscriber) Register(tx TXObserver) {
+func (h *blockHeaderSubscriber) Register(tx TXObserver) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.txObservers = append(h.txObservers, tx)
 }
 
-func (h *liquidBlockHeaderSubscriber) Deregister(o TXObserver) {
+func (h *blockHeaderSubscriber) Deregister(o TXObserver) {
 	newObservers := make([]TXObserver, 0, len(h.txObservers))
 	for _, observer := range h.txObservers {
 		if observer.GetSwapID() != o.GetSwapID() {
@@ -53,7 +53,7 @@ func (h *liquidBlockHeaderSubscriber) Deregister(o TXObserver) {
 	h.txObservers = newObservers
 }
 
-func (h *liquidBlockHeaderSubscriber) Update(ctx context.Context, blockHeight BlockHeight) error {
+func (h *blockHeaderSubscriber) Update(ctx context.Context, blockHeight BlockHeight) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	for _, observer := range h.txObservers {
@@ -71,7 +71,7 @@ func (h *liquidBlockHeaderSubscriber) Update(ctx context.Context, blockHeight Bl
 	return nil
 }
 
-func (h *liquidBlockHeaderSubscriber) Count() int {
+func (h *blockHeaderSubscriber) Count() int {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	return len(h.txObservers)
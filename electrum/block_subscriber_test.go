@@ -37,7 +37,7 @@ func TestConcurrentUpdate(t *testing.T) {
 		}
 	}
 
-	h := &liquidBlockHeaderSubscriber{
+	h := &blockHeaderSubscriber{
 		txObservers: observers,
 	}
 
@@ -60,7 +60,7 @@ func TestConcurrentUpdate(t *testing.T) {
 	}
 }
 
-func Test_liquidBlockHeaderSubscriber_Update(t *testing.T) {
+func Test_blockHeaderSubscriber_Update(t *testing.T) {
 	t.Parallel()
 	var blockHeight BlockHeight = 10
 	tests := map[string]struct {
@@ -101,11 +101,11 @@ func Test_liquidBlockHeaderSubscriber_Update(t *testing.T) {
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			h := &liquidBlockHeaderSubscriber{
+			h := &blockHeaderSubscriber{
 				txObservers: tt.txObservers,
 			}
 			if err := h.Update(context.Background(), blockHeight); (err != nil) != tt.wantErr {
-				t.Errorf("liquidBlockHeaderSubscriber.Update() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("blockHeaderSubscriber.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if len(h.txObservers) != tt.count {
 				t.Errorf("Expected length %d, but got %d", tt.count, len(h.txObservers))
@@ -3,8 +3,11 @@ package electrum
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -12,39 +15,296 @@ import (
 	"github.com/elementsproject/peerswap/log"
 )
 
+const (
+	// scripthashNotificationBuffer is the number of status changes that are
+	// buffered until they are read from ScripthashNotifications.
+	scripthashNotificationBuffer = 64
+	// endpointRetryInterval is the time after which an endpoint that failed
+	// its health check is tried again, as long as another one is healthy.
+	endpointRetryInterval = time.Minute
+	// shutdownGracePeriod is the time a replaced client has to report that
+	// its connection broke before it is shut down.
+	shutdownGracePeriod = time.Second
+)
+
+// healthCheckTimeout is the time an endpoint has to connect and answer a
+// ping.
+var healthCheckTimeout = 10 * time.Second
+
+// Endpoint is the address of an electrum server.
+type Endpoint struct {
+	Address string
+	TLS     bool
+}
+
+func (e Endpoint) String() string {
+	if e.TLS {
+		return "ssl://" + e.Address
+	}
+	return "tcp://" + e.Address
+}
+
+// ParseEndpoint parses an endpoint of the form ssl://host:port or
+// tcp://host:port.
+func ParseEndpoint(endpoint string) (Endpoint, error) {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return Endpoint{}, err
+	}
+	if u.Scheme != "ssl" && u.Scheme != "tcp" {
+		return Endpoint{}, fmt.Errorf("expected ssl or tcp scheme, got %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return Endpoint{}, fmt.Errorf("missing host in electrum endpoint %s", endpoint)
+	}
+	return Endpoint{Address: u.Host, TLS: u.Scheme == "ssl"}, nil
+}
+
+// EndpointStatus is the health of an electrum server of the client.
+type EndpointStatus struct {
+	Endpoint
+	// Active is set for the server that the client is connected to.
+	Active bool
+	// LastError is the error of the last failed health check, it is empty
+	// once the server passed a health check.
+	LastError string
+}
+
 type electrumClient struct {
-	client   *electrum.Client
-	endpoint string
-	isTLS    bool
+	// connectMu serializes health checks and failovers.
+	connectMu sync.Mutex
+
+	mu     sync.Mutex
+	client *electrum.Client
+	// endpoints are ordered by preference.
+	endpoints []Endpoint
+	active    int
+	lastErrs  []error
+	retryAt   []time.Time
+
+	// scripthashes are subscribed again when the client reconnects.
+	scripthashes  map[string]struct{}
+	scripthashSub *electrum.ScripthashSubscription
+	// stopForwarding stops forwarding the notifications of the
+	// subscriptions once the client is replaced.
+	stopForwarding chan struct{}
+	notifications  chan *electrum.SubscribeNotif
+	// headersSubscribed is set once the headers are subscribed, they are
+	// subscribed again on every new client and sent to headers.
+	headersSubscribed bool
+	headersOnClient   bool
+	headers           chan *electrum.SubscribeHeadersResult
 }
 
 func NewElectrumClient(ctx context.Context, endpoint string, isTLS bool) (RPC, error) {
-	ec, err := newClient(ctx, endpoint, isTLS)
+	return NewFailoverElectrumClient(ctx, []Endpoint{{Address: endpoint, TLS: isTLS}})
+}
+
+// NewFailoverElectrumClient connects to the first healthy endpoint. The
+// endpoints are ordered by preference. The client fails over to the next
+// healthy endpoint if the active one does not answer a ping, and returns to
+// a preferred endpoint on Reboot once it is healthy again.
+func NewFailoverElectrumClient(ctx context.Context, endpoints []Endpoint) (RPC, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no electrum endpoint set")
+	}
+	c := &electrumClient{
+		endpoints:     endpoints,
+		lastErrs:      make([]error, len(endpoints)),
+		retryAt:       make([]time.Time, len(endpoints)),
+		scripthashes:  make(map[string]struct{}),
+		notifications: make(chan *electrum.SubscribeNotif, scripthashNotificationBuffer),
+		headers:       make(chan *electrum.SubscribeHeadersResult, 1),
+	}
+	client, active, err := c.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	client := &electrumClient{
-		client:   ec,
-		endpoint: endpoint,
-		isTLS:    isTLS,
-	}
-	return client, nil
+	c.client, c.active = client, active
+	return c, nil
+}
+
+// current returns the client of the active endpoint.
+func (c *electrumClient) current() *electrum.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client
 }
 
-// reconnect reconnects to the electrum server if the connection is lost.
+// reconnect fails over to the first healthy endpoint if the active one does
+// not answer a ping.
 func (c *electrumClient) reconnect(ctx context.Context) error {
-	if err := c.client.Ping(ctx); err != nil {
-		log.Infof("failed to ping electrum server: %v", err)
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+	c.mu.Lock()
+	client, active := c.client, c.active
+	c.mu.Unlock()
+	pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := client.Ping(pingCtx); err != nil {
+		c.setHealth(active, err)
+		log.Infof("failed to ping electrum server %s: %v", c.endpoints[active], err)
 		log.Infof("reconnecting to electrum server")
-		client, err := newClient(ctx, c.endpoint, c.isTLS)
+		client, index, err := c.connect(ctx)
 		if err != nil {
 			return err
 		}
-		c.client = client
+		c.setClient(ctx, client, index)
 	}
 	return nil
 }
 
+// connect returns a client of the most preferred healthy endpoint.
+// Endpoints that failed recently are only tried if no other is healthy.
+func (c *electrumClient) connect(ctx context.Context) (*electrum.Client, int, error) {
+	now := time.Now()
+	var recentlyFailed []int
+	var errs []error
+	try := func(i int) *electrum.Client {
+		client, err := c.healthCheck(ctx, c.endpoints[i])
+		c.setHealth(i, err)
+		if err != nil {
+			log.Infof("electrum server %s is not healthy: %v", c.endpoints[i], err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.endpoints[i], err))
+			return nil
+		}
+		return client
+	}
+	for i := range c.endpoints {
+		c.mu.Lock()
+		retryAt := c.retryAt[i]
+		c.mu.Unlock()
+		if now.Before(retryAt) {
+			recentlyFailed = append(recentlyFailed, i)
+			continue
+		}
+		if client := try(i); client != nil {
+			return client, i, nil
+		}
+	}
+	for _, i := range recentlyFailed {
+		if client := try(i); client != nil {
+			return client, i, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("no healthy electrum server: %w", errors.Join(errs...))
+}
+
+// healthCheck connects to the endpoint and pings it.
+func (c *electrumClient) healthCheck(ctx context.Context, endpoint Endpoint) (*electrum.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	client, err := newClient(ctx, endpoint.Address, endpoint.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx); err != nil {
+		client.Shutdown()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (c *electrumClient) setHealth(i int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErrs[i] = err
+	if err != nil {
+		c.retryAt[i] = time.Now().Add(endpointRetryInterval)
+	} else {
+		c.retryAt[i] = time.Time{}
+	}
+}
+
+// setClient replaces the client and subscribes the headers and scripthashes
+// again. The replaced client is shut down.
+func (c *electrumClient) setClient(ctx context.Context, client *electrum.Client, active int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if active != c.active {
+		log.Infof("electrum: switched from %s to %s", c.endpoints[c.active], c.endpoints[active])
+	}
+	if c.client != nil && c.client != client {
+		go shutdown(c.client)
+	}
+	c.client, c.active = client, active
+	if c.stopForwarding != nil {
+		close(c.stopForwarding)
+	}
+	c.scripthashSub, c.stopForwarding, c.headersOnClient = nil, nil, false
+	if c.headersSubscribed {
+		if err := c.subscribeHeaders(ctx); err != nil {
+			log.Infof("failed to resubscribe headers: %v", err)
+		}
+	}
+	for scripthash := range c.scripthashes {
+		if err := c.addScripthash(ctx, scripthash); err != nil {
+			log.Infof("failed to resubscribe scripthash %s: %v", scripthash, err)
+		}
+	}
+}
+
+// shutdown shuts down a replaced client. A client whose connection broke
+// reports the error and then shuts itself down, shutting it down from here
+// as well would race with its listener.
+func shutdown(client *electrum.Client) {
+	select {
+	case <-client.Error:
+	case <-time.After(shutdownGracePeriod):
+		client.Shutdown()
+	}
+}
+
+// stop returns the channel that is closed once the current client is
+// replaced. The lock must be held.
+func (c *electrumClient) stop() chan struct{} {
+	if c.stopForwarding == nil {
+		c.stopForwarding = make(chan struct{})
+	}
+	return c.stopForwarding
+}
+
+// subscribeHeaders subscribes to the headers on the current client. The
+// lock must be held.
+func (c *electrumClient) subscribeHeaders(ctx context.Context) error {
+	if c.headersOnClient {
+		return nil
+	}
+	headers, err := c.client.SubscribeHeaders(ctx)
+	if err != nil {
+		return err
+	}
+	c.headersOnClient = true
+	go forward(headers, c.headers, c.stop())
+	return nil
+}
+
+// addScripthash subscribes to the scripthash on the current client. The
+// lock must be held.
+func (c *electrumClient) addScripthash(ctx context.Context, scripthash string) error {
+	if c.scripthashSub == nil {
+		sub, notifications := c.client.SubscribeScripthash()
+		go forward(notifications, c.notifications, c.stop())
+		c.scripthashSub = sub
+	}
+	return c.scripthashSub.Add(ctx, scripthash)
+}
+
+func forward[T any](from <-chan T, to chan<- T, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case n := <-from:
+			select {
+			case <-stop:
+				return
+			case to <- n:
+			}
+		}
+	}
+}
+
 func newClient(ctx context.Context, endpoint string, isTLS bool) (*electrum.Client, error) {
 	if isTLS {
 		return electrum.NewClientSSL(ctx, endpoint, &tls.Config{
@@ -54,25 +314,79 @@ func newClient(ctx context.Context, endpoint string, isTLS bool) (*electrum.Clie
 	return electrum.NewClientTCP(ctx, endpoint)
 }
 
+// Reboot reconnects to the most preferred healthy endpoint.
 func (c *electrumClient) Reboot(ctx context.Context) error {
-	c.client.Shutdown()
-	client, err := newClient(ctx, c.endpoint, c.isTLS)
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+	client, index, err := c.connect(ctx)
 	if err != nil {
 		return err
 	}
-	c.client = client
+	c.setClient(ctx, client, index)
 	return nil
 }
 
+// SubscribeHeaders returns the headers of the active endpoint. The headers
+// are subscribed again after a failover, so the returned channel keeps
+// receiving headers across endpoints.
 func (c *electrumClient) SubscribeHeaders(ctx context.Context) (<-chan *electrum.SubscribeHeadersResult, error) {
-	return c.client.SubscribeHeaders(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.subscribeHeaders(ctx); err != nil {
+		return nil, err
+	}
+	c.headersSubscribed = true
+	return c.headers, nil
+}
+
+// Endpoints returns the health of the endpoints in order of preference.
+func (c *electrumClient) Endpoints() []EndpointStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := make([]EndpointStatus, 0, len(c.endpoints))
+	for i, endpoint := range c.endpoints {
+		s := EndpointStatus{Endpoint: endpoint, Active: i == c.active}
+		if c.lastErrs[i] != nil {
+			s.LastError = c.lastErrs[i].Error()
+		}
+		status = append(status, s)
+	}
+	return status
 }
 
 func (c *electrumClient) GetHistory(ctx context.Context, scripthash string) ([]*electrum.GetMempoolResult, error) {
 	if err := c.reconnect(ctx); err != nil {
 		return nil, err
 	}
-	return c.client.GetHistory(ctx, scripthash)
+	return c.current().GetHistory(ctx, scripthash)
+}
+
+func (c *electrumClient) SubscribeScripthash(ctx context.Context, scripthash string) error {
+	if err := c.reconnect(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.addScripthash(ctx, scripthash); err != nil {
+		return err
+	}
+	c.scripthashes[scripthash] = struct{}{}
+	return nil
+}
+
+func (c *electrumClient) UnsubscribeScripthash(scripthash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.scripthashes, scripthash)
+	if c.scripthashSub != nil {
+		// The library does not send blockchain.scripthash.unsubscribe,
+		// the server drops the subscription once the client reconnects.
+		_ = c.scripthashSub.Remove(scripthash)
+	}
+}
+
+func (c *electrumClient) ScripthashNotifications() <-chan *electrum.SubscribeNotif {
+	return c.notifications
 }
 
 // GetRawTransaction retrieves the raw transaction data for a given transaction
@@ -89,7 +403,7 @@ func (c *electrumClient) GetRawTransaction(ctx context.Context, txHash string) (
 			return err
 		}
 		var innerErr error
-		rawTx, innerErr = c.client.GetRawTransaction(ctx, txHash)
+		rawTx, innerErr = c.current().GetRawTransaction(ctx, txHash)
 		return innerErr
 	})
 
@@ -121,14 +435,21 @@ func (c *electrumClient) BroadcastTransaction(ctx context.Context, rawTx string)
 	if err := c.reconnect(ctx); err != nil {
 		return "", err
 	}
-	return c.client.BroadcastTransaction(ctx, rawTx)
+	return c.current().BroadcastTransaction(ctx, rawTx)
 }
 
 func (c *electrumClient) GetFee(ctx context.Context, target uint32) (float32, error) {
 	if err := c.reconnect(ctx); err != nil {
 		return 0, err
 	}
-	return c.client.GetFee(ctx, target)
+	return c.current().GetFee(ctx, target)
+}
+
+func (c *electrumClient) GetRelayFee(ctx context.Context) (float32, error) {
+	if err := c.reconnect(ctx); err != nil {
+		return 0, err
+	}
+	return c.current().GetRelayFee(ctx)
 }
 
 func (c *electrumClient) Ping(ctx context.Context) error {
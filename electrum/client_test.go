@@ -0,0 +1,321 @@
+package electrum
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	goelectrum "github.com/checksum0/go-electrum/electrum"
+)
+
+// fakeElectrumServer answers the requests of the electrum client that are
+// needed for scripthash subscriptions and pushes status changes.
+type fakeElectrumServer struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	height   int32
+	status   map[string]string
+	requests map[string]int
+}
+
+func newFakeElectrumServer(t *testing.T) *fakeElectrumServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	s := &fakeElectrumServer{
+		listener: listener,
+		status:   make(map[string]string),
+		requests: make(map[string]int),
+	}
+	t.Cleanup(s.close)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// close stops the server and drops its connections.
+func (s *fakeElectrumServer) close() {
+	s.listener.Close()
+	s.dropConnections()
+}
+
+// dropConnections closes the connections of the server.
+func (s *fakeElectrumServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *fakeElectrumServer) endpoint() Endpoint {
+	return Endpoint{Address: s.listener.Addr().String()}
+}
+
+func (s *fakeElectrumServer) serve(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var req struct {
+			ID     uint64   `json:"id"`
+			Method string   `json:"method"`
+			Params []string `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.requests[req.Method]++
+		var result interface{}
+		switch req.Method {
+		case "blockchain.scripthash.subscribe":
+			result = s.status[req.Params[0]]
+		case "blockchain.headers.subscribe":
+			result = map[string]interface{}{"height": s.height, "hex": "00"}
+		}
+		s.mu.Unlock()
+		if err := s.write(conn, map[string]interface{}{"id": req.ID, "result": result}); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeElectrumServer) write(conn net.Conn, msg interface{}) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(b, '\n'))
+	return err
+}
+
+// setStatus changes the status of the scripthash and notifies the latest
+// connection.
+func (s *fakeElectrumServer) setStatus(scripthash, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status[scripthash] = status
+	_ = s.write(s.conns[len(s.conns)-1], map[string]interface{}{
+		"method": "blockchain.scripthash.subscribe",
+		"params": []string{scripthash, status},
+	})
+}
+
+// pushHeader notifies the latest connection of a new header.
+func (s *fakeElectrumServer) pushHeader(height int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.height = height
+	_ = s.write(s.conns[len(s.conns)-1], map[string]interface{}{
+		"method": "blockchain.headers.subscribe",
+		"params": []interface{}{map[string]interface{}{"height": height, "hex": "00"}},
+	})
+}
+
+func (s *fakeElectrumServer) requestCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method]
+}
+
+func receiveStatus(t *testing.T, notifications <-chan *goelectrum.SubscribeNotif) [2]string {
+	t.Helper()
+	select {
+	case n := <-notifications:
+		return n.Params
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scripthash notification received")
+	}
+	return [2]string{}
+}
+
+func TestElectrumClientScripthashSubscription(t *testing.T) {
+	server := newFakeElectrumServer(t)
+	server.status["sh"] = "s1"
+	ctx := context.Background()
+
+	addr := server.listener.Addr().String()
+	client, err := NewElectrumClient(ctx, addr, false)
+	if err != nil {
+		t.Fatalf("NewElectrumClient() error = %v", err)
+	}
+	// reconnect replaces a broken connection like reconnect does. The
+	// replaced client shuts itself down. This only synchronizes with its
+	// subscriptions once they received a notification, so every client gets
+	// one before it is replaced.
+	reconnect := func() {
+		t.Helper()
+		server.dropConnections()
+		old := client.(*electrumClient).current()
+		ec, err := newClient(ctx, addr, false)
+		if err != nil {
+			t.Fatalf("newClient() error = %v", err)
+		}
+		client.(*electrumClient).setClient(ctx, ec, 0)
+		deadline := time.Now().Add(5 * time.Second)
+		for !old.IsShutdown() {
+			if time.Now().After(deadline) {
+				t.Fatal("replaced client is not shut down")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if err := client.SubscribeScripthash(ctx, "sh"); err != nil {
+		t.Fatalf("SubscribeScripthash() error = %v", err)
+	}
+	notifications := client.ScripthashNotifications()
+	if got := receiveStatus(t, notifications); got != [2]string{"sh", "s1"} {
+		t.Fatalf("initial status = %v", got)
+	}
+
+	server.setStatus("sh", "s2")
+	if got := receiveStatus(t, notifications); got != [2]string{"sh", "s2"} {
+		t.Fatalf("changed status = %v", got)
+	}
+
+	// The scripthash is subscribed again on the new connection.
+	reconnect()
+	if got := receiveStatus(t, notifications); got != [2]string{"sh", "s2"} {
+		t.Fatalf("status after reboot = %v", got)
+	}
+	if got := server.requestCount("blockchain.scripthash.subscribe"); got != 2 {
+		t.Fatalf("subscribe requests = %d, want 2", got)
+	}
+	server.setStatus("sh", "s3")
+	if got := receiveStatus(t, notifications); got != [2]string{"sh", "s3"} {
+		t.Fatalf("changed status after reboot = %v", got)
+	}
+
+	client.UnsubscribeScripthash("sh")
+	reconnect()
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if got := server.requestCount("blockchain.scripthash.subscribe"); got != 2 {
+		t.Fatalf("subscribe requests after unsubscribe = %d, want 2", got)
+	}
+}
+
+func TestElectrumClientFailover(t *testing.T) {
+	healthCheckTimeout = 500 * time.Millisecond
+	t.Cleanup(func() { healthCheckTimeout = 10 * time.Second })
+
+	primary := newFakeElectrumServer(t)
+	primary.height = 100
+	fallback := newFakeElectrumServer(t)
+	fallback.height = 101
+	ctx := context.Background()
+
+	rpc, err := NewFailoverElectrumClient(ctx, []Endpoint{primary.endpoint(), fallback.endpoint()})
+	if err != nil {
+		t.Fatalf("NewFailoverElectrumClient() error = %v", err)
+	}
+	client := rpc.(*electrumClient)
+	headers, err := client.SubscribeHeaders(ctx)
+	if err != nil {
+		t.Fatalf("SubscribeHeaders() error = %v", err)
+	}
+	if got := (<-headers).Height; got != 100 {
+		t.Fatalf("header height = %d, want 100", got)
+	}
+	if err := client.SubscribeScripthash(ctx, "sh"); err != nil {
+		t.Fatalf("SubscribeScripthash() error = %v", err)
+	}
+	// The subscriptions of the primary receive a notification before it
+	// shuts itself down, see TestElectrumClientScripthashSubscription.
+	primary.pushHeader(102)
+	if got := (<-headers).Height; got != 102 {
+		t.Fatalf("header height = %d, want 102", got)
+	}
+	primary.setStatus("sh", "s1")
+	receiveStatus(t, client.ScripthashNotifications())
+
+	// The primary goes down, the headers and scripthashes are subscribed
+	// on the fallback and the client of the primary is shut down.
+	primaryClient := client.current()
+	primary.close()
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !primaryClient.IsShutdown() {
+		if time.Now().After(deadline) {
+			t.Fatal("client of the primary is not shut down")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case header := <-headers:
+		if header.Height != 101 {
+			t.Fatalf("header height after failover = %d, want 101", header.Height)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no header received after failover")
+	}
+	if got := fallback.requestCount("blockchain.scripthash.subscribe"); got != 1 {
+		t.Fatalf("subscribe requests on fallback = %d, want 1", got)
+	}
+	status := client.Endpoints()
+	if status[0].Active || status[0].LastError == "" {
+		t.Fatalf("primary status = %+v", status[0])
+	}
+	if !status[1].Active || status[1].LastError != "" {
+		t.Fatalf("fallback status = %+v", status[1])
+	}
+
+	// A failed endpoint is only tried again after the retry interval, as
+	// long as another one is healthy.
+	_, active, err := client.connect(ctx)
+	if err != nil {
+		t.Fatalf("connect() error = %v", err)
+	}
+	if active != 1 {
+		t.Fatalf("active endpoint = %d, want 1", active)
+	}
+
+	// Nothing is healthy.
+	fallback.close()
+	if err := client.Ping(ctx); err == nil {
+		t.Fatal("expected an error without a healthy endpoint")
+	}
+}
+
+func TestParseEndpoint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		endpoint string
+		want     Endpoint
+		wantErr  bool
+	}{
+		{endpoint: "ssl://electrum.example.com:50002", want: Endpoint{Address: "electrum.example.com:50002", TLS: true}},
+		{endpoint: "tcp://127.0.0.1:50001", want: Endpoint{Address: "127.0.0.1:50001"}},
+		{endpoint: "http://127.0.0.1:50001", wantErr: true},
+		{endpoint: "127.0.0.1:50001", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseEndpoint(tt.endpoint)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseEndpoint(%s) error = %v, wantErr %v", tt.endpoint, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseEndpoint(%s) = %+v, want %+v", tt.endpoint, got, tt.want)
+		}
+	}
+}
@@ -9,9 +9,27 @@ import (
 type RPC interface {
 	SubscribeHeaders(ctx context.Context) (<-chan *electrum.SubscribeHeadersResult, error)
 	GetHistory(ctx context.Context, scripthash string) ([]*electrum.GetMempoolResult, error)
+	// SubscribeScripthash subscribes to status changes of the scripthash
+	// with blockchain.scripthash.subscribe. The current status and every
+	// change are sent to ScripthashNotifications. The subscription is
+	// renewed when the client reconnects.
+	SubscribeScripthash(ctx context.Context, scripthash string) error
+	// UnsubscribeScripthash stops sending status changes of the scripthash.
+	UnsubscribeScripthash(scripthash string)
+	// ScripthashNotifications returns the status changes of the subscribed
+	// scripthashes.
+	ScripthashNotifications() <-chan *electrum.SubscribeNotif
 	GetRawTransaction(ctx context.Context, txHash string) (string, error)
 	BroadcastTransaction(ctx context.Context, rawTx string) (string, error)
+	// GetFee returns the fee rate in BTC/kB for a confirmation within target
+	// blocks, or -1 if the server has no estimate.
 	GetFee(ctx context.Context, target uint32) (float32, error)
+	// GetRelayFee returns the minimum fee rate in BTC/kB that the server
+	// relays transactions with.
+	GetRelayFee(ctx context.Context) (float32, error)
 	Ping(ctx context.Context) error
+	// Reboot reconnects to the most preferred healthy endpoint.
 	Reboot(ctx context.Context) error
+	// Endpoints returns the health of the endpoints in order of preference.
+	Endpoints() []EndpointStatus
 }
@@ -0,0 +1,165 @@
+package electrum
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/checksum0/go-electrum/electrum"
+	"github.com/elementsproject/peerswap/log"
+)
+
+// historyReconcileInterval is the time after which a cached history is
+// fetched again even if no status change was notified, as the electrum
+// client drops notifications that are not read in time.
+const historyReconcileInterval = 10 * time.Minute
+
+// SubscribedHistory serves the history of scripthashes from a cache that is
+// invalidated by blockchain.scripthash.subscribe notifications, so that
+// observers do not query the server for every watched script on every
+// block. The remaining methods are passed to the RPC.
+type SubscribedHistory struct {
+	RPC
+
+	mu      sync.Mutex
+	entries map[string]*historyEntry
+	changed chan struct{}
+	now     func() time.Time
+}
+
+type historyEntry struct {
+	subscribed       bool
+	subscribeTriedAt time.Time
+	// status is the last status notified by the server.
+	status string
+	// stale is set if the status changed since the history was fetched.
+	stale     bool
+	fetched   bool
+	history   []*electrum.GetMempoolResult
+	fetchedAt time.Time
+	// used is set if the history was requested since the last sweep.
+	used bool
+}
+
+var _ RPC = (*SubscribedHistory)(nil)
+
+func NewSubscribedHistory(rpc RPC) *SubscribedHistory {
+	return &SubscribedHistory{
+		RPC:     rpc,
+		entries: make(map[string]*historyEntry),
+		changed: make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Start handles the status notifications of the RPC until the context is
+// done.
+func (h *SubscribedHistory) Start(ctx context.Context) {
+	notifications := h.RPC.ScripthashNotifications()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n, ok := <-notifications:
+				if !ok {
+					return
+				}
+				if n != nil {
+					h.onStatus(n.Params[0], n.Params[1])
+				}
+			}
+		}
+	}()
+}
+
+// Changed receives a value after the status of a subscribed scripthash
+// changed.
+func (h *SubscribedHistory) Changed() <-chan struct{} {
+	return h.changed
+}
+
+func (h *SubscribedHistory) onStatus(scripthash, status string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e, ok := h.entries[scripthash]
+	if !ok || e.status == status {
+		return
+	}
+	log.Debugf("electrum: status of scripthash %s changed", scripthash)
+	e.status = status
+	e.stale = true
+	select {
+	case h.changed <- struct{}{}:
+	default:
+	}
+}
+
+// GetHistory returns the cached history of the scripthash. The history is
+// fetched if the scripthash is not subscribed, its status changed or the
+// cache is older than historyReconcileInterval.
+func (h *SubscribedHistory) GetHistory(ctx context.Context, scripthash string) ([]*electrum.GetMempoolResult, error) {
+	h.mu.Lock()
+	now := h.now()
+	e, ok := h.entries[scripthash]
+	if !ok {
+		e = &historyEntry{}
+		h.entries[scripthash] = e
+	}
+	e.used = true
+	if e.subscribed && e.fetched && !e.stale && now.Sub(e.fetchedAt) < historyReconcileInterval {
+		history := e.history
+		h.mu.Unlock()
+		return history, nil
+	}
+	// A status change while fetching marks the entry stale again.
+	e.stale = false
+	subscribe := !e.subscribed && (e.subscribeTriedAt.IsZero() || now.Sub(e.subscribeTriedAt) >= historyReconcileInterval)
+	if subscribe {
+		e.subscribeTriedAt = now
+	}
+	h.mu.Unlock()
+
+	if subscribe {
+		// The status is subscribed before the history is fetched, so that
+		// no change is missed in between.
+		if err := h.RPC.SubscribeScripthash(ctx, scripthash); err != nil {
+			log.Infof("electrum: failed to subscribe scripthash %s, polling instead: %v", scripthash, err)
+		} else {
+			h.mu.Lock()
+			e.subscribed = true
+			h.mu.Unlock()
+		}
+	}
+
+	history, err := h.RPC.GetHistory(ctx, scripthash)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil {
+		e.stale = true
+		return nil, err
+	}
+	e.fetched = true
+	e.history = history
+	e.fetchedAt = now
+	return history, nil
+}
+
+// Sweep drops the entries that were not requested since the previous sweep.
+// It is called after the tx observers were updated, every observer that is
+// still registered requests its history on an update, so only the histories
+// of deregistered observers are dropped, independent of the block interval.
+func (h *SubscribedHistory) Sweep() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for scripthash, e := range h.entries {
+		if e.used {
+			e.used = false
+			continue
+		}
+		delete(h.entries, scripthash)
+		if e.subscribed {
+			h.RPC.UnsubscribeScripthash(scripthash)
+		}
+	}
+}
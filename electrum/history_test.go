@@ -0,0 +1,94 @@
+package electrum
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goelectrum "github.com/checksum0/go-electrum/electrum"
+)
+
+func checkHistoryCalls(t *testing.T, h *SubscribedHistory, rpc *observerRPC, scripthash string, want int) {
+	t.Helper()
+	if _, err := h.GetHistory(context.Background(), scripthash); err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if rpc.historyCalls != want {
+		t.Fatalf("history calls = %d, want %d", rpc.historyCalls, want)
+	}
+}
+
+func TestSubscribedHistory(t *testing.T) {
+	rpc := &observerRPC{
+		history:       []*goelectrum.GetMempoolResult{{Hash: "1", Height: 100}},
+		notifications: make(chan *goelectrum.SubscribeNotif),
+	}
+	h := NewSubscribedHistory(rpc)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h.Start(ctx)
+
+	// The history is fetched once and then served from the cache.
+	checkHistoryCalls(t, h, rpc, "sh", 1)
+	checkHistoryCalls(t, h, rpc, "sh", 1)
+	if !rpc.subscribed["sh"] {
+		t.Fatal("scripthash not subscribed")
+	}
+
+	// A status change invalidates the cache.
+	rpc.notifications <- &goelectrum.SubscribeNotif{Params: [2]string{"sh", "status"}}
+	select {
+	case <-h.Changed():
+	case <-time.After(time.Second):
+		t.Fatal("status change not signaled")
+	}
+	checkHistoryCalls(t, h, rpc, "sh", 2)
+
+	// The same status is sent again after a reconnect.
+	rpc.notifications <- &goelectrum.SubscribeNotif{Params: [2]string{"sh", "status"}}
+	checkHistoryCalls(t, h, rpc, "sh", 2)
+
+	// The history is reconciled periodically.
+	now = now.Add(historyReconcileInterval - time.Second)
+	checkHistoryCalls(t, h, rpc, "sh", 2)
+	now = now.Add(time.Second)
+	checkHistoryCalls(t, h, rpc, "sh", 3)
+
+	// A scripthash that is requested on every update is kept, also if the
+	// updates are further apart than the reconcile interval.
+	h.Sweep()
+	now = now.Add(2 * historyReconcileInterval)
+	checkHistoryCalls(t, h, rpc, "sh", 4)
+	h.Sweep()
+	if !rpc.subscribed["sh"] {
+		t.Fatal("scripthash unsubscribed while watched")
+	}
+
+	// Scripthashes that are no longer watched are unsubscribed.
+	checkHistoryCalls(t, h, rpc, "other", 5)
+	h.Sweep()
+	if rpc.subscribed["sh"] {
+		t.Fatal("scripthash still subscribed")
+	}
+	if !rpc.subscribed["other"] {
+		t.Fatal("requested scripthash unsubscribed")
+	}
+}
+
+func TestSubscribedHistoryPollsWithoutSubscription(t *testing.T) {
+	rpc := &observerRPC{subscribeErr: errors.New("not supported")}
+	h := NewSubscribedHistory(rpc)
+
+	checkHistoryCalls(t, h, rpc, "sh", 1)
+	checkHistoryCalls(t, h, rpc, "sh", 2)
+
+	// Subscribing is retried after the reconcile interval.
+	rpc.subscribeErr = nil
+	checkHistoryCalls(t, h, rpc, "sh", 3)
+	h.now = func() time.Time { return time.Now().Add(historyReconcileInterval) }
+	checkHistoryCalls(t, h, rpc, "sh", 4)
+	checkHistoryCalls(t, h, rpc, "sh", 4)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	electrum "github.com/checksum0/go-electrum/electrum"
+	electrum0 "github.com/elementsproject/peerswap/electrum"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,6 +56,20 @@ func (mr *MockRPCMockRecorder) BroadcastTransaction(ctx, rawTx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTransaction", reflect.TypeOf((*MockRPC)(nil).BroadcastTransaction), ctx, rawTx)
 }
 
+// Endpoints mocks base method.
+func (m *MockRPC) Endpoints() []electrum0.EndpointStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Endpoints")
+	ret0, _ := ret[0].([]electrum0.EndpointStatus)
+	return ret0
+}
+
+// Endpoints indicates an expected call of Endpoints.
+func (mr *MockRPCMockRecorder) Endpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Endpoints", reflect.TypeOf((*MockRPC)(nil).Endpoints))
+}
+
 // GetFee mocks base method.
 func (m *MockRPC) GetFee(ctx context.Context, target uint32) (float32, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +115,21 @@ func (mr *MockRPCMockRecorder) GetRawTransaction(ctx, txHash any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRawTransaction", reflect.TypeOf((*MockRPC)(nil).GetRawTransaction), ctx, txHash)
 }
 
+// GetRelayFee mocks base method.
+func (m *MockRPC) GetRelayFee(ctx context.Context) (float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelayFee", ctx)
+	ret0, _ := ret[0].(float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelayFee indicates an expected call of GetRelayFee.
+func (mr *MockRPCMockRecorder) GetRelayFee(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelayFee", reflect.TypeOf((*MockRPC)(nil).GetRelayFee), ctx)
+}
+
 // Ping mocks base method.
 func (m *MockRPC) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -128,6 +158,20 @@ func (mr *MockRPCMockRecorder) Reboot(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reboot", reflect.TypeOf((*MockRPC)(nil).Reboot), ctx)
 }
 
+// ScripthashNotifications mocks base method.
+func (m *MockRPC) ScripthashNotifications() <-chan *electrum.SubscribeNotif {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScripthashNotifications")
+	ret0, _ := ret[0].(<-chan *electrum.SubscribeNotif)
+	return ret0
+}
+
+// ScripthashNotifications indicates an expected call of ScripthashNotifications.
+func (mr *MockRPCMockRecorder) ScripthashNotifications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScripthashNotifications", reflect.TypeOf((*MockRPC)(nil).ScripthashNotifications))
+}
+
 // SubscribeHeaders mocks base method.
 func (m *MockRPC) SubscribeHeaders(ctx context.Context) (<-chan *electrum.SubscribeHeadersResult, error) {
 	m.ctrl.T.Helper()
@@ -142,3 +186,29 @@ func (mr *MockRPCMockRecorder) SubscribeHeaders(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeHeaders", reflect.TypeOf((*MockRPC)(nil).SubscribeHeaders), ctx)
 }
+
+// SubscribeScripthash mocks base method.
+func (m *MockRPC) SubscribeScripthash(ctx context.Context, scripthash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeScripthash", ctx, scripthash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeScripthash indicates an expected call of SubscribeScripthash.
+func (mr *MockRPCMockRecorder) SubscribeScripthash(ctx, scripthash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeScripthash", reflect.TypeOf((*MockRPC)(nil).SubscribeScripthash), ctx, scripthash)
+}
+
+// UnsubscribeScripthash mocks base method.
+func (m *MockRPC) UnsubscribeScripthash(scripthash string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnsubscribeScripthash", scripthash)
+}
+
+// UnsubscribeScripthash indicates an expected call of UnsubscribeScripthash.
+func (mr *MockRPCMockRecorder) UnsubscribeScripthash(scripthash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeScripthash", reflect.TypeOf((*MockRPC)(nil).UnsubscribeScripthash), scripthash)
+}
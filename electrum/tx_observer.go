@@ -50,6 +50,7 @@ type observeOpeningTX struct {
 	cb             confirmationCallback
 	startingHeight uint32
 	paymentWindow  uint32
+	confirmations  uint32
 }
 
 var _ TXObserver = (*observeOpeningTX)(nil)
@@ -61,7 +62,8 @@ func NewObserveOpeningTX(
 	electrumClient RPC,
 	cb confirmationCallback,
 	startingHeight,
-	paymentWindow uint32,
+	paymentWindow,
+	confirmations uint32,
 ) observeOpeningTX {
 	return observeOpeningTX{
 		swapID:         swapID,
@@ -71,6 +73,7 @@ func NewObserveOpeningTX(
 		cb:             cb,
 		startingHeight: startingHeight,
 		paymentWindow:  paymentWindow,
+		confirmations:  confirmations,
 	}
 }
 
@@ -135,7 +138,7 @@ func (o *observeOpeningTX) Callback(ctx context.Context, currentHeight BlockHeig
 		return false, nil
 	}
 	confirmed, err := hasConfirmations(
-		txHeight, currentHeight, uint32(onchain.LiquidConfs),
+		txHeight, currentHeight, o.confirmations,
 	)
 	if err != nil {
 		return false, err
@@ -151,6 +154,64 @@ func (o *observeOpeningTX) Callback(ctx context.Context, currentHeight BlockHeig
 	return true, o.cb(o.swapID.String(), rawTx, nil)
 }
 
+type unconfirmedCallback = func(swapId string) error
+
+// observeConfirmedTX watches a confirmed opening transaction for reorgs
+// until it is buried by onchain.ReorgWatchDepth blocks.
+type observeConfirmedTX struct {
+	swapID         swap.SwapId
+	txID           *chainhash.Hash
+	scriptPubkey   scriptPubKey
+	electrumClient RPC
+	cb             unconfirmedCallback
+	// confirmedHeight is the height of the block that confirmed the
+	// transaction, it is set on the first check.
+	confirmedHeight BlockHeight
+}
+
+var _ TXObserver = (*observeConfirmedTX)(nil)
+
+func NewObserveConfirmedTX(
+	swapID swap.SwapId,
+	txID *chainhash.Hash,
+	scriptPubkey scriptPubKey,
+	electrumClient RPC,
+	cb unconfirmedCallback,
+) observeConfirmedTX {
+	return observeConfirmedTX{
+		swapID:         swapID,
+		txID:           txID,
+		scriptPubkey:   scriptPubkey,
+		electrumClient: electrumClient,
+		cb:             cb,
+	}
+}
+
+func (o *observeConfirmedTX) GetSwapID() swap.SwapId {
+	return o.swapID
+}
+
+// Callback calls the callback if the transaction is no longer confirmed.
+// The observer is done if the callback was called or the transaction is
+// buried deep enough.
+func (o *observeConfirmedTX) Callback(ctx context.Context, currentHeight BlockHeight) (bool, error) {
+	hs, err := o.electrumClient.GetHistory(ctx, o.scriptPubkey.scriptHash())
+	if err != nil {
+		return false, fmt.Errorf("failed to get history: %w", err)
+	}
+	txHeight, found := getHeight(hs, o.txID)
+	if !found || txHeight <= 0 {
+		log.Infof("the transaction was unconfirmed by a reorg. txhash: %s", o.txID.String())
+		return true, o.cb(o.swapID.String())
+	}
+	// The transaction might have been confirmed in another block.
+	o.confirmedHeight = txHeight
+	if currentHeight >= o.confirmedHeight+onchain.ReorgWatchDepth {
+		return true, nil
+	}
+	return false, nil
+}
+
 type csvCallback = func(swapId string) error
 
 type observeCSVTX struct {
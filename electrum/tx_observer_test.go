@@ -12,9 +12,19 @@ import (
 )
 
 type observerRPC struct {
-	history  []*goelectrum.GetMempoolResult
-	rawTx    string
-	rawCalls int
+	history      []*goelectrum.GetMempoolResult
+	historyCalls int
+	rawTx        string
+	rawCalls     int
+
+	subscribed    map[string]bool
+	subscribeErr  error
+	notifications chan *goelectrum.SubscribeNotif
+
+	fee       float32
+	feeErr    error
+	relayFee  float32
+	broadcast []string
 }
 
 func testScriptPubKey(t *testing.T) scriptPubKey {
@@ -37,20 +47,45 @@ func (r *observerRPC) SubscribeHeaders(context.Context) (<-chan *goelectrum.Subs
 }
 
 func (r *observerRPC) GetHistory(context.Context, string) ([]*goelectrum.GetMempoolResult, error) {
+	r.historyCalls++
 	return r.history, nil
 }
 
+func (r *observerRPC) SubscribeScripthash(_ context.Context, scripthash string) error {
+	if r.subscribeErr != nil {
+		return r.subscribeErr
+	}
+	if r.subscribed == nil {
+		r.subscribed = make(map[string]bool)
+	}
+	r.subscribed[scripthash] = true
+	return nil
+}
+
+func (r *observerRPC) UnsubscribeScripthash(scripthash string) {
+	delete(r.subscribed, scripthash)
+}
+
+func (r *observerRPC) ScripthashNotifications() <-chan *goelectrum.SubscribeNotif {
+	return r.notifications
+}
+
 func (r *observerRPC) GetRawTransaction(context.Context, string) (string, error) {
 	r.rawCalls++
 	return r.rawTx, nil
 }
 
-func (r *observerRPC) BroadcastTransaction(context.Context, string) (string, error) {
-	return "", nil
+func (r *observerRPC) BroadcastTransaction(_ context.Context, rawTx string) (string, error) {
+	r.broadcast = append(r.broadcast, rawTx)
+	return "txid", nil
 }
 
 func (r *observerRPC) GetFee(context.Context, uint32) (float32, error) {
-	return 0, nil
+	return r.fee, r.feeErr
+}
+
+func (r *observerRPC) GetRelayFee(context.Context) (float32, error) {
+	return r.relayFee, nil
 }
 
 func (r *observerRPC) Ping(context.Context) error {
@@ -61,6 +96,10 @@ func (r *observerRPC) Reboot(context.Context) error {
 	return nil
 }
 
+func (r *observerRPC) Endpoints() []EndpointStatus {
+	return nil
+}
+
 func checkExpectedError(t *testing.T, err error, wantErr bool) {
 	t.Helper()
 	if wantErr {
@@ -188,6 +227,7 @@ func TestObserveOpeningTXConfirmationBoundary(t *testing.T) {
 				},
 				1,
 				math.MaxUint32,
+				onchain.LiquidConfs,
 			)
 
 			called, err := observer.Callback(context.Background(), tt.tip)
@@ -214,6 +254,7 @@ func TestObserveOpeningTXPaymentWindowBoundary(t *testing.T) {
 		},
 		100,
 		60,
+		onchain.LiquidConfs,
 	)
 
 	called, err := observer.Callback(context.Background(), 159)
@@ -282,3 +323,67 @@ func TestObserveCSVTXConfirmationBoundary(t *testing.T) {
 		t.Errorf("Callback() at boundary called = %v, calls = %d", called, callbackCalls)
 	}
 }
+
+func TestObserveConfirmedTXReorg(t *testing.T) {
+	t.Parallel()
+	txID, err := chainhash.NewHashFromStr("01")
+	if err != nil {
+		t.Fatalf("NewHashFromStr() error = %v", err)
+	}
+	rpc := &observerRPC{history: []*goelectrum.GetMempoolResult{{
+		Hash:   txID.String(),
+		Height: 100,
+	}}}
+	callbackCalls := 0
+	observer := NewObserveConfirmedTX(
+		*swap.NewSwapId(), txID, testScriptPubKey(t), rpc,
+		func(string) error {
+			callbackCalls++
+			return nil
+		},
+	)
+
+	called, err := observer.Callback(context.Background(), 101)
+	if err != nil || called || callbackCalls != 0 {
+		t.Fatalf("Callback() while confirmed called = %v, calls = %d, err = %v", called, callbackCalls, err)
+	}
+
+	// The transaction is confirmed again in a later block.
+	rpc.history[0].Height = 102
+	called, err = observer.Callback(context.Background(), 102+onchain.ReorgWatchDepth-1)
+	if err != nil || called || callbackCalls != 0 {
+		t.Fatalf("Callback() after reconfirmation called = %v, calls = %d, err = %v", called, callbackCalls, err)
+	}
+
+	// The transaction is back in the mempool.
+	rpc.history[0].Height = 0
+	called, err = observer.Callback(context.Background(), 103)
+	if err != nil || !called || callbackCalls != 1 {
+		t.Fatalf("Callback() after reorg called = %v, calls = %d, err = %v", called, callbackCalls, err)
+	}
+}
+
+func TestObserveConfirmedTXBuried(t *testing.T) {
+	t.Parallel()
+	txID, err := chainhash.NewHashFromStr("01")
+	if err != nil {
+		t.Fatalf("NewHashFromStr() error = %v", err)
+	}
+	rpc := &observerRPC{history: []*goelectrum.GetMempoolResult{{
+		Hash:   txID.String(),
+		Height: 100,
+	}}}
+	callbackCalls := 0
+	observer := NewObserveConfirmedTX(
+		*swap.NewSwapId(), txID, testScriptPubKey(t), rpc,
+		func(string) error {
+			callbackCalls++
+			return nil
+		},
+	)
+
+	called, err := observer.Callback(context.Background(), 100+onchain.ReorgWatchDepth)
+	if err != nil || !called || callbackCalls != 0 {
+		t.Fatalf("Callback() when buried called = %v, calls = %d, err = %v", called, callbackCalls, err)
+	}
+}
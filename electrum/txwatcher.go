@@ -0,0 +1,364 @@
+package electrum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	goelectrum "github.com/checksum0/go-electrum/electrum"
+	"github.com/elementsproject/peerswap/log"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+const (
+	// initialBlockHeaderSubscriptionTimeout is
+	// the initial block header subscription timeout.
+	initialBlockHeaderSubscriptionTimeout = 1000 * time.Second
+	// Set prime seconds.
+	// This way, it prevents many automated clients from attacking the server at the same time.
+	// For example, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89 and 97 are good numbers.
+	blockHeaderSubscriptionTicker = 37 * time.Second
+)
+
+// TxWatcher watches the swap transactions of a chain with an electrum
+// server. It is used for Liquid with LWK and for Bitcoin if no bitcoind is
+// available.
+type TxWatcher struct {
+	electrumClient RPC
+	// history is passed to the tx observers instead of the client, so that
+	// the history of a script is only fetched once its status changed.
+	history *SubscribedHistory
+	// confirmations is the number of confirmations that is required if the
+	// swap does not ask for a specific number.
+	confirmations        uint32
+	blockHeight          BlockHeight
+	terminalErr          error
+	subscriber           BlockHeaderSubscriber
+	confirmationCallback func(swapId string, txHex string, err error) error
+	csvCallback          func(swapId string) error
+	unconfirmedCallback  func(swapId string) error
+	// resubscribeTicker periodically resubscribes to the block header subscription.
+	// Because the connection with the electrum client is
+	// disconnected after a certain period of time.
+	resubscribeTicker *time.Ticker
+	mu                sync.Mutex
+}
+
+// NewTxWatcher returns a TxWatcher that requires the given number of
+// confirmations for opening transactions by default.
+func NewTxWatcher(electrumClient RPC, confirmations uint32) *TxWatcher {
+	return &TxWatcher{
+		electrumClient:    electrumClient,
+		history:           NewSubscribedHistory(electrumClient),
+		confirmations:     confirmations,
+		subscriber:        NewBlockHeaderSubscriber(),
+		resubscribeTicker: time.NewTicker(blockHeaderSubscriptionTicker),
+	}
+}
+
+func (r *TxWatcher) StartWatchingTxs() error {
+	started := false
+	defer func() {
+		if !started {
+			r.resubscribeTicker.Stop()
+		}
+	}()
+	ctx := context.Background()
+	headerSubscription, err := r.electrumClient.SubscribeHeaders(ctx)
+	if err != nil {
+		return err
+	}
+	r.history.Start(ctx)
+
+	initialTimer := time.NewTimer(initialBlockHeaderSubscriptionTimeout)
+	defer initialTimer.Stop()
+	select {
+	case <-initialTimer.C:
+		return errors.New("initial block header subscription timeout")
+	case blockHeader, ok := <-headerSubscription:
+		if !ok {
+			return errors.New("header subscription closed before initial header")
+		}
+		height, changed, err := r.acceptBlockHeight(blockHeader)
+		if err != nil {
+			return err
+		}
+		if changed {
+			log.Debugf("New block received. block height:%d", height)
+			if err := r.updateObservers(ctx, height); err != nil {
+				return fmt.Errorf("failed to notify tx observers: %w", err)
+			}
+		}
+	}
+
+	go func() {
+		defer r.resubscribeTicker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Infof("Context canceled, stopping watching txs.")
+				return
+			case blockHeader, ok := <-headerSubscription:
+				if !ok {
+					log.Infof("Header subscription closed, stopping watching txs.")
+					return
+				}
+				height, changed, headerErr := r.acceptBlockHeight(blockHeader)
+				if headerErr != nil {
+					r.fail(headerErr)
+					return
+				}
+				if !changed {
+					continue
+				}
+				log.Debugf("New block received. block height:%d", height)
+				err = r.updateObservers(ctx, height)
+				if err != nil {
+					log.Infof("Error notifying tx observers: %v", err)
+					continue
+				}
+			case <-r.history.Changed():
+				// A watched script was seen in a block or the mempool
+				// before the header, or was reorged out.
+				r.mu.Lock()
+				height := r.blockHeight
+				r.mu.Unlock()
+				if height <= 0 {
+					continue
+				}
+				if err := r.updateObservers(ctx, height); err != nil {
+					log.Infof("Error notifying tx observers: %v", err)
+				}
+			case <-r.resubscribeTicker.C:
+				// The old subscription topic will remain in the memory
+				// and needs to be cleared by rebooting.
+				err := r.electrumClient.Reboot(ctx)
+				if err != nil {
+					log.Infof("Error rebooting electrum client: %v", err)
+					continue
+				}
+				headerSubscription, err = r.electrumClient.SubscribeHeaders(ctx)
+				if err != nil {
+					log.Infof("Error subscribe headers: %v", err)
+					continue
+				}
+			}
+		}
+	}()
+	started = true
+	return nil
+}
+
+// updateObservers notifies the tx observers of the block height and drops the
+// cached histories that no observer requested anymore.
+func (r *TxWatcher) updateObservers(ctx context.Context, height BlockHeight) error {
+	if err := r.subscriber.Update(ctx, height); err != nil {
+		return err
+	}
+	r.history.Sweep()
+	return nil
+}
+
+func parseBlockHeight(blockHeader *goelectrum.SubscribeHeadersResult) (BlockHeight, error) {
+	if blockHeader == nil {
+		return 0, errors.New("electrum returned a nil block header")
+	}
+	if blockHeader.Height <= 0 {
+		return 0, fmt.Errorf(
+			"electrum returned invalid block header height: %d",
+			blockHeader.Height,
+		)
+	}
+	return BlockHeight(blockHeader.Height), nil
+}
+
+func (r *TxWatcher) acceptBlockHeight(
+	blockHeader *goelectrum.SubscribeHeadersResult,
+) (BlockHeight, bool, error) {
+	height, err := parseBlockHeight(blockHeader)
+	if err != nil {
+		return 0, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.terminalErr != nil {
+		return 0, false, r.terminalErr
+	}
+	if r.blockHeight > 0 && height <= r.blockHeight {
+		return height, false, nil
+	}
+
+	r.blockHeight = height
+	return height, true, nil
+}
+
+func (r *TxWatcher) fail(err error) {
+	r.mu.Lock()
+	if r.terminalErr == nil {
+		r.terminalErr = err
+	}
+	r.mu.Unlock()
+	log.Infof("Electrum transaction watcher stopped safely: %v", err)
+}
+
+func (r *TxWatcher) AddWaitForConfirmationTx(
+	swapIDStr,
+	txIDStr string,
+	vout,
+	startingHeight,
+	paymentWindow,
+	confirmations uint32,
+	scriptpubkeyByte []byte,
+) {
+	swapID := swap.NewSwapId()
+	err := swapID.FromString(swapIDStr)
+	if err != nil {
+		log.Infof("Error parsing swapID: %v", err)
+		return
+	}
+	txID, err := chainhash.NewHashFromStr(txIDStr)
+	if err != nil {
+		log.Infof("Error parsing txID: %v", err)
+		return
+	}
+	scrypt, err := NewScriptPubKey(scriptpubkeyByte)
+	if err != nil {
+		log.Infof("Error parsing scriptpubkey: %v", err)
+		return
+	}
+	if confirmations == 0 {
+		confirmations = r.confirmations
+	}
+	confirmationCallback := r.confirmationCallback
+	tx := NewObserveOpeningTX(
+		*swapID,
+		txID,
+		scrypt,
+		r.history,
+		func(swapId string, txHex string, err error) error {
+			if err != nil {
+				return confirmationCallback(swapId, txHex, err)
+			}
+			// The subscriber is locked while it calls back, register the
+			// reorg observer once it is done.
+			reorgTx := NewObserveConfirmedTX(
+				*swapID,
+				txID,
+				scrypt,
+				r.history,
+				r.onUnconfirmed,
+			)
+			go r.subscriber.Register(&reorgTx)
+			// The claim invoice is paid in the callback. Do not block new
+			// blocks meanwhile so that a reorg can pause the payment.
+			go func() {
+				if err := confirmationCallback(swapId, txHex, nil); err != nil {
+					log.Infof("Error in confirmation callback: %v", err)
+				}
+			}()
+			return nil
+		},
+		startingHeight,
+		paymentWindow,
+		confirmations,
+	)
+	r.subscriber.Register(&tx)
+}
+
+// onUnconfirmed calls the unconfirmed callback without blocking the
+// subscriber, as the swap might register a new observer.
+func (r *TxWatcher) onUnconfirmed(swapId string) error {
+	r.mu.Lock()
+	cb := r.unconfirmedCallback
+	r.mu.Unlock()
+	if cb == nil {
+		return nil
+	}
+	go func() {
+		if err := cb(swapId); err != nil {
+			log.Infof("Error in unconfirmed callback: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (r *TxWatcher) AddConfirmationCallback(f func(swapId string, txHex string, err error) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.confirmationCallback = f
+}
+func (r *TxWatcher) AddCsvCallback(f func(swapId string) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.csvCallback = f
+}
+
+func (r *TxWatcher) AddUnconfirmedCallback(f func(swapId string) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unconfirmedCallback = f
+}
+
+func (r *TxWatcher) GetBlockHeight() (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.terminalErr != nil {
+		return 0, fmt.Errorf("electrum transaction watcher stopped: %w", r.terminalErr)
+	}
+	if r.blockHeight <= 0 {
+		return 0, fmt.Errorf("block height not confirmed")
+	}
+	if r.blockHeight > math.MaxUint32 {
+		return 0, fmt.Errorf("block height exceeds uint32: %d", r.blockHeight)
+	}
+	// #nosec G115 -- the value is checked against both uint32 bounds above.
+	return uint32(r.blockHeight), nil
+}
+
+var _ swap.TxLookup = (*TxWatcher)(nil)
+
+// IsTxKnown returns true if the electrum server knows the transaction, in the
+// mempool or in a block.
+//
+// NOTE: This method is part of the swap.TxLookup interface.
+func (r *TxWatcher) IsTxKnown(txId string, _ uint32) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if _, err := r.electrumClient.GetRawTransaction(ctx, txId); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *TxWatcher) AddWaitForCsvTx(
+	swapIDStr,
+	txIDStr string,
+	vout,
+	startingHeight,
+	csv uint32,
+	scriptpubkeyByte []byte,
+) {
+	swapID := swap.NewSwapId()
+	err := swapID.FromString(swapIDStr)
+	if err != nil {
+		log.Infof("Error parsing swapID: %v", err)
+		return
+	}
+	txID, err := chainhash.NewHashFromStr(txIDStr)
+	if err != nil {
+		log.Infof("Error parsing txID: %v", err)
+		return
+	}
+	scrypt, err := NewScriptPubKey(scriptpubkeyByte)
+	if err != nil {
+		log.Infof("Error parsing scriptpubkey: %v", err)
+		return
+	}
+	tx := NewobserveCSVTX(*swapID, txID, scrypt, r.history, r.csvCallback, csv)
+	r.subscriber.Register(&tx)
+}
@@ -0,0 +1,52 @@
+package electrum
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goelectrum "github.com/checksum0/go-electrum/electrum"
+	"github.com/elementsproject/peerswap/onchain"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+func TestTxWatcherDefaultConfirmations(t *testing.T) {
+	t.Parallel()
+	txID := "0000000000000000000000000000000000000000000000000000000000000001"
+	rpc := &observerRPC{
+		history: []*goelectrum.GetMempoolResult{{Hash: txID, Height: 100}},
+		rawTx:   "raw-opening-transaction",
+	}
+	watcher := NewTxWatcher(rpc, onchain.BitcoinMinConfs)
+	defer watcher.resubscribeTicker.Stop()
+	confirmed := make(chan string, 1)
+	watcher.AddConfirmationCallback(func(swapId string, txHex string, err error) error {
+		confirmed <- txHex
+		return err
+	})
+	watcher.AddWaitForConfirmationTx(
+		swap.NewSwapId().String(), txID, 0, 99, 60, 0, testScriptPubKey(t).Script(),
+	)
+
+	ctx := context.Background()
+	if err := watcher.subscriber.Update(ctx, 101); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	select {
+	case <-confirmed:
+		t.Fatal("confirmed with 2 confirmations")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := watcher.subscriber.Update(ctx, 102); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	select {
+	case txHex := <-confirmed:
+		if txHex != rpc.rawTx {
+			t.Fatalf("txHex = %s, want %s", txHex, rpc.rawTx)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("not confirmed with 3 confirmations")
+	}
+}
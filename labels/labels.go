@@ -1,6 +1,9 @@
 package labels
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	// peerswapLabelPattern is the pattern that peerswap uses to label on-chain transactions.
@@ -13,6 +16,8 @@ const (
 	claimByCoop = "ClaimByCoop"
 	// ClaimByCsv is the label used for the claim by CSV transaction.
 	claimByCsv = "ClaimByCsv"
+	// claimBatch is the label used for a transaction that claims several swaps.
+	claimBatch = "ClaimBatch"
 )
 
 // Opening returns the label used for the opening transaction.
@@ -34,3 +39,8 @@ func ClaimByCoop(swapID string) string {
 func ClaimByCsv(swapID string) string {
 	return fmt.Sprintf(peerswapLabelPattern, claimByCsv, swapID)
 }
+
+// ClaimBatch returns the label used for a transaction that claims several swaps.
+func ClaimBatch(swapIDs []string) string {
+	return fmt.Sprintf(peerswapLabelPattern, claimBatch, strings.Join(swapIDs, ","))
+}
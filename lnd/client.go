@@ -173,6 +173,23 @@ func (l *Client) ReceivableMsat(scid string) (uint64, error) {
 	return 0, fmt.Errorf("could not find a channel with scid: %s", scid)
 }
 
+// ChannelPeer returns the node id of the remote peer of the channel with the
+// given scid.
+func (l *Client) ChannelPeer(scid string) (string, error) {
+	s := lightning.Scid(scid)
+	r, err := l.lndClient.ListChannels(context.Background(), &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return "", err
+	}
+	for _, ch := range r.Channels {
+		channelShortId := lnwire.NewShortChanIDFromInt(ch.ChanId)
+		if channelShortId.String() == s.LndStyle() {
+			return ch.RemotePubkey, nil
+		}
+	}
+	return "", fmt.Errorf("could not find a channel with scid: %s", scid)
+}
+
 // checkChannel checks that a channel channel peer is connected and that the
 // channel is active.
 func (l *Client) checkChannel(ch *lnrpc.Channel) error {
@@ -387,13 +404,81 @@ func (l *Client) sendPaymentV2(req *routerrpc.SendPaymentRequest, operation stri
 	}
 }
 
-// RebalancePayment pays a claim invoice over the swap channel.
+// RebalancePayment pays a claim invoice over the swap channels. An invoice
+// that is paid over several channels is sent as a multi-part payment that is
+// restricted to these channels.
 func (l *Client) RebalancePayment(
 	payreq string,
-	channelID string,
+	channelIDs []string,
 	maxTotalCLTVDelta uint32,
 ) (preimage string, err error) {
-	return l.payInvoiceViaChannel(payreq, channelID, maxTotalCLTVDelta)
+	if len(channelIDs) == 1 {
+		return l.payInvoiceViaChannel(payreq, channelIDs[0], maxTotalCLTVDelta)
+	}
+	return l.payInvoiceViaChannels(payreq, channelIDs, maxTotalCLTVDelta)
+}
+
+func (l *Client) payInvoiceViaChannels(
+	payreq string,
+	scids []string,
+	maxTotalCLTVDelta uint32,
+) (preimage string, err error) {
+	if len(scids) == 0 {
+		return "", errors.New("no channel to pay the invoice")
+	}
+	decoded, err := l.lndClient.DecodePayReq(l.ctx, &lnrpc.PayReqString{PayReq: payreq})
+	if err != nil {
+		return "", err
+	}
+	var channels []*lnrpc.Channel
+	var localBalance int64
+	for _, scid := range scids {
+		// The balance is checked over all channels below.
+		channel, err := l.CheckChannel(scid, 0)
+		if err != nil {
+			return "", fmt.Errorf("channel %s: %w", scid, err)
+		}
+		channels = append(channels, channel)
+		localBalance += channel.LocalBalance
+	}
+	if localBalance < decoded.NumSatoshis {
+		return "", errors.New("not enough outbound capacity to pay invoice")
+	}
+	request, err := buildMultiChannelClaimPaymentRequest(payreq, decoded, channels, maxTotalCLTVDelta)
+	if err != nil {
+		return "", err
+	}
+	return l.sendPaymentV2(request, "PayInvoiceViaChannels")
+}
+
+// maxClaimPaymentParts limits the parts of a claim payment over several
+// channels.
+const maxClaimPaymentParts = 16
+
+// buildMultiChannelClaimPaymentRequest builds a multi-part payment that is
+// restricted to the direct channels to the peer.
+func buildMultiChannelClaimPaymentRequest(
+	payreq string,
+	decoded *lnrpc.PayReq,
+	channels []*lnrpc.Channel,
+	maxTotalCLTVDelta uint32,
+) (*routerrpc.SendPaymentRequest, error) {
+	var chanIds []uint64
+	var request *routerrpc.SendPaymentRequest
+	for _, channel := range channels {
+		r, err := buildDirectClaimPaymentRequest(payreq, decoded, channel, maxTotalCLTVDelta)
+		if err != nil {
+			return nil, err
+		}
+		request = r
+		chanIds = append(chanIds, channel.ChanId)
+	}
+	if request == nil {
+		return nil, errors.New("no channel to pay the invoice")
+	}
+	request.OutgoingChanIds = chanIds
+	request.MaxParts = maxClaimPaymentParts
+	return request, nil
 }
 
 // RecoverClaimPayment waits for an already-created outgoing payment without
@@ -46,3 +46,33 @@ func TestBuildDirectClaimPaymentRequestCLTVBoundary(t *testing.T) {
 		t.Fatalf("legacy CLTV limit changed: got %d, want %d", request.CltvLimit, wantLegacyLimit)
 	}
 }
+
+func TestBuildMultiChannelClaimPaymentRequest(t *testing.T) {
+	invoice := &lnrpc.PayReq{
+		Destination: "peer",
+		CltvExpiry:  29,
+	}
+	channels := []*lnrpc.Channel{
+		{RemotePubkey: "peer", ChanId: 42},
+		{RemotePubkey: "peer", ChanId: 43},
+	}
+	request, err := buildMultiChannelClaimPaymentRequest("invoice", invoice, channels, 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.CltvLimit != 33 {
+		t.Fatalf("unexpected CLTV limit: got %d, want 33", request.CltvLimit)
+	}
+	if len(request.OutgoingChanIds) != 2 || request.OutgoingChanIds[0] != 42 || request.OutgoingChanIds[1] != 43 {
+		t.Fatalf("unexpected outgoing channels: %v", request.OutgoingChanIds)
+	}
+	if request.MaxParts <= 1 {
+		t.Fatalf("unexpected max parts: got %d, want more than 1", request.MaxParts)
+	}
+
+	channels[1].RemotePubkey = "other"
+	_, err = buildMultiChannelClaimPaymentRequest("invoice", invoice, channels, 32)
+	if err == nil {
+		t.Fatal("expected an error for a channel to another peer")
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+	"math"
 
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/onchain"
 	"github.com/elementsproject/peerswap/swap"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -22,61 +25,267 @@ func (l *Client) CreateOpeningTransaction(swapParams *swap.OpeningParams) (rawTx
 		return "", "", "", 0, 0, err
 	}
 
-	fundPsbtTemplate := &walletrpc.TxTemplate{
-		Outputs: map[string]uint64{
-			addr: swapParams.Amount,
-		},
+	rawTxHex, fee, err = l.fundOpeningTransaction(map[string]uint64{
+		addr: swapParams.Amount,
+	}, swapParams.FeeRate, swapParams.CoinSelection)
+	if err != nil {
+		return "", "", "", 0, 0, err
 	}
-	fundRes, err := l.walletClient.FundPsbt(l.ctx, &walletrpc.FundPsbtRequest{
-		Template: &walletrpc.FundPsbtRequest_Raw{Raw: fundPsbtTemplate},
-		Fees:     &walletrpc.FundPsbtRequest_TargetConf{TargetConf: 3},
-	})
+
+	_, vout, err = l.bitcoinOnChain.GetVoutAndVerify(rawTxHex, swapParams)
 	if err != nil {
 		return "", "", "", 0, 0, err
 	}
-	unsignedPacket, err := psbt.NewFromRawBytes(bytes.NewReader(fundRes.FundedPsbt), false)
+
+	txId, err = l.publishOpeningTransaction(rawTxHex)
 	if err != nil {
 		return "", "", "", 0, 0, err
 	}
+	return rawTxHex, addr, txId, fee, vout, nil
+}
+
+// CreateBatchOpeningTransaction funds the opening outputs of several swaps
+// with a single transaction. The transaction is signed but not broadcast.
+func (l *Client) CreateBatchOpeningTransaction(swapParams []*swap.OpeningParams) (rawTxHex, txId string, fee uint64, outputs []*swap.BatchOutput, err error) {
+	addrs := make([]string, len(swapParams))
+	template := map[string]uint64{}
+	for i, params := range swapParams {
+		addrs[i], err = l.bitcoinOnChain.CreateOpeningAddress(params, onchain.BitcoinCsv)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		template[addrs[i]] = params.Amount
+	}
+
+	rawTxHex, fee, err = l.fundOpeningTransaction(template, swap.OpeningFeeRate(swapParams), swap.OpeningCoinSelection(swapParams))
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	for i, params := range swapParams {
+		ok, vout, err := l.bitcoinOnChain.GetVoutAndVerify(rawTxHex, params)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		if !ok {
+			return "", "", 0, nil, fmt.Errorf("opening output for %s not found", addrs[i])
+		}
+		outputs = append(outputs, &swap.BatchOutput{Address: addrs[i], Vout: vout})
+	}
+
+	txBytes, err := hex.DecodeString(rawTxHex)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	openingTx := wire.NewMsgTx(2)
+	err = openingTx.Deserialize(bytes.NewReader(txBytes))
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	return rawTxHex, openingTx.TxHash().String(), fee, outputs, nil
+}
+
+// BroadcastBatchOpeningTransaction broadcasts a transaction that was created
+// by CreateBatchOpeningTransaction.
+func (l *Client) BroadcastBatchOpeningTransaction(rawTxHex, txId string) (string, error) {
+	_, err := l.publishOpeningTransaction(rawTxHex)
+	if err != nil {
+		return "", err
+	}
+	return rawTxHex, nil
+}
+
+// defaultOpeningTargetConf is the confirmation target of the opening
+// transaction of a swap without a fee rate of its own.
+const defaultOpeningTargetConf = 3
+
+// fundOpeningTransaction funds and signs a transaction that pays the given
+// amounts to the addresses. The inputs are restricted to the utxos that the
+// coin selection allows.
+func (l *Client) fundOpeningTransaction(outputs map[string]uint64, feeRate *swap.FeeRate, selection *swap.CoinSelection) (rawTxHex string, fee uint64, err error) {
+	fundPsbtTemplate := &walletrpc.TxTemplate{
+		Outputs: outputs,
+	}
+	if selection != nil {
+		for _, u := range selection.Utxos {
+			outpoint, err := lndOutpoint(u)
+			if err != nil {
+				return "", 0, err
+			}
+			fundPsbtTemplate.Inputs = append(fundPsbtTemplate.Inputs, outpoint)
+		}
+		release, err := l.leaseExcludedUtxos(selection.ExcludeUtxos)
+		if err != nil {
+			return "", 0, err
+		}
+		defer release()
+	}
+	fundReq := &walletrpc.FundPsbtRequest{
+		Template: &walletrpc.FundPsbtRequest_Raw{Raw: fundPsbtTemplate},
+		Fees:     &walletrpc.FundPsbtRequest_TargetConf{TargetConf: defaultOpeningTargetConf},
+	}
+	// lnd only takes whole sat/vb, so the fee rate is rounded up.
+	switch {
+	case feeRate == nil:
+	case feeRate.SatPerVByte > 0:
+		fundReq.Fees = &walletrpc.FundPsbtRequest_SatPerVbyte{SatPerVbyte: uint64(math.Ceil(feeRate.SatPerVByte))}
+	default:
+		fundReq.Fees = &walletrpc.FundPsbtRequest_TargetConf{TargetConf: feeRate.TargetConf}
+	}
+	fundRes, err := l.walletClient.FundPsbt(l.ctx, fundReq)
+	if err != nil {
+		return "", 0, err
+	}
+	unsignedPacket, err := psbt.NewFromRawBytes(bytes.NewReader(fundRes.FundedPsbt), false)
+	if err != nil {
+		return "", 0, err
+	}
 
 	bytesBuffer := new(bytes.Buffer)
 	err = unsignedPacket.Serialize(bytesBuffer)
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", 0, err
 	}
 	finalizeRes, err := l.walletClient.FinalizePsbt(l.ctx, &walletrpc.FinalizePsbtRequest{
 		FundedPsbt: bytesBuffer.Bytes(),
 	})
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", 0, err
 	}
 	psbtString := base64.StdEncoding.EncodeToString(finalizeRes.SignedPsbt)
 	rawTxHex = hex.EncodeToString(finalizeRes.RawFinalTx)
 
 	fee, err = l.bitcoinOnChain.GetFeeSatsFromTx(psbtString, rawTxHex)
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", 0, err
 	}
+	return rawTxHex, fee, nil
+}
 
-	_, vout, err = l.bitcoinOnChain.GetVoutAndVerify(rawTxHex, swapParams)
+// coinSelectionLockId is the id of the leases that keep excluded utxos out of
+// the coin selection of lnd.
+var coinSelectionLockId = chainhash.HashB([]byte("peerswap-coin-selection"))
+
+// coinSelectionLeaseSeconds is the time that excluded utxos are leased for.
+// The leases are released once the opening transaction is funded.
+const coinSelectionLeaseSeconds = 60
+
+// CreateFundingPsbt returns an unsigned PSBT that pays the opening output of
+// a swap-in that is funded outside of lnd.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *Client) CreateFundingPsbt(params *swap.OpeningParams) (string, string, error) {
+	return l.bitcoinOnChain.CreateFundingPsbt(params)
+}
+
+// ExtractFundingTx returns the opening transaction of a signed PSBT and the
+// vout of the opening output.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *Client) ExtractFundingTx(signed string, params *swap.OpeningParams) (string, uint32, error) {
+	return l.bitcoinOnChain.ExtractFundingTx(signed, params)
+}
+
+// BroadcastFundingTx publishes the opening transaction of a swap-in that is
+// funded outside of lnd.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *Client) BroadcastFundingTx(txHex string) (string, error) {
+	return l.publishOpeningTransaction(txHex)
+}
+
+// ValidateCoinSelection returns an error if one of the selected utxos is
+// not a confirmed unspent output of the lnd wallet.
+//
+// NOTE: This method is part of the swap.CoinSelectionWallet interface.
+func (l *Client) ValidateCoinSelection(selection *swap.CoinSelection) error {
+	if len(selection.Utxos) == 0 {
+		return nil
+	}
+	res, err := l.walletClient.ListUnspent(l.ctx, &walletrpc.ListUnspentRequest{
+		MinConfs: 1,
+		MaxConfs: math.MaxInt32,
+	})
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return err
+	}
+	unspent := map[string]bool{}
+	for _, utxo := range res.Utxos {
+		unspent[fmt.Sprintf("%s:%d", utxo.Outpoint.TxidStr, utxo.Outpoint.OutputIndex)] = true
+	}
+	for _, u := range selection.Utxos {
+		if !unspent[u] {
+			return fmt.Errorf("utxo %s is not a confirmed unspent output of the wallet", u)
+		}
 	}
+	return nil
+}
+
+// leaseExcludedUtxos leases the utxos so that lnd does not select them. The
+// returned function releases the leases again. Utxos that lnd can not lease
+// are not in the wallet or already leased, lnd does not select them either.
+func (l *Client) leaseExcludedUtxos(utxos []string) (func(), error) {
+	var leased []*lnrpc.OutPoint
+	for _, u := range utxos {
+		outpoint, err := lndOutpoint(u)
+		if err != nil {
+			return nil, err
+		}
+		_, err = l.walletClient.LeaseOutput(l.ctx, &walletrpc.LeaseOutputRequest{
+			Id:                coinSelectionLockId,
+			Outpoint:          outpoint,
+			ExpirationSeconds: coinSelectionLeaseSeconds,
+		})
+		if err != nil {
+			log.Debugf("Could not lease excluded utxo %s: %v", u, err)
+			continue
+		}
+		leased = append(leased, outpoint)
+	}
+	return func() {
+		for _, outpoint := range leased {
+			_, err := l.walletClient.ReleaseOutput(l.ctx, &walletrpc.ReleaseOutputRequest{
+				Id:       coinSelectionLockId,
+				Outpoint: outpoint,
+			})
+			if err != nil {
+				log.Infof("Could not release excluded utxo %s:%d: %v",
+					outpoint.TxidStr, outpoint.OutputIndex, err)
+			}
+		}
+	}, nil
+}
+
+func lndOutpoint(outpoint string) (*lnrpc.OutPoint, error) {
+	op, err := wire.NewOutPointFromString(outpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &lnrpc.OutPoint{
+		TxidBytes:   op.Hash[:],
+		TxidStr:     op.Hash.String(),
+		OutputIndex: op.Index,
+	}, nil
+}
+
+// publishOpeningTransaction publishes the opening transaction and returns
+// its txid.
+func (l *Client) publishOpeningTransaction(rawTxHex string) (string, error) {
 	txBytes, err := hex.DecodeString(rawTxHex)
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", err
 	}
 	openingTx := wire.NewMsgTx(2)
 	err = openingTx.Deserialize(bytes.NewReader(txBytes))
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", err
 	}
 
 	_, err = l.walletClient.PublishTransaction(l.ctx, &walletrpc.Transaction{TxHex: txBytes})
 	if err != nil {
-		return "", "", "", 0, 0, err
+		return "", err
 	}
-	return rawTxHex, addr, openingTx.TxHash().String(), fee, vout, nil
+	return openingTx.TxHash().String(), nil
 }
 
 func (l *Client) CreatePreimageSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (string, string, string, error) {
@@ -85,7 +294,7 @@ func (l *Client) CreatePreimageSpendingTransaction(swapParams *swap.OpeningParam
 		return "", "", "", err
 	}
 
-	newAddr, err := l.NewAddress()
+	newAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -122,8 +331,35 @@ func (l *Client) CreatePreimageSpendingTransaction(swapParams *swap.OpeningParam
 	return tx.TxHash().String(), txHex, newAddr, nil
 }
 
+// CreateBatchSpendingTransaction claims the outputs of several swaps with a
+// single transaction.
+func (l *Client) CreateBatchSpendingTransaction(claims []*swap.BatchClaim, claimAddress string) (string, string, string, error) {
+	newAddr, err := l.claimAddress(&swap.ClaimParams{ClaimAddress: claimAddress})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	tx, err := l.bitcoinOnChain.CreateBatchSpendingTransaction(claims, newAddr)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	bytesBuffer := new(bytes.Buffer)
+	err = tx.Serialize(bytesBuffer)
+	if err != nil {
+		return "", "", "", err
+	}
+	txHex := hex.EncodeToString(bytesBuffer.Bytes())
+
+	_, err = l.walletClient.PublishTransaction(l.ctx, &walletrpc.Transaction{TxHex: bytesBuffer.Bytes()})
+	if err != nil {
+		return "", "", "", err
+	}
+	return tx.TxHash().String(), txHex, newAddr, nil
+}
+
 func (l *Client) CreateCsvSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (string, string, string, error) {
-	newAddr, err := l.NewAddress()
+	newAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -160,7 +396,7 @@ func (l *Client) CreateCsvSpendingTransaction(swapParams *swap.OpeningParams, cl
 }
 
 func (l *Client) CreateCoopSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, takerSigner swap.Signer) (txId, txHex, address string, error error) {
-	refundAddr, err := l.NewAddress()
+	refundAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -233,6 +469,15 @@ func (l *Client) GetOutputScript(params *swap.OpeningParams) ([]byte, error) {
 	return l.bitcoinOnChain.GetOutputScript(params)
 }
 
+// claimAddress returns the claim address of the swap, or a new wallet address
+// if the swap has none.
+func (l *Client) claimAddress(claimParams *swap.ClaimParams) (string, error) {
+	if claimParams.ClaimAddress != "" {
+		return claimParams.ClaimAddress, nil
+	}
+	return l.NewAddress()
+}
+
 func (l *Client) NewAddress() (string, error) {
 	res, err := l.lndClient.NewAddress(l.ctx, &lnrpc.NewAddressRequest{Type: lnrpc.AddressType_WITNESS_PUBKEY_HASH})
 	if err != nil {
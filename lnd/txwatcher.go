@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -17,10 +18,16 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// reorgWatchInterval is the interval in which the block height is checked
+// to stop watching a confirmed tx for reorgs.
+const reorgWatchInterval = time.Minute
+
 type confirmationEvent struct {
 	swapId      string
 	rawTx       []byte
 	blockHeight uint32
+	// reorg is set if the tx was unconfirmed by a reorg.
+	reorg bool
 }
 
 type TxWatcher struct {
@@ -39,6 +46,7 @@ type TxWatcher struct {
 
 	confirmationCallback func(swapId, txHex string, err error) error
 	csvPassedCallback    func(swapId string) error
+	unconfirmedCallback  func(swapId string) error
 
 	confirmationWatchers map[string]bool
 	waitForCsvWatchers   map[string]bool
@@ -88,7 +96,11 @@ func (t *TxWatcher) Stop() error {
 	return nil
 }
 
-func (t *TxWatcher) addTxWatcher(ctx context.Context, swapId string, txId string, numConfs, heightHint uint32, script []byte) (
+// addTxWatcher subscribes to the confirmation of the tx. If watchReorgs is
+// set the subscription is kept after the confirmation and reorgs that
+// unconfirm the tx are passed on to the unconfirmedCallback and the
+// confirmation channel.
+func (t *TxWatcher) addTxWatcher(ctx context.Context, swapId string, txId string, numConfs, heightHint uint32, script []byte, watchReorgs bool) (
 	chan confirmationEvent, chan error, error) {
 
 	txIdHash, err := chainhash.NewHashFromStr(txId)
@@ -128,18 +140,37 @@ func (t *TxWatcher) addTxWatcher(ctx context.Context, swapId string, txId string
 
 			switch event := res.Event.(type) {
 			case *chainrpc.ConfEvent_Conf:
-				confChan <- confirmationEvent{
+				select {
+				case confChan <- confirmationEvent{
 					swapId:      swapId,
 					rawTx:       event.Conf.RawTx,
 					blockHeight: event.Conf.BlockHeight,
+				}:
+				case <-ctx.Done():
+					return
+				}
+				if !watchReorgs {
+					return
 				}
-				return
 
 			case *chainrpc.ConfEvent_Reorg:
-				// Not supported yet, we can continue as we return on conf and
-				// should never get an reorg event in return.
 				log.Debugf("[TxWatcher] Swap: %s: Got an reorg event", swapId)
-				continue
+				if !watchReorgs {
+					continue
+				}
+				// The confirmation callback might still be running, call
+				// the unconfirmed callback from here so that it can stop.
+				t.Lock()
+				unconfirmedCallback := t.unconfirmedCallback
+				t.Unlock()
+				if unconfirmedCallback != nil {
+					_ = unconfirmedCallback(swapId)
+				}
+				select {
+				case confChan <- confirmationEvent{swapId: swapId, reorg: true}:
+				case <-ctx.Done():
+					return
+				}
 
 			default:
 				errChan <- fmt.Errorf("event has unexpected types")
@@ -152,10 +183,15 @@ func (t *TxWatcher) addTxWatcher(ctx context.Context, swapId string, txId string
 }
 
 // AddWaitForConfirmationTx subscribes to the lnd onchain tx watcher and calls
-// the callback as soon as the tx is confirmed. The empty uint32 parameter is
-// due to the Watcher interface of swap expecting a signature with a vout
-// parameter.
-func (t *TxWatcher) AddWaitForConfirmationTx(swapId string, txId string, _ uint32, heightHint uint32, _ uint32, script []byte) {
+// the callback as soon as the tx is confirmed. The tx is then watched for
+// reorgs until it is buried by onchain.ReorgWatchDepth blocks. The watcher
+// awaits the confirmations it was created with if confirmations is 0. The
+// empty uint32 parameter is due to the Watcher interface of swap expecting a
+// signature with a vout parameter.
+func (t *TxWatcher) AddWaitForConfirmationTx(swapId string, txId string, _ uint32, heightHint uint32, _ uint32, confirmations uint32, script []byte) {
+	if confirmations == 0 {
+		confirmations = t.targetConfs
+	}
 	t.Lock()
 	if _, ok := t.confirmationWatchers[swapId]; ok {
 		log.Debugf("[TxWatcher] Swap: %s: Tried to resubscribe to tx watcher for tx %s", swapId, txId)
@@ -165,13 +201,13 @@ func (t *TxWatcher) AddWaitForConfirmationTx(swapId string, txId string, _ uint3
 	log.Debugf("[TxWatcher] Swap: %s: Add new confirmation watcher for tx %s, awaiting %d confirmations",
 		swapId,
 		txId,
-		t.targetConfs,
+		confirmations,
 	)
 	t.confirmationWatchers[swapId] = true
 	t.Unlock()
 
 	ctx, cancel := context.WithCancel(t.ctx)
-	confChan, errChan, err := t.addTxWatcher(ctx, swapId, txId, t.targetConfs, heightHint, script)
+	confChan, errChan, err := t.addTxWatcher(ctx, swapId, txId, confirmations, heightHint, script, true)
 	if err != nil {
 		// TODO: Add error return to somehow handle error in swap. Else this
 		// could lead to stale swaps that might not resolve.
@@ -189,12 +225,22 @@ func (t *TxWatcher) AddWaitForConfirmationTx(swapId string, txId string, _ uint3
 			t.Unlock()
 		}()
 		defer cancel()
-		defer close(errChan)
-		defer close(confChan)
 
+		ticker := time.NewTicker(reorgWatchInterval)
+		defer ticker.Stop()
+
+		// confHeight is the height of the block that confirmed the tx, it
+		// is 0 as long as the tx is not confirmed.
+		var confHeight uint32
 		for {
 			select {
 			case conf := <-confChan:
+				if conf.reorg {
+					log.Infof("[TxWatcher] Wait for confirmation on swap %s: Tx %s was unconfirmed by a reorg", swapId, txId)
+					confHeight = 0
+					continue
+				}
+
 				// This tx watcher can also be used on recovery. This can lead to
 				// the situation that the tx is confirmed but also already too close
 				// to the CSV limit. Therefore we have to check on what we trigger
@@ -230,7 +276,21 @@ func (t *TxWatcher) AddWaitForConfirmationTx(swapId string, txId string, _ uint3
 					return
 				}
 				_ = t.confirmationCallback(swapId, hex.EncodeToString(conf.rawTx), nil)
-				return
+
+				// Keep the subscription to get notified about reorgs.
+				confHeight = conf.blockHeight
+			case <-ticker.C:
+				if confHeight == 0 {
+					continue
+				}
+				currentHeight, err := t.GetBlockHeight()
+				if err != nil {
+					continue
+				}
+				if currentHeight >= confHeight+onchain.ReorgWatchDepth {
+					log.Debugf("[TxWatcher] Wait for confirmation on swap %s: Stop watching tx %s for reorgs", swapId, txId)
+					return
+				}
 			case err := <-errChan:
 				if err == io.EOF {
 					log.Infof("[TxWatcher] Wait for confirmation on swap %s: Stream closed by server: %s", swapId)
@@ -275,7 +335,7 @@ func (t *TxWatcher) AddWaitForCsvTx(swapId string, txId string, vout uint32, hei
 	// for a tx to be reorganized out of the chain.
 	// This means that we have to count the blocks after this ourselves.
 	// TODO: Ask lnd why we can not listen longer?
-	confChan, errChan, err := t.addTxWatcher(ctx, swapId, txId, 144, heightHint, script)
+	confChan, errChan, err := t.addTxWatcher(ctx, swapId, txId, 144, heightHint, script, false)
 	if err != nil {
 		// TODO: Add error return to somehow handle error in swap. Else this
 		// could lead to stale swaps that might not resolve.
@@ -386,6 +446,15 @@ func (t *TxWatcher) AddCsvCallback(cb func(swapId string) error) {
 	t.csvPassedCallback = cb
 }
 
+// AddUnconfirmedCallback adds a callback to the watcher that will be called in
+// the case that a reorg unconfirmed a tx after the confirmation callback was
+// called for it.
+func (t *TxWatcher) AddUnconfirmedCallback(cb func(swapId string) error) {
+	t.Lock()
+	defer t.Unlock()
+	t.unconfirmedCallback = cb
+}
+
 // GetBlockHeight returns the current best block from the GetInfo call. Beware
 // that this height is the best block from the nodes view.
 func (t *TxWatcher) GetBlockHeight() (uint32, error) {
@@ -63,6 +63,13 @@ func (b *confBuilder) SetElectrumEndpoint(endpoint electsurl) *confBuilder {
 	return b
 }
 
+// AddFallbackElectrumEndpoint adds an electrum endpoint that is used if the
+// previous ones are down.
+func (b *confBuilder) AddFallbackElectrumEndpoint(endpoint electsurl) *confBuilder {
+	b.fallbackElectrumEndpoints = append(b.fallbackElectrumEndpoints, endpoint)
+	return b
+}
+
 func (b *confBuilder) SetLiquidSwaps(swaps bool) *confBuilder {
 	b.liquidSwaps = swaps
 	return b
@@ -71,6 +78,7 @@ func (b *confBuilder) SetLiquidSwaps(swaps bool) *confBuilder {
 func (b *confBuilder) Build() (*Conf, error) {
 	if err := Validate(
 		b.electrumEndpoint,
+		electsurls(b.fallbackElectrumEndpoints),
 		b.lwkEndpoint,
 		b.network,
 		b.signerName,
@@ -78,11 +86,12 @@ func (b *confBuilder) Build() (*Conf, error) {
 		return nil, err
 	}
 	return &Conf{
-		signerName:       b.signerName,
-		walletName:       b.walletName,
-		lwkEndpoint:      b.lwkEndpoint,
-		electrumEndpoint: b.electrumEndpoint,
-		network:          b.network,
-		liquidSwaps:      b.liquidSwaps,
+		signerName:                b.signerName,
+		walletName:                b.walletName,
+		lwkEndpoint:               b.lwkEndpoint,
+		electrumEndpoint:          b.electrumEndpoint,
+		fallbackElectrumEndpoints: b.fallbackElectrumEndpoints,
+		network:                   b.network,
+		liquidSwaps:               b.liquidSwaps,
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/vulpemventures/go-elements/network"
 )
 
@@ -13,8 +14,11 @@ type Conf struct {
 	walletName       confname
 	lwkEndpoint      lwkurl
 	electrumEndpoint electsurl
-	network          LwkNetwork
-	liquidSwaps      bool
+	// fallbackElectrumEndpoints are used in order if the electrum endpoint
+	// is down.
+	fallbackElectrumEndpoints []electsurl
+	network                   LwkNetwork
+	liquidSwaps               bool
 }
 
 func (c *Conf) GetSignerName() string {
@@ -37,6 +41,19 @@ func (c *Conf) IsElectrumWithTLS() bool {
 	return c.electrumEndpoint.Scheme == "ssl"
 }
 
+// GetElectrumEndpoints returns the electrum endpoint followed by the
+// fallback endpoints.
+func (c *Conf) GetElectrumEndpoints() []electrum.Endpoint {
+	endpoints := make([]electrum.Endpoint, 0, 1+len(c.fallbackElectrumEndpoints))
+	for _, u := range append([]electsurl{c.electrumEndpoint}, c.fallbackElectrumEndpoints...) {
+		endpoints = append(endpoints, electrum.Endpoint{
+			Address: u.Host,
+			TLS:     u.Scheme == "ssl",
+		})
+	}
+	return endpoints
+}
+
 func (c *Conf) GetNetwork() string {
 	return c.network.String()
 }
@@ -74,6 +91,7 @@ func (c *Conf) GetAssetID() string {
 func (c *Conf) Enabled() bool {
 	return Validate(
 		c.electrumEndpoint,
+		electsurls(c.fallbackElectrumEndpoints),
 		c.lwkEndpoint,
 		c.network,
 		c.signerName,
@@ -168,6 +186,17 @@ func NewElectrsURL(endpoint string) (*electsurl, error) {
 	return &electsurl{u}, nil
 }
 
+type electsurls []electsurl
+
+func (us electsurls) validate() error {
+	for _, u := range us {
+		if err := u.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (u electsurl) validate() error {
 	if u.URL == nil {
 		return errors.New("url must be set")
@@ -42,6 +42,8 @@ func TestElectrumTxWatcher_Callback(t *testing.T) {
 		headerResultChan := make(chan *electrum.SubscribeHeadersResult, 1)
 		electrumRPC.EXPECT().SubscribeHeaders(gomock.Any()).
 			Return(headerResultChan, nil)
+		electrumRPC.EXPECT().ScripthashNotifications().Return(nil)
+		electrumRPC.EXPECT().SubscribeScripthash(gomock.Any(), gomock.Any()).Return(nil)
 		electrumRPC.EXPECT().GetHistory(gomock.Any(), gomock.Any()).Return([]*electrum.GetMempoolResult{
 			{
 				Hash:   wantTxID,
@@ -74,6 +76,7 @@ func TestElectrumTxWatcher_Callback(t *testing.T) {
 			0,
 			uint32(targetTXHeight-1),
 			60,
+			onchain.LiquidConfs,
 			wantscriptpubkey,
 		)
 		headerResultChan <- &electrum.SubscribeHeadersResult{
@@ -108,6 +111,8 @@ func TestElectrumTxWatcher_Callback(t *testing.T) {
 		headerResultChan := make(chan *electrum.SubscribeHeadersResult, 1)
 		electrumRPC.EXPECT().SubscribeHeaders(gomock.Any()).
 			Return(headerResultChan, nil)
+		electrumRPC.EXPECT().ScripthashNotifications().Return(nil)
+		electrumRPC.EXPECT().SubscribeScripthash(gomock.Any(), gomock.Any()).Return(nil)
 		electrumRPC.EXPECT().GetHistory(gomock.Any(), gomock.Any()).Return([]*electrum.GetMempoolResult{
 			{
 				Hash:   wantTxID,
@@ -160,6 +165,7 @@ func TestElectrumTxWatcherRejectsInvalidInitialHeader(t *testing.T) {
 			headers := make(chan *electrum.SubscribeHeadersResult, 1)
 			headers <- tt.header
 			rpc.EXPECT().SubscribeHeaders(gomock.Any()).Return(headers, nil)
+			rpc.EXPECT().ScripthashNotifications().Return(nil).AnyTimes()
 			watcher, err := lwk.NewElectrumTxWatcher(rpc)
 			if err != nil {
 				t.Fatalf("NewElectrumTxWatcher() error = %v", err)
@@ -176,6 +182,7 @@ func TestElectrumTxWatcherFailsClosedOnNegativeRuntimeHeight(t *testing.T) {
 	headers := make(chan *electrum.SubscribeHeadersResult, 2)
 	headers <- &electrum.SubscribeHeadersResult{Height: 100, Hex: "00"}
 	rpc.EXPECT().SubscribeHeaders(gomock.Any()).Return(headers, nil)
+	rpc.EXPECT().ScripthashNotifications().Return(nil).AnyTimes()
 	watcher, err := lwk.NewElectrumTxWatcher(rpc)
 	if err != nil {
 		t.Fatalf("NewElectrumTxWatcher() error = %v", err)
@@ -197,6 +204,7 @@ func TestElectrumTxWatcherAcceptsMaxInt32Height(t *testing.T) {
 	headers := make(chan *electrum.SubscribeHeadersResult, 1)
 	headers <- &electrum.SubscribeHeadersResult{Height: math.MaxInt32, Hex: "00"}
 	rpc.EXPECT().SubscribeHeaders(gomock.Any()).Return(headers, nil)
+	rpc.EXPECT().ScripthashNotifications().Return(nil).AnyTimes()
 	watcher, err := lwk.NewElectrumTxWatcher(rpc)
 	if err != nil {
 		t.Fatalf("NewElectrumTxWatcher() error = %v", err)
@@ -13,6 +13,7 @@ import (
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/swap"
 	"github.com/elementsproject/peerswap/wallet"
+	"github.com/vulpemventures/go-elements/psetv2"
 )
 
 // Satoshi represents a Satoshi value.
@@ -63,7 +64,7 @@ func NewLWKRpcWallet(ctx context.Context, c *Conf) (*LWKRpcWallet, error) {
 	if !c.Enabled() {
 		return nil, errors.New("LWKRpcWallet is not enabled")
 	}
-	ec, err := electrum.NewElectrumClient(ctx, c.GetElectrumEndpoint(), c.IsElectrumWithTLS())
+	ec, err := electrum.NewFailoverElectrumClient(ctx, c.GetElectrumEndpoints())
 	if err != nil {
 		return nil, err
 	}
@@ -160,31 +161,13 @@ func (r *LWKRpcWallet) CreateAndBroadcastTransaction(swapParams *swap.OpeningPar
 	asset []byte) (txid, rawTx string, fee Satoshi, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
 	defer cancel()
-	feerate := r.getFeeSatPerVByte(ctx).getValue() * kb
-	// todo: There will be an option in the tx builder to enable the discount.
-	fundedTx, err := r.lwkClient.send(ctx, &sendRequest{
-		Addressees: []*unvalidatedAddressee{
-			{
-				Address: swapParams.OpeningAddress,
-				Satoshi: swapParams.Amount,
-			},
-		},
-		WalletName: r.c.GetWalletName(),
-		FeeRate:    &feerate,
-	})
-	if err != nil {
-		return "", "", 0, fmt.Errorf("failed to fund transaction: %w", err)
-	}
-	signed, err := r.lwkClient.sign(ctx, &signRequest{
-		SignerName: r.c.GetSignerName(),
-		Pset:       fundedTx.Pset,
-	})
+	signed, err := r.createSignedPset(ctx, []*swap.OpeningParams{swapParams})
 	if err != nil {
-		return "", "", 0, fmt.Errorf("failed to sign transaction: %w", err)
+		return "", "", 0, err
 	}
 	broadcasted, err := r.lwkClient.broadcast(ctx, &broadcastRequest{
 		WalletName: r.c.GetWalletName(),
-		Pset:       signed.Pset,
+		Pset:       signed,
 	})
 	if err != nil {
 		return "", "", 0, fmt.Errorf("failed to broadcast transaction: %w", err)
@@ -196,6 +179,66 @@ func (r *LWKRpcWallet) CreateAndBroadcastTransaction(swapParams *swap.OpeningPar
 	return broadcasted.Txid, hex, 0, nil
 }
 
+// CreateBatchTransaction creates a tx with an output for every swap and adds
+// inputs in order to spend the tx. The tx is signed but not broadcast.
+func (r *LWKRpcWallet) CreateBatchTransaction(swapParams []*swap.OpeningParams,
+	asset []byte) (txid, rawTx string, fee Satoshi, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	signed, err := r.createSignedPset(ctx, swapParams)
+	if err != nil {
+		return "", "", 0, err
+	}
+	pset, err := psetv2.NewPsetFromBase64(signed)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if err := psetv2.MaybeFinalizeAll(pset); err != nil {
+		return "", "", 0, fmt.Errorf("failed to finalize transaction: %w", err)
+	}
+	tx, err := psetv2.Extract(pset)
+	if err != nil {
+		return "", "", 0, err
+	}
+	rawTx, err = tx.ToHex()
+	if err != nil {
+		return "", "", 0, err
+	}
+	return tx.TxHash().String(), rawTx, 0, nil
+}
+
+// createSignedPset funds and signs a pset with an output for every swap.
+func (r *LWKRpcWallet) createSignedPset(ctx context.Context, swapParams []*swap.OpeningParams) (string, error) {
+	if swap.OpeningCoinSelection(swapParams) != nil {
+		return "", errLWKCoinSelection
+	}
+	feerate := r.getFeeSatPerVByte(ctx, swap.OpeningFeeRate(swapParams)).getValue() * kb
+	addressees := make([]*unvalidatedAddressee, 0, len(swapParams))
+	for _, params := range swapParams {
+		addressees = append(addressees, &unvalidatedAddressee{
+			Address: params.OpeningAddress,
+			Satoshi: params.Amount,
+		})
+	}
+	// todo: There will be an option in the tx builder to enable the discount.
+	fundedTx, err := r.lwkClient.send(ctx, &sendRequest{
+		Addressees: addressees,
+		WalletName: r.c.GetWalletName(),
+		FeeRate:    &feerate,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fund transaction: %w", err)
+	}
+	signed, err := r.lwkClient.sign(ctx, &signRequest{
+		SignerName: r.c.GetSignerName(),
+		Pset:       fundedTx.Pset,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signed.Pset, nil
+}
+
 // GetBalance returns the balance in sats
 func (r *LWKRpcWallet) GetBalance() (Satoshi, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
@@ -272,8 +315,28 @@ func (r *LWKRpcWallet) SendRawTx(txHex string) (string, error) {
 	return res, nil
 }
 
-func (r *LWKRpcWallet) getFeeSatPerVByte(ctx context.Context) SatPerVByte {
-	feeBTCPerKb, err := r.electrumClient.GetFee(ctx, wallet.LiquidTargetBlocks)
+// errLWKCoinSelection is returned for swaps with a coin selection, as lwk
+// chooses the inputs of a transaction itself.
+var errLWKCoinSelection = fmt.Errorf("lwk: %w", swap.ErrCoinSelectionNotSupported)
+
+// ValidateCoinSelection always returns an error, the utxos that fund a
+// transaction can not be chosen with lwk. The limitation is documented in
+// docs/usage.md.
+func (r *LWKRpcWallet) ValidateCoinSelection(*swap.CoinSelection) error {
+	return errLWKCoinSelection
+}
+
+// getFeeSatPerVByte returns the fee rate of a swap, or the fee rate that the
+// electrum server estimates if it is nil.
+func (r *LWKRpcWallet) getFeeSatPerVByte(ctx context.Context, swapFeeRate *swap.FeeRate) SatPerVByte {
+	targetBlocks := uint32(wallet.LiquidTargetBlocks)
+	if swapFeeRate != nil {
+		if swapFeeRate.SatPerVByte > 0 {
+			return max(SatPerVByte(swapFeeRate.SatPerVByte), minimumFee)
+		}
+		targetBlocks = swapFeeRate.TargetConf
+	}
+	feeBTCPerKb, err := r.electrumClient.GetFee(ctx, targetBlocks)
 	if err != nil {
 		log.Infof("error getting fee: %v.", err)
 	}
@@ -283,7 +346,15 @@ func (r *LWKRpcWallet) getFeeSatPerVByte(ctx context.Context) SatPerVByte {
 func (r *LWKRpcWallet) GetFee(txSizeBytes int64) (Satoshi, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
 	defer cancel()
-	return r.getFeeSatPerVByte(ctx).GetFee(txSizeBytes), nil
+	return r.getFeeSatPerVByte(ctx, nil).GetFee(txSizeBytes), nil
+}
+
+// GetFeeForRate returns the fee for a transaction of size txSizeBytes with
+// the fee rate of a swap, or with the estimated fee rate if it is nil.
+func (r *LWKRpcWallet) GetFeeForRate(txSizeBytes int64, feeRate *swap.FeeRate) (Satoshi, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	return r.getFeeSatPerVByte(ctx, feeRate).GetFee(txSizeBytes), nil
 }
 
 func (r *LWKRpcWallet) SetLabel(txID, address, label string) error {
@@ -0,0 +1,104 @@
+package messages
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elementsproject/peerswap/log"
+)
+
+// AckableMessenger is a StoppableMessenger that stops resending its message
+// once the peer acknowledged it.
+type AckableMessenger interface {
+	StoppableMessenger
+	// Ack stops the retries if the sent message is of the acknowledged
+	// message type and reports whether it did.
+	Ack(messageType int) bool
+}
+
+// BackoffMessenger resends a message until the peer acknowledges it or Stop
+// is called. The time between retries doubles after every retry up to a cap.
+// It must only be used for peers that send acks.
+type BackoffMessenger struct {
+	messenger        Messenger
+	initialRetryTime time.Duration
+	maxRetryTime     time.Duration
+
+	mu          sync.Mutex
+	messageType int
+	sending     bool
+
+	ackOnce sync.Once
+	acked   chan struct{}
+	stop    chan struct{}
+}
+
+func NewBackoffMessenger(messenger Messenger, initialRetryTime, maxRetryTime time.Duration) *BackoffMessenger {
+	return &BackoffMessenger{
+		messenger:        messenger,
+		initialRetryTime: initialRetryTime,
+		maxRetryTime:     maxRetryTime,
+		acked:            make(chan struct{}),
+		stop:             make(chan struct{}),
+	}
+}
+
+func (s *BackoffMessenger) SendMessage(peerId string, message []byte, messageType int) error {
+	log.Debugf("[BackoffSender] start sending messages of type %d to %s", messageType, peerId)
+
+	s.mu.Lock()
+	s.messageType = messageType
+	s.sending = true
+	s.mu.Unlock()
+
+	// Send one time before we go loop the send, so that we do not have to wait for the first retry.
+	err := s.messenger.SendMessage(peerId, message, messageType)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		retryTime := s.initialRetryTime
+		timer := time.NewTimer(retryTime)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				err := s.messenger.SendMessage(peerId, message, messageType)
+				if err != nil {
+					log.Debugf("[BackoffSender] SendMessageWithRetry: %v", err)
+				}
+				retryTime *= 2
+				if retryTime > s.maxRetryTime {
+					retryTime = s.maxRetryTime
+				}
+				timer.Reset(retryTime)
+			case <-s.acked:
+				log.Debugf("[BackoffSender] message of type %d acked by %s", messageType, peerId)
+				return
+			case <-s.stop:
+				log.Debugf("[BackoffSender] stop sending messages of type %d to %s", messageType, peerId)
+				return
+			}
+		}
+	}()
+
+	// This function returns an error to fulfill the Messenger interface.
+	return nil
+}
+
+func (s *BackoffMessenger) Ack(messageType int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.sending || s.messageType != messageType {
+		return false
+	}
+	s.ackOnce.Do(func() {
+		close(s.acked)
+	})
+	return true
+}
+
+func (s *BackoffMessenger) Stop() {
+	close(s.stop)
+}
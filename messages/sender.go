@@ -81,6 +81,18 @@ func (m *Manager) AddSender(id string, messenger StoppableMessenger) error {
 	return nil
 }
 
+// Ack forwards an ack of the message type to the sender with the id. It
+// reports whether the sender stopped its retries.
+func (m *Manager) Ack(id string, messageType int) bool {
+	m.Lock()
+	defer m.Unlock()
+	sender, ok := m.messengers[id].(AckableMessenger)
+	if !ok {
+		return false
+	}
+	return sender.Ack(messageType)
+}
+
 func (m *Manager) RemoveSender(id string) {
 	m.Lock()
 	defer m.Unlock()
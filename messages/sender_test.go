@@ -104,6 +104,65 @@ func TestSenderManager_AddSender(t *testing.T) {
 	})
 }
 
+func TestBackoffSender_StopsOnAck(t *testing.T) {
+	tRetry := 5 * time.Millisecond
+	tWait := 100 * time.Millisecond
+
+	msgr := &MessengerStub{}
+	m := NewManager()
+	bs := NewBackoffMessenger(msgr, tRetry, 20*time.Millisecond)
+	require.NoError(t, m.AddSender("swap_id", bs))
+
+	bs.SendMessage("peer_id", []byte("opening"), int(MESSAGETYPE_OPENINGTXBROADCASTED))
+	time.Sleep(tWait)
+
+	// An ack of a different message type or swap does not stop the retries.
+	assert.False(t, m.Ack("swap_id", int(MESSAGETYPE_CANCELED)))
+	assert.False(t, m.Ack("other_id", int(MESSAGETYPE_OPENINGTXBROADCASTED)))
+	assert.True(t, m.Ack("swap_id", int(MESSAGETYPE_OPENINGTXBROADCASTED)))
+	// Acks are idempotent.
+	assert.True(t, m.Ack("swap_id", int(MESSAGETYPE_OPENINGTXBROADCASTED)))
+
+	time.Sleep(tWait)
+	nMsgs := msgr.Called()
+	assert.Greater(t, nMsgs, 1)
+
+	// Check it is not sending anymore.
+	time.Sleep(tWait)
+	assert.Equal(t, nMsgs, msgr.Called())
+
+	// Removing an acked sender still works.
+	m.RemoveSender("swap_id")
+}
+
+func TestBackoffSender_Backoff(t *testing.T) {
+	tRetry := 10 * time.Millisecond
+	tMax := 40 * time.Millisecond
+
+	msgr := &MessengerStub{}
+	bs := NewBackoffMessenger(msgr, tRetry, tMax)
+	bs.SendMessage("peer_id", []byte("opening"), int(MESSAGETYPE_OPENINGTXBROADCASTED))
+
+	// Retries at 10, 30, 70, 110, 150ms: the delay doubles up to the cap.
+	time.Sleep(175 * time.Millisecond)
+	bs.Stop()
+	nMsgs := msgr.Called()
+	assert.GreaterOrEqual(t, nMsgs, 4)
+	assert.LessOrEqual(t, nMsgs, 7)
+
+	time.Sleep(2 * tMax)
+	assert.Equal(t, nMsgs, msgr.Called())
+}
+
+func TestManager_AckRedundantSender(t *testing.T) {
+	m := NewManager()
+	require.NoError(t, m.AddSender("swap_id", NewRedundantMessenger(&MessengerStub{}, time.Second)))
+
+	// Legacy senders do not support acks.
+	assert.False(t, m.Ack("swap_id", int(MESSAGETYPE_OPENINGTXBROADCASTED)))
+	m.RemoveSender("swap_id")
+}
+
 type MessengerStub struct {
 	sync.Mutex
 	called int
@@ -35,6 +35,10 @@ const (
 	MESSAGETYPE_POLL
 	_
 	MESSAGETYPE_REQUEST_POLL
+	_
+	// MESSAGETYPE_ACK acknowledges that a swap message was processed. It is
+	// only sent to peers that advertise support for acks through peersync.
+	MESSAGETYPE_ACK
 )
 
 // PeerswapCustomMessageType converts a hexadecimal string representation of a message type
@@ -66,6 +70,8 @@ func PeerswapCustomMessageType(msgType string) (MessageType, error) {
 		return MESSAGETYPE_POLL, nil
 	case MESSAGETYPE_REQUEST_POLL:
 		return MESSAGETYPE_REQUEST_POLL, nil
+	case MESSAGETYPE_ACK:
+		return MESSAGETYPE_ACK, nil
 	default:
 		// Return an error if the message type is not recognized.
 		return 0, NewErrNotPeerswapCustomMessage(msgType)
@@ -18,6 +18,7 @@ func TestPeerswapCustomMessageType(t *testing.T) {
 		"coopclose":           {msgType: MessageTypeToHexString(MESSAGETYPE_COOPCLOSE), want: MESSAGETYPE_COOPCLOSE},
 		"poll":                {msgType: MessageTypeToHexString(MESSAGETYPE_POLL), want: MESSAGETYPE_POLL},
 		"request_poll":        {msgType: MessageTypeToHexString(MESSAGETYPE_REQUEST_POLL), want: MESSAGETYPE_REQUEST_POLL},
+		"ack":                 {msgType: MessageTypeToHexString(MESSAGETYPE_ACK), want: MESSAGETYPE_ACK},
 		"invalid":             {msgType: "invalid", wantErr: true},
 	}
 	for name, tt := range tests {
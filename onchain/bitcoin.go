@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
@@ -34,6 +35,10 @@ const (
 	// it is too close to the csv limit to pay the invoice.
 	BitcoinCsvSafetyLimit = BitcoinCsv / 2
 
+	// ReorgWatchDepth is the amount of blocks that a confirmed opening
+	// transaction is watched for reorgs before we consider it final.
+	ReorgWatchDepth = 6
+
 	// EstimatedOpeningTxSize in vByte is the estimated size of a swap opening
 	// transaction with a security margin. The estimate is meant to express the
 	// fees for most, but not all swap out opening tx fees. This is calculated
@@ -43,6 +48,10 @@ const (
 	// leads to an expected size for the opening tx of (3*68 + 84) = 288 vByte.
 	// We add a security margin to this which leads to the size of 350 vByte.
 	EstimatedOpeningTxSize = 350
+
+	// MaxFeeTargetConf is the highest confirmation target that a fee rate
+	// is estimated for.
+	MaxFeeTargetConf = 1008
 )
 
 type BitcoinOnChain struct {
@@ -79,6 +88,32 @@ func (b *BitcoinOnChain) GetChain() *chaincfg.Params {
 	return b.chain
 }
 
+// ValidateAddress returns an error if the address is not an address on the
+// bitcoin network of the wallet.
+func (b *BitcoinOnChain) ValidateAddress(address string) error {
+	addr, err := btcutil.DecodeAddress(address, b.chain)
+	if err != nil {
+		return err
+	}
+	if !addr.IsForNet(b.chain) {
+		return fmt.Errorf("address is not on network %s", b.chain.Name)
+	}
+	return nil
+}
+
+// ValidateFeeRate returns an error if the fee rate of a swap is below the
+// fee floor or the confirmation target is too high to be estimated.
+func (b *BitcoinOnChain) ValidateFeeRate(feeRate *swap.FeeRate) error {
+	if feeRate.TargetConf > MaxFeeTargetConf {
+		return fmt.Errorf("confirmation target exceeds %d blocks", MaxFeeTargetConf)
+	}
+	if feeRate.SatPerVByte > 0 && btcutil.Amount(feeRate.SatPerVByte*satPerVByteToSatPerKw) < b.feeFloorSatPerKw {
+		return fmt.Errorf("fee rate is below the fee floor of %v sat/vb",
+			float64(b.feeFloorSatPerKw)/satPerVByteToSatPerKw)
+	}
+	return nil
+}
+
 func (b *BitcoinOnChain) ValidateTx(swapParams *swap.OpeningParams, openingTxHex string) (bool, error) {
 	msgTx := wire.NewMsgTx(2)
 
@@ -151,29 +186,20 @@ func (b *BitcoinOnChain) GetVoutAndVerify(txHex string, params *swap.OpeningPara
 		return false, 0, err
 	}
 
-	var scriptOut *wire.TxOut
-	var vout uint32
-	for i, out := range msgTx.TxOut {
-		if out.Value == int64(params.Amount) {
-			scriptOut = out
-			vout = uint32(i)
-			break
-		}
-	}
-	if scriptOut == nil {
-		return false, 0, err
-	}
-
 	wantScript, err := b.GetOutputScript(params)
 	if err != nil {
 		return false, 0, err
 	}
 
-	if bytes.Compare(wantScript, scriptOut.PkScript) != 0 {
-		return false, 0, err
+	// A batched opening transaction can hold several outputs with the same
+	// amount, so the output is identified by its script as well.
+	for i, out := range msgTx.TxOut {
+		if out.Value == int64(params.Amount) && bytes.Equal(wantScript, out.PkScript) {
+			return true, uint32(i), nil
+		}
 	}
 
-	return true, vout, nil
+	return false, 0, nil
 }
 
 func (b *BitcoinOnChain) GetOutputScript(params *swap.OpeningParams) ([]byte, error) {
@@ -215,8 +241,7 @@ func (b *BitcoinOnChain) PrepareSpendingTransaction(swapParams *swap.OpeningPara
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	scriptChangeAddrScript := scriptChangeAddr.ScriptAddress()
-	scriptChangeAddrScriptP2pkh, err := txscript.NewScriptBuilder().AddData([]byte{0x00}).AddData(scriptChangeAddrScript).Script()
+	scriptChangeAddrScriptP2pkh, err := txscript.PayToAddrScript(scriptChangeAddr)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -236,7 +261,7 @@ func (b *BitcoinOnChain) PrepareSpendingTransaction(swapParams *swap.OpeningPara
 	// assume largest witness
 	fee := preparedFee
 	if preparedFee == 0 {
-		fee, err = b.GetFee(int64(spendingTx.SerializeSizeStripped()) + 74)
+		fee, err = b.GetFeeForRate(int64(spendingTx.SerializeSizeStripped())+74, claimParams.FeeRate)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -298,8 +323,43 @@ func (b *BitcoinOnChain) GetFeeSatsFromTx(psbtString, txHex string) (uint64, err
 // fetches the fee estimation from the Estimator in sat/kw and converts the
 // returned fee estimation into sat/vb. The return value is in sat.
 func (b *BitcoinOnChain) GetFee(txSize int64) (uint64, error) {
+	return b.GetFeeForRate(txSize, nil)
+}
+
+// GetFeeForRate returns the fee in sat for a transaction of size txSize with
+// the fee rate of a swap. The fee rate is estimated for
+// BitcoinFeeTargetBlocks if feeRate is nil.
+func (b *BitcoinOnChain) GetFeeForRate(txSize int64, feeRate *swap.FeeRate) (uint64, error) {
+	satPerKw := b.FeeRateSatPerKw(feeRate)
+
+	// Convert to sat/vb. This operation is rounding down but should never be
+	// below 1.0 sat/vb if we set the fallback fee above 250 sat/kw. We can set
+	// this fallback fee in the fee estimator.
+	satPerKb := satPerKw * witnessScaleFactor
+	satPerVb := float64(satPerKb) / 1000
+
+	// assume largest witness
+	fee := uint64(satPerVb * float64(txSize))
+	log.Debugf("Using a fee rate of %.2f sat/vb for a total fee of %d", satPerVb, fee)
+	return fee, nil
+}
+
+// FeeRateSatPerKw returns the fee rate of a swap in sat/kw. The fee rate is
+// estimated for the confirmation target of the swap, or for
+// BitcoinFeeTargetBlocks if feeRate is nil. The returned fee rate is at
+// least the fee floor.
+func (b *BitcoinOnChain) FeeRateSatPerKw(feeRate *swap.FeeRate) btcutil.Amount {
+	if feeRate != nil && feeRate.SatPerVByte > 0 {
+		return max(btcutil.Amount(feeRate.SatPerVByte*satPerVByteToSatPerKw), b.feeFloorSatPerKw)
+	}
+
+	targetBlocks := uint32(BitcoinFeeTargetBlocks)
+	if feeRate != nil && feeRate.TargetConf > 0 {
+		targetBlocks = feeRate.TargetConf
+	}
+
 	// EstimateFeePerKw returns an btcutil.Amount that is in sat/kw.
-	satPerKw, err := b.estimator.EstimateFeePerKW(BitcoinFeeTargetBlocks)
+	satPerKw, err := b.estimator.EstimateFeePerKW(targetBlocks)
 	switch {
 	case err != nil:
 		log.Debugf("Error fetching fee from estimator: %v", err)
@@ -316,15 +376,5 @@ func (b *BitcoinOnChain) GetFee(txSize int64) (uint64, error) {
 			"instead", b.feeFloorSatPerKw)
 		satPerKw = b.feeFloorSatPerKw
 	}
-
-	// Convert to sat/vb. This operation is rounding down but should never be
-	// below 1.0 sat/vb if we set the fallback fee above 250 sat/kw. We can set
-	// this fallback fee in the fee estimator.
-	satPerKb := satPerKw * witnessScaleFactor
-	satPerVb := float64(satPerKb) / 1000
-
-	// assume largest witness
-	fee := uint64(satPerVb * float64(txSize))
-	log.Debugf("Using a fee rate of %.2f sat/vb for a total fee of %d", satPerVb, fee)
-	return fee, nil
+	return satPerKw
 }
@@ -0,0 +1,128 @@
+package onchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+// claimWitnessSize is the size in bytes that is assumed for the witness of
+// a claimed swap output, this is the size of the largest witness.
+const claimWitnessSize = 74
+
+// CreateBatchSpendingTransaction creates a signed transaction that spends the
+// swap outputs of all claims to spendingAddr.
+func (b *BitcoinOnChain) CreateBatchSpendingTransaction(claims []*swap.BatchClaim, spendingAddr string) (*wire.MsgTx, error) {
+	if len(claims) == 0 {
+		return nil, errors.New("no claims")
+	}
+
+	addr, err := btcutil.DecodeAddress(spendingAddr, b.chain)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	spendingTx := wire.NewMsgTx(2)
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	redeemScripts := make([][]byte, len(claims))
+	var total int64
+	for i, claim := range claims {
+		openingTx := wire.NewMsgTx(2)
+		txBytes, err := hex.DecodeString(claim.ClaimParams.OpeningTxHex)
+		if err != nil {
+			return nil, err
+		}
+		err = openingTx.Deserialize(bytes.NewReader(txBytes))
+		if err != nil {
+			return nil, err
+		}
+		ok, vout, err := b.GetVoutAndVerify(claim.ClaimParams.OpeningTxHex, claim.SwapParams)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("swap output not found in %s", openingTx.TxHash())
+		}
+
+		redeemScripts[i], err = ParamsToTxScript(claim.SwapParams, BitcoinCsv)
+		if err != nil {
+			return nil, err
+		}
+
+		prevHash := openingTx.TxHash()
+		prevOut := wire.NewOutPoint(&prevHash, vout)
+		input := wire.NewTxIn(prevOut, nil, [][]byte{})
+		if claim.Kind == swap.ClaimKindCsv {
+			input.Sequence = BitcoinCsv
+		}
+		spendingTx.AddTxIn(input)
+		prevOuts.AddPrevOut(*prevOut, openingTx.TxOut[vout])
+		total += openingTx.TxOut[vout].Value
+	}
+	spendingTx.AddTxOut(wire.NewTxOut(0, pkScript))
+
+	feeRates := make([]*swap.FeeRate, len(claims))
+	for i, claim := range claims {
+		feeRates[i] = claim.ClaimParams.FeeRate
+	}
+	fee, err := b.GetFeeForRate(int64(spendingTx.SerializeSizeStripped()+claimWitnessSize*len(claims)), swap.CommonFeeRate(feeRates...))
+	if err != nil {
+		return nil, err
+	}
+	if int64(fee) >= total {
+		return nil, fmt.Errorf("fee %d exceeds the claimed amount %d", fee, total)
+	}
+	spendingTx.TxOut[0].Value = total - int64(fee)
+
+	sigHashes := txscript.NewTxSigHashes(spendingTx, prevOuts)
+	for i, claim := range claims {
+		sigHash, err := txscript.CalcWitnessSigHash(redeemScripts[i], sigHashes, txscript.SigHashAll, spendingTx, i, int64(claim.SwapParams.Amount))
+		if err != nil {
+			return nil, err
+		}
+		spendingTx.TxIn[i].Witness, err = claimWitness(claim, sigHash, redeemScripts[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return spendingTx, nil
+}
+
+// claimWitness returns the witness for the spending path of the claim.
+func claimWitness(claim *swap.BatchClaim, sigHash, redeemScript []byte) (wire.TxWitness, error) {
+	sig, err := claim.ClaimParams.Signer.Sign(sigHash)
+	if err != nil {
+		return nil, err
+	}
+	switch claim.Kind {
+	case swap.ClaimKindPreimage:
+		preimage, err := lightning.MakePreimageFromStr(claim.ClaimParams.Preimage)
+		if err != nil {
+			return nil, err
+		}
+		return GetPreimageWitness(sig.Serialize(), preimage[:], redeemScript), nil
+	case swap.ClaimKindCsv:
+		return GetCsvWitness(sig.Serialize(), redeemScript), nil
+	case swap.ClaimKindCoop:
+		if claim.TakerSigner == nil {
+			return nil, errors.New("missing taker signer")
+		}
+		takerSig, err := claim.TakerSigner.Sign(sigHash)
+		if err != nil {
+			return nil, err
+		}
+		return GetCooperativeWitness(takerSig.Serialize(), sig.Serialize(), redeemScript), nil
+	}
+	return nil, fmt.Errorf("unknown claim kind %d", claim.Kind)
+}
@@ -0,0 +1,116 @@
+package onchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/swap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitcoinOnChain_CreateBatchSpendingTransaction(t *testing.T) {
+	btcOnChain := NewBitcoinOnChain(&EstimatorMock{}, btcutil.Amount(300), btcutil.Amount(275), &chaincfg.RegressionNetParams)
+
+	maker, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	taker, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	openingParams := func(preimage lightning.Preimage, amount uint64) *swap.OpeningParams {
+		return &swap.OpeningParams{
+			TakerPubkey:      hex.EncodeToString(taker.PubKey().SerializeCompressed()),
+			MakerPubkey:      hex.EncodeToString(maker.PubKey().SerializeCompressed()),
+			ClaimPaymentHash: preimage.Hash().String(),
+			Amount:           amount,
+		}
+	}
+
+	// One opening transaction that funds three swaps.
+	var preimages []lightning.Preimage
+	var params []*swap.OpeningParams
+	openingTx := wire.NewMsgTx(2)
+	openingTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, 0), nil, nil))
+	for _, amount := range []uint64{100000, 200000, 300000} {
+		preimage, err := lightning.GetPreimage()
+		require.NoError(t, err)
+		p := openingParams(preimage, amount)
+		script, err := btcOnChain.GetOutputScript(p)
+		require.NoError(t, err)
+		openingTx.AddTxOut(wire.NewTxOut(int64(amount), script))
+		preimages = append(preimages, preimage)
+		params = append(params, p)
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, openingTx.Serialize(buf))
+	openingTxHex := hex.EncodeToString(buf.Bytes())
+
+	claims := []*swap.BatchClaim{
+		{
+			Kind:       swap.ClaimKindPreimage,
+			SwapParams: params[0],
+			ClaimParams: &swap.ClaimParams{
+				Preimage:     preimages[0].String(),
+				Signer:       &testSigner{key: taker},
+				OpeningTxHex: openingTxHex,
+			},
+		},
+		{
+			Kind:       swap.ClaimKindCsv,
+			SwapParams: params[1],
+			ClaimParams: &swap.ClaimParams{
+				Signer:       &testSigner{key: maker},
+				OpeningTxHex: openingTxHex,
+			},
+		},
+		{
+			Kind:       swap.ClaimKindCoop,
+			SwapParams: params[2],
+			ClaimParams: &swap.ClaimParams{
+				Signer:       &testSigner{key: maker},
+				OpeningTxHex: openingTxHex,
+			},
+			TakerSigner: &testSigner{key: taker},
+		},
+	}
+
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &chaincfg.RegressionNetParams)
+	require.NoError(t, err)
+	tx, err := btcOnChain.CreateBatchSpendingTransaction(claims, addr.EncodeAddress())
+	require.NoError(t, err)
+
+	require.Len(t, tx.TxIn, 3)
+	require.Len(t, tx.TxOut, 1)
+	assert.Less(t, tx.TxOut[0].Value, int64(600000))
+	assert.Equal(t, uint32(BitcoinCsv), tx.TxIn[1].Sequence)
+
+	// Every input has to satisfy the script of its swap output.
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for _, in := range tx.TxIn {
+		prevOuts.AddPrevOut(in.PreviousOutPoint, openingTx.TxOut[in.PreviousOutPoint.Index])
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+	for i, in := range tx.TxIn {
+		prevOut := openingTx.TxOut[in.PreviousOutPoint.Index]
+		vm, err := txscript.NewEngine(prevOut.PkScript, tx, i, txscript.StandardVerifyFlags, nil, sigHashes, prevOut.Value, prevOuts)
+		require.NoError(t, err)
+		assert.NoError(t, vm.Execute(), "input %d", i)
+	}
+}
+
+type testSigner struct {
+	key *btcec.PrivateKey
+}
+
+func (s *testSigner) Sign(hash []byte) (*ecdsa.Signature, error) {
+	return ecdsa.Sign(s.key, hash), nil
+}
@@ -0,0 +1,71 @@
+package onchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+// CreateFundingPsbt returns an unsigned PSBT in base64 whose only output is
+// the opening output of the swap, and the address of the output. The inputs
+// and the change are added by the wallet that funds it.
+func (b *BitcoinOnChain) CreateFundingPsbt(params *swap.OpeningParams) (string, string, error) {
+	addr, err := b.CreateOpeningAddress(params, BitcoinCsv)
+	if err != nil {
+		return "", "", err
+	}
+	script, err := b.GetOutputScript(params)
+	if err != nil {
+		return "", "", err
+	}
+	packet, err := psbt.New(nil, []*wire.TxOut{wire.NewTxOut(int64(params.Amount), script)}, 2, 0, nil)
+	if err != nil {
+		return "", "", err
+	}
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return "", "", err
+	}
+	return encoded, addr, nil
+}
+
+// ExtractFundingTx returns the transaction of a signed PSBT in base64, or of a
+// raw transaction in hex, and the vout of the opening output of the swap.
+func (b *BitcoinOnChain) ExtractFundingTx(signed string, params *swap.OpeningParams) (string, uint32, error) {
+	txHex := strings.TrimSpace(signed)
+	if _, err := hex.DecodeString(txHex); err != nil {
+		packet, err := psbt.NewFromRawBytes(strings.NewReader(txHex), true)
+		if err != nil {
+			return "", 0, fmt.Errorf("expected a PSBT in base64 or a transaction in hex: %w", err)
+		}
+		err = psbt.MaybeFinalizeAll(packet)
+		if err != nil {
+			return "", 0, fmt.Errorf("the PSBT is not fully signed: %w", err)
+		}
+		tx, err := psbt.Extract(packet)
+		if err != nil {
+			return "", 0, err
+		}
+		var buf bytes.Buffer
+		err = tx.Serialize(&buf)
+		if err != nil {
+			return "", 0, err
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+
+	ok, vout, err := b.GetVoutAndVerify(txHex, params)
+	if err != nil {
+		return "", 0, err
+	}
+	if !ok {
+		return "", 0, errors.New("the transaction does not pay the opening output")
+	}
+	return txHex, vout, nil
+}
@@ -0,0 +1,83 @@
+package onchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/swap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitcoinOnChain_ExternalFunding(t *testing.T) {
+	btcOnChain := NewBitcoinOnChain(&EstimatorMock{}, 0, 0, &chaincfg.RegressionNetParams)
+
+	maker, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	taker, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	preimage, err := lightning.GetPreimage()
+	require.NoError(t, err)
+	params := &swap.OpeningParams{
+		TakerPubkey:      hex.EncodeToString(taker.PubKey().SerializeCompressed()),
+		MakerPubkey:      hex.EncodeToString(maker.PubKey().SerializeCompressed()),
+		ClaimPaymentHash: preimage.Hash().String(),
+		Amount:           100000,
+	}
+
+	encoded, addr, err := btcOnChain.CreateFundingPsbt(params)
+	require.NoError(t, err)
+	wantAddr, err := btcOnChain.CreateOpeningAddress(params, BitcoinCsv)
+	require.NoError(t, err)
+	assert.Equal(t, wantAddr, addr)
+
+	// The PSBT pays the opening output only.
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(encoded), true)
+	require.NoError(t, err)
+	assert.Empty(t, packet.UnsignedTx.TxIn)
+	require.Len(t, packet.UnsignedTx.TxOut, 1)
+	script, err := btcOnChain.GetOutputScript(params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(params.Amount), packet.UnsignedTx.TxOut[0].Value)
+	assert.Equal(t, script, packet.UnsignedTx.TxOut[0].PkScript)
+
+	// A wallet funds the PSBT with an input and a change output in front of
+	// the opening output.
+	fundedTx := wire.NewMsgTx(2)
+	fundedTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, [][]byte{{0x01}}))
+	fundedTx.AddTxOut(wire.NewTxOut(50000, []byte{0x00, 0x14}))
+	fundedTx.AddTxOut(packet.UnsignedTx.TxOut[0])
+	var buf bytes.Buffer
+	require.NoError(t, fundedTx.Serialize(&buf))
+	fundedHex := hex.EncodeToString(buf.Bytes())
+
+	txHex, vout, err := btcOnChain.ExtractFundingTx(fundedHex, params)
+	require.NoError(t, err)
+	assert.Equal(t, fundedHex, txHex)
+	assert.Equal(t, uint32(1), vout)
+
+	// The opening output has to pay the swap amount.
+	other := *params
+	other.Amount = 90000
+	_, _, err = btcOnChain.ExtractFundingTx(fundedHex, &other)
+	assert.ErrorContains(t, err, "does not pay the opening output")
+
+	// An input without a signature can not be finalized.
+	packet.UnsignedTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	packet.Inputs = append(packet.Inputs, psbt.PInput{})
+	unsigned, err := packet.B64Encode()
+	require.NoError(t, err)
+	_, _, err = btcOnChain.ExtractFundingTx(unsigned, params)
+	assert.ErrorContains(t, err, "not fully signed")
+
+	_, _, err = btcOnChain.ExtractFundingTx("no transaction", params)
+	assert.Error(t, err)
+}
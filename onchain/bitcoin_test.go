@@ -5,6 +5,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/elementsproject/peerswap/swap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -81,14 +82,56 @@ func TestBitcoinOnChain_GetFee_UseFloorFeeRate(t *testing.T) {
 	)
 }
 
+func TestBitcoinOnChain_GetFeeForRate(t *testing.T) {
+	estimator := &EstimatorMock{EstimateFeePerKWReturn: btcutil.Amount(1000)}
+	btcOnChain := NewBitcoinOnChain(
+		estimator,
+		btcutil.Amount(300),
+		LegacyFeeFloorSatPerKw,
+		&chaincfg.Params{},
+	)
+
+	// An explicit fee rate is used without asking the estimator.
+	fee, err := btcOnChain.GetFeeForRate(100, &swap.FeeRate{SatPerVByte: 2.5})
+	require.NoError(t, err)
+	require.Equal(t, uint64(250), fee)
+	require.Equal(t, 0, estimator.EstimateFeePerKWCalled)
+
+	// The confirmation target is passed to the estimator. 1000 sat/kw
+	// correspond to 4 sat/vb.
+	fee, err = btcOnChain.GetFeeForRate(100, &swap.FeeRate{TargetConf: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(400), fee)
+	require.Equal(t, uint32(2), estimator.EstimateFeePerKWTarget)
+
+	fee, err = btcOnChain.GetFeeForRate(100, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(400), fee)
+	require.Equal(t, uint32(BitcoinFeeTargetBlocks), estimator.EstimateFeePerKWTarget)
+}
+
+func TestBitcoinOnChain_ValidateFeeRate(t *testing.T) {
+	btcOnChain := NewBitcoinOnChain(&EstimatorMock{}, 0, LegacyFeeFloorSatPerKw, &chaincfg.Params{})
+	require.NoError(t, btcOnChain.ValidateFeeRate(&swap.FeeRate{SatPerVByte: 1.1}))
+	require.NoError(t, btcOnChain.ValidateFeeRate(&swap.FeeRate{TargetConf: 6}))
+	// 1 sat/vb is below the legacy floor of 253 sat/kw.
+	require.Error(t, btcOnChain.ValidateFeeRate(&swap.FeeRate{SatPerVByte: 1}))
+	require.Error(t, btcOnChain.ValidateFeeRate(&swap.FeeRate{TargetConf: MaxFeeTargetConf + 1}))
+
+	btcOnChain = NewBitcoinOnChain(&EstimatorMock{}, 0, ModernFeeFloorSatPerKw, &chaincfg.Params{})
+	require.NoError(t, btcOnChain.ValidateFeeRate(&swap.FeeRate{SatPerVByte: 0.1}))
+}
+
 type EstimatorMock struct {
 	EstimateFeePerKWCalled int
+	EstimateFeePerKWTarget uint32
 	EstimateFeePerKWReturn btcutil.Amount
 	EstimateFeePerKWError  *error
 }
 
 func (e *EstimatorMock) EstimateFeePerKW(targetBlocks uint32) (btcutil.Amount, error) {
 	e.EstimateFeePerKWCalled++
+	e.EstimateFeePerKWTarget = targetBlocks
 	if e.EstimateFeePerKWError != nil {
 		return e.EstimateFeePerKWReturn, *e.EstimateFeePerKWError
 	}
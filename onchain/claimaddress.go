@@ -0,0 +1,177 @@
+package onchain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/vulpemventures/go-elements/network"
+	"github.com/vulpemventures/go-elements/payment"
+	"github.com/vulpemventures/go-elements/slip77"
+)
+
+var errLiquidDescriptor = errors.New("descriptor must be of the form ct(slip77(<master blinding key>),elwpkh(<xpub>/0/*))")
+
+// BitcoinClaimAddressDeriver derives P2WPKH claim addresses from an xpub.
+type BitcoinClaimAddressDeriver struct {
+	key   *hdkeychain.ExtendedKey
+	path  []uint32
+	chain *chaincfg.Params
+}
+
+// NewBitcoinClaimAddressDeriver returns a deriver for a descriptor of the form
+// `wpkh([origin]xpub/0/*)`, or for a bare xpub whose addresses are derived at
+// `xpub/0/*`.
+func NewBitcoinClaimAddressDeriver(descriptor string, chain *chaincfg.Params) (*BitcoinClaimAddressDeriver, error) {
+	key, path, err := parseXpubDescriptor(descriptor, "wpkh")
+	if err != nil {
+		return nil, err
+	}
+	if !key.IsForNet(chain) {
+		return nil, fmt.Errorf("xpub is not on network %s", chain.Name)
+	}
+	return &BitcoinClaimAddressDeriver{key: key, path: path, chain: chain}, nil
+}
+
+func (d *BitcoinClaimAddressDeriver) DeriveClaimAddress(index uint32) (string, error) {
+	pubkey, err := derivePubkey(d.key, d.path, index)
+	if err != nil {
+		return "", err
+	}
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubkey.SerializeCompressed()), d.chain)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// LiquidClaimAddressDeriver derives confidential P2WPKH claim addresses from
+// an xpub. The blinding key of every address is derived from a SLIP-77 master
+// blinding key.
+type LiquidClaimAddressDeriver struct {
+	key      *hdkeychain.ExtendedKey
+	path     []uint32
+	blinding *slip77.Slip77
+	network  *network.Network
+}
+
+// NewLiquidClaimAddressDeriver returns a deriver for a descriptor of the form
+// `ct(slip77(<master blinding key>),elwpkh([origin]xpub/0/*))`.
+func NewLiquidClaimAddressDeriver(descriptor string, network *network.Network) (*LiquidClaimAddressDeriver, error) {
+	inner, ok := unwrapExpression(stripChecksum(descriptor), "ct")
+	if !ok {
+		return nil, errLiquidDescriptor
+	}
+	blindingExpr, keyExpr, ok := strings.Cut(inner, ",")
+	if !ok {
+		return nil, errLiquidDescriptor
+	}
+	masterKeyHex, ok := unwrapExpression(blindingExpr, "slip77")
+	if !ok {
+		return nil, errLiquidDescriptor
+	}
+	masterKey, err := hex.DecodeString(masterKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master blinding key: %w", err)
+	}
+	blinding, err := slip77.FromMasterKey(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := unwrapExpression(keyExpr, "elwpkh"); !ok {
+		return nil, errLiquidDescriptor
+	}
+	key, path, err := parseXpubDescriptor(keyExpr, "elwpkh")
+	if err != nil {
+		return nil, err
+	}
+	return &LiquidClaimAddressDeriver{key: key, path: path, blinding: blinding, network: network}, nil
+}
+
+func (d *LiquidClaimAddressDeriver) DeriveClaimAddress(index uint32) (string, error) {
+	pubkey, err := derivePubkey(d.key, d.path, index)
+	if err != nil {
+		return "", err
+	}
+	p2wpkh := payment.FromPublicKey(pubkey, d.network, nil)
+	_, blindingKey, err := d.blinding.DeriveKey(p2wpkh.WitnessScript)
+	if err != nil {
+		return "", err
+	}
+	p2wpkh.BlindingKey = blindingKey
+	return p2wpkh.ConfidentialWitnessPubKeyHash()
+}
+
+// parseXpubDescriptor parses `fn([origin]xpub/path/*)` or a bare xpub and
+// returns the xpub and the path to the keys. The path of a bare xpub is
+// `0/*`.
+func parseXpubDescriptor(descriptor, fn string) (*hdkeychain.ExtendedKey, []uint32, error) {
+	descriptor = stripChecksum(descriptor)
+	keyExpr, ok := unwrapExpression(descriptor, fn)
+	if !ok {
+		if strings.Contains(descriptor, "(") {
+			return nil, nil, fmt.Errorf("descriptor must be of the form %s(<xpub>/0/*)", fn)
+		}
+		keyExpr = descriptor + "/0/*"
+	}
+
+	// The key origin only documents where the xpub comes from.
+	if strings.HasPrefix(keyExpr, "[") {
+		end := strings.Index(keyExpr, "]")
+		if end < 0 {
+			return nil, nil, errors.New("unterminated key origin")
+		}
+		keyExpr = keyExpr[end+1:]
+	}
+
+	parts := strings.Split(keyExpr, "/")
+	if len(parts) < 2 || parts[len(parts)-1] != "*" {
+		return nil, nil, errors.New("xpub must end with a non-hardened wildcard, e.g. xpub/0/*")
+	}
+	key, err := hdkeychain.NewKeyFromString(parts[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if key.IsPrivate() {
+		return nil, nil, errors.New("expected an xpub, not a private key")
+	}
+	var path []uint32
+	for _, p := range parts[1 : len(parts)-1] {
+		index, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || index >= hdkeychain.HardenedKeyStart {
+			return nil, nil, fmt.Errorf("invalid path element %s, only non-hardened derivation is possible from an xpub", p)
+		}
+		path = append(path, uint32(index))
+	}
+	return key, path, nil
+}
+
+func derivePubkey(key *hdkeychain.ExtendedKey, path []uint32, index uint32) (*btcec.PublicKey, error) {
+	var err error
+	for _, p := range append(append([]uint32(nil), path...), index) {
+		key, err = key.Derive(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key.ECPubKey()
+}
+
+func stripChecksum(descriptor string) string {
+	descriptor, _, _ = strings.Cut(strings.TrimSpace(descriptor), "#")
+	return descriptor
+}
+
+// unwrapExpression returns the argument of `fn(arg)`.
+func unwrapExpression(expr, fn string) (string, bool) {
+	if !strings.HasPrefix(expr, fn+"(") || !strings.HasSuffix(expr, ")") {
+		return "", false
+	}
+	return expr[len(fn)+1 : len(expr)-1], true
+}
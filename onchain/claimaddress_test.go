@@ -0,0 +1,104 @@
+package onchain
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulpemventures/go-elements/address"
+	"github.com/vulpemventures/go-elements/network"
+	"github.com/vulpemventures/go-elements/payment"
+)
+
+// bip84Xpub is the account xpub of the BIP-84 test vector.
+const bip84Xpub = "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"
+
+func TestBitcoinClaimAddressDeriver(t *testing.T) {
+	for _, descriptor := range []string{
+		bip84Xpub,
+		"wpkh(" + bip84Xpub + "/0/*)",
+		"wpkh([73c5da0a/84h/0h/0h]" + bip84Xpub + "/0/*)#abcdefgh",
+	} {
+		d, err := NewBitcoinClaimAddressDeriver(descriptor, &chaincfg.MainNetParams)
+		require.NoError(t, err, descriptor)
+
+		addr, err := d.DeriveClaimAddress(0)
+		require.NoError(t, err)
+		assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", addr)
+		addr, err = d.DeriveClaimAddress(1)
+		require.NoError(t, err)
+		assert.Equal(t, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", addr)
+	}
+
+	for _, descriptor := range []string{
+		"pkh(" + bip84Xpub + "/0/*)",
+		"wpkh(" + bip84Xpub + "/0h/*)",
+		"wpkh(" + bip84Xpub + "/0)",
+		"xpub",
+	} {
+		_, err := NewBitcoinClaimAddressDeriver(descriptor, &chaincfg.MainNetParams)
+		assert.Error(t, err, descriptor)
+	}
+
+	_, err := NewBitcoinClaimAddressDeriver(bip84Xpub, &chaincfg.RegressionNetParams)
+	assert.Error(t, err)
+}
+
+func TestLiquidClaimAddressDeriver(t *testing.T) {
+	masterBlindingKey := "9c8e4f05c7711a98c838be228bcb84924d4570ca53f35fa1c793e58841d47023"
+	d, err := NewLiquidClaimAddressDeriver("ct(slip77("+masterBlindingKey+"),elwpkh("+bip84Xpub+"/0/*))", &network.Regtest)
+	require.NoError(t, err)
+
+	addr0, err := d.DeriveClaimAddress(0)
+	require.NoError(t, err)
+	addr1, err := d.DeriveClaimAddress(1)
+	require.NoError(t, err)
+	assert.NotEqual(t, addr0, addr1)
+
+	liquid := NewLiquidOnChain(nil, &network.Regtest)
+	assert.NoError(t, liquid.ValidateAddress(addr0))
+	info, err := address.FromConfidential(addr0)
+	require.NoError(t, err)
+	assert.Len(t, info.BlindingKey, 33)
+
+	_, err = NewLiquidClaimAddressDeriver("elwpkh("+bip84Xpub+"/0/*)", &network.Regtest)
+	assert.Error(t, err)
+	_, err = NewLiquidClaimAddressDeriver("ct(slip77(zz),elwpkh("+bip84Xpub+"/0/*))", &network.Regtest)
+	assert.Error(t, err)
+}
+
+func TestValidateAddress(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pubkeyHash := btcutil.Hash160(key.PubKey().SerializeCompressed())
+
+	btc := NewBitcoinOnChain(&EstimatorMock{}, 0, 0, &chaincfg.MainNetParams)
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.RegressionNetParams} {
+		p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(pubkeyHash, params)
+		require.NoError(t, err)
+		p2pkh, err := btcutil.NewAddressPubKeyHash(pubkeyHash, params)
+		require.NoError(t, err)
+		if params == &chaincfg.MainNetParams {
+			assert.NoError(t, btc.ValidateAddress(p2wpkh.EncodeAddress()))
+			assert.NoError(t, btc.ValidateAddress(p2pkh.EncodeAddress()))
+		} else {
+			assert.Error(t, btc.ValidateAddress(p2wpkh.EncodeAddress()))
+			assert.Error(t, btc.ValidateAddress(p2pkh.EncodeAddress()))
+		}
+	}
+	assert.Error(t, btc.ValidateAddress("not an address"))
+
+	liquid := NewLiquidOnChain(nil, &network.Regtest)
+	confidential, err := payment.FromPublicKey(key.PubKey(), &network.Regtest, key.PubKey()).ConfidentialWitnessPubKeyHash()
+	require.NoError(t, err)
+	assert.NoError(t, liquid.ValidateAddress(confidential))
+	unconfidential, err := payment.FromPublicKey(key.PubKey(), &network.Regtest, nil).WitnessPubKeyHash()
+	require.NoError(t, err)
+	assert.Error(t, liquid.ValidateAddress(unconfidential))
+	mainnet, err := payment.FromPublicKey(key.PubKey(), &network.Liquid, key.PubKey()).ConfidentialWitnessPubKeyHash()
+	require.NoError(t, err)
+	assert.Error(t, liquid.ValidateAddress(mainnet))
+}
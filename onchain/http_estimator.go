@@ -0,0 +1,336 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/elementsproject/peerswap/log"
+)
+
+const (
+	// DefaultFeeEstimatesCacheDuration is the time that the fee estimates of
+	// an HTTPEstimator are reused before they are requested again.
+	DefaultFeeEstimatesCacheDuration = time.Minute
+
+	// DefaultFeeEstimatesMaxAge is the age from which the fee estimates of an
+	// HTTPEstimator are no longer used while the endpoint fails, the
+	// fallback fee rate is used instead.
+	DefaultFeeEstimatesMaxAge = 30 * time.Minute
+
+	// httpEstimatorTimeout is the timeout of a fee estimates request.
+	httpEstimatorTimeout = 10 * time.Second
+
+	// httpEstimatorRetryInterval is the time after a failed request until
+	// the endpoint is requested again. It doubles with every further
+	// failure up to httpEstimatorMaxRetryInterval.
+	httpEstimatorRetryInterval    = 30 * time.Second
+	httpEstimatorMaxRetryInterval = 10 * time.Minute
+
+	// satPerVByteToSatPerKw converts sat/vb to sat/kw, as 1 vb is 4 wu.
+	satPerVByteToSatPerKw = 1000 / witnessScaleFactor
+)
+
+// HTTPEstimator reads the fee rates from a mempool.space or Esplora style
+// /fee-estimates endpoint. The endpoint returns a json object that maps the
+// confirmation targets to fee rates in sat/vb, e.g. {"1": 87.9, "6": 62.1}.
+type HTTPEstimator struct {
+	url    string
+	client *http.Client
+
+	// fallbackFeeRate is returned in case that the endpoint has an error or
+	// no estimate. This value is in sat/kw. If it is zero, ErrNoFeeEstimate
+	// is returned instead.
+	fallbackFeeRate btcutil.Amount
+
+	// feeFloorSatPerKw is the minimum fee rate that is returned.
+	feeFloorSatPerKw btcutil.Amount
+
+	// cacheDuration is the time that fetched estimates are reused.
+	cacheDuration time.Duration
+
+	// maxAge is the age from which fetched estimates are no longer used.
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	estimates map[uint32]float64
+	fetchedAt time.Time
+	// fetching is set while the estimates are requested, other callers use
+	// the last estimates meanwhile.
+	fetching bool
+	// retryAt is the time until which the endpoint is not requested after
+	// a failure, retryInterval the backoff of the last failure.
+	retryAt       time.Time
+	retryInterval time.Duration
+}
+
+var _ Estimator = (*HTTPEstimator)(nil)
+
+// ErrNoFeeEstimate is returned by an HTTPEstimator without fallback fee rate
+// if the endpoint has no estimate.
+var ErrNoFeeEstimate = errors.New("no fee estimate")
+
+// NewHTTPEstimator creates a new HTTPEstimator for the full url of the
+// endpoint, e.g. https://mempool.space/api/fee-estimates.
+func NewHTTPEstimator(endpoint string, fallbackFeeRate, feeFloorSatPerKw btcutil.Amount,
+	cacheDuration time.Duration) (*HTTPEstimator, error) {
+
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("expected http or https scheme, got %s", u.Scheme)
+	}
+
+	return &HTTPEstimator{
+		url:              endpoint,
+		client:           &http.Client{Timeout: httpEstimatorTimeout},
+		fallbackFeeRate:  fallbackFeeRate,
+		feeFloorSatPerKw: feeFloorSatPerKw,
+		cacheDuration:    cacheDuration,
+		maxAge:           max(DefaultFeeEstimatesMaxAge, cacheDuration),
+	}, nil
+}
+
+// Start fetches the fee estimates once. A failure is only logged, as the
+// estimator falls back to the fallback fee rate until the endpoint answers.
+//
+// NOTE: This method is part of the Estimator interface.
+func (h *HTTPEstimator) Start() error {
+	if _, err := h.getEstimates(); err != nil {
+		log.Infof("Could not fetch fee estimates from %s: %v", h.url, err)
+	}
+	return nil
+}
+
+// EstimateFeePerKW returns the estimated fee in sat/kw for a transaction
+// that should be confirmed in targetBlocks. The estimate of the closest
+// lower target is used if the endpoint has none for targetBlocks.
+func (h *HTTPEstimator) EstimateFeePerKW(targetBlocks uint32) (btcutil.Amount, error) {
+	estimates, err := h.getEstimates()
+	if err != nil {
+		log.Infof("Could not fetch fee estimates from %s: %v", h.url, err)
+	}
+	satPerVByte, ok := estimateForTarget(estimates, targetBlocks)
+	if !ok {
+		if h.fallbackFeeRate == 0 {
+			return 0, ErrNoFeeEstimate
+		}
+		fallback := max(h.fallbackFeeRate, h.feeFloorSatPerKw)
+		log.Debugf("No fee estimate from %s, using fallback fee %d", h.url, fallback)
+		return fallback, nil
+	}
+
+	satPerKw := btcutil.Amount(satPerVByte * satPerVByteToSatPerKw)
+	if satPerKw < h.feeFloorSatPerKw {
+		log.Debugf("Estimated fee rate %v sat/kw is too low, using floor %v sat/kw", satPerKw, h.feeFloorSatPerKw)
+		satPerKw = h.feeFloorSatPerKw
+	}
+	return satPerKw, nil
+}
+
+// getEstimates returns the cached estimates or fetches them if they are older
+// than the cache duration. The last estimates are returned with the error if
+// the fetch fails, as long as they are not older than the max age. After a
+// failure the endpoint is only requested again once the backoff is over.
+func (h *HTTPEstimator) getEstimates() (map[uint32]float64, error) {
+	h.mu.Lock()
+	if h.estimates != nil && time.Since(h.fetchedAt) < h.cacheDuration {
+		defer h.mu.Unlock()
+		return h.estimates, nil
+	}
+	if h.fetching || time.Now().Before(h.retryAt) {
+		defer h.mu.Unlock()
+		return h.lastEstimates(), nil
+	}
+	// The lock is not held during the request, so that other callers do
+	// not wait for the timeout of an endpoint that is down.
+	h.fetching = true
+	h.mu.Unlock()
+
+	estimates, err := h.fetchEstimates()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fetching = false
+	if err != nil {
+		h.retryInterval = min(max(2*h.retryInterval, httpEstimatorRetryInterval), httpEstimatorMaxRetryInterval)
+		h.retryAt = time.Now().Add(h.retryInterval)
+		return h.lastEstimates(), err
+	}
+	h.estimates = estimates
+	h.fetchedAt = time.Now()
+	h.retryAt, h.retryInterval = time.Time{}, 0
+	return estimates, nil
+}
+
+// lastEstimates returns the last fetched estimates unless they are older
+// than the max age. The lock must be held.
+func (h *HTTPEstimator) lastEstimates() map[uint32]float64 {
+	if time.Since(h.fetchedAt) >= h.maxAge {
+		return nil
+	}
+	return h.estimates
+}
+
+func (h *HTTPEstimator) fetchEstimates() (map[uint32]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpEstimatorTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var raw map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid fee estimates: %w", err)
+	}
+	estimates := make(map[uint32]float64, len(raw))
+	for target, feeRate := range raw {
+		t, err := strconv.ParseUint(target, 10, 32)
+		if err != nil || t == 0 || feeRate <= 0 {
+			continue
+		}
+		estimates[uint32(t)] = feeRate
+	}
+	if len(estimates) == 0 {
+		return nil, errors.New("no fee estimates returned")
+	}
+	return estimates, nil
+}
+
+// estimateForTarget returns the estimate of the target or of the closest
+// lower target. The lowest target is used if the target is below all of
+// them.
+func estimateForTarget(estimates map[uint32]float64, target uint32) (float64, bool) {
+	var (
+		best, lowest       uint32
+		hasBest, hasLowest bool
+	)
+	for t := range estimates {
+		if t <= target && (!hasBest || t > best) {
+			best, hasBest = t, true
+		}
+		if !hasLowest || t < lowest {
+			lowest, hasLowest = t, true
+		}
+	}
+	switch {
+	case hasBest:
+		return estimates[best], true
+	case hasLowest:
+		return estimates[lowest], true
+	}
+	return 0, false
+}
+
+// Ways to combine the fee rates of several estimators.
+const (
+	CombineMax    = "max"
+	CombineMedian = "median"
+)
+
+// CombinedEstimator returns the maximum or the median of the fee rates of
+// several estimators. Estimators that return an error are skipped.
+type CombinedEstimator struct {
+	mode       string
+	estimators []Estimator
+}
+
+var _ Estimator = (*CombinedEstimator)(nil)
+
+// NewCombinedEstimator combines the estimators with CombineMax or
+// CombineMedian.
+func NewCombinedEstimator(mode string, estimators ...Estimator) (*CombinedEstimator, error) {
+	if mode != CombineMax && mode != CombineMedian {
+		return nil, fmt.Errorf("unknown fee estimates mode %q, expected %s or %s", mode, CombineMax, CombineMedian)
+	}
+	if len(estimators) == 0 {
+		return nil, errors.New("no estimator to combine")
+	}
+	return &CombinedEstimator{
+		mode:       mode,
+		estimators: estimators,
+	}, nil
+}
+
+// Start starts all estimators.
+//
+// NOTE: This method is part of the Estimator interface.
+func (c *CombinedEstimator) Start() error {
+	for _, e := range c.estimators {
+		if err := e.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CombinedEstimator) EstimateFeePerKW(targetBlocks uint32) (btcutil.Amount, error) {
+	var (
+		feeRates []btcutil.Amount
+		lastErr  error
+	)
+	for _, e := range c.estimators {
+		feeRate, err := e.EstimateFeePerKW(targetBlocks)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		feeRates = append(feeRates, feeRate)
+	}
+	if len(feeRates) == 0 {
+		return 0, fmt.Errorf("no estimator returned a fee rate: %w", lastErr)
+	}
+
+	sort.Slice(feeRates, func(i, j int) bool { return feeRates[i] < feeRates[j] })
+	if c.mode == CombineMax {
+		return feeRates[len(feeRates)-1], nil
+	}
+	mid := len(feeRates) / 2
+	if len(feeRates)%2 == 0 {
+		return (feeRates[mid-1] + feeRates[mid]) / 2, nil
+	}
+	return feeRates[mid], nil
+}
+
+// WithHTTPEstimators combines the estimator with an HTTPEstimator for each
+// of the urls. The estimator is returned as is if no url is set. Endpoints
+// without estimate are left out, the estimator provides the fallback.
+func WithHTTPEstimators(estimator Estimator, urls []string, mode string,
+	feeFloorSatPerKw btcutil.Amount) (Estimator, error) {
+
+	if len(urls) == 0 {
+		return estimator, nil
+	}
+	estimators := []Estimator{estimator}
+	for _, u := range urls {
+		e, err := NewHTTPEstimator(u, 0, feeFloorSatPerKw, DefaultFeeEstimatesCacheDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee estimates url %s: %w", u, err)
+		}
+		estimators = append(estimators, e)
+	}
+	if mode == "" {
+		mode = CombineMax
+	}
+	return NewCombinedEstimator(mode, estimators...)
+}
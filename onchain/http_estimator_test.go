@@ -0,0 +1,189 @@
+package onchain
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeeEstimatesServer(t *testing.T, body *atomic.Value, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		b := body.Load().(string)
+		if b == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(b))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPEstimator(t *testing.T) {
+	var body atomic.Value
+	var requests atomic.Int32
+	body.Store(`{"1": 20.5, "3": 10, "6": 4, "144": 0.5, "x": 7}`)
+	server := newFeeEstimatesServer(t, &body, &requests)
+
+	estimator, err := NewHTTPEstimator(server.URL+"/api/fee-estimates", 6250, LegacyFeeFloorSatPerKw, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, estimator.Start())
+
+	tests := []struct {
+		target uint32
+		want   btcutil.Amount
+	}{
+		{target: 1, want: 5125},
+		{target: 3, want: 2500},
+		// The closest lower target is used.
+		{target: 5, want: 2500},
+		{target: 200, want: LegacyFeeFloorSatPerKw},
+	}
+	for _, tt := range tests {
+		fee, err := estimator.EstimateFeePerKW(tt.target)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, fee, "target %d", tt.target)
+	}
+	// The estimates are cached.
+	require.Equal(t, int32(1), requests.Load())
+
+	// The last estimates are used while the endpoint fails.
+	body.Store("")
+	estimator.cacheDuration = 0
+	fee, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(1000), fee)
+	require.Equal(t, int32(2), requests.Load())
+
+	// The endpoint is not requested again until the backoff is over.
+	fee, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(1000), fee)
+	require.Equal(t, int32(2), requests.Load())
+	require.Equal(t, httpEstimatorRetryInterval, estimator.retryInterval)
+
+	// The backoff doubles with every failure.
+	estimator.retryAt = time.Time{}
+	_, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), requests.Load())
+	require.Equal(t, 2*httpEstimatorRetryInterval, estimator.retryInterval)
+
+	// Estimates older than the max age are not used, the fallback is.
+	estimator.maxAge = 0
+	fee, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(6250), fee)
+
+	// The backoff is reset once the endpoint answers again.
+	body.Store(`{"6": 8}`)
+	estimator.retryAt = time.Time{}
+	fee, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(2000), fee)
+	require.Equal(t, int32(4), requests.Load())
+	require.Zero(t, estimator.retryInterval)
+}
+
+func TestHTTPEstimatorFallback(t *testing.T) {
+	var body atomic.Value
+	var requests atomic.Int32
+	body.Store("")
+	server := newFeeEstimatesServer(t, &body, &requests)
+
+	estimator, err := NewHTTPEstimator(server.URL, 6250, LegacyFeeFloorSatPerKw, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, estimator.Start())
+	fee, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(6250), fee)
+
+	body.Store(`{}`)
+	fee, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(6250), fee)
+
+	_, err = NewHTTPEstimator("ftp://example.com/fee-estimates", 6250, LegacyFeeFloorSatPerKw, time.Hour)
+	require.Error(t, err)
+}
+
+type staticEstimator struct {
+	fee btcutil.Amount
+	err error
+}
+
+func (s staticEstimator) EstimateFeePerKW(uint32) (btcutil.Amount, error) {
+	return s.fee, s.err
+}
+
+func (s staticEstimator) Start() error {
+	return nil
+}
+
+func TestCombinedEstimator(t *testing.T) {
+	failing := staticEstimator{err: errors.New("down")}
+	tests := []struct {
+		name       string
+		mode       string
+		estimators []Estimator
+		want       btcutil.Amount
+		wantErr    bool
+	}{
+		{name: "max", mode: CombineMax, estimators: []Estimator{staticEstimator{fee: 300}, staticEstimator{fee: 900}, staticEstimator{fee: 500}}, want: 900},
+		{name: "median odd", mode: CombineMedian, estimators: []Estimator{staticEstimator{fee: 300}, staticEstimator{fee: 900}, staticEstimator{fee: 500}}, want: 500},
+		{name: "median even", mode: CombineMedian, estimators: []Estimator{staticEstimator{fee: 300}, staticEstimator{fee: 900}}, want: 600},
+		{name: "skips errors", mode: CombineMax, estimators: []Estimator{failing, staticEstimator{fee: 300}}, want: 300},
+		{name: "all fail", mode: CombineMedian, estimators: []Estimator{failing}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator, err := NewCombinedEstimator(tt.mode, tt.estimators...)
+			require.NoError(t, err)
+			fee, err := estimator.EstimateFeePerKW(6)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, fee)
+		})
+	}
+
+	_, err := NewCombinedEstimator("min", staticEstimator{})
+	require.Error(t, err)
+}
+
+func TestWithHTTPEstimators(t *testing.T) {
+	var body atomic.Value
+	var requests atomic.Int32
+	body.Store(`{"6": 20}`)
+	server := newFeeEstimatesServer(t, &body, &requests)
+
+	estimator, err := WithHTTPEstimators(staticEstimator{fee: 1000}, []string{server.URL}, "", LegacyFeeFloorSatPerKw)
+	require.NoError(t, err)
+	require.NoError(t, estimator.Start())
+	fee, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(5000), fee)
+
+	// An endpoint without estimate is left out.
+	estimator, err = WithHTTPEstimators(staticEstimator{fee: 1000}, []string{server.URL + "/down"}, CombineMedian, LegacyFeeFloorSatPerKw)
+	require.NoError(t, err)
+	body.Store("")
+	fee, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(1000), fee)
+
+	base := staticEstimator{fee: 1000}
+	estimator, err = WithHTTPEstimators(base, nil, "", LegacyFeeFloorSatPerKw)
+	require.NoError(t, err)
+	require.Equal(t, base, estimator)
+}
@@ -28,6 +28,9 @@ import (
 const (
 	LiquidCsv   = 60
 	LiquidConfs = 2
+	// LiquidFeeFloorSatPerVByte is the minimum fee rate of a liquid
+	// transaction.
+	LiquidFeeFloorSatPerVByte = 0.1
 	// EstimatedOpeningConfidentialTxSizeBytes is the estimated size of a opening transaction.
 	// The size is a calculate 2672 bytes for 3 inputs and 3 outputs of which 2 are
 	// blinded. An additional safety margin is added for a total of 3000 bytes.
@@ -79,11 +82,61 @@ func (l *LiquidOnChain) CreateOpeningTransaction(swapParams *swap.OpeningParams)
 	return txHex, blindedScriptAddr, txId, fee, vout, nil
 }
 
+// CreateBatchOpeningTransaction funds the opening outputs of several swaps
+// with a single transaction. The transaction is not broadcast.
+func (l *LiquidOnChain) CreateBatchOpeningTransaction(swapParams []*swap.OpeningParams) (txHex, txId string, fee uint64, outputs []*swap.BatchOutput, err error) {
+	redeemScripts := make([][]byte, len(swapParams))
+	for i, params := range swapParams {
+		redeemScripts[i], err = ParamsToTxScript(params, params.CSV)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		scriptPubKey := []byte{0x00, 0x20}
+		witnessProgram := sha256.Sum256(redeemScripts[i])
+		scriptPubKey = append(scriptPubKey, witnessProgram[:]...)
+
+		redeemPayment, _ := payment.FromScript(scriptPubKey, l.network, params.BlindingKey.PubKey())
+		blindedScriptAddr, err := redeemPayment.ConfidentialWitnessScriptHash()
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		params.OpeningAddress = blindedScriptAddr
+	}
+
+	txId, txHex, fee, err = l.liquidWallet.CreateBatchTransaction(swapParams, l.asset)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	tx, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	for i, params := range swapParams {
+		vout, err := l.FindVout(tx.Outputs, redeemScripts[i])
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		outputs = append(outputs, &swap.BatchOutput{Address: params.OpeningAddress, Vout: vout})
+	}
+	return txHex, txId, fee, outputs, nil
+}
+
+// BroadcastBatchOpeningTransaction broadcasts a transaction that was created
+// by CreateBatchOpeningTransaction.
+func (l *LiquidOnChain) BroadcastBatchOpeningTransaction(txHex, txId string) (string, error) {
+	_, err := l.liquidWallet.SendRawTx(txHex)
+	if err != nil {
+		return "", err
+	}
+	return txHex, nil
+}
+
 // feeAmountPlaceholder is a placeholder for the fee amount
 const feeAmountPlaceholder = uint64(500)
 
 func (l *LiquidOnChain) CreatePreimageSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (string, string, string, error) {
-	fee, err := l.liquidWallet.GetFee(int64(getEstimatedTxSize(transactionKindPreimageSpending)))
+	fee, err := l.liquidWallet.GetFeeForRate(int64(getEstimatedTxSize(transactionKindPreimageSpending)), claimParams.FeeRate)
 	if err != nil {
 		log.Infof("error getting fee %v", err)
 		fee = feeAmountPlaceholder
@@ -92,7 +145,7 @@ func (l *LiquidOnChain) CreatePreimageSpendingTransaction(swapParams *swap.Openi
 }
 
 func (l *LiquidOnChain) createPreimageSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, fee uint64) (string, string, string, error) {
-	newAddr, err := l.liquidWallet.GetAddress()
+	newAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -128,7 +181,7 @@ func (l *LiquidOnChain) createPreimageSpendingTransaction(swapParams *swap.Openi
 }
 
 func (l *LiquidOnChain) CreateCsvSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams) (txId, txHex, address string, error error) {
-	fee, err := l.liquidWallet.GetFee(int64(getEstimatedTxSize(transactionKindPreimageSpending)))
+	fee, err := l.liquidWallet.GetFeeForRate(int64(getEstimatedTxSize(transactionKindPreimageSpending)), claimParams.FeeRate)
 	if err != nil {
 		log.Infof("error getting fee %v", err)
 		fee = feeAmountPlaceholder
@@ -137,7 +190,7 @@ func (l *LiquidOnChain) CreateCsvSpendingTransaction(swapParams *swap.OpeningPar
 }
 
 func (l *LiquidOnChain) createCsvSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, fee uint64) (txId, txHex, address string, error error) {
-	newAddr, err := l.liquidWallet.GetAddress()
+	newAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -159,7 +212,7 @@ func (l *LiquidOnChain) createCsvSpendingTransaction(swapParams *swap.OpeningPar
 }
 
 func (l *LiquidOnChain) CreateCoopSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, takerSigner swap.Signer) (txId, txHex, address string, error error) {
-	fee, err := l.liquidWallet.GetFee(int64(getEstimatedTxSize(transactionKindCoop)))
+	fee, err := l.liquidWallet.GetFeeForRate(int64(getEstimatedTxSize(transactionKindCoop)), claimParams.FeeRate)
 	if err != nil {
 		log.Infof("error getting fee %v", err)
 		fee = feeAmountPlaceholder
@@ -168,7 +221,7 @@ func (l *LiquidOnChain) CreateCoopSpendingTransaction(swapParams *swap.OpeningPa
 }
 
 func (l *LiquidOnChain) createCoopSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, takerSigner swap.Signer, fee uint64) (txId, txHex, address string, error error) {
-	refundAddr, err := l.NewAddress()
+	refundAddr, err := l.claimAddress(claimParams)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -239,6 +292,55 @@ func (l *LiquidOnChain) NewAddress() (string, error) {
 	return addr, nil
 }
 
+// claimAddress returns the claim address of the swap, or a new wallet address
+// if the swap has none.
+func (l *LiquidOnChain) claimAddress(claimParams *swap.ClaimParams) (string, error) {
+	if claimParams.ClaimAddress != "" {
+		return claimParams.ClaimAddress, nil
+	}
+	return l.NewAddress()
+}
+
+// ValidateCoinSelection returns an error if the liquid wallet can not fund
+// the opening transaction from the selected utxos.
+//
+// NOTE: This method is part of the swap.CoinSelectionWallet interface.
+func (l *LiquidOnChain) ValidateCoinSelection(selection *swap.CoinSelection) error {
+	return l.liquidWallet.ValidateCoinSelection(selection)
+}
+
+// ValidateFeeRate returns an error if the fee rate of a swap is below the
+// liquid fee floor or the confirmation target is too high to be estimated.
+func (l *LiquidOnChain) ValidateFeeRate(feeRate *swap.FeeRate) error {
+	if feeRate.TargetConf > MaxFeeTargetConf {
+		return fmt.Errorf("confirmation target exceeds %d blocks", MaxFeeTargetConf)
+	}
+	if feeRate.SatPerVByte > 0 && feeRate.SatPerVByte < LiquidFeeFloorSatPerVByte {
+		return fmt.Errorf("fee rate is below the fee floor of %v sat/vb", LiquidFeeFloorSatPerVByte)
+	}
+	return nil
+}
+
+// ValidateAddress returns an error if the address is not a confidential
+// address on the liquid network of the wallet.
+func (l *LiquidOnChain) ValidateAddress(addr string) error {
+	net, err := address.NetworkForAddress(addr)
+	if err != nil {
+		return err
+	}
+	if net.Name != l.network.Name {
+		return fmt.Errorf("address is not on network %s", l.network.Name)
+	}
+	confidential, err := address.IsConfidential(addr)
+	if err != nil {
+		return err
+	}
+	if !confidential {
+		return errors.New("address is not confidential")
+	}
+	return nil
+}
+
 func (l *LiquidOnChain) prepareSpendingTransaction(swapParams *swap.OpeningParams, claimParams *swap.ClaimParams, spendingAddr string, csv uint32, preparedFee uint64) (tx *transaction.Transaction, sigBytes, redeemScript []byte, err error) {
 	redeemScript, err = ParamsToTxScript(swapParams, swapParams.CSV)
 	if err != nil {
@@ -633,6 +735,10 @@ func (l *LiquidOnChain) GetAsset() string {
 	return hex.EncodeToString(l.asset)
 }
 
+func (l *LiquidOnChain) GetChain() *network.Network {
+	return l.network
+}
+
 func (l *LiquidOnChain) GetNetwork() string {
 	return ""
 }
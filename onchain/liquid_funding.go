@@ -0,0 +1,93 @@
+package onchain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elementsproject/peerswap/swap"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+// CreateFundingPsbt returns an unsigned PSET in base64 whose only output is
+// the opening output of the swap, and the confidential address of the
+// output. The output is blinded to the blinding key of the swap by the
+// wallet that funds it.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *LiquidOnChain) CreateFundingPsbt(params *swap.OpeningParams) (string, string, error) {
+	redeemScript, err := ParamsToTxScript(params, params.CSV)
+	if err != nil {
+		return "", "", err
+	}
+	addr, err := l.CreateBlindedOpeningAddress(redeemScript, params.BlindingKey.PubKey())
+	if err != nil {
+		return "", "", err
+	}
+	script, err := l.GetOutputScript(params)
+	if err != nil {
+		return "", "", err
+	}
+	pset, err := psetv2.New(nil, []psetv2.OutputArgs{{
+		Asset:       l.network.AssetID,
+		Amount:      params.Amount,
+		Script:      script,
+		BlindingKey: params.BlindingKey.PubKey().SerializeCompressed(),
+	}}, nil)
+	if err != nil {
+		return "", "", err
+	}
+	encoded, err := pset.ToBase64()
+	if err != nil {
+		return "", "", err
+	}
+	return encoded, addr, nil
+}
+
+// ExtractFundingTx returns the transaction of a signed PSET in base64, or of a
+// raw transaction in hex, and the vout of the opening output of the swap.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *LiquidOnChain) ExtractFundingTx(signed string, params *swap.OpeningParams) (string, uint32, error) {
+	txHex := strings.TrimSpace(signed)
+	if _, err := hex.DecodeString(txHex); err != nil {
+		pset, err := psetv2.NewPsetFromBase64(txHex)
+		if err != nil {
+			return "", 0, fmt.Errorf("expected a PSET in base64 or a transaction in hex: %w", err)
+		}
+		if pset.NeedsBlinding() {
+			return "", 0, errors.New("the PSET is not blinded")
+		}
+		err = psetv2.MaybeFinalizeAll(pset)
+		if err != nil {
+			return "", 0, fmt.Errorf("the PSET is not fully signed: %w", err)
+		}
+		tx, err := psetv2.Extract(pset)
+		if err != nil {
+			return "", 0, err
+		}
+		txHex, err = tx.ToHex()
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	redeemScript, err := ParamsToTxScript(params, params.CSV)
+	if err != nil {
+		return "", 0, err
+	}
+	vout, err := l.VoutFromTxHex(txHex, redeemScript)
+	if err != nil {
+		return "", 0, fmt.Errorf("the transaction does not pay the opening output: %w", err)
+	}
+	return txHex, vout, nil
+}
+
+// BroadcastFundingTx broadcasts the opening transaction of an externally
+// funded swap-in.
+//
+// NOTE: This method is part of the swap.ExternalFundingWallet interface.
+func (l *LiquidOnChain) BroadcastFundingTx(txHex string) (string, error) {
+	return l.liquidWallet.SendRawTx(txHex)
+}
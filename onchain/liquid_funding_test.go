@@ -0,0 +1,40 @@
+package onchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulpemventures/go-elements/network"
+	"github.com/vulpemventures/go-elements/psetv2"
+)
+
+func TestLiquidOnChain_CreateFundingPsbt(t *testing.T) {
+	liquidOnChain := NewLiquidOnChain(nil, &network.Regtest)
+	params := newLiquidOpeningParams(t)
+
+	encoded, addr, err := liquidOnChain.CreateFundingPsbt(params)
+	require.NoError(t, err)
+	redeemScript, err := ParamsToTxScript(params, params.CSV)
+	require.NoError(t, err)
+	wantAddr, err := liquidOnChain.CreateBlindedOpeningAddress(redeemScript, params.BlindingKey.PubKey())
+	require.NoError(t, err)
+	assert.Equal(t, wantAddr, addr)
+
+	// The PSET pays the opening output only, blinded to the blinding key of
+	// the swap.
+	pset, err := psetv2.NewPsetFromBase64(encoded)
+	require.NoError(t, err)
+	assert.Empty(t, pset.Inputs)
+	require.Len(t, pset.Outputs, 1)
+	script, err := liquidOnChain.GetOutputScript(params)
+	require.NoError(t, err)
+	out := pset.Outputs[0]
+	assert.Equal(t, params.Amount, out.Value)
+	assert.Equal(t, script, out.Script)
+	assert.Equal(t, liquidOnChain.asset[1:], out.Asset)
+	assert.Equal(t, params.BlindingKey.PubKey().SerializeCompressed(), out.BlindingPubkey)
+
+	_, _, err = liquidOnChain.ExtractFundingTx(encoded, params)
+	assert.ErrorContains(t, err, "not blinded")
+}
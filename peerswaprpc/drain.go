@@ -0,0 +1,70 @@
+package peerswaprpc
+
+import (
+	"sync"
+
+	"github.com/elementsproject/peerswap/log"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+// Drainer handles drain requests and shuts the daemon down once a drain is
+// done if requested.
+type Drainer struct {
+	swaps    *swap.SwapService
+	shutdown func()
+
+	mu sync.Mutex
+	// shutdownOn is the drained channel of the drain that the shutdown
+	// waits for.
+	shutdownOn <-chan struct{}
+}
+
+func NewDrainer(swaps *swap.SwapService, shutdown func()) *Drainer {
+	return &Drainer{swaps: swaps, shutdown: shutdown}
+}
+
+// Drain starts or stops a drain and returns its progress. A status request
+// only returns the progress.
+func (d *Drainer) Drain(request *DrainRequest) *DrainResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case request.Status:
+		// The drain is left as it is.
+	case request.Stop:
+		d.swaps.StopDrain()
+		d.shutdownOn = nil
+	default:
+		d.swaps.StartDrain()
+		drained := d.swaps.Drained()
+		if request.Shutdown && d.shutdownOn != drained {
+			d.shutdownOn = drained
+			go d.shutdownWhenDrained(drained)
+		}
+	}
+
+	draining := d.swaps.IsDraining()
+	resp := &DrainResponse{
+		Draining: draining,
+		Shutdown: draining && d.shutdownOn != nil && d.shutdownOn == d.swaps.Drained(),
+	}
+	for _, left := range d.swaps.DrainStatus() {
+		resp.ActiveSwaps = append(resp.ActiveSwaps, &DrainingSwap{
+			Swap:                     PrettyprintFromServiceSwap(left.Swap),
+			CurrentHeight:            left.CurrentHeight,
+			ExpectedCompletionHeight: left.ExpectedCompletionHeight,
+		})
+	}
+	return resp
+}
+
+func (d *Drainer) shutdownWhenDrained(drained <-chan struct{}) {
+	<-drained
+	// The channel is also closed if the drain was stopped.
+	if d.swaps.Drained() != drained {
+		return
+	}
+	log.Infof("drain done, shutting down")
+	d.shutdown()
+}
@@ -1,6 +1,7 @@
 package peerswaprpc
 
 import (
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/elementsproject/peerswap/policy"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -13,9 +14,36 @@ func GetPolicyMessage(p policy.Policy) *Policy {
 		AllowNewSwaps:      p.AllowNewSwaps,
 		AllowlistedPeers:   p.PeerAllowlist,
 		SuspiciousPeerList: p.SuspiciousPeerList,
+		BtcConfirmations:   p.BtcConfirmations,
+		LbtcConfirmations:  p.LbtcConfirmations,
 	}
 }
 
+// GetStatusMessage returns the health of the chain backends. The electrum
+// clients are nil if the chain does not use electrum.
+func GetStatusMessage(liquidElectrum, bitcoinElectrum electrum.RPC) *GetStatusResponse {
+	resp := &GetStatusResponse{}
+	if liquidElectrum != nil {
+		resp.LiquidElectrumEndpoints = electrumEndpointMessages(liquidElectrum.Endpoints())
+	}
+	if bitcoinElectrum != nil {
+		resp.BitcoinElectrumEndpoints = electrumEndpointMessages(bitcoinElectrum.Endpoints())
+	}
+	return resp
+}
+
+func electrumEndpointMessages(endpoints []electrum.EndpointStatus) []*ElectrumEndpoint {
+	msgs := make([]*ElectrumEndpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		msgs = append(msgs, &ElectrumEndpoint{
+			Endpoint:  e.String(),
+			Active:    e.Active,
+			LastError: e.LastError,
+		})
+	}
+	return msgs
+}
+
 func (p *Policy) MarshalJSON() ([]byte, error) {
 	return protojson.MarshalOptions{
 		Multiline:       true,
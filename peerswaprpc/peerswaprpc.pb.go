@@ -163,7 +163,7 @@ func (x RequestedSwap_SwapType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RequestedSwap_SwapType.Descriptor instead.
 func (RequestedSwap_SwapType) EnumDescriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{21, 0}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{56, 0}
 }
 
 type GetAddressRequest struct {
@@ -448,6 +448,41 @@ type SwapOutRequest struct {
 	Asset               string `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
 	Force               bool   `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
 	PremiumLimitRatePpm int64  `protobuf:"varint,5,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	// Swap the maximum possible amount instead of swap_amount. The chosen
+	// amount is returned with the swap.
+	Max bool `protobuf:"varint,6,opt,name=max,proto3" json:"max,omitempty"`
+	// Amount of sats to keep on our side of the channel when max is set.
+	LeaveLocalSat uint64 `protobuf:"varint,7,opt,name=leave_local_sat,json=leaveLocalSat,proto3" json:"leave_local_sat,omitempty"`
+	// Node id of the peer to swap with. Used instead of channel_id, the channel
+	// to the peer is then chosen automatically.
+	PeerPubkey string `protobuf:"bytes,8,opt,name=peer_pubkey,json=peerPubkey,proto3" json:"peer_pubkey,omitempty"`
+	// Queue the swap if the channel already has an active swap. The queued
+	// swap is started once the active swap is done.
+	Queue bool `protobuf:"varint,9,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Defer the swap if the peer is offline. The deferred swap is started once
+	// the peer comes online.
+	DeferUntilOnline bool `protobuf:"varint,10,opt,name=defer_until_online,json=deferUntilOnline,proto3" json:"defer_until_online,omitempty"`
+	// Seconds after which a deferred swap is dropped if the peer did not come
+	// online. Defaults to 24 hours.
+	DeferExpirySec uint64 `protobuf:"varint,11,opt,name=defer_expiry_sec,json=deferExpirySec,proto3" json:"defer_expiry_sec,omitempty"`
+	// Optional client supplied key. A request with a key that was used before
+	// returns the swap started by the first request instead of a new swap.
+	IdempotencyKey string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Further channels to the same peer that the swap-out may use in addition
+	// to channel_id. The claim invoice is then paid as a multi-part payment
+	// over all of these channels. The peer must support multi channel
+	// swap-outs.
+	ExtraChannelIds []uint64 `protobuf:"varint,13,rep,packed,name=extra_channel_ids,json=extraChannelIds,proto3" json:"extra_channel_ids,omitempty"`
+	// Address the claimed funds are sent to instead of the node wallet. Must
+	// be an address of the network of the asset.
+	ClaimAddress string `protobuf:"bytes,14,opt,name=claim_address,json=claimAddress,proto3" json:"claim_address,omitempty"`
+	// Fee rate in sat/vb of our claim transaction. Overrides the configured
+	// default fee rate and the wallet estimate.
+	SatPerVbyte float64 `protobuf:"fixed64,15,opt,name=sat_per_vbyte,json=satPerVbyte,proto3" json:"sat_per_vbyte,omitempty"`
+	// Confirmation target in blocks that the fee rate of our claim
+	// transaction is estimated for. Can not be set together with
+	// sat_per_vbyte.
+	TargetConf uint32 `protobuf:"varint,16,opt,name=target_conf,json=targetConf,proto3" json:"target_conf,omitempty"`
 }
 
 func (x *SwapOutRequest) Reset() {
@@ -517,31 +552,2502 @@ func (x *SwapOutRequest) GetPremiumLimitRatePpm() int64 {
 	return 0
 }
 
+func (x *SwapOutRequest) GetMax() bool {
+	if x != nil {
+		return x.Max
+	}
+	return false
+}
+
+func (x *SwapOutRequest) GetLeaveLocalSat() uint64 {
+	if x != nil {
+		return x.LeaveLocalSat
+	}
+	return 0
+}
+
+func (x *SwapOutRequest) GetPeerPubkey() string {
+	if x != nil {
+		return x.PeerPubkey
+	}
+	return ""
+}
+
+func (x *SwapOutRequest) GetQueue() bool {
+	if x != nil {
+		return x.Queue
+	}
+	return false
+}
+
+func (x *SwapOutRequest) GetDeferUntilOnline() bool {
+	if x != nil {
+		return x.DeferUntilOnline
+	}
+	return false
+}
+
+func (x *SwapOutRequest) GetDeferExpirySec() uint64 {
+	if x != nil {
+		return x.DeferExpirySec
+	}
+	return 0
+}
+
+func (x *SwapOutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SwapOutRequest) GetExtraChannelIds() []uint64 {
+	if x != nil {
+		return x.ExtraChannelIds
+	}
+	return nil
+}
+
+func (x *SwapOutRequest) GetClaimAddress() string {
+	if x != nil {
+		return x.ClaimAddress
+	}
+	return ""
+}
+
+func (x *SwapOutRequest) GetSatPerVbyte() float64 {
+	if x != nil {
+		return x.SatPerVbyte
+	}
+	return 0
+}
+
+func (x *SwapOutRequest) GetTargetConf() uint32 {
+	if x != nil {
+		return x.TargetConf
+	}
+	return 0
+}
+
 type SwapOutResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+}
+
+func (x *SwapOutResponse) Reset() {
+	*x = SwapOutResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapOutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapOutResponse) ProtoMessage() {}
+
+func (x *SwapOutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapOutResponse.ProtoReflect.Descriptor instead.
+func (*SwapOutResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SwapOutResponse) GetSwap() *PrettyPrintSwap {
+	if x != nil {
+		return x.Swap
+	}
+	return nil
+}
+
+type SwapInRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId           uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	SwapAmount          uint64 `protobuf:"varint,2,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
+	Asset               string `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Force               bool   `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	PremiumLimitRatePpm int64  `protobuf:"varint,5,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	// Swap the maximum possible amount instead of swap_amount. The chosen
+	// amount is returned with the swap.
+	Max bool `protobuf:"varint,6,opt,name=max,proto3" json:"max,omitempty"`
+	// Amount of sats to keep in the onchain wallet when max is set.
+	LeaveLocalSat uint64 `protobuf:"varint,7,opt,name=leave_local_sat,json=leaveLocalSat,proto3" json:"leave_local_sat,omitempty"`
+	// Node id of the peer to swap with. Used instead of channel_id, the channel
+	// to the peer is then chosen automatically.
+	PeerPubkey string `protobuf:"bytes,8,opt,name=peer_pubkey,json=peerPubkey,proto3" json:"peer_pubkey,omitempty"`
+	// Queue the swap if the channel already has an active swap. The queued
+	// swap is started once the active swap is done.
+	Queue bool `protobuf:"varint,9,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Defer the swap if the peer is offline. The deferred swap is started once
+	// the peer comes online.
+	DeferUntilOnline bool `protobuf:"varint,10,opt,name=defer_until_online,json=deferUntilOnline,proto3" json:"defer_until_online,omitempty"`
+	// Seconds after which a deferred swap is dropped if the peer did not come
+	// online. Defaults to 24 hours.
+	DeferExpirySec uint64 `protobuf:"varint,11,opt,name=defer_expiry_sec,json=deferExpirySec,proto3" json:"defer_expiry_sec,omitempty"`
+	// Optional client supplied key. A request with a key that was used before
+	// returns the swap started by the first request instead of a new swap.
+	IdempotencyKey string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Address the claimed funds are sent to instead of the node wallet. Must
+	// be an address of the network of the asset.
+	ClaimAddress string `protobuf:"bytes,13,opt,name=claim_address,json=claimAddress,proto3" json:"claim_address,omitempty"`
+	// Fee rate in sat/vb of our opening and claim transactions. Overrides the
+	// configured default fee rate and the wallet estimate.
+	SatPerVbyte float64 `protobuf:"fixed64,14,opt,name=sat_per_vbyte,json=satPerVbyte,proto3" json:"sat_per_vbyte,omitempty"`
+	// Confirmation target in blocks that the fee rate of our opening and
+	// claim transactions is estimated for. Can not be set together with
+	// sat_per_vbyte.
+	TargetConf uint32 `protobuf:"varint,15,opt,name=target_conf,json=targetConf,proto3" json:"target_conf,omitempty"`
+	// Outpoints txid:vout that fund the opening transaction. The swap fails if
+	// they do not cover the swap amount and the fee.
+	Utxos []string `protobuf:"bytes,16,rep,name=utxos,proto3" json:"utxos,omitempty"`
+	// Outpoints txid:vout that must not fund the opening transaction.
+	ExcludeUtxos []string `protobuf:"bytes,17,rep,name=exclude_utxos,json=excludeUtxos,proto3" json:"exclude_utxos,omitempty"`
+	// Fund the opening transaction outside of the node. Once the peer agreed,
+	// the swap returns an unsigned PSBT (btc) or PSET (lbtc) that pays the
+	// opening output and waits for the signed transaction, see
+	// FinalizeSwapFunding.
+	ExternalFunding bool `protobuf:"varint,18,opt,name=external_funding,json=externalFunding,proto3" json:"external_funding,omitempty"`
+}
+
+func (x *SwapInRequest) Reset() {
+	*x = SwapInRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapInRequest) ProtoMessage() {}
+
+func (x *SwapInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapInRequest.ProtoReflect.Descriptor instead.
+func (*SwapInRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SwapInRequest) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetSwapAmount() uint64 {
+	if x != nil {
+		return x.SwapAmount
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *SwapInRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *SwapInRequest) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetMax() bool {
+	if x != nil {
+		return x.Max
+	}
+	return false
+}
+
+func (x *SwapInRequest) GetLeaveLocalSat() uint64 {
+	if x != nil {
+		return x.LeaveLocalSat
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetPeerPubkey() string {
+	if x != nil {
+		return x.PeerPubkey
+	}
+	return ""
+}
+
+func (x *SwapInRequest) GetQueue() bool {
+	if x != nil {
+		return x.Queue
+	}
+	return false
+}
+
+func (x *SwapInRequest) GetDeferUntilOnline() bool {
+	if x != nil {
+		return x.DeferUntilOnline
+	}
+	return false
+}
+
+func (x *SwapInRequest) GetDeferExpirySec() uint64 {
+	if x != nil {
+		return x.DeferExpirySec
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SwapInRequest) GetClaimAddress() string {
+	if x != nil {
+		return x.ClaimAddress
+	}
+	return ""
+}
+
+func (x *SwapInRequest) GetSatPerVbyte() float64 {
+	if x != nil {
+		return x.SatPerVbyte
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetTargetConf() uint32 {
+	if x != nil {
+		return x.TargetConf
+	}
+	return 0
+}
+
+func (x *SwapInRequest) GetUtxos() []string {
+	if x != nil {
+		return x.Utxos
+	}
+	return nil
+}
+
+func (x *SwapInRequest) GetExcludeUtxos() []string {
+	if x != nil {
+		return x.ExcludeUtxos
+	}
+	return nil
+}
+
+func (x *SwapInRequest) GetExternalFunding() bool {
+	if x != nil {
+		return x.ExternalFunding
+	}
+	return false
+}
+
+type BatchSwapIn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId           uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	SwapAmount          uint64 `protobuf:"varint,2,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
+	PremiumLimitRatePpm int64  `protobuf:"varint,3,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+}
+
+func (x *BatchSwapIn) Reset() {
+	*x = BatchSwapIn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSwapIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSwapIn) ProtoMessage() {}
+
+func (x *BatchSwapIn) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSwapIn.ProtoReflect.Descriptor instead.
+func (*BatchSwapIn) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchSwapIn) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *BatchSwapIn) GetSwapAmount() uint64 {
+	if x != nil {
+		return x.SwapAmount
+	}
+	return 0
+}
+
+func (x *BatchSwapIn) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+type SwapInBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Swaps []*BatchSwapIn `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	Asset string         `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Force bool           `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *SwapInBatchRequest) Reset() {
+	*x = SwapInBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapInBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapInBatchRequest) ProtoMessage() {}
+
+func (x *SwapInBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapInBatchRequest.ProtoReflect.Descriptor instead.
+func (*SwapInBatchRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SwapInBatchRequest) GetSwaps() []*BatchSwapIn {
+	if x != nil {
+		return x.Swaps
+	}
+	return nil
+}
+
+func (x *SwapInBatchRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *SwapInBatchRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type BatchSwapInResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The swap if it was started.
+	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	// Reason why the swap was not started.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BatchSwapInResult) Reset() {
+	*x = BatchSwapInResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSwapInResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSwapInResult) ProtoMessage() {}
+
+func (x *BatchSwapInResult) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSwapInResult.ProtoReflect.Descriptor instead.
+func (*BatchSwapInResult) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchSwapInResult) GetSwap() *PrettyPrintSwap {
+	if x != nil {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *BatchSwapInResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SwapInBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId string `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// The results in the order of the requested swaps.
+	Results []*BatchSwapInResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SwapInBatchResponse) Reset() {
+	*x = SwapInBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapInBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapInBatchResponse) ProtoMessage() {}
+
+func (x *SwapInBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapInBatchResponse.ProtoReflect.Descriptor instead.
+func (*SwapInBatchResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SwapInBatchResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *SwapInBatchResponse) GetResults() []*BatchSwapInResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type PreflightSwapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SWAP_OUT or SWAP_IN.
+	Operation           OperationType `protobuf:"varint,1,opt,name=operation,proto3,enum=peerswap.OperationType" json:"operation,omitempty"`
+	ChannelId           uint64        `protobuf:"varint,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	SwapAmount          uint64        `protobuf:"varint,3,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
+	Asset               string        `protobuf:"bytes,4,opt,name=asset,proto3" json:"asset,omitempty"`
+	PremiumLimitRatePpm int64         `protobuf:"varint,5,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+}
+
+func (x *PreflightSwapRequest) Reset() {
+	*x = PreflightSwapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreflightSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightSwapRequest) ProtoMessage() {}
+
+func (x *PreflightSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightSwapRequest.ProtoReflect.Descriptor instead.
+func (*PreflightSwapRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PreflightSwapRequest) GetOperation() OperationType {
+	if x != nil {
+		return x.Operation
+	}
+	return OperationType_OPERATION_UNSPECIFIED
+}
+
+func (x *PreflightSwapRequest) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *PreflightSwapRequest) GetSwapAmount() uint64 {
+	if x != nil {
+		return x.SwapAmount
+	}
+	return 0
+}
+
+func (x *PreflightSwapRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *PreflightSwapRequest) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+type PreflightCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passed bool   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Reason why the check did not pass.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PreflightCheck) Reset() {
+	*x = PreflightCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreflightCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightCheck) ProtoMessage() {}
+
+func (x *PreflightCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightCheck.ProtoReflect.Descriptor instead.
+func (*PreflightCheck) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PreflightCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PreflightCheck) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *PreflightCheck) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PreflightSwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// True if all checks passed.
+	Passed bool              `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	Checks []*PreflightCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	// Estimated fee of the opening transaction at the current feerate. On a
+	// swap-out this is charged by the peer with the fee invoice.
+	EstimatedOpeningFeeSat uint64 `protobuf:"varint,3,opt,name=estimated_opening_fee_sat,json=estimatedOpeningFeeSat,proto3" json:"estimated_opening_fee_sat,omitempty"`
+	// Estimated fee of the claim transaction at the current feerate.
+	EstimatedClaimFeeSat uint64 `protobuf:"varint,4,opt,name=estimated_claim_fee_sat,json=estimatedClaimFeeSat,proto3" json:"estimated_claim_fee_sat,omitempty"`
+	PremiumLimitSat      int64  `protobuf:"varint,5,opt,name=premium_limit_sat,json=premiumLimitSat,proto3" json:"premium_limit_sat,omitempty"`
+	SpendableMsat        uint64 `protobuf:"varint,6,opt,name=spendable_msat,json=spendableMsat,proto3" json:"spendable_msat,omitempty"`
+	ReceivableMsat       uint64 `protobuf:"varint,7,opt,name=receivable_msat,json=receivableMsat,proto3" json:"receivable_msat,omitempty"`
+	MaximumSwapAmountSat uint64 `protobuf:"varint,8,opt,name=maximum_swap_amount_sat,json=maximumSwapAmountSat,proto3" json:"maximum_swap_amount_sat,omitempty"`
+}
+
+func (x *PreflightSwapResponse) Reset() {
+	*x = PreflightSwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreflightSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightSwapResponse) ProtoMessage() {}
+
+func (x *PreflightSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightSwapResponse.ProtoReflect.Descriptor instead.
+func (*PreflightSwapResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PreflightSwapResponse) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *PreflightSwapResponse) GetChecks() []*PreflightCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *PreflightSwapResponse) GetEstimatedOpeningFeeSat() uint64 {
+	if x != nil {
+		return x.EstimatedOpeningFeeSat
+	}
+	return 0
+}
+
+func (x *PreflightSwapResponse) GetEstimatedClaimFeeSat() uint64 {
+	if x != nil {
+		return x.EstimatedClaimFeeSat
+	}
+	return 0
+}
+
+func (x *PreflightSwapResponse) GetPremiumLimitSat() int64 {
+	if x != nil {
+		return x.PremiumLimitSat
+	}
+	return 0
+}
+
+func (x *PreflightSwapResponse) GetSpendableMsat() uint64 {
+	if x != nil {
+		return x.SpendableMsat
+	}
+	return 0
+}
+
+func (x *PreflightSwapResponse) GetReceivableMsat() uint64 {
+	if x != nil {
+		return x.ReceivableMsat
+	}
+	return 0
+}
+
+func (x *PreflightSwapResponse) GetMaximumSwapAmountSat() uint64 {
+	if x != nil {
+		return x.MaximumSwapAmountSat
+	}
+	return 0
+}
+
+type SwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	// Set if the channel was chosen automatically.
+	ChannelSelection *ChannelSelection `protobuf:"bytes,2,opt,name=channel_selection,json=channelSelection,proto3" json:"channel_selection,omitempty"`
+	// Set instead of swap if the swap was queued or deferred.
+	QueuedSwap *QueuedSwap `protobuf:"bytes,3,opt,name=queued_swap,json=queuedSwap,proto3" json:"queued_swap,omitempty"`
+}
+
+func (x *SwapResponse) Reset() {
+	*x = SwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapResponse) ProtoMessage() {}
+
+func (x *SwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
+func (*SwapResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SwapResponse) GetSwap() *PrettyPrintSwap {
+	if x != nil {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *SwapResponse) GetChannelSelection() *ChannelSelection {
+	if x != nil {
+		return x.ChannelSelection
+	}
+	return nil
+}
+
+func (x *SwapResponse) GetQueuedSwap() *QueuedSwap {
+	if x != nil {
+		return x.QueuedSwap
+	}
+	return nil
+}
+
+// A swap that waits for its channel to become free or for its peer to come
+// online.
+type QueuedSwap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// swap-out or swap-in.
+	Type                string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	PeerNodeId          string `protobuf:"bytes,3,opt,name=peer_node_id,json=peerNodeId,proto3" json:"peer_node_id,omitempty"`
+	Asset               string `protobuf:"bytes,4,opt,name=asset,proto3" json:"asset,omitempty"`
+	ChannelId           string `protobuf:"bytes,5,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Amount              uint64 `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	PremiumLimitRatePpm int64  `protobuf:"varint,7,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	RetryOf             string `protobuf:"bytes,8,opt,name=retry_of,json=retryOf,proto3" json:"retry_of,omitempty"`
+	CreatedAt           int64  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set on deferred swaps that wait for the peer to come online.
+	WaitForPeer bool `protobuf:"varint,10,opt,name=wait_for_peer,json=waitForPeer,proto3" json:"wait_for_peer,omitempty"`
+	// Unix time after which a deferred swap is dropped.
+	ExpiresAt int64 `protobuf:"varint,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Idempotency key the swap was requested with.
+	IdempotencyKey string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Extra channels of a swap-out that spans several channels.
+	ExtraChannelIds []string `protobuf:"bytes,13,rep,name=extra_channel_ids,json=extraChannelIds,proto3" json:"extra_channel_ids,omitempty"`
+	// Address the claimed funds are sent to.
+	ClaimAddress string `protobuf:"bytes,14,opt,name=claim_address,json=claimAddress,proto3" json:"claim_address,omitempty"`
+}
+
+func (x *QueuedSwap) Reset() {
+	*x = QueuedSwap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueuedSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueuedSwap) ProtoMessage() {}
+
+func (x *QueuedSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueuedSwap.ProtoReflect.Descriptor instead.
+func (*QueuedSwap) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QueuedSwap) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetPeerNodeId() string {
+	if x != nil {
+		return x.PeerNodeId
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *QueuedSwap) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+func (x *QueuedSwap) GetRetryOf() string {
+	if x != nil {
+		return x.RetryOf
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *QueuedSwap) GetWaitForPeer() bool {
+	if x != nil {
+		return x.WaitForPeer
+	}
+	return false
+}
+
+func (x *QueuedSwap) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *QueuedSwap) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *QueuedSwap) GetExtraChannelIds() []string {
+	if x != nil {
+		return x.ExtraChannelIds
+	}
+	return nil
+}
+
+func (x *QueuedSwap) GetClaimAddress() string {
+	if x != nil {
+		return x.ClaimAddress
+	}
+	return ""
+}
+
+type ListQueuedSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListQueuedSwapsRequest) Reset() {
+	*x = ListQueuedSwapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListQueuedSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueuedSwapsRequest) ProtoMessage() {}
+
+func (x *ListQueuedSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueuedSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ListQueuedSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{18}
+}
+
+type ListQueuedSwapsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueuedSwaps []*QueuedSwap `protobuf:"bytes,1,rep,name=queued_swaps,json=queuedSwaps,proto3" json:"queued_swaps,omitempty"`
+}
+
+func (x *ListQueuedSwapsResponse) Reset() {
+	*x = ListQueuedSwapsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListQueuedSwapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueuedSwapsResponse) ProtoMessage() {}
+
+func (x *ListQueuedSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueuedSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ListQueuedSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListQueuedSwapsResponse) GetQueuedSwaps() []*QueuedSwap {
+	if x != nil {
+		return x.QueuedSwaps
+	}
+	return nil
+}
+
+type ListDeferredSwapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListDeferredSwapsRequest) Reset() {
+	*x = ListDeferredSwapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeferredSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeferredSwapsRequest) ProtoMessage() {}
+
+func (x *ListDeferredSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeferredSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeferredSwapsRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{20}
+}
+
+type ListDeferredSwapsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeferredSwaps []*QueuedSwap `protobuf:"bytes,1,rep,name=deferred_swaps,json=deferredSwaps,proto3" json:"deferred_swaps,omitempty"`
+}
+
+func (x *ListDeferredSwapsResponse) Reset() {
+	*x = ListDeferredSwapsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeferredSwapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeferredSwapsResponse) ProtoMessage() {}
+
+func (x *ListDeferredSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeferredSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeferredSwapsResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDeferredSwapsResponse) GetDeferredSwaps() []*QueuedSwap {
+	if x != nil {
+		return x.DeferredSwaps
+	}
+	return nil
+}
+
+type RemoveQueuedSwapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RemoveQueuedSwapRequest) Reset() {
+	*x = RemoveQueuedSwapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveQueuedSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveQueuedSwapRequest) ProtoMessage() {}
+
+func (x *RemoveQueuedSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveQueuedSwapRequest.ProtoReflect.Descriptor instead.
+func (*RemoveQueuedSwapRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemoveQueuedSwapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveQueuedSwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveQueuedSwapResponse) Reset() {
+	*x = RemoveQueuedSwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveQueuedSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveQueuedSwapResponse) ProtoMessage() {}
+
+func (x *RemoveQueuedSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveQueuedSwapResponse.ProtoReflect.Descriptor instead.
+func (*RemoveQueuedSwapResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{23}
+}
+
+type CreateSwapScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SWAP_OUT or SWAP_IN.
+	Operation OperationType `protobuf:"varint,1,opt,name=operation,proto3,enum=peerswap.OperationType" json:"operation,omitempty"`
+	ChannelId uint64        `protobuf:"varint,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Asset     string        `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	// Amount of each swap, mutually exclusive with max.
+	SwapAmount uint64 `protobuf:"varint,4,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
+	// Swap the maximum possible amount at each run.
+	Max                 bool  `protobuf:"varint,5,opt,name=max,proto3" json:"max,omitempty"`
+	PremiumLimitRatePpm int64 `protobuf:"varint,6,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	// Exactly one of run_at, cron and interval_sec is required.
+	// Unix time of a one-shot schedule.
+	RunAt int64 `protobuf:"varint,7,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	// Cron expression with five fields that is evaluated in UTC.
+	Cron        string `protobuf:"bytes,8,opt,name=cron,proto3" json:"cron,omitempty"`
+	IntervalSec uint64 `protobuf:"varint,9,opt,name=interval_sec,json=intervalSec,proto3" json:"interval_sec,omitempty"`
+	// Skip a run unless our balance on the channel is below this value.
+	OnlyIfLocalBelowSat uint64 `protobuf:"varint,10,opt,name=only_if_local_below_sat,json=onlyIfLocalBelowSat,proto3" json:"only_if_local_below_sat,omitempty"`
+	// Skip a run unless our balance on the channel is above this value.
+	OnlyIfLocalAboveSat uint64 `protobuf:"varint,11,opt,name=only_if_local_above_sat,json=onlyIfLocalAboveSat,proto3" json:"only_if_local_above_sat,omitempty"`
+}
+
+func (x *CreateSwapScheduleRequest) Reset() {
+	*x = CreateSwapScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSwapScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSwapScheduleRequest) ProtoMessage() {}
+
+func (x *CreateSwapScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSwapScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateSwapScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateSwapScheduleRequest) GetOperation() OperationType {
+	if x != nil {
+		return x.Operation
+	}
+	return OperationType_OPERATION_UNSPECIFIED
+}
+
+func (x *CreateSwapScheduleRequest) GetChannelId() uint64 {
+	if x != nil {
+		return x.ChannelId
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *CreateSwapScheduleRequest) GetSwapAmount() uint64 {
+	if x != nil {
+		return x.SwapAmount
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetMax() bool {
+	if x != nil {
+		return x.Max
+	}
+	return false
+}
+
+func (x *CreateSwapScheduleRequest) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetRunAt() int64 {
+	if x != nil {
+		return x.RunAt
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *CreateSwapScheduleRequest) GetIntervalSec() uint64 {
+	if x != nil {
+		return x.IntervalSec
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetOnlyIfLocalBelowSat() uint64 {
+	if x != nil {
+		return x.OnlyIfLocalBelowSat
+	}
+	return 0
+}
+
+func (x *CreateSwapScheduleRequest) GetOnlyIfLocalAboveSat() uint64 {
+	if x != nil {
+		return x.OnlyIfLocalAboveSat
+	}
+	return 0
+}
+
+type SwapSchedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// swap-out or swap-in.
+	Type                string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	PeerNodeId          string `protobuf:"bytes,3,opt,name=peer_node_id,json=peerNodeId,proto3" json:"peer_node_id,omitempty"`
+	ChannelId           string `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Asset               string `protobuf:"bytes,5,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount              uint64 `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Max                 bool   `protobuf:"varint,7,opt,name=max,proto3" json:"max,omitempty"`
+	PremiumLimitRatePpm int64  `protobuf:"varint,8,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	RunAt               int64  `protobuf:"varint,9,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Cron                string `protobuf:"bytes,10,opt,name=cron,proto3" json:"cron,omitempty"`
+	IntervalSec         uint64 `protobuf:"varint,11,opt,name=interval_sec,json=intervalSec,proto3" json:"interval_sec,omitempty"`
+	OnlyIfLocalBelowSat uint64 `protobuf:"varint,12,opt,name=only_if_local_below_sat,json=onlyIfLocalBelowSat,proto3" json:"only_if_local_below_sat,omitempty"`
+	OnlyIfLocalAboveSat uint64 `protobuf:"varint,13,opt,name=only_if_local_above_sat,json=onlyIfLocalAboveSat,proto3" json:"only_if_local_above_sat,omitempty"`
+	Paused              bool   `protobuf:"varint,14,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Set once a one-shot schedule ran.
+	Done      bool           `protobuf:"varint,15,opt,name=done,proto3" json:"done,omitempty"`
+	NextRunAt int64          `protobuf:"varint,16,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	CreatedAt int64          `protobuf:"varint,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Runs      []*ScheduleRun `protobuf:"bytes,18,rep,name=runs,proto3" json:"runs,omitempty"`
+}
+
+func (x *SwapSchedule) Reset() {
+	*x = SwapSchedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapSchedule) ProtoMessage() {}
+
+func (x *SwapSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapSchedule.ProtoReflect.Descriptor instead.
+func (*SwapSchedule) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SwapSchedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetPeerNodeId() string {
+	if x != nil {
+		return x.PeerNodeId
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetMax() bool {
+	if x != nil {
+		return x.Max
+	}
+	return false
+}
+
+func (x *SwapSchedule) GetPremiumLimitRatePpm() int64 {
+	if x != nil {
+		return x.PremiumLimitRatePpm
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetRunAt() int64 {
+	if x != nil {
+		return x.RunAt
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *SwapSchedule) GetIntervalSec() uint64 {
+	if x != nil {
+		return x.IntervalSec
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetOnlyIfLocalBelowSat() uint64 {
+	if x != nil {
+		return x.OnlyIfLocalBelowSat
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetOnlyIfLocalAboveSat() uint64 {
+	if x != nil {
+		return x.OnlyIfLocalAboveSat
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *SwapSchedule) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *SwapSchedule) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SwapSchedule) GetRuns() []*ScheduleRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type ScheduleRun struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Id of the swap that was started by the run.
+	SwapId  string `protobuf:"bytes,2,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+	Skipped bool   `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// Reason why the run was skipped.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Error why the swap could not be started.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ScheduleRun) Reset() {
+	*x = ScheduleRun{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRun) ProtoMessage() {}
+
+func (x *ScheduleRun) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRun.ProtoReflect.Descriptor instead.
+func (*ScheduleRun) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ScheduleRun) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ScheduleRun) GetSwapId() string {
+	if x != nil {
+		return x.SwapId
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *ScheduleRun) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListSwapSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSwapSchedulesRequest) Reset() {
+	*x = ListSwapSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSwapSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwapSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSwapSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwapSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSwapSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{27}
+}
+
+type ListSwapSchedulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*SwapSchedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *ListSwapSchedulesResponse) Reset() {
+	*x = ListSwapSchedulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSwapSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSwapSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSwapSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSwapSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSwapSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListSwapSchedulesResponse) GetSchedules() []*SwapSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type PauseSwapScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Resume the schedule if false.
+	Paused bool `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (x *PauseSwapScheduleRequest) Reset() {
+	*x = PauseSwapScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseSwapScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSwapScheduleRequest) ProtoMessage() {}
+
+func (x *PauseSwapScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSwapScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseSwapScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PauseSwapScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PauseSwapScheduleRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type DeleteSwapScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteSwapScheduleRequest) Reset() {
+	*x = DeleteSwapScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSwapScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSwapScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteSwapScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSwapScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSwapScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteSwapScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSwapScheduleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteSwapScheduleResponse) Reset() {
+	*x = DeleteSwapScheduleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSwapScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSwapScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteSwapScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSwapScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSwapScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{31}
+}
+
+type DrainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// End the drain and allow new swaps again.
+	Stop bool `protobuf:"varint,1,opt,name=stop,proto3" json:"stop,omitempty"`
+	// Shut the daemon down once the last active swap is done.
+	Shutdown bool `protobuf:"varint,2,opt,name=shutdown,proto3" json:"shutdown,omitempty"`
+	// Only return the progress, without starting or stopping a drain.
+	Status bool `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *DrainRequest) Reset() {
+	*x = DrainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainRequest) ProtoMessage() {}
+
+func (x *DrainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainRequest.ProtoReflect.Descriptor instead.
+func (*DrainRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DrainRequest) GetStop() bool {
+	if x != nil {
+		return x.Stop
+	}
+	return false
+}
+
+func (x *DrainRequest) GetShutdown() bool {
+	if x != nil {
+		return x.Shutdown
+	}
+	return false
+}
+
+func (x *DrainRequest) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+type DrainingSwap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	// Block height of the chain of the swap.
+	CurrentHeight uint32 `protobuf:"varint,2,opt,name=current_height,json=currentHeight,proto3" json:"current_height,omitempty"`
+	// Height at which the swap is done at the latest. 0 as long as the
+	// opening transaction is not known.
+	ExpectedCompletionHeight uint32 `protobuf:"varint,3,opt,name=expected_completion_height,json=expectedCompletionHeight,proto3" json:"expected_completion_height,omitempty"`
+}
+
+func (x *DrainingSwap) Reset() {
+	*x = DrainingSwap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainingSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainingSwap) ProtoMessage() {}
+
+func (x *DrainingSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainingSwap.ProtoReflect.Descriptor instead.
+func (*DrainingSwap) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DrainingSwap) GetSwap() *PrettyPrintSwap {
+	if x != nil {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *DrainingSwap) GetCurrentHeight() uint32 {
+	if x != nil {
+		return x.CurrentHeight
+	}
+	return 0
+}
+
+func (x *DrainingSwap) GetExpectedCompletionHeight() uint32 {
+	if x != nil {
+		return x.ExpectedCompletionHeight
+	}
+	return 0
+}
+
+type DrainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Draining bool `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	// Set if the daemon shuts down once the drain is done.
+	Shutdown bool `protobuf:"varint,2,opt,name=shutdown,proto3" json:"shutdown,omitempty"`
+	// Active swaps that are left, ordered by their expected completion height.
+	ActiveSwaps []*DrainingSwap `protobuf:"bytes,3,rep,name=active_swaps,json=activeSwaps,proto3" json:"active_swaps,omitempty"`
+}
+
+func (x *DrainResponse) Reset() {
+	*x = DrainResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainResponse) ProtoMessage() {}
+
+func (x *DrainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainResponse.ProtoReflect.Descriptor instead.
+func (*DrainResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DrainResponse) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *DrainResponse) GetShutdown() bool {
+	if x != nil {
+		return x.Shutdown
+	}
+	return false
+}
+
+func (x *DrainResponse) GetActiveSwaps() []*DrainingSwap {
+	if x != nil {
+		return x.ActiveSwaps
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{35}
+}
+
+type ElectrumEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Endpoint in the form ssl://host:port or tcp://host:port.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Set for the endpoint that is currently used.
+	Active bool `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	// Error of the last failed health check, empty once the endpoint passed
+	// a health check.
+	LastError string `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *ElectrumEndpoint) Reset() {
+	*x = ElectrumEndpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ElectrumEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElectrumEndpoint) ProtoMessage() {}
+
+func (x *ElectrumEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElectrumEndpoint.ProtoReflect.Descriptor instead.
+func (*ElectrumEndpoint) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ElectrumEndpoint) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ElectrumEndpoint) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ElectrumEndpoint) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type GetStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Electrum endpoints of the liquid wallet in order of preference. Empty if
+	// liquid does not use electrum.
+	LiquidElectrumEndpoints []*ElectrumEndpoint `protobuf:"bytes,1,rep,name=liquid_electrum_endpoints,json=liquidElectrumEndpoints,proto3" json:"liquid_electrum_endpoints,omitempty"`
+	// Electrum endpoints of the bitcoin backend in order of preference. Empty
+	// if bitcoin does not use electrum.
+	BitcoinElectrumEndpoints []*ElectrumEndpoint `protobuf:"bytes,2,rep,name=bitcoin_electrum_endpoints,json=bitcoinElectrumEndpoints,proto3" json:"bitcoin_electrum_endpoints,omitempty"`
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetStatusResponse) GetLiquidElectrumEndpoints() []*ElectrumEndpoint {
+	if x != nil {
+		return x.LiquidElectrumEndpoints
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetBitcoinElectrumEndpoints() []*ElectrumEndpoint {
+	if x != nil {
+		return x.BitcoinElectrumEndpoints
+	}
+	return nil
+}
+
+// ChannelSelection explains which channel to a peer was chosen for a swap.
+type ChannelSelection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChosenChannelId string           `protobuf:"bytes,1,opt,name=chosen_channel_id,json=chosenChannelId,proto3" json:"chosen_channel_id,omitempty"`
+	Channels        []*ChannelChoice `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *ChannelSelection) Reset() {
+	*x = ChannelSelection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelSelection) ProtoMessage() {}
+
+func (x *ChannelSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelSelection.ProtoReflect.Descriptor instead.
+func (*ChannelSelection) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ChannelSelection) GetChosenChannelId() string {
+	if x != nil {
+		return x.ChosenChannelId
+	}
+	return ""
+}
+
+func (x *ChannelSelection) GetChannels() []*ChannelChoice {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type ChannelChoice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Spendable balance for a swap-out, receivable balance for a swap-in.
+	BalanceMsat uint64 `protobuf:"varint,2,opt,name=balance_msat,json=balanceMsat,proto3" json:"balance_msat,omitempty"`
+	Chosen      bool   `protobuf:"varint,3,opt,name=chosen,proto3" json:"chosen,omitempty"`
+	// Why the channel was or was not chosen.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ChannelChoice) Reset() {
+	*x = ChannelChoice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelChoice) ProtoMessage() {}
+
+func (x *ChannelChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelChoice.ProtoReflect.Descriptor instead.
+func (*ChannelChoice) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ChannelChoice) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelChoice) GetBalanceMsat() uint64 {
+	if x != nil {
+		return x.BalanceMsat
+	}
+	return 0
+}
+
+func (x *ChannelChoice) GetChosen() bool {
+	if x != nil {
+		return x.Chosen
+	}
+	return false
+}
+
+func (x *ChannelChoice) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetSwapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SwapId string `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+}
+
+func (x *GetSwapRequest) Reset() {
+	*x = GetSwapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwapRequest) ProtoMessage() {}
+
+func (x *GetSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwapRequest.ProtoReflect.Descriptor instead.
+func (*GetSwapRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetSwapRequest) GetSwapId() string {
+	if x != nil {
+		return x.SwapId
+	}
+	return ""
+}
+
+type FinalizeSwapFundingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SwapId string `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+	// Signed and finalized PSBT (btc) or PSET (lbtc) in base64, or the signed
+	// transaction in hex.
+	SignedTx string `protobuf:"bytes,2,opt,name=signed_tx,json=signedTx,proto3" json:"signed_tx,omitempty"`
 }
 
-func (x *SwapOutResponse) Reset() {
-	*x = SwapOutResponse{}
+func (x *FinalizeSwapFundingRequest) Reset() {
+	*x = FinalizeSwapFundingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[7]
+		mi := &file_peerswaprpc_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SwapOutResponse) String() string {
+func (x *FinalizeSwapFundingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwapOutResponse) ProtoMessage() {}
+func (*FinalizeSwapFundingRequest) ProtoMessage() {}
 
-func (x *SwapOutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[7]
+func (x *FinalizeSwapFundingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,47 +3058,55 @@ func (x *SwapOutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwapOutResponse.ProtoReflect.Descriptor instead.
-func (*SwapOutResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use FinalizeSwapFundingRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeSwapFundingRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *SwapOutResponse) GetSwap() *PrettyPrintSwap {
+func (x *FinalizeSwapFundingRequest) GetSwapId() string {
 	if x != nil {
-		return x.Swap
+		return x.SwapId
 	}
-	return nil
+	return ""
 }
 
-type SwapInRequest struct {
+func (x *FinalizeSwapFundingRequest) GetSignedTx() string {
+	if x != nil {
+		return x.SignedTx
+	}
+	return ""
+}
+
+type RetrySwapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ChannelId           uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	SwapAmount          uint64 `protobuf:"varint,2,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
-	Asset               string `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
-	Force               bool   `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
-	PremiumLimitRatePpm int64  `protobuf:"varint,5,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	SwapId string `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+	// Overrides the amount of the original swap if set.
+	SwapAmount uint64 `protobuf:"varint,2,opt,name=swap_amount,json=swapAmount,proto3" json:"swap_amount,omitempty"`
+	// Overrides the premium limit rate of the original swap if set.
+	PremiumLimitRatePpm int64 `protobuf:"varint,3,opt,name=premium_limit_rate_ppm,json=premiumLimitRatePpm,proto3" json:"premium_limit_rate_ppm,omitempty"`
+	Force               bool  `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
 }
 
-func (x *SwapInRequest) Reset() {
-	*x = SwapInRequest{}
+func (x *RetrySwapRequest) Reset() {
+	*x = RetrySwapRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[8]
+		mi := &file_peerswaprpc_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SwapInRequest) String() string {
+func (x *RetrySwapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwapInRequest) ProtoMessage() {}
+func (*RetrySwapRequest) ProtoMessage() {}
 
-func (x *SwapInRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[8]
+func (x *RetrySwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,71 +3117,112 @@ func (x *SwapInRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwapInRequest.ProtoReflect.Descriptor instead.
-func (*SwapInRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RetrySwapRequest.ProtoReflect.Descriptor instead.
+func (*RetrySwapRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *SwapInRequest) GetChannelId() uint64 {
+func (x *RetrySwapRequest) GetSwapId() string {
 	if x != nil {
-		return x.ChannelId
+		return x.SwapId
 	}
-	return 0
+	return ""
 }
 
-func (x *SwapInRequest) GetSwapAmount() uint64 {
+func (x *RetrySwapRequest) GetSwapAmount() uint64 {
 	if x != nil {
 		return x.SwapAmount
 	}
 	return 0
 }
 
-func (x *SwapInRequest) GetAsset() string {
+func (x *RetrySwapRequest) GetPremiumLimitRatePpm() int64 {
 	if x != nil {
-		return x.Asset
+		return x.PremiumLimitRatePpm
 	}
-	return ""
+	return 0
 }
 
-func (x *SwapInRequest) GetForce() bool {
+func (x *RetrySwapRequest) GetForce() bool {
 	if x != nil {
 		return x.Force
 	}
 	return false
 }
 
-func (x *SwapInRequest) GetPremiumLimitRatePpm() int64 {
+type GetSwapHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SwapId string `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+}
+
+func (x *GetSwapHistoryRequest) Reset() {
+	*x = GetSwapHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_peerswaprpc_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSwapHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwapHistoryRequest) ProtoMessage() {}
+
+func (x *GetSwapHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwapHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetSwapHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSwapHistoryRequest) GetSwapId() string {
 	if x != nil {
-		return x.PremiumLimitRatePpm
+		return x.SwapId
 	}
-	return 0
+	return ""
 }
 
-type SwapResponse struct {
+type GetSwapHistoryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Swap *PrettyPrintSwap `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	Swap    *PrettyPrintSwap       `protobuf:"bytes,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	History []*SwapStateTransition `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
 }
 
-func (x *SwapResponse) Reset() {
-	*x = SwapResponse{}
+func (x *GetSwapHistoryResponse) Reset() {
+	*x = GetSwapHistoryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[9]
+		mi := &file_peerswaprpc_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SwapResponse) String() string {
+func (x *GetSwapHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwapResponse) ProtoMessage() {}
+func (*GetSwapHistoryResponse) ProtoMessage() {}
 
-func (x *SwapResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[9]
+func (x *GetSwapHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,43 +3233,59 @@ func (x *SwapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
-func (*SwapResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetSwapHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetSwapHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *SwapResponse) GetSwap() *PrettyPrintSwap {
+func (x *GetSwapHistoryResponse) GetSwap() *PrettyPrintSwap {
 	if x != nil {
 		return x.Swap
 	}
 	return nil
 }
 
-type GetSwapRequest struct {
+func (x *GetSwapHistoryResponse) GetHistory() []*SwapStateTransition {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// A single state transition of a swap state machine.
+type SwapStateTransition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SwapId string `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`
+	// Unix timestamp of the transition.
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	FromState string `protobuf:"bytes,2,opt,name=from_state,json=fromState,proto3" json:"from_state,omitempty"`
+	Event     string `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	ToState   string `protobuf:"bytes,4,opt,name=to_state,json=toState,proto3" json:"to_state,omitempty"`
+	// Error or cancel message attached to the transition, if any.
+	Error       string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	TxId        string `protobuf:"bytes,6,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	PaymentHash string `protobuf:"bytes,7,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
 }
 
-func (x *GetSwapRequest) Reset() {
-	*x = GetSwapRequest{}
+func (x *SwapStateTransition) Reset() {
+	*x = SwapStateTransition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[10]
+		mi := &file_peerswaprpc_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSwapRequest) String() string {
+func (x *SwapStateTransition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSwapRequest) ProtoMessage() {}
+func (*SwapStateTransition) ProtoMessage() {}
 
-func (x *GetSwapRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[10]
+func (x *SwapStateTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_peerswaprpc_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,14 +3296,56 @@ func (x *GetSwapRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSwapRequest.ProtoReflect.Descriptor instead.
-func (*GetSwapRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SwapStateTransition.ProtoReflect.Descriptor instead.
+func (*SwapStateTransition) Descriptor() ([]byte, []int) {
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetSwapRequest) GetSwapId() string {
+func (x *SwapStateTransition) GetTimestamp() int64 {
 	if x != nil {
-		return x.SwapId
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SwapStateTransition) GetFromState() string {
+	if x != nil {
+		return x.FromState
+	}
+	return ""
+}
+
+func (x *SwapStateTransition) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *SwapStateTransition) GetToState() string {
+	if x != nil {
+		return x.ToState
+	}
+	return ""
+}
+
+func (x *SwapStateTransition) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SwapStateTransition) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *SwapStateTransition) GetPaymentHash() string {
+	if x != nil {
+		return x.PaymentHash
 	}
 	return ""
 }
@@ -756,7 +3369,7 @@ type ListSwapsRequest struct {
 func (x *ListSwapsRequest) Reset() {
 	*x = ListSwapsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[11]
+		mi := &file_peerswaprpc_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -769,7 +3382,7 @@ func (x *ListSwapsRequest) String() string {
 func (*ListSwapsRequest) ProtoMessage() {}
 
 func (x *ListSwapsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[11]
+	mi := &file_peerswaprpc_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,7 +3395,7 @@ func (x *ListSwapsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSwapsRequest.ProtoReflect.Descriptor instead.
 func (*ListSwapsRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{11}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *ListSwapsRequest) GetPageSize() uint32 {
@@ -818,7 +3431,7 @@ type ListSwapsResponse struct {
 func (x *ListSwapsResponse) Reset() {
 	*x = ListSwapsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[12]
+		mi := &file_peerswaprpc_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -831,7 +3444,7 @@ func (x *ListSwapsResponse) String() string {
 func (*ListSwapsResponse) ProtoMessage() {}
 
 func (x *ListSwapsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[12]
+	mi := &file_peerswaprpc_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +3457,7 @@ func (x *ListSwapsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSwapsResponse.ProtoReflect.Descriptor instead.
 func (*ListSwapsResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{12}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListSwapsResponse) GetSwaps() []*PrettyPrintSwap {
@@ -878,7 +3491,7 @@ type ListPeersRequest struct {
 func (x *ListPeersRequest) Reset() {
 	*x = ListPeersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[13]
+		mi := &file_peerswaprpc_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -891,7 +3504,7 @@ func (x *ListPeersRequest) String() string {
 func (*ListPeersRequest) ProtoMessage() {}
 
 func (x *ListPeersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[13]
+	mi := &file_peerswaprpc_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,7 +3517,7 @@ func (x *ListPeersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPeersRequest.ProtoReflect.Descriptor instead.
 func (*ListPeersRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{13}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *ListPeersRequest) GetPageSize() uint32 {
@@ -933,7 +3546,7 @@ type ListPeersResponse struct {
 func (x *ListPeersResponse) Reset() {
 	*x = ListPeersResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[14]
+		mi := &file_peerswaprpc_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -946,7 +3559,7 @@ func (x *ListPeersResponse) String() string {
 func (*ListPeersResponse) ProtoMessage() {}
 
 func (x *ListPeersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[14]
+	mi := &file_peerswaprpc_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +3572,7 @@ func (x *ListPeersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPeersResponse.ProtoReflect.Descriptor instead.
 func (*ListPeersResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{14}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *ListPeersResponse) GetPeers() []*PeerSwapPeer {
@@ -985,7 +3598,7 @@ type ReloadPolicyFileRequest struct {
 func (x *ReloadPolicyFileRequest) Reset() {
 	*x = ReloadPolicyFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[15]
+		mi := &file_peerswaprpc_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +3611,7 @@ func (x *ReloadPolicyFileRequest) String() string {
 func (*ReloadPolicyFileRequest) ProtoMessage() {}
 
 func (x *ReloadPolicyFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[15]
+	mi := &file_peerswaprpc_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +3624,7 @@ func (x *ReloadPolicyFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReloadPolicyFileRequest.ProtoReflect.Descriptor instead.
 func (*ReloadPolicyFileRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{15}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{50}
 }
 
 type AddPeerRequest struct {
@@ -1025,7 +3638,7 @@ type AddPeerRequest struct {
 func (x *AddPeerRequest) Reset() {
 	*x = AddPeerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[16]
+		mi := &file_peerswaprpc_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1038,7 +3651,7 @@ func (x *AddPeerRequest) String() string {
 func (*AddPeerRequest) ProtoMessage() {}
 
 func (x *AddPeerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[16]
+	mi := &file_peerswaprpc_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +3664,7 @@ func (x *AddPeerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPeerRequest.ProtoReflect.Descriptor instead.
 func (*AddPeerRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{16}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *AddPeerRequest) GetPeerPubkey() string {
@@ -1072,7 +3685,7 @@ type RemovePeerRequest struct {
 func (x *RemovePeerRequest) Reset() {
 	*x = RemovePeerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[17]
+		mi := &file_peerswaprpc_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1085,7 +3698,7 @@ func (x *RemovePeerRequest) String() string {
 func (*RemovePeerRequest) ProtoMessage() {}
 
 func (x *RemovePeerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[17]
+	mi := &file_peerswaprpc_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1098,7 +3711,7 @@ func (x *RemovePeerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemovePeerRequest.ProtoReflect.Descriptor instead.
 func (*RemovePeerRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{17}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *RemovePeerRequest) GetPeerPubkey() string {
@@ -1117,7 +3730,7 @@ type ListRequestedSwapsRequest struct {
 func (x *ListRequestedSwapsRequest) Reset() {
 	*x = ListRequestedSwapsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[18]
+		mi := &file_peerswaprpc_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1130,7 +3743,7 @@ func (x *ListRequestedSwapsRequest) String() string {
 func (*ListRequestedSwapsRequest) ProtoMessage() {}
 
 func (x *ListRequestedSwapsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[18]
+	mi := &file_peerswaprpc_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1143,7 +3756,7 @@ func (x *ListRequestedSwapsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRequestedSwapsRequest.ProtoReflect.Descriptor instead.
 func (*ListRequestedSwapsRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{18}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{53}
 }
 
 type ListRequestedSwapsResponse struct {
@@ -1157,7 +3770,7 @@ type ListRequestedSwapsResponse struct {
 func (x *ListRequestedSwapsResponse) Reset() {
 	*x = ListRequestedSwapsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[19]
+		mi := &file_peerswaprpc_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1170,7 +3783,7 @@ func (x *ListRequestedSwapsResponse) String() string {
 func (*ListRequestedSwapsResponse) ProtoMessage() {}
 
 func (x *ListRequestedSwapsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[19]
+	mi := &file_peerswaprpc_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,7 +3796,7 @@ func (x *ListRequestedSwapsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRequestedSwapsResponse.ProtoReflect.Descriptor instead.
 func (*ListRequestedSwapsResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{19}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *ListRequestedSwapsResponse) GetRequestedSwaps() map[string]*RequestSwapList {
@@ -1204,7 +3817,7 @@ type RequestSwapList struct {
 func (x *RequestSwapList) Reset() {
 	*x = RequestSwapList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[20]
+		mi := &file_peerswaprpc_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1217,7 +3830,7 @@ func (x *RequestSwapList) String() string {
 func (*RequestSwapList) ProtoMessage() {}
 
 func (x *RequestSwapList) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[20]
+	mi := &file_peerswaprpc_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,7 +3843,7 @@ func (x *RequestSwapList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestSwapList.ProtoReflect.Descriptor instead.
 func (*RequestSwapList) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{20}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *RequestSwapList) GetRequestedSwaps() []*RequestedSwap {
@@ -1254,7 +3867,7 @@ type RequestedSwap struct {
 func (x *RequestedSwap) Reset() {
 	*x = RequestedSwap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[21]
+		mi := &file_peerswaprpc_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1267,7 +3880,7 @@ func (x *RequestedSwap) String() string {
 func (*RequestedSwap) ProtoMessage() {}
 
 func (x *RequestedSwap) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[21]
+	mi := &file_peerswaprpc_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1280,7 +3893,7 @@ func (x *RequestedSwap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestedSwap.ProtoReflect.Descriptor instead.
 func (*RequestedSwap) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{21}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *RequestedSwap) GetAsset() string {
@@ -1331,12 +3944,35 @@ type PrettyPrintSwap struct {
 	CancelMessage   string `protobuf:"bytes,13,opt,name=cancel_message,json=cancelMessage,proto3" json:"cancel_message,omitempty"`
 	LndChanId       uint64 `protobuf:"varint,14,opt,name=lnd_chan_id,json=lndChanId,proto3" json:"lnd_chan_id,omitempty"`
 	PremiumAmount   int64  `protobuf:"varint,15,opt,name=premium_amount,json=premiumAmount,proto3" json:"premium_amount,omitempty"`
+	// Id of the swap that this swap is a retry of.
+	RetryOf string `protobuf:"bytes,16,opt,name=retry_of,json=retryOf,proto3" json:"retry_of,omitempty"`
+	// Idempotency key the swap was requested with.
+	IdempotencyKey string `protobuf:"bytes,17,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Extra channels of a swap-out that spans several channels.
+	ExtraChannelIds []string `protobuf:"bytes,18,rep,name=extra_channel_ids,json=extraChannelIds,proto3" json:"extra_channel_ids,omitempty"`
+	// Opening batch of a batched swap-in.
+	BatchId string `protobuf:"bytes,19,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// Address the claimed funds are sent to. Empty if they are sent to the
+	// node wallet.
+	ClaimAddress string `protobuf:"bytes,20,opt,name=claim_address,json=claimAddress,proto3" json:"claim_address,omitempty"`
+	// Number of confirmations the opening transaction needs before the claim
+	// invoice is paid.
+	RequiredConfirmations uint32 `protobuf:"varint,21,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	// Fee rate of the opening and claim transactions that we create, e.g.
+	// 12.5satvb or 6blocks. Empty if the wallet estimate is used.
+	FeeRate string `protobuf:"bytes,22,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	// Unsigned PSBT (btc) or PSET (lbtc) of an externally funded swap-in that
+	// awaits its funding.
+	FundingPsbt string `protobuf:"bytes,23,opt,name=funding_psbt,json=fundingPsbt,proto3" json:"funding_psbt,omitempty"`
+	// Unix time after which an externally funded swap-in that awaits its
+	// funding is canceled.
+	FundingExpiresAt int64 `protobuf:"varint,24,opt,name=funding_expires_at,json=fundingExpiresAt,proto3" json:"funding_expires_at,omitempty"`
 }
 
 func (x *PrettyPrintSwap) Reset() {
 	*x = PrettyPrintSwap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[22]
+		mi := &file_peerswaprpc_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1349,7 +3985,7 @@ func (x *PrettyPrintSwap) String() string {
 func (*PrettyPrintSwap) ProtoMessage() {}
 
 func (x *PrettyPrintSwap) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[22]
+	mi := &file_peerswaprpc_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,7 +3998,7 @@ func (x *PrettyPrintSwap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrettyPrintSwap.ProtoReflect.Descriptor instead.
 func (*PrettyPrintSwap) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{22}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *PrettyPrintSwap) GetId() string {
@@ -1460,12 +4096,75 @@ func (x *PrettyPrintSwap) GetLndChanId() uint64 {
 	if x != nil {
 		return x.LndChanId
 	}
-	return 0
+	return 0
+}
+
+func (x *PrettyPrintSwap) GetPremiumAmount() int64 {
+	if x != nil {
+		return x.PremiumAmount
+	}
+	return 0
+}
+
+func (x *PrettyPrintSwap) GetRetryOf() string {
+	if x != nil {
+		return x.RetryOf
+	}
+	return ""
+}
+
+func (x *PrettyPrintSwap) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PrettyPrintSwap) GetExtraChannelIds() []string {
+	if x != nil {
+		return x.ExtraChannelIds
+	}
+	return nil
+}
+
+func (x *PrettyPrintSwap) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *PrettyPrintSwap) GetClaimAddress() string {
+	if x != nil {
+		return x.ClaimAddress
+	}
+	return ""
+}
+
+func (x *PrettyPrintSwap) GetRequiredConfirmations() uint32 {
+	if x != nil {
+		return x.RequiredConfirmations
+	}
+	return 0
+}
+
+func (x *PrettyPrintSwap) GetFeeRate() string {
+	if x != nil {
+		return x.FeeRate
+	}
+	return ""
+}
+
+func (x *PrettyPrintSwap) GetFundingPsbt() string {
+	if x != nil {
+		return x.FundingPsbt
+	}
+	return ""
 }
 
-func (x *PrettyPrintSwap) GetPremiumAmount() int64 {
+func (x *PrettyPrintSwap) GetFundingExpiresAt() int64 {
 	if x != nil {
-		return x.PremiumAmount
+		return x.FundingExpiresAt
 	}
 	return 0
 }
@@ -1488,7 +4187,7 @@ type PeerSwapPeer struct {
 func (x *PeerSwapPeer) Reset() {
 	*x = PeerSwapPeer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[23]
+		mi := &file_peerswaprpc_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1501,7 +4200,7 @@ func (x *PeerSwapPeer) String() string {
 func (*PeerSwapPeer) ProtoMessage() {}
 
 func (x *PeerSwapPeer) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[23]
+	mi := &file_peerswaprpc_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1514,7 +4213,7 @@ func (x *PeerSwapPeer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerSwapPeer.ProtoReflect.Descriptor instead.
 func (*PeerSwapPeer) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{23}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *PeerSwapPeer) GetNodeId() string {
@@ -1588,7 +4287,7 @@ type PeerSwapPeerChannel struct {
 func (x *PeerSwapPeerChannel) Reset() {
 	*x = PeerSwapPeerChannel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[24]
+		mi := &file_peerswaprpc_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1601,7 +4300,7 @@ func (x *PeerSwapPeerChannel) String() string {
 func (*PeerSwapPeerChannel) ProtoMessage() {}
 
 func (x *PeerSwapPeerChannel) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[24]
+	mi := &file_peerswaprpc_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1614,7 +4313,7 @@ func (x *PeerSwapPeerChannel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerSwapPeerChannel.ProtoReflect.Descriptor instead.
 func (*PeerSwapPeerChannel) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{24}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *PeerSwapPeerChannel) GetChannelId() uint64 {
@@ -1666,7 +4365,7 @@ type SwapStats struct {
 func (x *SwapStats) Reset() {
 	*x = SwapStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[25]
+		mi := &file_peerswaprpc_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1679,7 +4378,7 @@ func (x *SwapStats) String() string {
 func (*SwapStats) ProtoMessage() {}
 
 func (x *SwapStats) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[25]
+	mi := &file_peerswaprpc_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,7 +4391,7 @@ func (x *SwapStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwapStats.ProtoReflect.Descriptor instead.
 func (*SwapStats) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{25}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *SwapStats) GetSwapsOut() uint64 {
@@ -1734,12 +4433,15 @@ type Policy struct {
 	AllowNewSwaps      bool     `protobuf:"varint,4,opt,name=allow_new_swaps,json=allowNewSwaps,proto3" json:"allow_new_swaps,omitempty"`
 	AllowlistedPeers   []string `protobuf:"bytes,5,rep,name=allowlisted_peers,json=allowlistedPeers,proto3" json:"allowlisted_peers,omitempty"`
 	SuspiciousPeerList []string `protobuf:"bytes,6,rep,name=suspicious_peer_list,json=suspiciousPeerList,proto3" json:"suspicious_peer_list,omitempty"`
+	// Confirmation tiers in the form <min amount sat>:<confirmations>.
+	BtcConfirmations  []string `protobuf:"bytes,7,rep,name=btc_confirmations,json=btcConfirmations,proto3" json:"btc_confirmations,omitempty"`
+	LbtcConfirmations []string `protobuf:"bytes,8,rep,name=lbtc_confirmations,json=lbtcConfirmations,proto3" json:"lbtc_confirmations,omitempty"`
 }
 
 func (x *Policy) Reset() {
 	*x = Policy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[26]
+		mi := &file_peerswaprpc_proto_msgTypes[61]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1752,7 +4454,7 @@ func (x *Policy) String() string {
 func (*Policy) ProtoMessage() {}
 
 func (x *Policy) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[26]
+	mi := &file_peerswaprpc_proto_msgTypes[61]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1765,7 +4467,7 @@ func (x *Policy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Policy.ProtoReflect.Descriptor instead.
 func (*Policy) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{26}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *Policy) GetReserveOnchainMsat() uint64 {
@@ -1810,6 +4512,20 @@ func (x *Policy) GetSuspiciousPeerList() []string {
 	return nil
 }
 
+func (x *Policy) GetBtcConfirmations() []string {
+	if x != nil {
+		return x.BtcConfirmations
+	}
+	return nil
+}
+
+func (x *Policy) GetLbtcConfirmations() []string {
+	if x != nil {
+		return x.LbtcConfirmations
+	}
+	return nil
+}
+
 type AllowSwapRequestsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1821,7 +4537,7 @@ type AllowSwapRequestsRequest struct {
 func (x *AllowSwapRequestsRequest) Reset() {
 	*x = AllowSwapRequestsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[27]
+		mi := &file_peerswaprpc_proto_msgTypes[62]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1834,7 +4550,7 @@ func (x *AllowSwapRequestsRequest) String() string {
 func (*AllowSwapRequestsRequest) ProtoMessage() {}
 
 func (x *AllowSwapRequestsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[27]
+	mi := &file_peerswaprpc_proto_msgTypes[62]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,7 +4563,7 @@ func (x *AllowSwapRequestsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllowSwapRequestsRequest.ProtoReflect.Descriptor instead.
 func (*AllowSwapRequestsRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{27}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *AllowSwapRequestsRequest) GetAllow() bool {
@@ -1868,7 +4584,7 @@ type AllowSwapRequestsResponse struct {
 func (x *AllowSwapRequestsResponse) Reset() {
 	*x = AllowSwapRequestsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[28]
+		mi := &file_peerswaprpc_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1881,7 +4597,7 @@ func (x *AllowSwapRequestsResponse) String() string {
 func (*AllowSwapRequestsResponse) ProtoMessage() {}
 
 func (x *AllowSwapRequestsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[28]
+	mi := &file_peerswaprpc_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1894,7 +4610,7 @@ func (x *AllowSwapRequestsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllowSwapRequestsResponse.ProtoReflect.Descriptor instead.
 func (*AllowSwapRequestsResponse) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{28}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *AllowSwapRequestsResponse) GetAllow() bool {
@@ -1913,7 +4629,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[29]
+		mi := &file_peerswaprpc_proto_msgTypes[64]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1926,7 +4642,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[29]
+	mi := &file_peerswaprpc_proto_msgTypes[64]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1939,7 +4655,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{29}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{64}
 }
 
 // PremiumRate defines the premium rate for a specific asset and operation.
@@ -1956,7 +4672,7 @@ type PremiumRate struct {
 func (x *PremiumRate) Reset() {
 	*x = PremiumRate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[30]
+		mi := &file_peerswaprpc_proto_msgTypes[65]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1969,7 +4685,7 @@ func (x *PremiumRate) String() string {
 func (*PremiumRate) ProtoMessage() {}
 
 func (x *PremiumRate) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[30]
+	mi := &file_peerswaprpc_proto_msgTypes[65]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1982,7 +4698,7 @@ func (x *PremiumRate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PremiumRate.ProtoReflect.Descriptor instead.
 func (*PremiumRate) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{30}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *PremiumRate) GetAsset() AssetType {
@@ -2022,7 +4738,7 @@ type PeerPremium struct {
 func (x *PeerPremium) Reset() {
 	*x = PeerPremium{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[31]
+		mi := &file_peerswaprpc_proto_msgTypes[66]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2035,7 +4751,7 @@ func (x *PeerPremium) String() string {
 func (*PeerPremium) ProtoMessage() {}
 
 func (x *PeerPremium) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[31]
+	mi := &file_peerswaprpc_proto_msgTypes[66]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2048,7 +4764,7 @@ func (x *PeerPremium) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerPremium.ProtoReflect.Descriptor instead.
 func (*PeerPremium) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{31}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *PeerPremium) GetNodeId() string {
@@ -2083,7 +4799,7 @@ type GetPremiumRateRequest struct {
 func (x *GetPremiumRateRequest) Reset() {
 	*x = GetPremiumRateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[32]
+		mi := &file_peerswaprpc_proto_msgTypes[67]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2096,7 +4812,7 @@ func (x *GetPremiumRateRequest) String() string {
 func (*GetPremiumRateRequest) ProtoMessage() {}
 
 func (x *GetPremiumRateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[32]
+	mi := &file_peerswaprpc_proto_msgTypes[67]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2109,7 +4825,7 @@ func (x *GetPremiumRateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPremiumRateRequest.ProtoReflect.Descriptor instead.
 func (*GetPremiumRateRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{32}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *GetPremiumRateRequest) GetNodeId() string {
@@ -2151,7 +4867,7 @@ type DeletePremiumRateRequest struct {
 func (x *DeletePremiumRateRequest) Reset() {
 	*x = DeletePremiumRateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[33]
+		mi := &file_peerswaprpc_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2164,7 +4880,7 @@ func (x *DeletePremiumRateRequest) String() string {
 func (*DeletePremiumRateRequest) ProtoMessage() {}
 
 func (x *DeletePremiumRateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[33]
+	mi := &file_peerswaprpc_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2177,7 +4893,7 @@ func (x *DeletePremiumRateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePremiumRateRequest.ProtoReflect.Descriptor instead.
 func (*DeletePremiumRateRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{33}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *DeletePremiumRateRequest) GetNodeId() string {
@@ -2217,7 +4933,7 @@ type UpdatePremiumRateRequest struct {
 func (x *UpdatePremiumRateRequest) Reset() {
 	*x = UpdatePremiumRateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[34]
+		mi := &file_peerswaprpc_proto_msgTypes[69]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2230,7 +4946,7 @@ func (x *UpdatePremiumRateRequest) String() string {
 func (*UpdatePremiumRateRequest) ProtoMessage() {}
 
 func (x *UpdatePremiumRateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[34]
+	mi := &file_peerswaprpc_proto_msgTypes[69]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2243,7 +4959,7 @@ func (x *UpdatePremiumRateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePremiumRateRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePremiumRateRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{34}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *UpdatePremiumRateRequest) GetNodeId() string {
@@ -2276,7 +4992,7 @@ type GetGlobalPremiumRateRequest struct {
 func (x *GetGlobalPremiumRateRequest) Reset() {
 	*x = GetGlobalPremiumRateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[35]
+		mi := &file_peerswaprpc_proto_msgTypes[70]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2289,7 +5005,7 @@ func (x *GetGlobalPremiumRateRequest) String() string {
 func (*GetGlobalPremiumRateRequest) ProtoMessage() {}
 
 func (x *GetGlobalPremiumRateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[35]
+	mi := &file_peerswaprpc_proto_msgTypes[70]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2302,7 +5018,7 @@ func (x *GetGlobalPremiumRateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGlobalPremiumRateRequest.ProtoReflect.Descriptor instead.
 func (*GetGlobalPremiumRateRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{35}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *GetGlobalPremiumRateRequest) GetAsset() AssetType {
@@ -2334,7 +5050,7 @@ type UpdateGlobalPremiumRateRequest struct {
 func (x *UpdateGlobalPremiumRateRequest) Reset() {
 	*x = UpdateGlobalPremiumRateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[36]
+		mi := &file_peerswaprpc_proto_msgTypes[71]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2347,7 +5063,7 @@ func (x *UpdateGlobalPremiumRateRequest) String() string {
 func (*UpdateGlobalPremiumRateRequest) ProtoMessage() {}
 
 func (x *UpdateGlobalPremiumRateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[36]
+	mi := &file_peerswaprpc_proto_msgTypes[71]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2360,7 +5076,7 @@ func (x *UpdateGlobalPremiumRateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGlobalPremiumRateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateGlobalPremiumRateRequest) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{36}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *UpdateGlobalPremiumRateRequest) GetRate() *PremiumRate {
@@ -2381,7 +5097,7 @@ type PeerSwapNodes struct {
 func (x *PeerSwapNodes) Reset() {
 	*x = PeerSwapNodes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_peerswaprpc_proto_msgTypes[37]
+		mi := &file_peerswaprpc_proto_msgTypes[72]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2394,7 +5110,7 @@ func (x *PeerSwapNodes) String() string {
 func (*PeerSwapNodes) ProtoMessage() {}
 
 func (x *PeerSwapNodes) ProtoReflect() protoreflect.Message {
-	mi := &file_peerswaprpc_proto_msgTypes[37]
+	mi := &file_peerswaprpc_proto_msgTypes[72]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2407,7 +5123,7 @@ func (x *PeerSwapNodes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerSwapNodes.ProtoReflect.Descriptor instead.
 func (*PeerSwapNodes) Descriptor() ([]byte, []int) {
-	return file_peerswaprpc_proto_rawDescGZIP(), []int{37}
+	return file_peerswaprpc_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *PeerSwapNodes) GetNodeId() string {
@@ -2438,7 +5154,7 @@ var file_peerswaprpc_proto_rawDesc = []byte{
 	0x28, 0x04, 0x52, 0x09, 0x73, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2c, 0x0a,
 	0x15, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x22, 0xb1, 0x01, 0x0a, 0x0e,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x22, 0xb9, 0x04, 0x0a, 0x0e,
 	0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
 	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a,
@@ -2449,289 +5165,745 @@ var file_peerswaprpc_proto_rawDesc = []byte{
 	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x72,
 	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65,
 	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x70, 0x72, 0x65, 0x6d,
-	0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x22,
-	0x40, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x74,
-	0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x04, 0x73, 0x77, 0x61,
-	0x70, 0x22, 0xb0, 0x01, 0x0a, 0x0d, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12,
-	0x33, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x13, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74,
-	0x65, 0x50, 0x70, 0x6d, 0x22, 0x3d, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72,
-	0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x04, 0x73,
-	0x77, 0x61, 0x70, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x22, 0x6e,
-	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1e,
-	0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x6c,
-	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72,
-	0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x05, 0x73,
-	0x77, 0x61, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
-	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
-	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x4e, 0x0a, 0x10,
-	0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x69, 0x0a, 0x11,
-	0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2c, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x53, 0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12,
-	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
-	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x6c, 0x6f, 0x61,
-	0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x31, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x6b, 0x65, 0x79, 0x22, 0x34, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50,
-	0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65,
-	0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x70, 0x65, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x22, 0x1b, 0x0a, 0x19, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xdd, 0x01, 0x0a, 0x1a, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x38, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x53, 0x77, 0x61, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x1a, 0x5c, 0x0a, 0x13, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x72,
+	0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x73, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x76,
+	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x70, 0x65, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x65,
+	0x66, 0x65, 0x72, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x28,
+	0x0a, 0x10, 0x64, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x73,
+	0x65, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x65, 0x72, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x79, 0x53, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78,
+	0x74, 0x72, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62,
+	0x79, 0x74, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x61, 0x74, 0x50, 0x65,
+	0x72, 0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x22, 0x40, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70, 0x4f,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77,
+	0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x22, 0xf2, 0x04, 0x0a, 0x0d, 0x53, 0x77,
+	0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x77,
+	0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x73, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69,
+	0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70,
+	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x26,
+	0x0a, 0x0f, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x53, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70,
+	0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65,
+	0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x2c, 0x0a,
+	0x12, 0x64, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x65, 0x66, 0x65, 0x72,
+	0x55, 0x6e, 0x74, 0x69, 0x6c, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x64,
+	0x65, 0x66, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x65, 0x72, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x79, 0x53, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x61, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76,
+	0x62, 0x79, 0x74, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x61, 0x74, 0x50,
+	0x65, 0x72, 0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x74, 0x78, 0x6f,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x74, 0x78, 0x6f, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x74,
+	0x78, 0x6f, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f,
+	0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x65,
+	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x82,
+	0x01, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33,
+	0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13,
+	0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x50, 0x70, 0x6d, 0x22, 0x6d, 0x0a, 0x12, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52,
+	0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72,
+	0x63, 0x65, 0x22, 0x58, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x77, 0x61, 0x70, 0x49,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70,
+	0x2e, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x13,
+	0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x35,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xd8, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x70,
+	0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x70, 0x72, 0x65,
+	0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d,
+	0x22, 0x54, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x86, 0x03, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x66, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x39, 0x0a, 0x19, 0x65, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x65,
+	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x46,
+	0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x35, 0x0a, 0x17, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x64, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x11,
+	0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x61, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x70, 0x65, 0x6e,
+	0x64, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x73, 0x61, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x73,
+	0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x61, 0x62, 0x6c, 0x65, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x35, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x69,
+	0x6d, 0x75, 0x6d, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x73, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x22,
+	0xbd, 0x01, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79,
+	0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x12,
+	0x47, 0x0a, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53,
+	0x77, 0x61, 0x70, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x22,
+	0xcb, 0x03, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x13, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52,
+	0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f,
+	0x6f, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x79, 0x4f,
+	0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x22, 0x0a, 0x0d, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x70, 0x65, 0x65,
+	0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72,
+	0x50, 0x65, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x11,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x18, 0x0a,
+	0x16, 0x4c, 0x69, 0x73, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0b,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x22, 0x1a, 0x0a, 0x18, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x58, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64,
+	0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x22, 0x29, 0x0a, 0x17, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x64, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1a, 0x0a, 0x18,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa9, 0x03, 0x0a, 0x19, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75,
+	0x6e, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x41,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x34, 0x0a, 0x17, 0x6f, 0x6e, 0x6c, 0x79,
+	0x5f, 0x69, 0x66, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62, 0x65, 0x6c, 0x6f, 0x77, 0x5f,
+	0x73, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x6f, 0x6e, 0x6c, 0x79, 0x49,
+	0x66, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x65, 0x6c, 0x6f, 0x77, 0x53, 0x61, 0x74, 0x12, 0x34,
+	0x0a, 0x17, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x69, 0x66, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
+	0x61, 0x62, 0x6f, 0x76, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x13, 0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x66, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x62, 0x6f, 0x76,
+	0x65, 0x53, 0x61, 0x74, 0x22, 0xb8, 0x04, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x33, 0x0a, 0x16, 0x70, 0x72,
+	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65,
+	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x70, 0x72, 0x65, 0x6d,
+	0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x12,
+	0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x72, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x34, 0x0a,
+	0x17, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x69, 0x66, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62,
+	0x65, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13,
+	0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x66, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x65, 0x6c, 0x6f, 0x77,
+	0x53, 0x61, 0x74, 0x12, 0x34, 0x0a, 0x17, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x69, 0x66, 0x5f, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x62, 0x6f, 0x76, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x6f, 0x6e, 0x6c, 0x79, 0x49, 0x66, 0x4c, 0x6f, 0x63, 0x61,
+	0x6c, 0x41, 0x62, 0x6f, 0x76, 0x65, 0x53, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75,
+	0x6e, 0x5f, 0x61, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74,
+	0x52, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x22,
+	0x82, 0x01, 0x0a, 0x0b, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x51, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a,
+	0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x22, 0x42, 0x0a, 0x18, 0x50, 0x61, 0x75, 0x73, 0x65, 0x53, 0x77, 0x61, 0x70,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x2b, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77,
+	0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x56, 0x0a, 0x0c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x0c, 0x44,
+	0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x77, 0x61, 0x70, 0x12, 0x2d, 0x0a, 0x04, 0x73,
+	0x77, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x82, 0x01, 0x0a, 0x0d, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x39, 0x0a, 0x0c, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x10, 0x45, 0x6c, 0x65, 0x63,
+	0x74, 0x72, 0x75, 0x6d, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0xc5, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x19, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x5f,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x72, 0x75, 0x6d, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x45, 0x6c, 0x65, 0x63, 0x74, 0x72, 0x75, 0x6d, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x17, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x45, 0x6c, 0x65, 0x63,
+	0x74, 0x72, 0x75, 0x6d, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x58, 0x0a,
+	0x1a, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x5f, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x72, 0x75,
+	0x6d, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x45, 0x6c, 0x65,
+	0x63, 0x74, 0x72, 0x75, 0x6d, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x18, 0x62,
+	0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x45, 0x6c, 0x65, 0x63, 0x74, 0x72, 0x75, 0x6d, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x73, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x63,
+	0x68, 0x6f, 0x73, 0x65, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x68, 0x6f, 0x73, 0x65, 0x6e, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x6f, 0x69,
+	0x63, 0x65, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0x81, 0x01, 0x0a,
+	0x0d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x61, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x6f, 0x73, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x63, 0x68, 0x6f, 0x73, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x22, 0x52, 0x0a, 0x1a, 0x46,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x77, 0x61, 0x70, 0x46, 0x75, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61,
+	0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x74, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x22,
+	0x97, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33,
+	0x0a, 0x16, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13,
+	0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x50, 0x70, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x30, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x53, 0x77, 0x61, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x50, 0x72, 0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52,
+	0x04, 0x73, 0x77, 0x61, 0x70, 0x12, 0x37, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0xd1,
+	0x01, 0x0a, 0x13, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78,
+	0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x22, 0x6e, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x22, 0x6c, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x52, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x4e, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x69, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x19, 0x0a, 0x17, 0x52,
+	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72,
+	0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x65, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x22, 0x34, 0x0a, 0x11, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x22,
+	0x1b, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xdd, 0x01, 0x0a,
+	0x1a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77,
+	0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0f, 0x72,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0e, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x22, 0xd5, 0x01,
-	0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x12,
-	0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x53, 0x61, 0x74, 0x12, 0x3d, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77,
-	0x61, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
-	0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72,
-	0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x25,
-	0x0a, 0x08, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x57,
-	0x41, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x57, 0x41, 0x50, 0x5f,
-	0x4f, 0x55, 0x54, 0x10, 0x01, 0x22, 0xcb, 0x03, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x74, 0x74, 0x79,
-	0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x11,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74,
-	0x6f, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72,
-	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
-	0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x78, 0x5f,
-	0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6e,
-	0x67, 0x54, 0x78, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74,
-	0x78, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69,
-	0x6d, 0x54, 0x78, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
-	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0b,
-	0x6c, 0x6e, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x09, 0x6c, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
-	0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x22, 0xef, 0x02, 0x0a, 0x0c, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70,
-	0x50, 0x65, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a,
-	0x0d, 0x73, 0x77, 0x61, 0x70, 0x73, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
-	0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f,
-	0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75,
-	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x39, 0x0a,
-	0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1d, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53,
-	0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x61, 0x73, 0x5f, 0x73,
-	0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x65,
-	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x52, 0x08, 0x61, 0x73, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x0b, 0x61, 0x73,
-	0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x61, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
-	0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x69, 0x64, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x69, 0x64, 0x46, 0x65, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x70,
-	0x65, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x65, 0x65,
-	0x72, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x50, 0x72,
-	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x22, 0xc2, 0x01, 0x0a, 0x13, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77,
-	0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74,
-	0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
-	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x12, 0x28, 0x0a, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x68, 0x6f, 0x72,
-	0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x77, 0x0a, 0x09, 0x53, 0x77,
-	0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x73,
-	0x5f, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70,
-	0x73, 0x4f, 0x75, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x77, 0x61, 0x70, 0x73, 0x5f, 0x69, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x77, 0x61, 0x70, 0x73, 0x49, 0x6e, 0x12,
-	0x19, 0x0a, 0x08, 0x73, 0x61, 0x74, 0x73, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x07, 0x73, 0x61, 0x74, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x61,
-	0x74, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x61, 0x74,
-	0x73, 0x49, 0x6e, 0x22, 0x9c, 0x02, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x30,
-	0x0a, 0x14, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x72, 0x65,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4d, 0x73, 0x61, 0x74,
-	0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11,
-	0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61,
-	0x74, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x5f,
-	0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x63, 0x63,
-	0x65, 0x70, 0x74, 0x41, 0x6c, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x61,
-	0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4e, 0x65, 0x77, 0x53, 0x77,
-	0x61, 0x70, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74,
-	0x65, 0x64, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10,
-	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73,
-	0x12, 0x30, 0x0a, 0x14, 0x73, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70,
-	0x65, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12,
-	0x73, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x69,
-	0x73, 0x74, 0x22, 0x30, 0x0a, 0x18, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x77, 0x61, 0x70, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61,
-	0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x31, 0x0a, 0x19, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x77, 0x61,
-	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x99, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65,
-	0x12, 0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x1a, 0x5c,
+	0x0a, 0x13, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53, 0x0a, 0x0f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x40, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61,
+	0x70, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x22, 0xd5, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53,
+	0x77, 0x61, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x12, 0x3d, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x53, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73,
+	0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x25, 0x0a, 0x08, 0x53, 0x77, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x57, 0x41, 0x50, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x22, 0x9e, 0x06, 0x0a, 0x0f, 0x50, 0x72,
+	0x65, 0x74, 0x74, 0x79, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x53, 0x77, 0x61, 0x70, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67,
+	0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x70,
+	0x65, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x78, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x78, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1e, 0x0a, 0x0b, 0x6c, 0x6e, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x6e, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75,
+	0x6d, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x6f, 0x66, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x4f, 0x66, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x35, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x66, 0x65, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x75, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x73, 0x62, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x73, 0x62, 0x74, 0x12, 0x2c, 0x0a, 0x12,
+	0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0xef, 0x02, 0x0a, 0x0c, 0x50,
+	0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x77, 0x61, 0x70, 0x73, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x77, 0x61,
+	0x70, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12,
+	0x30, 0x0a, 0x09, 0x61, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77,
+	0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x61, 0x73, 0x53, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x34, 0x0a, 0x0b, 0x61, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x61, 0x73, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x69, 0x64, 0x5f,
+	0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x69, 0x64, 0x46,
+	0x65, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x6d, 0x69,
+	0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52,
+	0x0b, 0x70, 0x65, 0x65, 0x72, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x22, 0xc2, 0x01, 0x0a,
+	0x13, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x50, 0x65, 0x65, 0x72, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x22, 0x77, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x77, 0x61, 0x70, 0x73, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x77, 0x61, 0x70, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73,
+	0x77, 0x61, 0x70, 0x73, 0x49, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x61, 0x74, 0x73, 0x5f, 0x6f,
+	0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x61, 0x74, 0x73, 0x4f, 0x75,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x61, 0x74, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x73, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x22, 0xf8, 0x02, 0x0a, 0x06, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x5f, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x12, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x6e, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x73,
+	0x77, 0x61, 0x70, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x6c, 0x6c, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6e, 0x65, 0x77, 0x5f,
+	0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x4e, 0x65, 0x77, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x75, 0x73, 0x70, 0x69,
+	0x63, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x73, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75,
+	0x73, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x74, 0x63,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x62, 0x74, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6c, 0x62, 0x74, 0x63, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x11, 0x6c, 0x62, 0x74, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x30, 0x0a, 0x18, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x31, 0x0a, 0x19, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52,
+	0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x35,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d,
+	0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x22,
+	0x53, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x12, 0x17,
+	0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72,
+	0x61, 0x74, 0x65, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x6d,
+	0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x95, 0x01, 0x0a, 0x18, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12,
+	0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x5e, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x6d, 0x69,
+	0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x22, 0x7f, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x50, 0x72,
+	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
 	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
 	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x5f, 0x72, 0x61,
-	0x74, 0x65, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x70, 0x72,
-	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x50, 0x70, 0x6d, 0x22, 0x53, 0x0a, 0x0b,
-	0x50, 0x65, 0x65, 0x72, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x6e,
-	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f,
-	0x64, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50,
-	0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65,
-	0x73, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d,
-	0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e,
-	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f,
-	0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41,
-	0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12,
-	0x35, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x95, 0x01, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05,
-	0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x65,
-	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65, 0x65,
-	0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5e,
-	0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f,
-	0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64,
-	0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65,
-	0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x7f,
-	0x0a, 0x1b, 0x47, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x6d, 0x69,
-	0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
-	0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70,
-	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x65,
-	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0x4b, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x50,
-	0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x6d, 0x69,
-	0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x28, 0x0a, 0x0d,
-	0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x17, 0x0a,
-	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x2a, 0x35, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x53, 0x53, 0x45, 0x54, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x54,
-	0x43, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x42, 0x54, 0x43, 0x10, 0x02, 0x2a, 0x45, 0x0a,
-	0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19,
-	0x0a, 0x15, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x57, 0x41,
-	0x50, 0x5f, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x4f,
-	0x55, 0x54, 0x10, 0x02, 0x32, 0xb6, 0x0c, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61,
-	0x70, 0x12, 0x3b, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x18, 0x2e, 0x70,
-	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52,
+	0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6c, 0x6f, 0x62,
+	0x61, 0x6c, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72,
+	0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22,
+	0x28, 0x0a, 0x0d, 0x50, 0x65, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x2a, 0x35, 0x0a, 0x09, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x53, 0x53, 0x45, 0x54, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a,
+	0x03, 0x42, 0x54, 0x43, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x42, 0x54, 0x43, 0x10, 0x02,
+	0x2a, 0x45, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x53, 0x57, 0x41, 0x50, 0x5f, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x57, 0x41,
+	0x50, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x32, 0xb3, 0x15, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72,
+	0x53, 0x77, 0x61, 0x70, 0x12, 0x3b, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12,
+	0x18, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x4f,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x17, 0x2e, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b,
+	0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x66,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x77, 0x61, 0x70, 0x12, 0x1e, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65,
+	0x74, 0x53, 0x77, 0x61, 0x70, 0x12, 0x18, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x53, 0x77, 0x61, 0x70, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x53, 0x77, 0x61, 0x70, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x24, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x53, 0x77, 0x61, 0x70, 0x46, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70,
+	0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
+	0x09, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x12, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12,
+	0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x1a, 0x2e,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0f, 0x4c,
+	0x69, 0x73, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x20,
+	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x12, 0x21, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77,
+	0x61, 0x70, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x53,
+	0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x77,
+	0x61, 0x70, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77,
+	0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53,
+	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x12,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x12, 0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77,
+	0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12,
+	0x5c, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a,
+	0x11, 0x50, 0x61, 0x75, 0x73, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x12, 0x22, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x61,
+	0x75, 0x73, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
-	0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
-	0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
-	0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74,
-	0x53, 0x77, 0x61, 0x70, 0x12, 0x18, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
-	0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77,
-	0x61, 0x70, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
-	0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x09,
-	0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72,
-	0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x5f, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
-	0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
-	0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
-	0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x70, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x5f,
+	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x23, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x77, 0x61, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x49, 0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
 	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
@@ -2797,7 +5969,15 @@ var file_peerswaprpc_proto_rawDesc = []byte{
 	0x77, 0x61, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75,
 	0x6d, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70,
 	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x52,
-	0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0f, 0x2e, 0x70, 0x65,
+	0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x05, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x16, 0x2e, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e,
+	0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x77, 0x61,
+	0x70, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0f, 0x2e, 0x70, 0x65,
 	0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x70,
 	0x65, 0x65, 0x72, 0x73, 0x77, 0x61, 0x70, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x31, 0x5a,
 	0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6c, 0x65, 0x6d,
@@ -2819,7 +5999,7 @@ func file_peerswaprpc_proto_rawDescGZIP() []byte {
 }
 
 var file_peerswaprpc_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_peerswaprpc_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_peerswaprpc_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_peerswaprpc_proto_goTypes = []interface{}{
 	(AssetType)(0),                         // 0: peerswap.AssetType
 	(OperationType)(0),                     // 1: peerswap.OperationType
@@ -2833,110 +6013,192 @@ var file_peerswaprpc_proto_goTypes = []interface{}{
 	(*SwapOutRequest)(nil),                 // 9: peerswap.SwapOutRequest
 	(*SwapOutResponse)(nil),                // 10: peerswap.SwapOutResponse
 	(*SwapInRequest)(nil),                  // 11: peerswap.SwapInRequest
-	(*SwapResponse)(nil),                   // 12: peerswap.SwapResponse
-	(*GetSwapRequest)(nil),                 // 13: peerswap.GetSwapRequest
-	(*ListSwapsRequest)(nil),               // 14: peerswap.ListSwapsRequest
-	(*ListSwapsResponse)(nil),              // 15: peerswap.ListSwapsResponse
-	(*ListPeersRequest)(nil),               // 16: peerswap.ListPeersRequest
-	(*ListPeersResponse)(nil),              // 17: peerswap.ListPeersResponse
-	(*ReloadPolicyFileRequest)(nil),        // 18: peerswap.ReloadPolicyFileRequest
-	(*AddPeerRequest)(nil),                 // 19: peerswap.AddPeerRequest
-	(*RemovePeerRequest)(nil),              // 20: peerswap.RemovePeerRequest
-	(*ListRequestedSwapsRequest)(nil),      // 21: peerswap.ListRequestedSwapsRequest
-	(*ListRequestedSwapsResponse)(nil),     // 22: peerswap.ListRequestedSwapsResponse
-	(*RequestSwapList)(nil),                // 23: peerswap.RequestSwapList
-	(*RequestedSwap)(nil),                  // 24: peerswap.RequestedSwap
-	(*PrettyPrintSwap)(nil),                // 25: peerswap.PrettyPrintSwap
-	(*PeerSwapPeer)(nil),                   // 26: peerswap.PeerSwapPeer
-	(*PeerSwapPeerChannel)(nil),            // 27: peerswap.PeerSwapPeerChannel
-	(*SwapStats)(nil),                      // 28: peerswap.SwapStats
-	(*Policy)(nil),                         // 29: peerswap.Policy
-	(*AllowSwapRequestsRequest)(nil),       // 30: peerswap.AllowSwapRequestsRequest
-	(*AllowSwapRequestsResponse)(nil),      // 31: peerswap.AllowSwapRequestsResponse
-	(*Empty)(nil),                          // 32: peerswap.Empty
-	(*PremiumRate)(nil),                    // 33: peerswap.PremiumRate
-	(*PeerPremium)(nil),                    // 34: peerswap.PeerPremium
-	(*GetPremiumRateRequest)(nil),          // 35: peerswap.GetPremiumRateRequest
-	(*DeletePremiumRateRequest)(nil),       // 36: peerswap.DeletePremiumRateRequest
-	(*UpdatePremiumRateRequest)(nil),       // 37: peerswap.UpdatePremiumRateRequest
-	(*GetGlobalPremiumRateRequest)(nil),    // 38: peerswap.GetGlobalPremiumRateRequest
-	(*UpdateGlobalPremiumRateRequest)(nil), // 39: peerswap.UpdateGlobalPremiumRateRequest
-	(*PeerSwapNodes)(nil),                  // 40: peerswap.PeerSwapNodes
-	nil,                                    // 41: peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry
+	(*BatchSwapIn)(nil),                    // 12: peerswap.BatchSwapIn
+	(*SwapInBatchRequest)(nil),             // 13: peerswap.SwapInBatchRequest
+	(*BatchSwapInResult)(nil),              // 14: peerswap.BatchSwapInResult
+	(*SwapInBatchResponse)(nil),            // 15: peerswap.SwapInBatchResponse
+	(*PreflightSwapRequest)(nil),           // 16: peerswap.PreflightSwapRequest
+	(*PreflightCheck)(nil),                 // 17: peerswap.PreflightCheck
+	(*PreflightSwapResponse)(nil),          // 18: peerswap.PreflightSwapResponse
+	(*SwapResponse)(nil),                   // 19: peerswap.SwapResponse
+	(*QueuedSwap)(nil),                     // 20: peerswap.QueuedSwap
+	(*ListQueuedSwapsRequest)(nil),         // 21: peerswap.ListQueuedSwapsRequest
+	(*ListQueuedSwapsResponse)(nil),        // 22: peerswap.ListQueuedSwapsResponse
+	(*ListDeferredSwapsRequest)(nil),       // 23: peerswap.ListDeferredSwapsRequest
+	(*ListDeferredSwapsResponse)(nil),      // 24: peerswap.ListDeferredSwapsResponse
+	(*RemoveQueuedSwapRequest)(nil),        // 25: peerswap.RemoveQueuedSwapRequest
+	(*RemoveQueuedSwapResponse)(nil),       // 26: peerswap.RemoveQueuedSwapResponse
+	(*CreateSwapScheduleRequest)(nil),      // 27: peerswap.CreateSwapScheduleRequest
+	(*SwapSchedule)(nil),                   // 28: peerswap.SwapSchedule
+	(*ScheduleRun)(nil),                    // 29: peerswap.ScheduleRun
+	(*ListSwapSchedulesRequest)(nil),       // 30: peerswap.ListSwapSchedulesRequest
+	(*ListSwapSchedulesResponse)(nil),      // 31: peerswap.ListSwapSchedulesResponse
+	(*PauseSwapScheduleRequest)(nil),       // 32: peerswap.PauseSwapScheduleRequest
+	(*DeleteSwapScheduleRequest)(nil),      // 33: peerswap.DeleteSwapScheduleRequest
+	(*DeleteSwapScheduleResponse)(nil),     // 34: peerswap.DeleteSwapScheduleResponse
+	(*DrainRequest)(nil),                   // 35: peerswap.DrainRequest
+	(*DrainingSwap)(nil),                   // 36: peerswap.DrainingSwap
+	(*DrainResponse)(nil),                  // 37: peerswap.DrainResponse
+	(*GetStatusRequest)(nil),               // 38: peerswap.GetStatusRequest
+	(*ElectrumEndpoint)(nil),               // 39: peerswap.ElectrumEndpoint
+	(*GetStatusResponse)(nil),              // 40: peerswap.GetStatusResponse
+	(*ChannelSelection)(nil),               // 41: peerswap.ChannelSelection
+	(*ChannelChoice)(nil),                  // 42: peerswap.ChannelChoice
+	(*GetSwapRequest)(nil),                 // 43: peerswap.GetSwapRequest
+	(*FinalizeSwapFundingRequest)(nil),     // 44: peerswap.FinalizeSwapFundingRequest
+	(*RetrySwapRequest)(nil),               // 45: peerswap.RetrySwapRequest
+	(*GetSwapHistoryRequest)(nil),          // 46: peerswap.GetSwapHistoryRequest
+	(*GetSwapHistoryResponse)(nil),         // 47: peerswap.GetSwapHistoryResponse
+	(*SwapStateTransition)(nil),            // 48: peerswap.SwapStateTransition
+	(*ListSwapsRequest)(nil),               // 49: peerswap.ListSwapsRequest
+	(*ListSwapsResponse)(nil),              // 50: peerswap.ListSwapsResponse
+	(*ListPeersRequest)(nil),               // 51: peerswap.ListPeersRequest
+	(*ListPeersResponse)(nil),              // 52: peerswap.ListPeersResponse
+	(*ReloadPolicyFileRequest)(nil),        // 53: peerswap.ReloadPolicyFileRequest
+	(*AddPeerRequest)(nil),                 // 54: peerswap.AddPeerRequest
+	(*RemovePeerRequest)(nil),              // 55: peerswap.RemovePeerRequest
+	(*ListRequestedSwapsRequest)(nil),      // 56: peerswap.ListRequestedSwapsRequest
+	(*ListRequestedSwapsResponse)(nil),     // 57: peerswap.ListRequestedSwapsResponse
+	(*RequestSwapList)(nil),                // 58: peerswap.RequestSwapList
+	(*RequestedSwap)(nil),                  // 59: peerswap.RequestedSwap
+	(*PrettyPrintSwap)(nil),                // 60: peerswap.PrettyPrintSwap
+	(*PeerSwapPeer)(nil),                   // 61: peerswap.PeerSwapPeer
+	(*PeerSwapPeerChannel)(nil),            // 62: peerswap.PeerSwapPeerChannel
+	(*SwapStats)(nil),                      // 63: peerswap.SwapStats
+	(*Policy)(nil),                         // 64: peerswap.Policy
+	(*AllowSwapRequestsRequest)(nil),       // 65: peerswap.AllowSwapRequestsRequest
+	(*AllowSwapRequestsResponse)(nil),      // 66: peerswap.AllowSwapRequestsResponse
+	(*Empty)(nil),                          // 67: peerswap.Empty
+	(*PremiumRate)(nil),                    // 68: peerswap.PremiumRate
+	(*PeerPremium)(nil),                    // 69: peerswap.PeerPremium
+	(*GetPremiumRateRequest)(nil),          // 70: peerswap.GetPremiumRateRequest
+	(*DeletePremiumRateRequest)(nil),       // 71: peerswap.DeletePremiumRateRequest
+	(*UpdatePremiumRateRequest)(nil),       // 72: peerswap.UpdatePremiumRateRequest
+	(*GetGlobalPremiumRateRequest)(nil),    // 73: peerswap.GetGlobalPremiumRateRequest
+	(*UpdateGlobalPremiumRateRequest)(nil), // 74: peerswap.UpdateGlobalPremiumRateRequest
+	(*PeerSwapNodes)(nil),                  // 75: peerswap.PeerSwapNodes
+	nil,                                    // 76: peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry
 }
 var file_peerswaprpc_proto_depIdxs = []int32{
-	25, // 0: peerswap.SwapOutResponse.swap:type_name -> peerswap.PrettyPrintSwap
-	25, // 1: peerswap.SwapResponse.swap:type_name -> peerswap.PrettyPrintSwap
-	25, // 2: peerswap.ListSwapsResponse.swaps:type_name -> peerswap.PrettyPrintSwap
-	26, // 3: peerswap.ListPeersResponse.peers:type_name -> peerswap.PeerSwapPeer
-	41, // 4: peerswap.ListRequestedSwapsResponse.requested_swaps:type_name -> peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry
-	24, // 5: peerswap.RequestSwapList.requested_swaps:type_name -> peerswap.RequestedSwap
-	2,  // 6: peerswap.RequestedSwap.swap_type:type_name -> peerswap.RequestedSwap.SwapType
-	27, // 7: peerswap.PeerSwapPeer.channels:type_name -> peerswap.PeerSwapPeerChannel
-	28, // 8: peerswap.PeerSwapPeer.as_sender:type_name -> peerswap.SwapStats
-	28, // 9: peerswap.PeerSwapPeer.as_receiver:type_name -> peerswap.SwapStats
-	34, // 10: peerswap.PeerSwapPeer.peer_premium:type_name -> peerswap.PeerPremium
-	0,  // 11: peerswap.PremiumRate.asset:type_name -> peerswap.AssetType
-	1,  // 12: peerswap.PremiumRate.operation:type_name -> peerswap.OperationType
-	33, // 13: peerswap.PeerPremium.rates:type_name -> peerswap.PremiumRate
-	0,  // 14: peerswap.GetPremiumRateRequest.asset:type_name -> peerswap.AssetType
-	1,  // 15: peerswap.GetPremiumRateRequest.operation:type_name -> peerswap.OperationType
-	0,  // 16: peerswap.DeletePremiumRateRequest.asset:type_name -> peerswap.AssetType
-	1,  // 17: peerswap.DeletePremiumRateRequest.operation:type_name -> peerswap.OperationType
-	33, // 18: peerswap.UpdatePremiumRateRequest.rate:type_name -> peerswap.PremiumRate
-	0,  // 19: peerswap.GetGlobalPremiumRateRequest.asset:type_name -> peerswap.AssetType
-	1,  // 20: peerswap.GetGlobalPremiumRateRequest.operation:type_name -> peerswap.OperationType
-	33, // 21: peerswap.UpdateGlobalPremiumRateRequest.rate:type_name -> peerswap.PremiumRate
-	23, // 22: peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry.value:type_name -> peerswap.RequestSwapList
-	9,  // 23: peerswap.PeerSwap.SwapOut:input_type -> peerswap.SwapOutRequest
-	11, // 24: peerswap.PeerSwap.SwapIn:input_type -> peerswap.SwapInRequest
-	13, // 25: peerswap.PeerSwap.GetSwap:input_type -> peerswap.GetSwapRequest
-	14, // 26: peerswap.PeerSwap.ListSwaps:input_type -> peerswap.ListSwapsRequest
-	16, // 27: peerswap.PeerSwap.ListPeers:input_type -> peerswap.ListPeersRequest
-	21, // 28: peerswap.PeerSwap.ListRequestedSwaps:input_type -> peerswap.ListRequestedSwapsRequest
-	14, // 29: peerswap.PeerSwap.ListActiveSwaps:input_type -> peerswap.ListSwapsRequest
-	30, // 30: peerswap.PeerSwap.AllowSwapRequests:input_type -> peerswap.AllowSwapRequestsRequest
-	18, // 31: peerswap.PeerSwap.ReloadPolicyFile:input_type -> peerswap.ReloadPolicyFileRequest
-	19, // 32: peerswap.PeerSwap.AddPeer:input_type -> peerswap.AddPeerRequest
-	20, // 33: peerswap.PeerSwap.RemovePeer:input_type -> peerswap.RemovePeerRequest
-	19, // 34: peerswap.PeerSwap.AddSusPeer:input_type -> peerswap.AddPeerRequest
-	20, // 35: peerswap.PeerSwap.RemoveSusPeer:input_type -> peerswap.RemovePeerRequest
-	3,  // 36: peerswap.PeerSwap.LiquidGetAddress:input_type -> peerswap.GetAddressRequest
-	5,  // 37: peerswap.PeerSwap.LiquidGetBalance:input_type -> peerswap.GetBalanceRequest
-	7,  // 38: peerswap.PeerSwap.LiquidSendToAddress:input_type -> peerswap.SendToAddressRequest
-	38, // 39: peerswap.PeerSwap.GetGlobalPremiumRate:input_type -> peerswap.GetGlobalPremiumRateRequest
-	39, // 40: peerswap.PeerSwap.UpdateGlobalPremiumRate:input_type -> peerswap.UpdateGlobalPremiumRateRequest
-	35, // 41: peerswap.PeerSwap.GetPremiumRate:input_type -> peerswap.GetPremiumRateRequest
-	37, // 42: peerswap.PeerSwap.UpdatePremiumRate:input_type -> peerswap.UpdatePremiumRateRequest
-	36, // 43: peerswap.PeerSwap.DeletePremiumRate:input_type -> peerswap.DeletePremiumRateRequest
-	32, // 44: peerswap.PeerSwap.Stop:input_type -> peerswap.Empty
-	12, // 45: peerswap.PeerSwap.SwapOut:output_type -> peerswap.SwapResponse
-	12, // 46: peerswap.PeerSwap.SwapIn:output_type -> peerswap.SwapResponse
-	12, // 47: peerswap.PeerSwap.GetSwap:output_type -> peerswap.SwapResponse
-	15, // 48: peerswap.PeerSwap.ListSwaps:output_type -> peerswap.ListSwapsResponse
-	17, // 49: peerswap.PeerSwap.ListPeers:output_type -> peerswap.ListPeersResponse
-	22, // 50: peerswap.PeerSwap.ListRequestedSwaps:output_type -> peerswap.ListRequestedSwapsResponse
-	15, // 51: peerswap.PeerSwap.ListActiveSwaps:output_type -> peerswap.ListSwapsResponse
-	29, // 52: peerswap.PeerSwap.AllowSwapRequests:output_type -> peerswap.Policy
-	29, // 53: peerswap.PeerSwap.ReloadPolicyFile:output_type -> peerswap.Policy
-	29, // 54: peerswap.PeerSwap.AddPeer:output_type -> peerswap.Policy
-	29, // 55: peerswap.PeerSwap.RemovePeer:output_type -> peerswap.Policy
-	29, // 56: peerswap.PeerSwap.AddSusPeer:output_type -> peerswap.Policy
-	29, // 57: peerswap.PeerSwap.RemoveSusPeer:output_type -> peerswap.Policy
-	4,  // 58: peerswap.PeerSwap.LiquidGetAddress:output_type -> peerswap.GetAddressResponse
-	6,  // 59: peerswap.PeerSwap.LiquidGetBalance:output_type -> peerswap.GetBalanceResponse
-	8,  // 60: peerswap.PeerSwap.LiquidSendToAddress:output_type -> peerswap.SendToAddressResponse
-	33, // 61: peerswap.PeerSwap.GetGlobalPremiumRate:output_type -> peerswap.PremiumRate
-	33, // 62: peerswap.PeerSwap.UpdateGlobalPremiumRate:output_type -> peerswap.PremiumRate
-	33, // 63: peerswap.PeerSwap.GetPremiumRate:output_type -> peerswap.PremiumRate
-	33, // 64: peerswap.PeerSwap.UpdatePremiumRate:output_type -> peerswap.PremiumRate
-	33, // 65: peerswap.PeerSwap.DeletePremiumRate:output_type -> peerswap.PremiumRate
-	32, // 66: peerswap.PeerSwap.Stop:output_type -> peerswap.Empty
-	45, // [45:67] is the sub-list for method output_type
-	23, // [23:45] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	60, // 0: peerswap.SwapOutResponse.swap:type_name -> peerswap.PrettyPrintSwap
+	12, // 1: peerswap.SwapInBatchRequest.swaps:type_name -> peerswap.BatchSwapIn
+	60, // 2: peerswap.BatchSwapInResult.swap:type_name -> peerswap.PrettyPrintSwap
+	14, // 3: peerswap.SwapInBatchResponse.results:type_name -> peerswap.BatchSwapInResult
+	1,  // 4: peerswap.PreflightSwapRequest.operation:type_name -> peerswap.OperationType
+	17, // 5: peerswap.PreflightSwapResponse.checks:type_name -> peerswap.PreflightCheck
+	60, // 6: peerswap.SwapResponse.swap:type_name -> peerswap.PrettyPrintSwap
+	41, // 7: peerswap.SwapResponse.channel_selection:type_name -> peerswap.ChannelSelection
+	20, // 8: peerswap.SwapResponse.queued_swap:type_name -> peerswap.QueuedSwap
+	20, // 9: peerswap.ListQueuedSwapsResponse.queued_swaps:type_name -> peerswap.QueuedSwap
+	20, // 10: peerswap.ListDeferredSwapsResponse.deferred_swaps:type_name -> peerswap.QueuedSwap
+	1,  // 11: peerswap.CreateSwapScheduleRequest.operation:type_name -> peerswap.OperationType
+	29, // 12: peerswap.SwapSchedule.runs:type_name -> peerswap.ScheduleRun
+	28, // 13: peerswap.ListSwapSchedulesResponse.schedules:type_name -> peerswap.SwapSchedule
+	60, // 14: peerswap.DrainingSwap.swap:type_name -> peerswap.PrettyPrintSwap
+	36, // 15: peerswap.DrainResponse.active_swaps:type_name -> peerswap.DrainingSwap
+	39, // 16: peerswap.GetStatusResponse.liquid_electrum_endpoints:type_name -> peerswap.ElectrumEndpoint
+	39, // 17: peerswap.GetStatusResponse.bitcoin_electrum_endpoints:type_name -> peerswap.ElectrumEndpoint
+	42, // 18: peerswap.ChannelSelection.channels:type_name -> peerswap.ChannelChoice
+	60, // 19: peerswap.GetSwapHistoryResponse.swap:type_name -> peerswap.PrettyPrintSwap
+	48, // 20: peerswap.GetSwapHistoryResponse.history:type_name -> peerswap.SwapStateTransition
+	60, // 21: peerswap.ListSwapsResponse.swaps:type_name -> peerswap.PrettyPrintSwap
+	61, // 22: peerswap.ListPeersResponse.peers:type_name -> peerswap.PeerSwapPeer
+	76, // 23: peerswap.ListRequestedSwapsResponse.requested_swaps:type_name -> peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry
+	59, // 24: peerswap.RequestSwapList.requested_swaps:type_name -> peerswap.RequestedSwap
+	2,  // 25: peerswap.RequestedSwap.swap_type:type_name -> peerswap.RequestedSwap.SwapType
+	62, // 26: peerswap.PeerSwapPeer.channels:type_name -> peerswap.PeerSwapPeerChannel
+	63, // 27: peerswap.PeerSwapPeer.as_sender:type_name -> peerswap.SwapStats
+	63, // 28: peerswap.PeerSwapPeer.as_receiver:type_name -> peerswap.SwapStats
+	69, // 29: peerswap.PeerSwapPeer.peer_premium:type_name -> peerswap.PeerPremium
+	0,  // 30: peerswap.PremiumRate.asset:type_name -> peerswap.AssetType
+	1,  // 31: peerswap.PremiumRate.operation:type_name -> peerswap.OperationType
+	68, // 32: peerswap.PeerPremium.rates:type_name -> peerswap.PremiumRate
+	0,  // 33: peerswap.GetPremiumRateRequest.asset:type_name -> peerswap.AssetType
+	1,  // 34: peerswap.GetPremiumRateRequest.operation:type_name -> peerswap.OperationType
+	0,  // 35: peerswap.DeletePremiumRateRequest.asset:type_name -> peerswap.AssetType
+	1,  // 36: peerswap.DeletePremiumRateRequest.operation:type_name -> peerswap.OperationType
+	68, // 37: peerswap.UpdatePremiumRateRequest.rate:type_name -> peerswap.PremiumRate
+	0,  // 38: peerswap.GetGlobalPremiumRateRequest.asset:type_name -> peerswap.AssetType
+	1,  // 39: peerswap.GetGlobalPremiumRateRequest.operation:type_name -> peerswap.OperationType
+	68, // 40: peerswap.UpdateGlobalPremiumRateRequest.rate:type_name -> peerswap.PremiumRate
+	58, // 41: peerswap.ListRequestedSwapsResponse.RequestedSwapsEntry.value:type_name -> peerswap.RequestSwapList
+	9,  // 42: peerswap.PeerSwap.SwapOut:input_type -> peerswap.SwapOutRequest
+	11, // 43: peerswap.PeerSwap.SwapIn:input_type -> peerswap.SwapInRequest
+	13, // 44: peerswap.PeerSwap.SwapInBatch:input_type -> peerswap.SwapInBatchRequest
+	16, // 45: peerswap.PeerSwap.PreflightSwap:input_type -> peerswap.PreflightSwapRequest
+	43, // 46: peerswap.PeerSwap.GetSwap:input_type -> peerswap.GetSwapRequest
+	45, // 47: peerswap.PeerSwap.RetrySwap:input_type -> peerswap.RetrySwapRequest
+	44, // 48: peerswap.PeerSwap.FinalizeSwapFunding:input_type -> peerswap.FinalizeSwapFundingRequest
+	49, // 49: peerswap.PeerSwap.ListSwaps:input_type -> peerswap.ListSwapsRequest
+	51, // 50: peerswap.PeerSwap.ListPeers:input_type -> peerswap.ListPeersRequest
+	56, // 51: peerswap.PeerSwap.ListRequestedSwaps:input_type -> peerswap.ListRequestedSwapsRequest
+	49, // 52: peerswap.PeerSwap.ListActiveSwaps:input_type -> peerswap.ListSwapsRequest
+	46, // 53: peerswap.PeerSwap.GetSwapHistory:input_type -> peerswap.GetSwapHistoryRequest
+	21, // 54: peerswap.PeerSwap.ListQueuedSwaps:input_type -> peerswap.ListQueuedSwapsRequest
+	25, // 55: peerswap.PeerSwap.RemoveQueuedSwap:input_type -> peerswap.RemoveQueuedSwapRequest
+	23, // 56: peerswap.PeerSwap.ListDeferredSwaps:input_type -> peerswap.ListDeferredSwapsRequest
+	27, // 57: peerswap.PeerSwap.CreateSwapSchedule:input_type -> peerswap.CreateSwapScheduleRequest
+	30, // 58: peerswap.PeerSwap.ListSwapSchedules:input_type -> peerswap.ListSwapSchedulesRequest
+	32, // 59: peerswap.PeerSwap.PauseSwapSchedule:input_type -> peerswap.PauseSwapScheduleRequest
+	33, // 60: peerswap.PeerSwap.DeleteSwapSchedule:input_type -> peerswap.DeleteSwapScheduleRequest
+	65, // 61: peerswap.PeerSwap.AllowSwapRequests:input_type -> peerswap.AllowSwapRequestsRequest
+	53, // 62: peerswap.PeerSwap.ReloadPolicyFile:input_type -> peerswap.ReloadPolicyFileRequest
+	54, // 63: peerswap.PeerSwap.AddPeer:input_type -> peerswap.AddPeerRequest
+	55, // 64: peerswap.PeerSwap.RemovePeer:input_type -> peerswap.RemovePeerRequest
+	54, // 65: peerswap.PeerSwap.AddSusPeer:input_type -> peerswap.AddPeerRequest
+	55, // 66: peerswap.PeerSwap.RemoveSusPeer:input_type -> peerswap.RemovePeerRequest
+	3,  // 67: peerswap.PeerSwap.LiquidGetAddress:input_type -> peerswap.GetAddressRequest
+	5,  // 68: peerswap.PeerSwap.LiquidGetBalance:input_type -> peerswap.GetBalanceRequest
+	7,  // 69: peerswap.PeerSwap.LiquidSendToAddress:input_type -> peerswap.SendToAddressRequest
+	73, // 70: peerswap.PeerSwap.GetGlobalPremiumRate:input_type -> peerswap.GetGlobalPremiumRateRequest
+	74, // 71: peerswap.PeerSwap.UpdateGlobalPremiumRate:input_type -> peerswap.UpdateGlobalPremiumRateRequest
+	70, // 72: peerswap.PeerSwap.GetPremiumRate:input_type -> peerswap.GetPremiumRateRequest
+	72, // 73: peerswap.PeerSwap.UpdatePremiumRate:input_type -> peerswap.UpdatePremiumRateRequest
+	71, // 74: peerswap.PeerSwap.DeletePremiumRate:input_type -> peerswap.DeletePremiumRateRequest
+	35, // 75: peerswap.PeerSwap.Drain:input_type -> peerswap.DrainRequest
+	38, // 76: peerswap.PeerSwap.GetStatus:input_type -> peerswap.GetStatusRequest
+	67, // 77: peerswap.PeerSwap.Stop:input_type -> peerswap.Empty
+	19, // 78: peerswap.PeerSwap.SwapOut:output_type -> peerswap.SwapResponse
+	19, // 79: peerswap.PeerSwap.SwapIn:output_type -> peerswap.SwapResponse
+	15, // 80: peerswap.PeerSwap.SwapInBatch:output_type -> peerswap.SwapInBatchResponse
+	18, // 81: peerswap.PeerSwap.PreflightSwap:output_type -> peerswap.PreflightSwapResponse
+	19, // 82: peerswap.PeerSwap.GetSwap:output_type -> peerswap.SwapResponse
+	19, // 83: peerswap.PeerSwap.RetrySwap:output_type -> peerswap.SwapResponse
+	19, // 84: peerswap.PeerSwap.FinalizeSwapFunding:output_type -> peerswap.SwapResponse
+	50, // 85: peerswap.PeerSwap.ListSwaps:output_type -> peerswap.ListSwapsResponse
+	52, // 86: peerswap.PeerSwap.ListPeers:output_type -> peerswap.ListPeersResponse
+	57, // 87: peerswap.PeerSwap.ListRequestedSwaps:output_type -> peerswap.ListRequestedSwapsResponse
+	50, // 88: peerswap.PeerSwap.ListActiveSwaps:output_type -> peerswap.ListSwapsResponse
+	47, // 89: peerswap.PeerSwap.GetSwapHistory:output_type -> peerswap.GetSwapHistoryResponse
+	22, // 90: peerswap.PeerSwap.ListQueuedSwaps:output_type -> peerswap.ListQueuedSwapsResponse
+	26, // 91: peerswap.PeerSwap.RemoveQueuedSwap:output_type -> peerswap.RemoveQueuedSwapResponse
+	24, // 92: peerswap.PeerSwap.ListDeferredSwaps:output_type -> peerswap.ListDeferredSwapsResponse
+	28, // 93: peerswap.PeerSwap.CreateSwapSchedule:output_type -> peerswap.SwapSchedule
+	31, // 94: peerswap.PeerSwap.ListSwapSchedules:output_type -> peerswap.ListSwapSchedulesResponse
+	28, // 95: peerswap.PeerSwap.PauseSwapSchedule:output_type -> peerswap.SwapSchedule
+	34, // 96: peerswap.PeerSwap.DeleteSwapSchedule:output_type -> peerswap.DeleteSwapScheduleResponse
+	64, // 97: peerswap.PeerSwap.AllowSwapRequests:output_type -> peerswap.Policy
+	64, // 98: peerswap.PeerSwap.ReloadPolicyFile:output_type -> peerswap.Policy
+	64, // 99: peerswap.PeerSwap.AddPeer:output_type -> peerswap.Policy
+	64, // 100: peerswap.PeerSwap.RemovePeer:output_type -> peerswap.Policy
+	64, // 101: peerswap.PeerSwap.AddSusPeer:output_type -> peerswap.Policy
+	64, // 102: peerswap.PeerSwap.RemoveSusPeer:output_type -> peerswap.Policy
+	4,  // 103: peerswap.PeerSwap.LiquidGetAddress:output_type -> peerswap.GetAddressResponse
+	6,  // 104: peerswap.PeerSwap.LiquidGetBalance:output_type -> peerswap.GetBalanceResponse
+	8,  // 105: peerswap.PeerSwap.LiquidSendToAddress:output_type -> peerswap.SendToAddressResponse
+	68, // 106: peerswap.PeerSwap.GetGlobalPremiumRate:output_type -> peerswap.PremiumRate
+	68, // 107: peerswap.PeerSwap.UpdateGlobalPremiumRate:output_type -> peerswap.PremiumRate
+	68, // 108: peerswap.PeerSwap.GetPremiumRate:output_type -> peerswap.PremiumRate
+	68, // 109: peerswap.PeerSwap.UpdatePremiumRate:output_type -> peerswap.PremiumRate
+	68, // 110: peerswap.PeerSwap.DeletePremiumRate:output_type -> peerswap.PremiumRate
+	37, // 111: peerswap.PeerSwap.Drain:output_type -> peerswap.DrainResponse
+	40, // 112: peerswap.PeerSwap.GetStatus:output_type -> peerswap.GetStatusResponse
+	67, // 113: peerswap.PeerSwap.Stop:output_type -> peerswap.Empty
+	78, // [78:114] is the sub-list for method output_type
+	42, // [42:78] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_peerswaprpc_proto_init() }
@@ -2957,8 +6219,212 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAddressResponse); i {
+		file_peerswaprpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendToAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendToAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapOutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapOutResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapInRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSwapIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapInBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSwapInResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapInBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreflightSwapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreflightCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreflightSwapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueuedSwap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListQueuedSwapsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2969,8 +6435,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBalanceRequest); i {
+		file_peerswaprpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListQueuedSwapsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2981,8 +6447,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBalanceResponse); i {
+		file_peerswaprpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeferredSwapsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2993,8 +6459,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendToAddressRequest); i {
+		file_peerswaprpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeferredSwapsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3005,8 +6471,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendToAddressResponse); i {
+		file_peerswaprpc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveQueuedSwapRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3017,8 +6483,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapOutRequest); i {
+		file_peerswaprpc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveQueuedSwapResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3029,8 +6495,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapOutResponse); i {
+		file_peerswaprpc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSwapScheduleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3041,8 +6507,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapInRequest); i {
+		file_peerswaprpc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapSchedule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3053,8 +6519,8 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapResponse); i {
+		file_peerswaprpc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRun); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3065,7 +6531,163 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSwapSchedulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseSwapScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSwapScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSwapScheduleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainingSwap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ElectrumEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelSelection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelChoice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSwapRequest); i {
 			case 0:
 				return &v.state
@@ -3077,7 +6699,67 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalizeSwapFundingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrySwapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSwapHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSwapHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapStateTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_peerswaprpc_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListSwapsRequest); i {
 			case 0:
 				return &v.state
@@ -3089,7 +6771,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListSwapsResponse); i {
 			case 0:
 				return &v.state
@@ -3101,7 +6783,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListPeersRequest); i {
 			case 0:
 				return &v.state
@@ -3113,7 +6795,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListPeersResponse); i {
 			case 0:
 				return &v.state
@@ -3125,7 +6807,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReloadPolicyFileRequest); i {
 			case 0:
 				return &v.state
@@ -3137,7 +6819,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddPeerRequest); i {
 			case 0:
 				return &v.state
@@ -3149,7 +6831,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemovePeerRequest); i {
 			case 0:
 				return &v.state
@@ -3161,7 +6843,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListRequestedSwapsRequest); i {
 			case 0:
 				return &v.state
@@ -3173,7 +6855,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListRequestedSwapsResponse); i {
 			case 0:
 				return &v.state
@@ -3185,7 +6867,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RequestSwapList); i {
 			case 0:
 				return &v.state
@@ -3197,7 +6879,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RequestedSwap); i {
 			case 0:
 				return &v.state
@@ -3209,7 +6891,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PrettyPrintSwap); i {
 			case 0:
 				return &v.state
@@ -3221,7 +6903,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerSwapPeer); i {
 			case 0:
 				return &v.state
@@ -3233,7 +6915,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerSwapPeerChannel); i {
 			case 0:
 				return &v.state
@@ -3245,7 +6927,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SwapStats); i {
 			case 0:
 				return &v.state
@@ -3257,7 +6939,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Policy); i {
 			case 0:
 				return &v.state
@@ -3269,7 +6951,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AllowSwapRequestsRequest); i {
 			case 0:
 				return &v.state
@@ -3281,7 +6963,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AllowSwapRequestsResponse); i {
 			case 0:
 				return &v.state
@@ -3293,7 +6975,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Empty); i {
 			case 0:
 				return &v.state
@@ -3305,7 +6987,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PremiumRate); i {
 			case 0:
 				return &v.state
@@ -3317,7 +6999,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerPremium); i {
 			case 0:
 				return &v.state
@@ -3329,7 +7011,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetPremiumRateRequest); i {
 			case 0:
 				return &v.state
@@ -3341,7 +7023,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeletePremiumRateRequest); i {
 			case 0:
 				return &v.state
@@ -3353,7 +7035,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdatePremiumRateRequest); i {
 			case 0:
 				return &v.state
@@ -3365,7 +7047,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetGlobalPremiumRateRequest); i {
 			case 0:
 				return &v.state
@@ -3377,7 +7059,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateGlobalPremiumRateRequest); i {
 			case 0:
 				return &v.state
@@ -3389,7 +7071,7 @@ func file_peerswaprpc_proto_init() {
 				return nil
 			}
 		}
-		file_peerswaprpc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_peerswaprpc_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerSwapNodes); i {
 			case 0:
 				return &v.state
@@ -3408,7 +7090,7 @@ func file_peerswaprpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_peerswaprpc_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   39,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
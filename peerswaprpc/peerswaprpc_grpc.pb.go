@@ -20,11 +20,39 @@ const _ = grpc.SupportPackageIsVersion7
 type PeerSwapClient interface {
 	SwapOut(ctx context.Context, in *SwapOutRequest, opts ...grpc.CallOption) (*SwapResponse, error)
 	SwapIn(ctx context.Context, in *SwapInRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	// Start swap-ins with several peers that share a single opening
+	// transaction. The transaction is created once every peer agreed.
+	SwapInBatch(ctx context.Context, in *SwapInBatchRequest, opts ...grpc.CallOption) (*SwapInBatchResponse, error)
+	// Run the local checks of a swap and estimate its fees without starting the
+	// swap or sending any message to the peer.
+	PreflightSwap(ctx context.Context, in *PreflightSwapRequest, opts ...grpc.CallOption) (*PreflightSwapResponse, error)
 	GetSwap(ctx context.Context, in *GetSwapRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	// Start a new swap with the parameters of a finished swap that did not
+	// succeed. The new swap is linked to the original one.
+	RetrySwap(ctx context.Context, in *RetrySwapRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	// Submit the signed opening transaction of a swap-in that was started with
+	// external_funding. The transaction is validated and broadcast.
+	FinalizeSwapFunding(ctx context.Context, in *FinalizeSwapFundingRequest, opts ...grpc.CallOption) (*SwapResponse, error)
 	ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error)
 	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
 	ListRequestedSwaps(ctx context.Context, in *ListRequestedSwapsRequest, opts ...grpc.CallOption) (*ListRequestedSwapsResponse, error)
 	ListActiveSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error)
+	// Get a swap together with its recorded state transitions.
+	GetSwapHistory(ctx context.Context, in *GetSwapHistoryRequest, opts ...grpc.CallOption) (*GetSwapHistoryResponse, error)
+	// List the swaps that wait for their channel to become free.
+	ListQueuedSwaps(ctx context.Context, in *ListQueuedSwapsRequest, opts ...grpc.CallOption) (*ListQueuedSwapsResponse, error)
+	// Remove a queued or deferred swap before it is started.
+	RemoveQueuedSwap(ctx context.Context, in *RemoveQueuedSwapRequest, opts ...grpc.CallOption) (*RemoveQueuedSwapResponse, error)
+	// List the swaps that wait for their peer to come online.
+	ListDeferredSwaps(ctx context.Context, in *ListDeferredSwapsRequest, opts ...grpc.CallOption) (*ListDeferredSwapsResponse, error)
+	// schedules
+	// Create a one-shot or recurring swap schedule.
+	CreateSwapSchedule(ctx context.Context, in *CreateSwapScheduleRequest, opts ...grpc.CallOption) (*SwapSchedule, error)
+	// List the swap schedules together with their run history.
+	ListSwapSchedules(ctx context.Context, in *ListSwapSchedulesRequest, opts ...grpc.CallOption) (*ListSwapSchedulesResponse, error)
+	// Pause or resume a swap schedule.
+	PauseSwapSchedule(ctx context.Context, in *PauseSwapScheduleRequest, opts ...grpc.CallOption) (*SwapSchedule, error)
+	DeleteSwapSchedule(ctx context.Context, in *DeleteSwapScheduleRequest, opts ...grpc.CallOption) (*DeleteSwapScheduleResponse, error)
 	// policy
 	AllowSwapRequests(ctx context.Context, in *AllowSwapRequestsRequest, opts ...grpc.CallOption) (*Policy, error)
 	ReloadPolicyFile(ctx context.Context, in *ReloadPolicyFileRequest, opts ...grpc.CallOption) (*Policy, error)
@@ -46,6 +74,12 @@ type PeerSwapClient interface {
 	UpdatePremiumRate(ctx context.Context, in *UpdatePremiumRateRequest, opts ...grpc.CallOption) (*PremiumRate, error)
 	// Delete a premium rate for a specific peer, asset, and operation.
 	DeletePremiumRate(ctx context.Context, in *DeletePremiumRateRequest, opts ...grpc.CallOption) (*PremiumRate, error)
+	// Drain blocks incoming swap requests and new outgoing swaps until the
+	// active swaps are done, for example before an upgrade. Call it with status
+	// to get the progress of the drain.
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	// GetStatus returns the health of the chain backends.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
 	Stop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
@@ -75,6 +109,24 @@ func (c *peerSwapClient) SwapIn(ctx context.Context, in *SwapInRequest, opts ...
 	return out, nil
 }
 
+func (c *peerSwapClient) SwapInBatch(ctx context.Context, in *SwapInBatchRequest, opts ...grpc.CallOption) (*SwapInBatchResponse, error) {
+	out := new(SwapInBatchResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/SwapInBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) PreflightSwap(ctx context.Context, in *PreflightSwapRequest, opts ...grpc.CallOption) (*PreflightSwapResponse, error) {
+	out := new(PreflightSwapResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/PreflightSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerSwapClient) GetSwap(ctx context.Context, in *GetSwapRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
 	out := new(SwapResponse)
 	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/GetSwap", in, out, opts...)
@@ -84,6 +136,24 @@ func (c *peerSwapClient) GetSwap(ctx context.Context, in *GetSwapRequest, opts .
 	return out, nil
 }
 
+func (c *peerSwapClient) RetrySwap(ctx context.Context, in *RetrySwapRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
+	out := new(SwapResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/RetrySwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) FinalizeSwapFunding(ctx context.Context, in *FinalizeSwapFundingRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
+	out := new(SwapResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/FinalizeSwapFunding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerSwapClient) ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error) {
 	out := new(ListSwapsResponse)
 	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/ListSwaps", in, out, opts...)
@@ -120,6 +190,78 @@ func (c *peerSwapClient) ListActiveSwaps(ctx context.Context, in *ListSwapsReque
 	return out, nil
 }
 
+func (c *peerSwapClient) GetSwapHistory(ctx context.Context, in *GetSwapHistoryRequest, opts ...grpc.CallOption) (*GetSwapHistoryResponse, error) {
+	out := new(GetSwapHistoryResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/GetSwapHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) ListQueuedSwaps(ctx context.Context, in *ListQueuedSwapsRequest, opts ...grpc.CallOption) (*ListQueuedSwapsResponse, error) {
+	out := new(ListQueuedSwapsResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/ListQueuedSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) RemoveQueuedSwap(ctx context.Context, in *RemoveQueuedSwapRequest, opts ...grpc.CallOption) (*RemoveQueuedSwapResponse, error) {
+	out := new(RemoveQueuedSwapResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/RemoveQueuedSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) ListDeferredSwaps(ctx context.Context, in *ListDeferredSwapsRequest, opts ...grpc.CallOption) (*ListDeferredSwapsResponse, error) {
+	out := new(ListDeferredSwapsResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/ListDeferredSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) CreateSwapSchedule(ctx context.Context, in *CreateSwapScheduleRequest, opts ...grpc.CallOption) (*SwapSchedule, error) {
+	out := new(SwapSchedule)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/CreateSwapSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) ListSwapSchedules(ctx context.Context, in *ListSwapSchedulesRequest, opts ...grpc.CallOption) (*ListSwapSchedulesResponse, error) {
+	out := new(ListSwapSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/ListSwapSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) PauseSwapSchedule(ctx context.Context, in *PauseSwapScheduleRequest, opts ...grpc.CallOption) (*SwapSchedule, error) {
+	out := new(SwapSchedule)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/PauseSwapSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) DeleteSwapSchedule(ctx context.Context, in *DeleteSwapScheduleRequest, opts ...grpc.CallOption) (*DeleteSwapScheduleResponse, error) {
+	out := new(DeleteSwapScheduleResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/DeleteSwapSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerSwapClient) AllowSwapRequests(ctx context.Context, in *AllowSwapRequestsRequest, opts ...grpc.CallOption) (*Policy, error) {
 	out := new(Policy)
 	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/AllowSwapRequests", in, out, opts...)
@@ -246,6 +388,24 @@ func (c *peerSwapClient) DeletePremiumRate(ctx context.Context, in *DeletePremiu
 	return out, nil
 }
 
+func (c *peerSwapClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerSwapClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerSwapClient) Stop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/peerswap.PeerSwap/Stop", in, out, opts...)
@@ -261,11 +421,39 @@ func (c *peerSwapClient) Stop(ctx context.Context, in *Empty, opts ...grpc.CallO
 type PeerSwapServer interface {
 	SwapOut(context.Context, *SwapOutRequest) (*SwapResponse, error)
 	SwapIn(context.Context, *SwapInRequest) (*SwapResponse, error)
+	// Start swap-ins with several peers that share a single opening
+	// transaction. The transaction is created once every peer agreed.
+	SwapInBatch(context.Context, *SwapInBatchRequest) (*SwapInBatchResponse, error)
+	// Run the local checks of a swap and estimate its fees without starting the
+	// swap or sending any message to the peer.
+	PreflightSwap(context.Context, *PreflightSwapRequest) (*PreflightSwapResponse, error)
 	GetSwap(context.Context, *GetSwapRequest) (*SwapResponse, error)
+	// Start a new swap with the parameters of a finished swap that did not
+	// succeed. The new swap is linked to the original one.
+	RetrySwap(context.Context, *RetrySwapRequest) (*SwapResponse, error)
+	// Submit the signed opening transaction of a swap-in that was started with
+	// external_funding. The transaction is validated and broadcast.
+	FinalizeSwapFunding(context.Context, *FinalizeSwapFundingRequest) (*SwapResponse, error)
 	ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error)
 	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
 	ListRequestedSwaps(context.Context, *ListRequestedSwapsRequest) (*ListRequestedSwapsResponse, error)
 	ListActiveSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error)
+	// Get a swap together with its recorded state transitions.
+	GetSwapHistory(context.Context, *GetSwapHistoryRequest) (*GetSwapHistoryResponse, error)
+	// List the swaps that wait for their channel to become free.
+	ListQueuedSwaps(context.Context, *ListQueuedSwapsRequest) (*ListQueuedSwapsResponse, error)
+	// Remove a queued or deferred swap before it is started.
+	RemoveQueuedSwap(context.Context, *RemoveQueuedSwapRequest) (*RemoveQueuedSwapResponse, error)
+	// List the swaps that wait for their peer to come online.
+	ListDeferredSwaps(context.Context, *ListDeferredSwapsRequest) (*ListDeferredSwapsResponse, error)
+	// schedules
+	// Create a one-shot or recurring swap schedule.
+	CreateSwapSchedule(context.Context, *CreateSwapScheduleRequest) (*SwapSchedule, error)
+	// List the swap schedules together with their run history.
+	ListSwapSchedules(context.Context, *ListSwapSchedulesRequest) (*ListSwapSchedulesResponse, error)
+	// Pause or resume a swap schedule.
+	PauseSwapSchedule(context.Context, *PauseSwapScheduleRequest) (*SwapSchedule, error)
+	DeleteSwapSchedule(context.Context, *DeleteSwapScheduleRequest) (*DeleteSwapScheduleResponse, error)
 	// policy
 	AllowSwapRequests(context.Context, *AllowSwapRequestsRequest) (*Policy, error)
 	ReloadPolicyFile(context.Context, *ReloadPolicyFileRequest) (*Policy, error)
@@ -287,6 +475,12 @@ type PeerSwapServer interface {
 	UpdatePremiumRate(context.Context, *UpdatePremiumRateRequest) (*PremiumRate, error)
 	// Delete a premium rate for a specific peer, asset, and operation.
 	DeletePremiumRate(context.Context, *DeletePremiumRateRequest) (*PremiumRate, error)
+	// Drain blocks incoming swap requests and new outgoing swaps until the
+	// active swaps are done, for example before an upgrade. Call it with status
+	// to get the progress of the drain.
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+	// GetStatus returns the health of the chain backends.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
 	Stop(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedPeerSwapServer()
 }
@@ -301,9 +495,21 @@ func (UnimplementedPeerSwapServer) SwapOut(context.Context, *SwapOutRequest) (*S
 func (UnimplementedPeerSwapServer) SwapIn(context.Context, *SwapInRequest) (*SwapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SwapIn not implemented")
 }
+func (UnimplementedPeerSwapServer) SwapInBatch(context.Context, *SwapInBatchRequest) (*SwapInBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapInBatch not implemented")
+}
+func (UnimplementedPeerSwapServer) PreflightSwap(context.Context, *PreflightSwapRequest) (*PreflightSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreflightSwap not implemented")
+}
 func (UnimplementedPeerSwapServer) GetSwap(context.Context, *GetSwapRequest) (*SwapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSwap not implemented")
 }
+func (UnimplementedPeerSwapServer) RetrySwap(context.Context, *RetrySwapRequest) (*SwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrySwap not implemented")
+}
+func (UnimplementedPeerSwapServer) FinalizeSwapFunding(context.Context, *FinalizeSwapFundingRequest) (*SwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeSwapFunding not implemented")
+}
 func (UnimplementedPeerSwapServer) ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSwaps not implemented")
 }
@@ -316,6 +522,30 @@ func (UnimplementedPeerSwapServer) ListRequestedSwaps(context.Context, *ListRequ
 func (UnimplementedPeerSwapServer) ListActiveSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListActiveSwaps not implemented")
 }
+func (UnimplementedPeerSwapServer) GetSwapHistory(context.Context, *GetSwapHistoryRequest) (*GetSwapHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwapHistory not implemented")
+}
+func (UnimplementedPeerSwapServer) ListQueuedSwaps(context.Context, *ListQueuedSwapsRequest) (*ListQueuedSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueuedSwaps not implemented")
+}
+func (UnimplementedPeerSwapServer) RemoveQueuedSwap(context.Context, *RemoveQueuedSwapRequest) (*RemoveQueuedSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveQueuedSwap not implemented")
+}
+func (UnimplementedPeerSwapServer) ListDeferredSwaps(context.Context, *ListDeferredSwapsRequest) (*ListDeferredSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeferredSwaps not implemented")
+}
+func (UnimplementedPeerSwapServer) CreateSwapSchedule(context.Context, *CreateSwapScheduleRequest) (*SwapSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSwapSchedule not implemented")
+}
+func (UnimplementedPeerSwapServer) ListSwapSchedules(context.Context, *ListSwapSchedulesRequest) (*ListSwapSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSwapSchedules not implemented")
+}
+func (UnimplementedPeerSwapServer) PauseSwapSchedule(context.Context, *PauseSwapScheduleRequest) (*SwapSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSwapSchedule not implemented")
+}
+func (UnimplementedPeerSwapServer) DeleteSwapSchedule(context.Context, *DeleteSwapScheduleRequest) (*DeleteSwapScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSwapSchedule not implemented")
+}
 func (UnimplementedPeerSwapServer) AllowSwapRequests(context.Context, *AllowSwapRequestsRequest) (*Policy, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AllowSwapRequests not implemented")
 }
@@ -358,6 +588,12 @@ func (UnimplementedPeerSwapServer) UpdatePremiumRate(context.Context, *UpdatePre
 func (UnimplementedPeerSwapServer) DeletePremiumRate(context.Context, *DeletePremiumRateRequest) (*PremiumRate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePremiumRate not implemented")
 }
+func (UnimplementedPeerSwapServer) Drain(context.Context, *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedPeerSwapServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
 func (UnimplementedPeerSwapServer) Stop(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
@@ -410,6 +646,42 @@ func _PeerSwap_SwapIn_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerSwap_SwapInBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapInBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).SwapInBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/SwapInBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).SwapInBatch(ctx, req.(*SwapInBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_PreflightSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreflightSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).PreflightSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/PreflightSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).PreflightSwap(ctx, req.(*PreflightSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerSwap_GetSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSwapRequest)
 	if err := dec(in); err != nil {
@@ -428,6 +700,42 @@ func _PeerSwap_GetSwap_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerSwap_RetrySwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrySwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).RetrySwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/RetrySwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).RetrySwap(ctx, req.(*RetrySwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_FinalizeSwapFunding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeSwapFundingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).FinalizeSwapFunding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/FinalizeSwapFunding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).FinalizeSwapFunding(ctx, req.(*FinalizeSwapFundingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerSwap_ListSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSwapsRequest)
 	if err := dec(in); err != nil {
@@ -500,6 +808,150 @@ func _PeerSwap_ListActiveSwaps_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerSwap_GetSwapHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSwapHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).GetSwapHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/GetSwapHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).GetSwapHistory(ctx, req.(*GetSwapHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_ListQueuedSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueuedSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).ListQueuedSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/ListQueuedSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).ListQueuedSwaps(ctx, req.(*ListQueuedSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_RemoveQueuedSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveQueuedSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).RemoveQueuedSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/RemoveQueuedSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).RemoveQueuedSwap(ctx, req.(*RemoveQueuedSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_ListDeferredSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeferredSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).ListDeferredSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/ListDeferredSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).ListDeferredSwaps(ctx, req.(*ListDeferredSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_CreateSwapSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSwapScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).CreateSwapSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/CreateSwapSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).CreateSwapSchedule(ctx, req.(*CreateSwapScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_ListSwapSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSwapSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).ListSwapSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/ListSwapSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).ListSwapSchedules(ctx, req.(*ListSwapSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_PauseSwapSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseSwapScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).PauseSwapSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/PauseSwapSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).PauseSwapSchedule(ctx, req.(*PauseSwapScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_DeleteSwapSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSwapScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).DeleteSwapSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/DeleteSwapSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).DeleteSwapSchedule(ctx, req.(*DeleteSwapScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerSwap_AllowSwapRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AllowSwapRequestsRequest)
 	if err := dec(in); err != nil {
@@ -752,6 +1204,42 @@ func _PeerSwap_DeletePremiumRate_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerSwap_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerSwap_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSwapServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/peerswap.PeerSwap/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSwapServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerSwap_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -785,10 +1273,26 @@ var PeerSwap_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SwapIn",
 			Handler:    _PeerSwap_SwapIn_Handler,
 		},
+		{
+			MethodName: "SwapInBatch",
+			Handler:    _PeerSwap_SwapInBatch_Handler,
+		},
+		{
+			MethodName: "PreflightSwap",
+			Handler:    _PeerSwap_PreflightSwap_Handler,
+		},
 		{
 			MethodName: "GetSwap",
 			Handler:    _PeerSwap_GetSwap_Handler,
 		},
+		{
+			MethodName: "RetrySwap",
+			Handler:    _PeerSwap_RetrySwap_Handler,
+		},
+		{
+			MethodName: "FinalizeSwapFunding",
+			Handler:    _PeerSwap_FinalizeSwapFunding_Handler,
+		},
 		{
 			MethodName: "ListSwaps",
 			Handler:    _PeerSwap_ListSwaps_Handler,
@@ -805,6 +1309,38 @@ var PeerSwap_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListActiveSwaps",
 			Handler:    _PeerSwap_ListActiveSwaps_Handler,
 		},
+		{
+			MethodName: "GetSwapHistory",
+			Handler:    _PeerSwap_GetSwapHistory_Handler,
+		},
+		{
+			MethodName: "ListQueuedSwaps",
+			Handler:    _PeerSwap_ListQueuedSwaps_Handler,
+		},
+		{
+			MethodName: "RemoveQueuedSwap",
+			Handler:    _PeerSwap_RemoveQueuedSwap_Handler,
+		},
+		{
+			MethodName: "ListDeferredSwaps",
+			Handler:    _PeerSwap_ListDeferredSwaps_Handler,
+		},
+		{
+			MethodName: "CreateSwapSchedule",
+			Handler:    _PeerSwap_CreateSwapSchedule_Handler,
+		},
+		{
+			MethodName: "ListSwapSchedules",
+			Handler:    _PeerSwap_ListSwapSchedules_Handler,
+		},
+		{
+			MethodName: "PauseSwapSchedule",
+			Handler:    _PeerSwap_PauseSwapSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteSwapSchedule",
+			Handler:    _PeerSwap_DeleteSwapSchedule_Handler,
+		},
 		{
 			MethodName: "AllowSwapRequests",
 			Handler:    _PeerSwap_AllowSwapRequests_Handler,
@@ -861,6 +1397,14 @@ var PeerSwap_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeletePremiumRate",
 			Handler:    _PeerSwap_DeletePremiumRate_Handler,
 		},
+		{
+			MethodName: "Drain",
+			Handler:    _PeerSwap_Drain_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _PeerSwap_GetStatus_Handler,
+		},
 		{
 			MethodName: "Stop",
 			Handler:    _PeerSwap_Stop_Handler,
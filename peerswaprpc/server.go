@@ -11,11 +11,13 @@ import (
 	"time"
 
 	"github.com/elementsproject/glightning/gelements"
+	"github.com/elementsproject/peerswap/electrum"
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/peersync"
 	"github.com/elementsproject/peerswap/peersync/format"
 	"github.com/elementsproject/peerswap/policy"
 	"github.com/elementsproject/peerswap/premium"
+	"github.com/elementsproject/peerswap/scheduler"
 	"github.com/samber/lo"
 
 	"github.com/elementsproject/peerswap/swap"
@@ -32,6 +34,9 @@ type PeerswapServer struct {
 	peerStore      *peersync.Store
 	policy         *policy.Policy
 	ps             *premium.Setting
+	scheduler      *scheduler.Scheduler
+	drainer        *Drainer
+	liquidElectrum electrum.RPC
 
 	lnd lnrpc.LightningClient
 
@@ -78,6 +83,15 @@ func (p *PeerswapServer) RemoveSusPeer(ctx context.Context, request *RemovePeerR
 	return GetPolicyMessage(pol), nil
 }
 
+func (p *PeerswapServer) Drain(ctx context.Context, request *DrainRequest) (*DrainResponse, error) {
+	return p.drainer.Drain(request), nil
+}
+
+func (p *PeerswapServer) GetStatus(ctx context.Context, request *GetStatusRequest) (*GetStatusResponse, error) {
+	// Bitcoin uses the chain backend of lnd.
+	return GetStatusMessage(p.liquidElectrum, nil), nil
+}
+
 func (p *PeerswapServer) Stop(ctx context.Context, empty *Empty) (*Empty, error) {
 	p.sigchan <- os.Interrupt
 	return &Empty{}, nil
@@ -92,8 +106,10 @@ func NewPeerswapServer(
 	peerStore *peersync.Store,
 	policy *policy.Policy,
 	gelements *gelements.Elements,
+	liquidElectrum electrum.RPC,
 	lnd lnrpc.LightningClient,
 	ps *premium.Setting,
+	scheduler *scheduler.Scheduler,
 	sigchan chan os.Signal,
 ) *PeerswapServer {
 	return &PeerswapServer{
@@ -105,36 +121,99 @@ func NewPeerswapServer(
 		policy:         policy,
 		lnd:            lnd,
 		ps:             ps,
-		sigchan:        sigchan,
+		scheduler:      scheduler,
+		liquidElectrum: liquidElectrum,
+		drainer: NewDrainer(swaps, func() {
+			sigchan <- os.Interrupt
+		}),
+		sigchan: sigchan,
 	}
 }
 
 func (p *PeerswapServer) SwapOut(ctx context.Context, request *SwapOutRequest) (*SwapResponse, error) {
-	if request.SwapAmount <= 0 {
+	return p.swapOut(ctx, request)
+}
+
+func (p *PeerswapServer) swapOut(ctx context.Context, request *SwapOutRequest, opts ...swap.SwapOption) (*SwapResponse, error) {
+	if request.SwapAmount <= 0 && !request.Max {
 		return nil, errors.New("Missing required swap_amount parameter")
 	}
-	if request.ChannelId == 0 {
-		return nil, errors.New("Missing required channel_id parameter")
+	if request.ChannelId == 0 && request.PeerPubkey == "" {
+		return nil, errors.New("Missing required channel_id or peer_pubkey parameter")
+	}
+	if request.IdempotencyKey != "" {
+		defer p.swaps.LockIdempotencyKey(request.IdempotencyKey)()
+		resp, err := p.idempotentSwapResponse(request.IdempotencyKey, swap.SWAPTYPE_OUT)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+		opts = append(opts, swap.WithIdempotencyKey(request.IdempotencyKey))
+	}
+	if request.ClaimAddress != "" {
+		opts = append(opts, swap.WithClaimAddress(request.ClaimAddress))
+	}
+	feeRate, err := swap.NewFeeRate(request.SatPerVbyte, request.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	if feeRate != nil {
+		opts = append(opts, swap.WithFeeRate(feeRate))
+	}
+	channelId := request.ChannelId
+	var selection *ChannelSelection
+	if channelId == 0 {
+		var err error
+		channelId, selection, err = p.selectChannel(ctx, request.PeerPubkey,
+			func(candidates []swap.ChannelCandidate) (string, []*swap.ChannelChoice, error) {
+				return p.swaps.SelectSwapOutChannel(request.Asset, candidates, request.SwapAmount)
+			})
+		if err != nil {
+			return nil, err
+		}
 	}
 	var swapchan *lnrpc.Channel
-	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{ActiveOnly: true})
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{ActiveOnly: !request.DeferUntilOnline})
 	if err != nil {
 		return nil, err
 	}
 	for _, v := range chans.Channels {
-		if v.ChanId == request.ChannelId {
+		if v.ChanId == channelId {
 			swapchan = v
 		}
 	}
 	if swapchan == nil {
 		return nil, errors.New("channel not found")
 	}
+	// The channel of a deferred swap is inactive while the peer is offline.
+	deferSwap := request.DeferUntilOnline && !p.isPeerConnected(ctx, swapchan.RemotePubkey)
+
+	localBalance := uint64(swapchan.LocalBalance)
+	if len(request.ExtraChannelIds) > 0 {
+		if request.Max {
+			return nil, errors.New("max can not be combined with extra channels")
+		}
+		extraScids, extraBalance, err := extraSwapOutChannels(chans.Channels, swapchan, request.ExtraChannelIds, deferSwap)
+		if err != nil {
+			return nil, err
+		}
+		localBalance += extraBalance
+		opts = append(opts, swap.WithExtraChannels(extraScids...))
+	}
 
-	if uint64(swapchan.LocalBalance) < (request.SwapAmount + 5000) {
+	swapAmount := request.SwapAmount
+	if request.Max {
+		scid := lnwire.NewShortChanIDFromInt(swapchan.ChanId)
+		swapAmount, err = p.swaps.MaxSwapOutAmountSat(request.Asset, scid.String(), request.LeaveLocalSat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if localBalance < (swapAmount + 5000) {
 		return nil, errors.New("not enough local balance on channel to perform swap out")
 	}
 
-	if !swapchan.Active {
+	if !swapchan.Active && !deferSwap {
 		return nil, errors.New("channel is not connected")
 	}
 
@@ -169,13 +248,34 @@ func (p *PeerswapServer) SwapOut(ctx context.Context, request *SwapOutRequest) (
 		}
 	}
 
+	if deferSwap {
+		deferred, err := p.swaps.DeferSwapOut(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(),
+			time.Duration(request.DeferExpirySec)*time.Second, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &SwapResponse{QueuedSwap: QueuedSwapFromServiceQueuedSwap(deferred), ChannelSelection: selection}, nil
+	}
+
 	if !p.isPeerConnected(ctx, peerId) {
 		return nil, fmt.Errorf("peer is not connected")
 	}
 
-	swapOut, err := p.swaps.SwapOut(peerId, request.Asset, shortId.String(), pk, request.SwapAmount, request.GetPremiumLimitRatePpm())
-	if err != nil {
-		return nil, err
+	var swapOut *swap.SwapStateMachine
+	if request.Queue {
+		var queued *swap.QueuedSwap
+		swapOut, queued, err = p.swaps.QueueSwapOut(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(), opts...)
+		if err != nil {
+			return nil, err
+		}
+		if queued != nil {
+			return &SwapResponse{QueuedSwap: QueuedSwapFromServiceQueuedSwap(queued), ChannelSelection: selection}, nil
+		}
+	} else {
+		swapOut, err = p.swaps.SwapOut(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(), opts...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// In order to be responsive we wait for the `opening_tx` to be sent before
 	// we return. Time out if we wait too long.
@@ -196,7 +296,53 @@ func (p *PeerswapServer) SwapOut(ctx context.Context, request *SwapOutRequest) (
 	if err != nil {
 		return nil, err
 	}
-	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapOut)}, nil
+	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapOut), ChannelSelection: selection}, nil
+}
+
+// idempotentSwapResponse returns the response for the swap that was started
+// with the idempotency key, or nil if there is none.
+func (p *PeerswapServer) idempotentSwapResponse(key string, swapType swap.SwapType) (*SwapResponse, error) {
+	swp, queued, err := p.swaps.FindByIdempotencyKey(key, swapType)
+	if err != nil {
+		return nil, err
+	}
+	if swp != nil {
+		return &SwapResponse{Swap: PrettyprintFromServiceSwap(swp)}, nil
+	}
+	if queued != nil {
+		return &SwapResponse{QueuedSwap: QueuedSwapFromServiceQueuedSwap(queued)}, nil
+	}
+	return nil, nil
+}
+
+// selectChannel chooses one of the channels to the peer with selectFn. It
+// returns the lnd channel id of the chosen channel and an explanation of the
+// choice.
+func (p *PeerswapServer) selectChannel(ctx context.Context, peerId string,
+	selectFn func([]swap.ChannelCandidate) (string, []*swap.ChannelChoice, error)) (uint64, *ChannelSelection, error) {
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return 0, nil, err
+	}
+	var candidates []swap.ChannelCandidate
+	chanIds := map[string]uint64{}
+	for _, ch := range chans.Channels {
+		if ch.RemotePubkey != peerId {
+			continue
+		}
+		scid := lnwire.NewShortChanIDFromInt(ch.ChanId).String()
+		chanIds[scid] = ch.ChanId
+		candidates = append(candidates, swap.ChannelCandidate{ChannelId: scid, Active: ch.Active})
+	}
+	if len(candidates) == 0 {
+		return 0, nil, errors.New("no channel to peer found")
+	}
+
+	chosen, choices, err := selectFn(candidates)
+	if err != nil {
+		return 0, nil, ChannelSelectionError(err, choices)
+	}
+	return chanIds[chosen], ChannelSelectionFromChoices(chosen, choices), nil
 }
 
 // isPeerConnected returns true if the peer is connected to the lnd node.
@@ -216,26 +362,114 @@ func (p *PeerswapServer) isPeerConnected(ctx context.Context, peerId string) boo
 	return false
 }
 
+// extraSwapOutChannels returns the short channel ids and the summed local
+// balance of the extra channels of a swap-out. They must be further active
+// channels to the peer of the swap channel.
+func extraSwapOutChannels(channels []*lnrpc.Channel, swapchan *lnrpc.Channel, extraIds []uint64, allowInactive bool) ([]string, uint64, error) {
+	seen := map[uint64]bool{swapchan.ChanId: true}
+	var scids []string
+	var localBalance uint64
+	for _, id := range extraIds {
+		if seen[id] {
+			return nil, 0, fmt.Errorf("channel %d is used twice", id)
+		}
+		seen[id] = true
+		var extra *lnrpc.Channel
+		for _, v := range channels {
+			if v.ChanId == id {
+				extra = v
+			}
+		}
+		if extra == nil {
+			return nil, 0, fmt.Errorf("channel %d not found", id)
+		}
+		if extra.RemotePubkey != swapchan.RemotePubkey {
+			return nil, 0, fmt.Errorf("channel %d is not a channel to peer %s", id, swapchan.RemotePubkey)
+		}
+		if !extra.Active && !allowInactive {
+			return nil, 0, fmt.Errorf("channel %d is not connected", id)
+		}
+		scids = append(scids, lnwire.NewShortChanIDFromInt(id).String())
+		localBalance += uint64(extra.LocalBalance)
+	}
+	return scids, localBalance, nil
+}
+
 func (p *PeerswapServer) SwapIn(ctx context.Context, request *SwapInRequest) (*SwapResponse, error) {
+	return p.swapIn(ctx, request)
+}
+
+func (p *PeerswapServer) swapIn(ctx context.Context, request *SwapInRequest, opts ...swap.SwapOption) (*SwapResponse, error) {
+	if request.IdempotencyKey != "" {
+		defer p.swaps.LockIdempotencyKey(request.IdempotencyKey)()
+		resp, err := p.idempotentSwapResponse(request.IdempotencyKey, swap.SWAPTYPE_IN)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+		opts = append(opts, swap.WithIdempotencyKey(request.IdempotencyKey))
+	}
+	if request.ClaimAddress != "" {
+		opts = append(opts, swap.WithClaimAddress(request.ClaimAddress))
+	}
+	feeRate, err := swap.NewFeeRate(request.SatPerVbyte, request.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	if feeRate != nil {
+		opts = append(opts, swap.WithFeeRate(feeRate))
+	}
+	coinSelection, err := swap.NewCoinSelection(request.Utxos, request.ExcludeUtxos)
+	if err != nil {
+		return nil, err
+	}
+	if coinSelection != nil {
+		opts = append(opts, swap.WithCoinSelection(coinSelection))
+	}
+	if request.ExternalFunding {
+		opts = append(opts, swap.WithExternalFunding())
+	}
+	channelId := request.ChannelId
+	var selection *ChannelSelection
+	if channelId == 0 && request.PeerPubkey != "" {
+		var err error
+		channelId, selection, err = p.selectChannel(ctx, request.PeerPubkey,
+			func(candidates []swap.ChannelCandidate) (string, []*swap.ChannelChoice, error) {
+				return p.swaps.SelectSwapInChannel(candidates, request.SwapAmount)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
 	var swapchan *lnrpc.Channel
-	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{ActiveOnly: true})
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{ActiveOnly: !request.DeferUntilOnline})
 	if err != nil {
 		return nil, err
 	}
 	for _, v := range chans.Channels {
-		if v.ChanId == request.ChannelId {
+		if v.ChanId == channelId {
 			swapchan = v
 		}
 	}
 	if swapchan == nil {
 		return nil, errors.New("channel not found")
 	}
+	// The channel of a deferred swap is inactive while the peer is offline.
+	deferSwap := request.DeferUntilOnline && !p.isPeerConnected(ctx, swapchan.RemotePubkey)
 
-	if uint64(swapchan.RemoteBalance) < (request.SwapAmount) {
+	swapAmount := request.SwapAmount
+	if request.Max {
+		scid := lnwire.NewShortChanIDFromInt(swapchan.ChanId)
+		swapAmount, err = p.swaps.MaxSwapInAmountSat(request.Asset, scid.String(), request.LeaveLocalSat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if uint64(swapchan.RemoteBalance) < swapAmount {
 		return nil, errors.New("not enough remote balance on channel to perform swap in")
 	}
 
-	if !swapchan.Active {
+	if !swapchan.Active && !deferSwap {
 		return nil, errors.New("channel is not connected")
 	}
 
@@ -268,13 +502,34 @@ func (p *PeerswapServer) SwapIn(ctx context.Context, request *SwapInRequest) (*S
 		}
 	}
 
+	if deferSwap {
+		deferred, err := p.swaps.DeferSwapIn(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(),
+			time.Duration(request.DeferExpirySec)*time.Second, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &SwapResponse{QueuedSwap: QueuedSwapFromServiceQueuedSwap(deferred), ChannelSelection: selection}, nil
+	}
+
 	if !p.isPeerConnected(ctx, peerId) {
 		return nil, fmt.Errorf("peer is not connected")
 	}
 
-	swapIn, err := p.swaps.SwapIn(peerId, request.Asset, shortId.String(), pk, request.SwapAmount, request.GetPremiumLimitRatePpm())
-	if err != nil {
-		return nil, err
+	var swapIn *swap.SwapStateMachine
+	if request.Queue {
+		var queued *swap.QueuedSwap
+		swapIn, queued, err = p.swaps.QueueSwapIn(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(), opts...)
+		if err != nil {
+			return nil, err
+		}
+		if queued != nil {
+			return &SwapResponse{QueuedSwap: QueuedSwapFromServiceQueuedSwap(queued), ChannelSelection: selection}, nil
+		}
+	} else {
+		swapIn, err = p.swaps.SwapIn(peerId, request.Asset, shortId.String(), pk, swapAmount, request.GetPremiumLimitRatePpm(), opts...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// In order to be responsive we wait for the `opening_tx` to be sent before
 	// we return. Time out if we wait too long.
@@ -295,7 +550,96 @@ func (p *PeerswapServer) SwapIn(ctx context.Context, request *SwapInRequest) (*S
 	if err != nil {
 		return nil, err
 	}
-	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapIn)}, nil
+	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapIn), ChannelSelection: selection}, nil
+}
+
+func (p *PeerswapServer) SwapInBatch(ctx context.Context, request *SwapInBatchRequest) (*SwapInBatchResponse, error) {
+	switch request.Asset {
+	case "lbtc":
+		if !p.swaps.LiquidEnabled {
+			return nil, errors.New("liquid swaps are not enabled")
+		}
+	case "btc":
+		if !p.swaps.BitcoinEnabled {
+			return nil, errors.New("bitcoin swaps are not enabled")
+		}
+	default:
+		return nil, errors.New("invalid asset (btc or lbtc)")
+	}
+
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{ActiveOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	var swaps []*swap.BatchSwapIn
+	for _, entry := range request.Swaps {
+		var swapchan *lnrpc.Channel
+		for _, v := range chans.Channels {
+			if v.ChanId == entry.ChannelId {
+				swapchan = v
+			}
+		}
+		if swapchan == nil {
+			return nil, fmt.Errorf("channel %d not found", entry.ChannelId)
+		}
+		if uint64(swapchan.RemoteBalance) < entry.SwapAmount {
+			return nil, fmt.Errorf("not enough remote balance on channel %d to perform swap in", entry.ChannelId)
+		}
+		peerId := swapchan.RemotePubkey
+		// Skip this test if force flag is set.
+		if !request.Force {
+			if p.peerSync == nil || !p.peerSync.HasCompatiblePeer(peerId) {
+				return nil, fmt.Errorf("peer %s does not run peerswap", peerId)
+			}
+		}
+		if !p.isPeerConnected(ctx, peerId) {
+			return nil, fmt.Errorf("peer %s is not connected", peerId)
+		}
+		swaps = append(swaps, &swap.BatchSwapIn{
+			PeerId:              peerId,
+			ChannelId:           lnwire.NewShortChanIDFromInt(swapchan.ChanId).String(),
+			AmountSat:           entry.SwapAmount,
+			PremiumLimitRatePpm: entry.PremiumLimitRatePpm,
+		})
+	}
+
+	gi, err := p.lnd.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	batchId, results, err := p.swaps.SwapInBatch(request.Asset, gi.IdentityPubkey, swaps)
+	if err != nil {
+		return nil, err
+	}
+
+	// In order to be responsive we wait for the `opening_tx` to be sent before
+	// we return. Time out if we wait too long.
+	deadline := time.Now().Add(30 * time.Second)
+	resp := &SwapInBatchResponse{BatchId: batchId}
+	for _, res := range results {
+		if res.Err != nil {
+			resp.Results = append(resp.Results, &BatchSwapInResult{Error: res.Err.Error()})
+			continue
+		}
+		var errMsg string
+		res.Swap.WaitForStateChange(func(st swap.StateType) bool {
+			switch st {
+			case swap.State_SwapInSender_SendTxBroadcastedMessage:
+				return true
+			case swap.State_SwapCanceled:
+				errMsg = res.Swap.Data.GetCancelMessage()
+				return true
+			default:
+				return false
+			}
+		}, time.Until(deadline))
+		resp.Results = append(resp.Results, &BatchSwapInResult{
+			Swap:  PrettyprintFromServiceSwap(res.Swap),
+			Error: errMsg,
+		})
+	}
+	return resp, nil
 }
 
 func (p *PeerswapServer) GetSwap(ctx context.Context, request *GetSwapRequest) (*SwapResponse, error) {
@@ -309,6 +653,269 @@ func (p *PeerswapServer) GetSwap(ctx context.Context, request *GetSwapRequest) (
 	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapRes)}, nil
 }
 
+func (p *PeerswapServer) PreflightSwap(ctx context.Context, request *PreflightSwapRequest) (*PreflightSwapResponse, error) {
+	if request.SwapAmount <= 0 {
+		return nil, errors.New("Missing required swap_amount parameter")
+	}
+	if request.ChannelId == 0 {
+		return nil, errors.New("Missing required channel_id parameter")
+	}
+	if request.Operation != OperationType_SWAP_OUT && request.Operation != OperationType_SWAP_IN {
+		return nil, errors.New("operation must be SWAP_OUT or SWAP_IN")
+	}
+
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var swapchan *lnrpc.Channel
+	for _, v := range chans.Channels {
+		if v.ChanId == request.ChannelId {
+			swapchan = v
+		}
+	}
+	if swapchan == nil {
+		swapType := swap.SWAPTYPE_OUT
+		if request.Operation == OperationType_SWAP_IN {
+			swapType = swap.SWAPTYPE_IN
+		}
+		report := &swap.PreflightReport{
+			Type:      swapType,
+			Asset:     request.Asset,
+			ChannelId: lnwire.NewShortChanIDFromInt(request.ChannelId).String(),
+			AmountSat: request.SwapAmount,
+		}
+		report.AddCheck("channel_found", errors.New("channel not found"))
+		return PreflightSwapResponseFromReport(report), nil
+	}
+
+	peerId := swapchan.RemotePubkey
+	shortId := lnwire.NewShortChanIDFromInt(swapchan.ChanId)
+
+	var report *swap.PreflightReport
+	if request.Operation == OperationType_SWAP_OUT {
+		report = p.swaps.PreflightSwapOut(peerId, request.Asset, shortId.String(), request.SwapAmount, request.PremiumLimitRatePpm)
+	} else {
+		report = p.swaps.PreflightSwapIn(peerId, request.Asset, shortId.String(), request.SwapAmount, request.PremiumLimitRatePpm)
+	}
+	report.AddCheck("channel_found", nil)
+
+	err = nil
+	if !swapchan.Active {
+		err = errors.New("channel is not connected")
+	}
+	report.AddCheck("channel_active", err)
+
+	err = nil
+	if p.peerSync == nil || !p.peerSync.HasCompatiblePeer(peerId) {
+		err = errors.New("peer does not run peerswap")
+	}
+	report.AddCheck("peer_compatible", err)
+
+	err = nil
+	if !p.isPeerConnected(ctx, peerId) {
+		err = errors.New("peer is not connected")
+	}
+	report.AddCheck("peer_connected", err)
+
+	if request.Asset == "lbtc" && p.swaps.LiquidEnabled {
+		if ok, perr := p.liquidWallet.Ping(); perr != nil || !ok {
+			report.AddCheck("liquid_wallet_reachable", fmt.Errorf("liquid wallet not reachable: %v", perr))
+		} else {
+			report.AddCheck("liquid_wallet_reachable", nil)
+		}
+	}
+
+	return PreflightSwapResponseFromReport(report), nil
+}
+
+func (p *PeerswapServer) RetrySwap(ctx context.Context, request *RetrySwapRequest) (*SwapResponse, error) {
+	if request.SwapId == "" {
+		return nil, errors.New("SwapId required")
+	}
+	params, err := p.swaps.GetRetryParams(request.SwapId, request.SwapAmount, request.PremiumLimitRatePpm)
+	if err != nil {
+		return nil, err
+	}
+	scid, err := NewScidFromString(params.Scid)
+	if err != nil {
+		return nil, err
+	}
+
+	var extraChannelIds []uint64
+	for _, extraScid := range params.ExtraScids {
+		extra, err := NewScidFromString(extraScid)
+		if err != nil {
+			return nil, err
+		}
+		extraChannelIds = append(extraChannelIds, extra.ToUint64())
+	}
+
+	opts := params.Options()
+	if params.Type == swap.SWAPTYPE_IN {
+		return p.swapIn(ctx, &SwapInRequest{
+			ChannelId:           scid.ToUint64(),
+			SwapAmount:          params.Amount,
+			Asset:               params.Chain,
+			Force:               request.Force,
+			PremiumLimitRatePpm: params.PremiumLimitRatePpm,
+		}, opts...)
+	}
+	return p.swapOut(ctx, &SwapOutRequest{
+		ChannelId:           scid.ToUint64(),
+		ExtraChannelIds:     extraChannelIds,
+		SwapAmount:          params.Amount,
+		Asset:               params.Chain,
+		Force:               request.Force,
+		PremiumLimitRatePpm: params.PremiumLimitRatePpm,
+	}, opts...)
+}
+
+func (p *PeerswapServer) FinalizeSwapFunding(ctx context.Context, request *FinalizeSwapFundingRequest) (*SwapResponse, error) {
+	if request.SwapId == "" {
+		return nil, errors.New("SwapId required")
+	}
+	if request.SignedTx == "" {
+		return nil, errors.New("SignedTx required")
+	}
+	swapRes, err := p.swaps.FinalizeSwapFunding(request.SwapId, request.SignedTx)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapResponse{Swap: PrettyprintFromServiceSwap(swapRes)}, nil
+}
+
+func (p *PeerswapServer) ListQueuedSwaps(ctx context.Context, request *ListQueuedSwapsRequest) (*ListQueuedSwapsResponse, error) {
+	queue, err := p.swaps.ListQueuedSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var resSwaps []*QueuedSwap
+	for _, v := range queue {
+		resSwaps = append(resSwaps, QueuedSwapFromServiceQueuedSwap(v))
+	}
+	return &ListQueuedSwapsResponse{QueuedSwaps: resSwaps}, nil
+}
+
+func (p *PeerswapServer) ListDeferredSwaps(ctx context.Context, request *ListDeferredSwapsRequest) (*ListDeferredSwapsResponse, error) {
+	deferred, err := p.swaps.ListDeferredSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var resSwaps []*QueuedSwap
+	for _, v := range deferred {
+		resSwaps = append(resSwaps, QueuedSwapFromServiceQueuedSwap(v))
+	}
+	return &ListDeferredSwapsResponse{DeferredSwaps: resSwaps}, nil
+}
+
+func (p *PeerswapServer) CreateSwapSchedule(ctx context.Context, request *CreateSwapScheduleRequest) (*SwapSchedule, error) {
+	if request.ChannelId == 0 {
+		return nil, errors.New("Missing required channel_id parameter")
+	}
+	var swapType swap.SwapType
+	switch request.Operation {
+	case OperationType_SWAP_OUT:
+		swapType = swap.SWAPTYPE_OUT
+	case OperationType_SWAP_IN:
+		swapType = swap.SWAPTYPE_IN
+	default:
+		return nil, errors.New("operation must be SWAP_OUT or SWAP_IN")
+	}
+
+	chans, err := p.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var swapchan *lnrpc.Channel
+	for _, v := range chans.Channels {
+		if v.ChanId == request.ChannelId {
+			swapchan = v
+		}
+	}
+	if swapchan == nil {
+		return nil, errors.New("channel does not exist")
+	}
+
+	schedule, err := p.scheduler.Create(&scheduler.Schedule{
+		Type:                swapType,
+		PeerId:              swapchan.RemotePubkey,
+		ChannelId:           lnwire.NewShortChanIDFromInt(swapchan.ChanId).String(),
+		Asset:               request.Asset,
+		AmountSat:           request.SwapAmount,
+		Max:                 request.Max,
+		PremiumLimitRatePpm: request.PremiumLimitRatePpm,
+		RunAt:               request.RunAt,
+		Cron:                request.Cron,
+		IntervalSec:         request.IntervalSec,
+		OnlyIfLocalBelowSat: request.OnlyIfLocalBelowSat,
+		OnlyIfLocalAboveSat: request.OnlyIfLocalAboveSat,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SwapScheduleFromSchedule(schedule), nil
+}
+
+func (p *PeerswapServer) ListSwapSchedules(ctx context.Context, request *ListSwapSchedulesRequest) (*ListSwapSchedulesResponse, error) {
+	schedules, err := p.scheduler.List()
+	if err != nil {
+		return nil, err
+	}
+	var resSchedules []*SwapSchedule
+	for _, v := range schedules {
+		resSchedules = append(resSchedules, SwapScheduleFromSchedule(v))
+	}
+	return &ListSwapSchedulesResponse{Schedules: resSchedules}, nil
+}
+
+func (p *PeerswapServer) PauseSwapSchedule(ctx context.Context, request *PauseSwapScheduleRequest) (*SwapSchedule, error) {
+	if request.Id == "" {
+		return nil, errors.New("Missing required id parameter")
+	}
+	schedule, err := p.scheduler.SetPaused(request.Id, request.Paused)
+	if err != nil {
+		return nil, err
+	}
+	return SwapScheduleFromSchedule(schedule), nil
+}
+
+func (p *PeerswapServer) DeleteSwapSchedule(ctx context.Context, request *DeleteSwapScheduleRequest) (*DeleteSwapScheduleResponse, error) {
+	if request.Id == "" {
+		return nil, errors.New("Missing required id parameter")
+	}
+	err := p.scheduler.Delete(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteSwapScheduleResponse{}, nil
+}
+
+func (p *PeerswapServer) RemoveQueuedSwap(ctx context.Context, request *RemoveQueuedSwapRequest) (*RemoveQueuedSwapResponse, error) {
+	if request.Id == "" {
+		return nil, errors.New("Missing required id parameter")
+	}
+	err := p.swaps.RemoveQueuedSwap(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveQueuedSwapResponse{}, nil
+}
+
+func (p *PeerswapServer) GetSwapHistory(ctx context.Context, request *GetSwapHistoryRequest) (*GetSwapHistoryResponse, error) {
+	if request.SwapId == "" {
+		return nil, errors.New("SwapId required")
+	}
+	swapRes, err := p.swaps.GetSwap(request.SwapId)
+	if err != nil {
+		return nil, err
+	}
+	return &GetSwapHistoryResponse{
+		Swap:    PrettyprintFromServiceSwap(swapRes),
+		History: SwapStateTransitionsFromServiceSwap(swapRes),
+	}, nil
+}
+
 func (p *PeerswapServer) ListSwaps(ctx context.Context, request *ListSwapsRequest) (*ListSwapsResponse, error) {
 	swaps, err := p.swaps.ListSwaps()
 	if err != nil {
@@ -703,6 +1310,58 @@ func (p *PeerswapServer) DeletePremiumRate(ctx context.Context,
 	}, nil
 }
 
+func QueuedSwapFromServiceQueuedSwap(queued *swap.QueuedSwap) *QueuedSwap {
+	return &QueuedSwap{
+		Id:                  queued.Id,
+		Type:                queued.Type.String(),
+		PeerNodeId:          queued.PeerNodeId,
+		Asset:               queued.Asset,
+		ChannelId:           queued.ChannelId,
+		Amount:              queued.AmountSat,
+		PremiumLimitRatePpm: queued.PremiumLimitRatePpm,
+		RetryOf:             queued.RetryOf,
+		CreatedAt:           queued.CreatedAt,
+		WaitForPeer:         queued.WaitForPeer,
+		ExpiresAt:           queued.ExpiresAt,
+		IdempotencyKey:      queued.IdempotencyKey,
+		ExtraChannelIds:     queued.ExtraChannelIds,
+		ClaimAddress:        queued.ClaimAddress,
+	}
+}
+
+func SwapScheduleFromSchedule(schedule *scheduler.Schedule) *SwapSchedule {
+	var runs []*ScheduleRun
+	for _, run := range schedule.Runs {
+		runs = append(runs, &ScheduleRun{
+			Time:    run.Time,
+			SwapId:  run.SwapId,
+			Skipped: run.Skipped,
+			Reason:  run.Reason,
+			Error:   run.Error,
+		})
+	}
+	return &SwapSchedule{
+		Id:                  schedule.Id,
+		Type:                schedule.Type.String(),
+		PeerNodeId:          schedule.PeerId,
+		ChannelId:           schedule.ChannelId,
+		Asset:               schedule.Asset,
+		Amount:              schedule.AmountSat,
+		Max:                 schedule.Max,
+		PremiumLimitRatePpm: schedule.PremiumLimitRatePpm,
+		RunAt:               schedule.RunAt,
+		Cron:                schedule.Cron,
+		IntervalSec:         schedule.IntervalSec,
+		OnlyIfLocalBelowSat: schedule.OnlyIfLocalBelowSat,
+		OnlyIfLocalAboveSat: schedule.OnlyIfLocalAboveSat,
+		Paused:              schedule.Paused,
+		Done:                schedule.Done,
+		NextRunAt:           schedule.NextRunAt,
+		CreatedAt:           schedule.CreatedAt,
+		Runs:                runs,
+	}
+}
+
 func PrettyprintFromServiceSwap(swp *swap.SwapStateMachine) *PrettyPrintSwap {
 	scid, err := NewScidFromString(swp.Data.GetScid())
 	if err != nil {
@@ -714,21 +1373,36 @@ func PrettyprintFromServiceSwap(swp *swap.SwapStateMachine) *PrettyPrintSwap {
 		lnd_chan_id = scid.ToUint64()
 	}
 
+	var fundingPsbt string
+	var fundingExpiresAt int64
+	if funding := swp.Data.ExternalFunding; funding != nil && funding.TxHex == "" {
+		fundingPsbt, fundingExpiresAt = funding.Psbt, funding.ExpiresAt
+	}
+
 	return &PrettyPrintSwap{
-		Id:              swp.SwapId.String(),
-		CreatedAt:       swp.Data.CreatedAt,
-		Asset:           swp.Data.GetChain(),
-		Type:            swp.Type.String(),
-		Role:            swp.Role.String(),
-		State:           string(swp.Current),
-		InitiatorNodeId: swp.Data.InitiatorNodeId,
-		PeerNodeId:      swp.Data.PeerNodeId,
-		Amount:          swp.Data.GetAmount(),
-		ChannelId:       swp.Data.GetScid(),
-		OpeningTxId:     swp.Data.GetOpeningTxId(),
-		ClaimTxId:       swp.Data.ClaimTxId,
-		CancelMessage:   swp.Data.GetCancelMessage(),
-		LndChanId:       lnd_chan_id,
+		Id:                    swp.SwapId.String(),
+		CreatedAt:             swp.Data.CreatedAt,
+		Asset:                 swp.Data.GetChain(),
+		Type:                  swp.Type.String(),
+		Role:                  swp.Role.String(),
+		State:                 string(swp.Current),
+		InitiatorNodeId:       swp.Data.InitiatorNodeId,
+		PeerNodeId:            swp.Data.PeerNodeId,
+		Amount:                swp.Data.GetAmount(),
+		ChannelId:             swp.Data.GetScid(),
+		OpeningTxId:           swp.Data.GetOpeningTxId(),
+		ClaimTxId:             swp.Data.ClaimTxId,
+		CancelMessage:         swp.Data.GetCancelMessage(),
+		LndChanId:             lnd_chan_id,
+		RetryOf:               swp.Data.RetryOf,
+		IdempotencyKey:        swp.Data.IdempotencyKey,
+		ExtraChannelIds:       lo.Drop(swp.Data.GetScids(), 1),
+		BatchId:               swp.Data.BatchId,
+		ClaimAddress:          swp.Data.ClaimAddress,
+		RequiredConfirmations: swp.Data.GetRequiredConfirmations(),
+		FeeRate:               swp.Data.FeeRate.String(),
+		FundingPsbt:           fundingPsbt,
+		FundingExpiresAt:      fundingExpiresAt,
 		// Reversing sign if role=sender because sender pays premium to peer
 		PremiumAmount: lo.Ternary(swp.Role == swap.SWAPROLE_SENDER,
 			-swp.Data.GetPremium(),
@@ -737,6 +1411,75 @@ func PrettyprintFromServiceSwap(swp *swap.SwapStateMachine) *PrettyPrintSwap {
 	}
 }
 
+// ChannelSelectionFromChoices converts the result of an automatic channel
+// selection into its rpc representation.
+func ChannelSelectionFromChoices(chosen string, choices []*swap.ChannelChoice) *ChannelSelection {
+	channels := make([]*ChannelChoice, 0, len(choices))
+	for _, c := range choices {
+		channels = append(channels, &ChannelChoice{
+			ChannelId:   c.ChannelId,
+			BalanceMsat: c.BalanceMsat,
+			Chosen:      c.Chosen,
+			Reason:      c.Reason,
+		})
+	}
+	return &ChannelSelection{
+		ChosenChannelId: chosen,
+		Channels:        channels,
+	}
+}
+
+// ChannelSelectionError adds the reasons why no channel could be chosen to
+// the error.
+func ChannelSelectionError(err error, choices []*swap.ChannelChoice) error {
+	reasons := make([]string, 0, len(choices))
+	for _, c := range choices {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", c.ChannelId, c.Reason))
+	}
+	return fmt.Errorf("%w (%s)", err, strings.Join(reasons, ", "))
+}
+
+// PreflightSwapResponseFromReport converts a preflight report into its rpc
+// representation.
+func PreflightSwapResponseFromReport(report *swap.PreflightReport) *PreflightSwapResponse {
+	checks := make([]*PreflightCheck, 0, len(report.Checks))
+	for _, c := range report.Checks {
+		checks = append(checks, &PreflightCheck{
+			Name:   c.Name,
+			Passed: c.Passed,
+			Reason: c.Reason,
+		})
+	}
+	return &PreflightSwapResponse{
+		Passed:                 report.Passed(),
+		Checks:                 checks,
+		EstimatedOpeningFeeSat: report.EstimatedOpeningFeeSat,
+		EstimatedClaimFeeSat:   report.EstimatedClaimFeeSat,
+		PremiumLimitSat:        report.PremiumLimitSat,
+		SpendableMsat:          report.SpendableMsat,
+		ReceivableMsat:         report.ReceivableMsat,
+		MaximumSwapAmountSat:   report.MaximumSwapAmountSat,
+	}
+}
+
+// SwapStateTransitionsFromServiceSwap converts the recorded transition history
+// of a swap into its rpc representation.
+func SwapStateTransitionsFromServiceSwap(swp *swap.SwapStateMachine) []*SwapStateTransition {
+	history := make([]*SwapStateTransition, 0, len(swp.History))
+	for _, t := range swp.History {
+		history = append(history, &SwapStateTransition{
+			Timestamp:   t.Timestamp,
+			FromState:   string(t.From),
+			Event:       string(t.Event),
+			ToState:     string(t.To),
+			Error:       t.Error,
+			TxId:        t.TxId,
+			PaymentHash: t.PaymentHash,
+		})
+	}
+	return history
+}
+
 func NewScidFromString(scid string) (*lnwire.ShortChannelID, error) {
 	scid = strings.ReplaceAll(scid, "x", ":")
 	parts := strings.Split(scid, ":")
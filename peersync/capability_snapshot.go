@@ -15,6 +15,7 @@ type PeerCapabilitySnapshot struct {
 	BTCSwapOutPremiumRatePPM  int64    `json:"btc_swap_out_premium_rate_ppm,omitempty"`
 	LBTCSwapInPremiumRatePPM  int64    `json:"lbtc_swap_in_premium_rate_ppm,omitempty"`
 	LBTCSwapOutPremiumRatePPM int64    `json:"lbtc_swap_out_premium_rate_ppm,omitempty"`
+	Features                  []string `json:"features,omitempty"`
 }
 
 // SnapshotFromCapability builds a snapshot from the given capability.
@@ -37,6 +38,7 @@ func SnapshotFromCapability(capability *PeerCapability) *PeerCapabilitySnapshot
 		BTCSwapOutPremiumRatePPM:  ppmValue(capability.GetPremiumRate(premium.BTC, premium.SwapOut)),
 		LBTCSwapInPremiumRatePPM:  ppmValue(capability.GetPremiumRate(premium.LBTC, premium.SwapIn)),
 		LBTCSwapOutPremiumRatePPM: ppmValue(capability.GetPremiumRate(premium.LBTC, premium.SwapOut)),
+		Features:                  capability.Features(),
 	}
 }
 
@@ -80,7 +82,7 @@ func (s *PeerCapabilitySnapshot) ToCapability() (*PeerCapability, error) {
 		btcOut,
 		lbtcIn,
 		lbtcOut,
-	), nil
+	).WithFeatures(s.Features), nil
 }
 
 // PollMessageDTO and RequestPollMessageDTO reuse the snapshot layout.
@@ -1,5 +1,13 @@
 package peersync
 
+// FeatureMessageAck is advertised by nodes that acknowledge processed swap
+// messages, so that their peers can stop resending them.
+const FeatureMessageAck = "message_ack"
+
+// FeatureMultiChannelSwapOut is advertised by nodes that accept swap-outs
+// whose claim payment spans several channels.
+const FeatureMultiChannelSwapOut = "multi_channel_swap_out"
+
 // HasCompatiblePeer reports whether peersync knows a peer with the given ID
 // whose advertised capability matches the local protocol version.
 func (ps *PeerSync) HasCompatiblePeer(peerID string) bool {
@@ -42,3 +50,32 @@ func (ps *PeerSync) CompatiblePeers() (map[string]*Peer, error) {
 	return result, nil
 }
 
+// SupportsMessageAck reports whether the peer with the given ID advertised
+// that it acknowledges swap messages.
+func (ps *PeerSync) SupportsMessageAck(peerID string) bool {
+	return ps.supportsFeature(peerID, FeatureMessageAck)
+}
+
+// SupportsMultiChannelSwapOut reports whether the peer with the given ID
+// advertised that it accepts swap-outs across several channels.
+func (ps *PeerSync) SupportsMultiChannelSwapOut(peerID string) bool {
+	return ps.supportsFeature(peerID, FeatureMultiChannelSwapOut)
+}
+
+func (ps *PeerSync) supportsFeature(peerID, feature string) bool {
+	if ps == nil || ps.store == nil {
+		return false
+	}
+
+	id, err := NewPeerID(peerID)
+	if err != nil {
+		return false
+	}
+
+	peer, err := ps.store.GetPeerState(id)
+	if err != nil || peer == nil {
+		return false
+	}
+
+	return peer.Capability().SupportsFeature(feature)
+}
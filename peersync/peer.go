@@ -130,6 +130,7 @@ type PeerCapability struct {
 	btcSwapOutPremiumRate  *premium.PPM
 	lbtcSwapInPremiumRate  *premium.PPM
 	lbtcSwapOutPremiumRate *premium.PPM
+	features               []string
 	observedAt             time.Time
 }
 
@@ -178,6 +179,30 @@ func (c *PeerCapability) ObservedAt() time.Time {
 	return c.observedAt
 }
 
+// WithFeatures sets the optional protocol features of the capability.
+func (c *PeerCapability) WithFeatures(features []string) *PeerCapability {
+	c.features = append([]string(nil), features...)
+	return c
+}
+
+// Features returns a copy of the advertised optional protocol features.
+func (c *PeerCapability) Features() []string {
+	return append([]string(nil), c.features...)
+}
+
+// SupportsFeature reports whether the capability advertises the feature.
+func (c *PeerCapability) SupportsFeature(feature string) bool {
+	if c == nil {
+		return false
+	}
+	for _, f := range c.features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 // SupportsAsset reports whether the capability supports the given asset.
 func (c *PeerCapability) SupportsAsset(asset Asset) bool {
 	for _, a := range c.supportedAssets {
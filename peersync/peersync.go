@@ -65,6 +65,7 @@ type PeerSync struct {
 	supportedAssets []Asset
 	premiumSetting  *premium.Setting
 	version         Version
+	features        []string
 
 	poller  *poller
 	handler *messageHandler
@@ -97,6 +98,7 @@ func NewPeerSync(
 		supportedAssets:       normalizeSupportedAssets(supportedAssets),
 		premiumSetting:        premiumSetting,
 		version:               NewVersion(swap.PEERSWAP_PROTOCOL_VERSION),
+		features:              []string{FeatureMessageAck, FeatureMultiChannelSwapOut},
 		pollTickerInterval:    10 * time.Second,
 		cleanupTickerInterval: 1 * time.Minute,
 		cleanupTimeout:        30 * time.Minute,
@@ -274,7 +276,7 @@ func (ps *PeerSync) localCapabilityForPeer(peer PeerID) *PeerCapability {
 		btcOut,
 		lbtcIn,
 		lbtcOut,
-	)
+	).WithFeatures(ps.features)
 }
 
 func (ps *PeerSync) capabilityToDTO(capability *PeerCapability, peer PeerID) *PollMessageDTO {
@@ -385,6 +385,40 @@ func TestHandlePollMessage(t *testing.T) {
 	}
 }
 
+func TestHandlePollMessageStoresFeatures(t *testing.T) {
+	syncer, _ := newTestPeerSync(t)
+
+	peerID, err := NewPeerID("peer-features")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := PollMessageDTO{
+		Version:  2,
+		Assets:   []string{"BTC"},
+		Features: []string{FeatureMessageAck, "unknown_feature"},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+
+	syncer.handler.handlePollMessage(context.Background(), CustomMessage{From: peerID, Type: messages.MESSAGETYPE_POLL, Payload: data})
+
+	if !syncer.SupportsMessageAck(peerID.String()) {
+		t.Fatalf("expected ack feature to be stored")
+	}
+
+	if syncer.SupportsMultiChannelSwapOut(peerID.String()) {
+		t.Fatalf("expected multi channel swap-out feature to be missing")
+	}
+
+	// The local capability advertises acks and multi channel swap-outs.
+	local := syncer.capabilityToDTO(syncer.localCapabilityForPeer(peerID), peerID)
+	if len(local.Features) != 2 || local.Features[0] != FeatureMessageAck || local.Features[1] != FeatureMultiChannelSwapOut {
+		t.Fatalf("unexpected local features: %v", local.Features)
+	}
+}
+
 func TestHandleRequestPollMessage(t *testing.T) {
 	syncer, deps := newTestPeerSync(t)
 
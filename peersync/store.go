@@ -278,6 +278,7 @@ type peerRecord struct {
 	BTCSwapOutPremiumRatePPM  int64      `json:"btc_swap_out_premium_rate_ppm,omitempty"`
 	LBTCSwapInPremiumRatePPM  int64      `json:"lbtc_swap_in_premium_rate_ppm,omitempty"`
 	LBTCSwapOutPremiumRatePPM int64      `json:"lbtc_swap_out_premium_rate_ppm,omitempty"`
+	Features                  []string   `json:"features,omitempty"`
 }
 
 func (r *peerRecord) snapshot() *PeerCapabilitySnapshot {
@@ -295,6 +296,7 @@ func (r *peerRecord) snapshot() *PeerCapabilitySnapshot {
 		BTCSwapOutPremiumRatePPM:  r.BTCSwapOutPremiumRatePPM,
 		LBTCSwapInPremiumRatePPM:  r.LBTCSwapInPremiumRatePPM,
 		LBTCSwapOutPremiumRatePPM: r.LBTCSwapOutPremiumRatePPM,
+		Features:                  append([]string(nil), r.Features...),
 	}
 }
 
@@ -310,6 +312,7 @@ func (r *peerRecord) applySnapshot(snapshot *PeerCapabilitySnapshot) {
 	r.BTCSwapOutPremiumRatePPM = snapshot.BTCSwapOutPremiumRatePPM
 	r.LBTCSwapInPremiumRatePPM = snapshot.LBTCSwapInPremiumRatePPM
 	r.LBTCSwapOutPremiumRatePPM = snapshot.LBTCSwapOutPremiumRatePPM
+	r.Features = append([]string(nil), snapshot.Features...)
 }
 
 func peerToRecord(peer *Peer) *peerRecord {
@@ -395,5 +398,6 @@ func (r *peerRecord) hasCapabilityData() bool {
 		r.BTCSwapInPremiumRatePPM != 0 ||
 		r.BTCSwapOutPremiumRatePPM != 0 ||
 		r.LBTCSwapInPremiumRatePPM != 0 ||
-		r.LBTCSwapOutPremiumRatePPM != 0
+		r.LBTCSwapOutPremiumRatePPM != 0 ||
+		len(r.Features) > 0
 }
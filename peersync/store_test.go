@@ -290,6 +290,50 @@ func TestHasCompatiblePeer(t *testing.T) {
 	}
 }
 
+func TestSupportsMessageAck(t *testing.T) {
+	store := newTestStore(t)
+	ps := &PeerSync{store: store, version: NewVersion(42)}
+
+	savePeer := func(idStr string, features []string) {
+		id, err := NewPeerID(idStr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		peer := NewPeer(id, "addr-"+idStr)
+		capability := NewPeerCapability(NewVersion(42), []Asset{AssetBTC}, true, nil, nil, nil, nil)
+		peer.UpdateCapability(capability.WithFeatures(features))
+		if err := store.SavePeerState(peer); err != nil {
+			t.Fatalf("failed to save peer %s: %v", idStr, err)
+		}
+	}
+
+	savePeer("ack-peer", []string{FeatureMessageAck})
+	savePeer("multi-channel-peer", []string{FeatureMessageAck, FeatureMultiChannelSwapOut})
+	savePeer("legacy-peer", nil)
+
+	if !ps.SupportsMultiChannelSwapOut("multi-channel-peer") {
+		t.Fatalf("expected persisted multi channel swap-out feature to be detected")
+	}
+	if ps.SupportsMultiChannelSwapOut("ack-peer") {
+		t.Fatalf("expected peers without multi channel swap-out feature to be excluded")
+	}
+
+	if !ps.SupportsMessageAck("ack-peer") {
+		t.Fatalf("expected persisted ack feature to be detected")
+	}
+	if ps.SupportsMessageAck("legacy-peer") {
+		t.Fatalf("expected peers without ack feature to be excluded")
+	}
+	if ps.SupportsMessageAck("unknown-peer") {
+		t.Fatalf("expected unknown peers to be excluded")
+	}
+
+	var nilSync *PeerSync
+	if nilSync.SupportsMessageAck("ack-peer") {
+		t.Fatalf("expected nil peersync to not support acks")
+	}
+}
+
 func TestCompatiblePeers(t *testing.T) {
 	store := newTestStore(t)
 	target := NewVersion(7)
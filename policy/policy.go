@@ -10,6 +10,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/jessevdk/go-flags"
@@ -26,6 +29,10 @@ const (
 	// to perform a swap. We need this lower boundary as it is uneconomical to
 	// swap small amounts.
 	defaultMinSwapAmountMsat uint64 = 100000000
+
+	// maxConfirmations is the most confirmations that peers agree on for
+	// a swap.
+	maxConfirmations = 12
 )
 
 // Global Mutex
@@ -85,6 +92,13 @@ type Policy struct {
 	// when we want to upgrade the node and do not want to allow for any new
 	// swap request from the peer or the node operator.
 	AllowNewSwaps bool `json:"allow_new_swaps" long:"allow_new_swaps" description:"If set to false, disables all swap requests, defaults to true."`
+
+	// BtcConfirmations and LbtcConfirmations scale the confirmations that
+	// an opening tx needs before the claim invoice is paid with the swap
+	// amount. Every entry has the form <min amount sat>:<confirmations>,
+	// the entry with the highest amount up to the swap amount applies.
+	BtcConfirmations  []string `json:"btc_confirmations" long:"btc_confirmations" description:"Confirmations required for btc opening txs from a swap amount on, in the form <min amount sat>:<confirmations>. Can be set multiple times."`
+	LbtcConfirmations []string `json:"lbtc_confirmations" long:"lbtc_confirmations" description:"Confirmations required for lbtc opening txs from a swap amount on, in the form <min amount sat>:<confirmations>. Can be set multiple times."`
 }
 
 func (p *Policy) String() string {
@@ -94,13 +108,17 @@ func (p *Policy) String() string {
 			"reserve_onchain_msat: %d\n"+
 			"allowlisted_peers: %s\n"+
 			"accept_all_peers: %t\n"+
-			"suspicious_peers: %s\n",
+			"suspicious_peers: %s\n"+
+			"btc_confirmations: %s\n"+
+			"lbtc_confirmations: %s\n",
 		p.AllowNewSwaps,
 		p.MinSwapAmountMsat,
 		p.ReserveOnchainMsat,
 		p.PeerAllowlist,
 		p.AcceptAllPeers,
 		p.SuspiciousPeerList,
+		p.BtcConfirmations,
+		p.LbtcConfirmations,
 	)
 	return str
 }
@@ -116,6 +134,8 @@ func (p *Policy) Get() Policy {
 		AcceptAllPeers:     p.AcceptAllPeers,
 		MinSwapAmountMsat:  p.MinSwapAmountMsat,
 		AllowNewSwaps:      p.AllowNewSwaps,
+		BtcConfirmations:   p.BtcConfirmations,
+		LbtcConfirmations:  p.LbtcConfirmations,
 	}
 }
 
@@ -141,6 +161,74 @@ func (p *Policy) NewSwapsAllowed() bool {
 	return p.AllowNewSwaps
 }
 
+// GetConfirmations returns the confirmations that an opening tx of the asset
+// ("btc" or "lbtc") needs for a swap of the amount. It returns 0 if the
+// policy does not set confirmations for the amount, the default of the chain
+// applies then.
+func (p *Policy) GetConfirmations(asset string, amountSat uint64) uint32 {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var entries []string
+	switch asset {
+	case "btc":
+		entries = p.BtcConfirmations
+	case "lbtc":
+		entries = p.LbtcConfirmations
+	}
+	// The entries are validated on creation.
+	tiers, _ := parseConfirmationTiers(entries)
+	var confs uint32
+	for _, tier := range tiers {
+		if amountSat < tier.minAmountSat {
+			break
+		}
+		confs = tier.confirmations
+	}
+	return confs
+}
+
+// confirmationTier are the confirmations that swaps from a minimum amount on
+// require.
+type confirmationTier struct {
+	minAmountSat  uint64
+	confirmations uint32
+}
+
+// parseConfirmationTiers parses entries of the form
+// <min amount sat>:<confirmations> and returns them sorted by amount.
+func parseConfirmationTiers(entries []string) ([]confirmationTier, error) {
+	tiers := make([]confirmationTier, 0, len(entries))
+	for _, entry := range entries {
+		amount, confs, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid confirmation tier %q, expected <min amount sat>:<confirmations>", entry)
+		}
+		minAmountSat, err := strconv.ParseUint(strings.TrimSpace(amount), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount in confirmation tier %q: %w", entry, err)
+		}
+		confirmations, err := strconv.ParseUint(strings.TrimSpace(confs), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid confirmations in confirmation tier %q: %w", entry, err)
+		}
+		if confirmations == 0 {
+			return nil, fmt.Errorf("confirmations in confirmation tier %q must be at least 1", entry)
+		}
+		if confirmations > maxConfirmations {
+			return nil, fmt.Errorf("confirmations in confirmation tier %q must be at most %d", entry, maxConfirmations)
+		}
+		tiers = append(tiers, confirmationTier{
+			minAmountSat:  minAmountSat,
+			confirmations: uint32(confirmations),
+		})
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].minAmountSat < tiers[j].minAmountSat
+	})
+	return tiers, nil
+}
+
 // IsPeerAllowed returns if a peer or node is part of
 // the allowlist.
 func (p *Policy) IsPeerAllowed(peer string) bool {
@@ -460,6 +548,11 @@ func create(r io.Reader) (*Policy, error) {
 	if err != nil {
 		return nil, ErrCreatePolicy(err.Error())
 	}
+	for _, entries := range [][]string{policy.BtcConfirmations, policy.LbtcConfirmations} {
+		if _, err := parseConfirmationTiers(entries); err != nil {
+			return nil, ErrCreatePolicy(err.Error())
+		}
+	}
 
 	return policy, nil
 }
@@ -368,6 +368,42 @@ func Test_AllowSwapRequests(t *testing.T) {
 		string(policyFile))
 }
 
+func Test_GetConfirmations(t *testing.T) {
+	conf := "btc_confirmations=10000000:6\n" +
+		"btc_confirmations=0:1\n" +
+		"btc_confirmations=1000000:3\n" +
+		"lbtc_confirmations=5000000:4"
+
+	policy, err := create(strings.NewReader(conf))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		asset  string
+		amount uint64
+		want   uint32
+	}{
+		{"btc", 100000, 1},
+		{"btc", 1000000, 3},
+		{"btc", 9999999, 3},
+		{"btc", 10000000, 6},
+		{"lbtc", 100000, 0},
+		{"lbtc", 5000000, 4},
+		{"unknown", 5000000, 0},
+	} {
+		assert.Equal(t, tc.want, policy.GetConfirmations(tc.asset, tc.amount), "%s %d", tc.asset, tc.amount)
+	}
+
+	for _, invalid := range []string{
+		"btc_confirmations=1000000",
+		"btc_confirmations=abc:3",
+		"lbtc_confirmations=1000000:0",
+		"lbtc_confirmations=1000000:13",
+	} {
+		_, err := create(strings.NewReader(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
 func randomPubKeyHex() string {
 	var b = make([]byte, 33)
 	rand.Read(b)
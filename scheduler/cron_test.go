@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CronNext(t *testing.T) {
+	t.Parallel()
+	// Wednesday.
+	start := time.Date(2024, 5, 15, 10, 30, 20, 0, time.UTC)
+
+	tests := map[string]struct {
+		expr string
+		want time.Time
+	}{
+		"every minute": {
+			expr: "* * * * *",
+			want: time.Date(2024, 5, 15, 10, 31, 0, 0, time.UTC),
+		},
+		"daily": {
+			expr: "0 6 * * *",
+			want: time.Date(2024, 5, 16, 6, 0, 0, 0, time.UTC),
+		},
+		"weekly on monday": {
+			expr: "0 6 * * 1",
+			want: time.Date(2024, 5, 20, 6, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			expr: "0 0 * * 7",
+			want: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC),
+		},
+		"steps": {
+			expr: "*/15 * * * *",
+			want: time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC),
+		},
+		"range with step": {
+			expr: "0 8-18/4 * * *",
+			want: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC),
+		},
+		"list": {
+			expr: "0 0 1,20 * *",
+			want: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			expr: "0 0 1 * 5",
+			want: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			expr: "0 0 1 1 *",
+			want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap day": {
+			expr: "0 0 29 2 *",
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			expr: "0 0 31 2 *",
+			want: time.Time{},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			spec, err := parseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.next(start))
+		})
+	}
+}
+
+func Test_ParseCronInvalid(t *testing.T) {
+	t.Parallel()
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elementsproject/peerswap/swap"
+)
+
+// maxRunHistory is the number of runs that are kept per schedule.
+const maxRunHistory = 50
+
+// Schedule describes swaps that are started at a given time or recurringly.
+// Exactly one of RunAt, Cron and IntervalSec is set.
+type Schedule struct {
+	Id        string        `json:"id"`
+	Type      swap.SwapType `json:"type"`
+	PeerId    string        `json:"peer_id"`
+	ChannelId string        `json:"channel_id"`
+	Asset     string        `json:"asset"`
+	// AmountSat is the amount of each swap. It is ignored if Max is set.
+	AmountSat uint64 `json:"amount_sat"`
+	// Max swaps the maximum possible amount at each run.
+	Max                 bool  `json:"max,omitempty"`
+	PremiumLimitRatePpm int64 `json:"premium_limit_rate_ppm"`
+
+	// RunAt is the unix time of a one-shot schedule.
+	RunAt int64 `json:"run_at,omitempty"`
+	// Cron is a cron expression that is evaluated in UTC.
+	Cron        string `json:"cron,omitempty"`
+	IntervalSec uint64 `json:"interval_sec,omitempty"`
+
+	// OnlyIfLocalBelowSat skips a run if our balance on the channel is not
+	// below the value.
+	OnlyIfLocalBelowSat uint64 `json:"only_if_local_below_sat,omitempty"`
+	// OnlyIfLocalAboveSat skips a run if our balance on the channel is not
+	// above the value.
+	OnlyIfLocalAboveSat uint64 `json:"only_if_local_above_sat,omitempty"`
+
+	Paused bool `json:"paused"`
+	// Done is set once a one-shot schedule ran.
+	Done      bool   `json:"done"`
+	NextRunAt int64  `json:"next_run_at"`
+	CreatedAt int64  `json:"created_at"`
+	Runs      []*Run `json:"runs"`
+}
+
+// Run is a single run of a schedule.
+type Run struct {
+	Time int64 `json:"time"`
+	// SwapId is the id of the swap that was started by the run.
+	SwapId  string `json:"swap_id,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+	// Reason why the run was skipped.
+	Reason string `json:"reason,omitempty"`
+	// Error why the swap could not be started.
+	Error string `json:"error,omitempty"`
+}
+
+// validate checks that the schedule describes a swap and exactly one way of
+// timing it.
+func (s *Schedule) validate(now time.Time) error {
+	if s.Type != swap.SWAPTYPE_OUT && s.Type != swap.SWAPTYPE_IN {
+		return errors.New("type must be swap-out or swap-in")
+	}
+	if s.PeerId == "" || s.ChannelId == "" {
+		return errors.New("peer and channel required")
+	}
+	if s.Asset == "" {
+		return errors.New("asset required")
+	}
+	if s.AmountSat == 0 && !s.Max {
+		return errors.New("amount or max required")
+	}
+	if s.AmountSat != 0 && s.Max {
+		return errors.New("amount and max are mutually exclusive")
+	}
+
+	timings := 0
+	if s.RunAt != 0 {
+		timings++
+		if s.RunAt <= now.Unix() {
+			return fmt.Errorf("run time %s is in the past", time.Unix(s.RunAt, 0).UTC())
+		}
+	}
+	if s.Cron != "" {
+		timings++
+		if _, err := parseCron(s.Cron); err != nil {
+			return err
+		}
+	}
+	if s.IntervalSec != 0 {
+		timings++
+		if s.IntervalSec < uint64(minInterval.Seconds()) {
+			return fmt.Errorf("interval must be at least %s", minInterval)
+		}
+	}
+	if timings != 1 {
+		return errors.New("exactly one of run_at, cron and interval required")
+	}
+	return nil
+}
+
+// nextRun returns the unix time of the first run after now or 0 if the
+// schedule does not run again. Intervals are counted from the previous run
+// time so that runs do not drift.
+func (s *Schedule) nextRun(now time.Time) int64 {
+	switch {
+	case s.RunAt != 0:
+		if s.Done {
+			return 0
+		}
+		return s.RunAt
+	case s.IntervalSec != 0:
+		if s.NextRunAt == 0 {
+			return now.Unix() + int64(s.IntervalSec)
+		}
+		next := s.NextRunAt
+		for next <= now.Unix() {
+			next += int64(s.IntervalSec)
+		}
+		return next
+	case s.Cron != "":
+		spec, err := parseCron(s.Cron)
+		if err != nil {
+			return 0
+		}
+		next := spec.next(now)
+		if next.IsZero() {
+			return 0
+		}
+		return next.Unix()
+	}
+	return 0
+}
+
+// isDue returns true if the next run time of the schedule has passed.
+func (s *Schedule) isDue(now time.Time) bool {
+	return !s.Paused && !s.Done && s.NextRunAt != 0 && s.NextRunAt <= now.Unix()
+}
+
+func (s *Schedule) addRun(run *Run) {
+	s.Runs = append(s.Runs, run)
+	if len(s.Runs) > maxRunHistory {
+		s.Runs = s.Runs[len(s.Runs)-maxRunHistory:]
+	}
+}
@@ -0,0 +1,269 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elementsproject/peerswap/log"
+	"github.com/elementsproject/peerswap/swap"
+)
+
+// minInterval is the shortest interval of a recurring schedule.
+const minInterval = 10 * time.Minute
+
+// defaultCheckInterval is how often the scheduler looks for due schedules.
+const defaultCheckInterval = 30 * time.Second
+
+// SwapService starts the swaps of the schedules.
+type SwapService interface {
+	SwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...swap.SwapOption) (*swap.SwapStateMachine, error)
+	SwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...swap.SwapOption) (*swap.SwapStateMachine, error)
+	MaxSwapOutAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error)
+	MaxSwapInAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error)
+	LocalBalanceSat(channelId string) (uint64, error)
+}
+
+// Scheduler persists swap schedules and starts their swaps when they are due.
+type Scheduler struct {
+	store  ScheduleStore
+	swaps  SwapService
+	nodeId string
+
+	checkInterval time.Duration
+	now           func() time.Time
+
+	mu       sync.Mutex
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler returns a scheduler that starts swaps as node nodeId.
+func NewScheduler(store ScheduleStore, swaps SwapService, nodeId string) *Scheduler {
+	return &Scheduler{
+		store:         store,
+		swaps:         swaps,
+		nodeId:        nodeId,
+		checkInterval: defaultCheckInterval,
+		now:           time.Now,
+		done:          make(chan struct{}),
+	}
+}
+
+// Start runs due schedules until Stop is called. Runs that were missed while
+// the node was offline are run once on start.
+func (s *Scheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.checkInterval)
+		defer ticker.Stop()
+		for {
+			s.runDue()
+			select {
+			case <-ticker.C:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops running due schedules. It can be called more than once.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// Create validates and persists a new schedule.
+func (s *Scheduler) Create(schedule *Schedule) (*Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if err := schedule.validate(now); err != nil {
+		return nil, err
+	}
+	schedule.Id = swap.NewSwapId().String()
+	schedule.CreatedAt = now.Unix()
+	schedule.Paused = false
+	schedule.Done = false
+	schedule.Runs = nil
+	schedule.NextRunAt = schedule.nextRun(now)
+	if schedule.NextRunAt == 0 {
+		return nil, fmt.Errorf("cron expression %q never matches", schedule.Cron)
+	}
+	if err := s.store.Put(schedule); err != nil {
+		return nil, err
+	}
+	log.Infof("[Scheduler] created %s schedule %s on channel %s, next run at %s",
+		schedule.Type, schedule.Id, schedule.ChannelId, time.Unix(schedule.NextRunAt, 0).UTC())
+	return schedule, nil
+}
+
+// List returns all schedules ordered by their creation time.
+func (s *Scheduler) List() ([]*Schedule, error) {
+	schedules, err := s.store.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt < schedules[j].CreatedAt
+	})
+	return schedules, nil
+}
+
+// SetPaused pauses or resumes a schedule. Runs that were missed while the
+// schedule was paused are not made up for.
+func (s *Scheduler) SetPaused(id string, paused bool) (*Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Paused == paused {
+		return schedule, nil
+	}
+	schedule.Paused = paused
+	if !paused && schedule.RunAt == 0 {
+		schedule.NextRunAt = schedule.nextRun(s.now())
+	}
+	if err := s.store.Put(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *Scheduler) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store.Delete(id)
+}
+
+// runDue runs every schedule whose next run time has passed. The swaps are
+// started without holding the lock, so that schedules can be changed while a
+// swap is requested.
+func (s *Scheduler) runDue() {
+	schedules, err := s.store.ListAll()
+	if err != nil {
+		log.Infof("[Scheduler] error listing schedules: %v", err)
+		return
+	}
+	now := s.now()
+	for _, schedule := range schedules {
+		if !schedule.isDue(now) {
+			continue
+		}
+		// An earlier run may have taken long enough for the schedule to
+		// be changed.
+		schedule = s.getDue(schedule.Id, now)
+		if schedule == nil {
+			continue
+		}
+		run := s.run(schedule, now)
+		s.recordRun(schedule.Id, run, now)
+	}
+}
+
+// getDue returns the schedule if it is still due, and nil otherwise.
+func (s *Scheduler) getDue(id string, now time.Time) *Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.store.Get(id)
+	if err != nil || !schedule.isDue(now) {
+		return nil
+	}
+	return schedule
+}
+
+// recordRun adds the run to the schedule and sets its next run time. The
+// schedule is read again as it may have changed during the run.
+func (s *Scheduler) recordRun(id string, run *Run, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.store.Get(id)
+	if errors.Is(err, ErrScheduleNotFound) {
+		// Deleted during the run.
+		return
+	}
+	if err != nil {
+		log.Infof("[Scheduler] error reading schedule %s: %v", id, err)
+		return
+	}
+	schedule.addRun(run)
+	if schedule.RunAt != 0 {
+		schedule.Done = true
+	}
+	schedule.NextRunAt = schedule.nextRun(now)
+	if err := s.store.Put(schedule); err != nil {
+		log.Infof("[Scheduler] error saving schedule %s: %v", schedule.Id, err)
+	}
+}
+
+// run checks the skip conditions of the schedule and starts its swap.
+func (s *Scheduler) run(schedule *Schedule, now time.Time) *Run {
+	run := &Run{Time: now.Unix()}
+
+	if schedule.OnlyIfLocalBelowSat != 0 || schedule.OnlyIfLocalAboveSat != 0 {
+		local, err := s.swaps.LocalBalanceSat(schedule.ChannelId)
+		if err != nil {
+			run.Error = fmt.Sprintf("could not get channel balance: %v", err)
+			return s.logRun(schedule, run)
+		}
+		if schedule.OnlyIfLocalBelowSat != 0 && local >= schedule.OnlyIfLocalBelowSat {
+			run.Skipped = true
+			run.Reason = fmt.Sprintf("local balance of %d sat is not below %d sat", local, schedule.OnlyIfLocalBelowSat)
+			return s.logRun(schedule, run)
+		}
+		if schedule.OnlyIfLocalAboveSat != 0 && local <= schedule.OnlyIfLocalAboveSat {
+			run.Skipped = true
+			run.Reason = fmt.Sprintf("local balance of %d sat is not above %d sat", local, schedule.OnlyIfLocalAboveSat)
+			return s.logRun(schedule, run)
+		}
+	}
+
+	amtSat := schedule.AmountSat
+	if schedule.Max {
+		var err error
+		if schedule.Type == swap.SWAPTYPE_OUT {
+			amtSat, err = s.swaps.MaxSwapOutAmountSat(schedule.Asset, schedule.ChannelId, 0)
+		} else {
+			amtSat, err = s.swaps.MaxSwapInAmountSat(schedule.Asset, schedule.ChannelId, 0)
+		}
+		if err != nil {
+			run.Error = fmt.Sprintf("could not determine maximum amount: %v", err)
+			return s.logRun(schedule, run)
+		}
+	}
+
+	var sw *swap.SwapStateMachine
+	var err error
+	if schedule.Type == swap.SWAPTYPE_OUT {
+		sw, err = s.swaps.SwapOut(schedule.PeerId, schedule.Asset, schedule.ChannelId, s.nodeId, amtSat, schedule.PremiumLimitRatePpm)
+	} else {
+		sw, err = s.swaps.SwapIn(schedule.PeerId, schedule.Asset, schedule.ChannelId, s.nodeId, amtSat, schedule.PremiumLimitRatePpm)
+	}
+	if err != nil {
+		run.Error = err.Error()
+		return s.logRun(schedule, run)
+	}
+	run.SwapId = sw.SwapId.String()
+	return s.logRun(schedule, run)
+}
+
+func (s *Scheduler) logRun(schedule *Schedule, run *Run) *Run {
+	switch {
+	case run.Skipped:
+		log.Infof("[Scheduler] skipped run of schedule %s: %s", schedule.Id, run.Reason)
+	case run.Error != "":
+		log.Infof("[Scheduler] run of schedule %s failed: %s", schedule.Id, run.Error)
+	default:
+		log.Infof("[Scheduler] schedule %s started swap %s", schedule.Id, run.SwapId)
+	}
+	return run
+}
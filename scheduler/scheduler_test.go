@@ -0,0 +1,300 @@
+package scheduler
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/swap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+type startedSwap struct {
+	swapType swap.SwapType
+	peer     string
+	channel  string
+	amtSat   uint64
+}
+
+type dummySwapService struct {
+	localSat  uint64
+	maxSat    uint64
+	swapErr   error
+	started   []startedSwap
+	initiator string
+	// onStart is called when a swap is started.
+	onStart func()
+}
+
+func (d *dummySwapService) start(swapType swap.SwapType, peer, channelId, initiator string, amtSat uint64) (*swap.SwapStateMachine, error) {
+	if d.onStart != nil {
+		d.onStart()
+	}
+	if d.swapErr != nil {
+		return nil, d.swapErr
+	}
+	d.initiator = initiator
+	d.started = append(d.started, startedSwap{swapType, peer, channelId, amtSat})
+	return &swap.SwapStateMachine{SwapId: swap.NewSwapId()}, nil
+}
+
+func (d *dummySwapService) SwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...swap.SwapOption) (*swap.SwapStateMachine, error) {
+	return d.start(swap.SWAPTYPE_OUT, peer, channelId, initiator, amtSat)
+}
+
+func (d *dummySwapService) SwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...swap.SwapOption) (*swap.SwapStateMachine, error) {
+	return d.start(swap.SWAPTYPE_IN, peer, channelId, initiator, amtSat)
+}
+
+func (d *dummySwapService) MaxSwapOutAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error) {
+	return d.maxSat, nil
+}
+
+func (d *dummySwapService) MaxSwapInAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error) {
+	return d.maxSat, nil
+}
+
+func (d *dummySwapService) LocalBalanceSat(channelId string) (uint64, error) {
+	return d.localSat, nil
+}
+
+func setupScheduler(t *testing.T, swaps SwapService, now *time.Time) *Scheduler {
+	db, err := bbolt.Open(path.Join(t.TempDir(), "swaps"), os.ModePerm, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	store, err := NewBBoltScheduleStore(db)
+	require.NoError(t, err)
+
+	s := NewScheduler(store, swaps, "our-node")
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func Test_Scheduler_OneShot(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	swaps := &dummySwapService{}
+	s := setupScheduler(t, swaps, &now)
+
+	schedule, err := s.Create(&Schedule{
+		Type:      swap.SWAPTYPE_IN,
+		PeerId:    "peer",
+		ChannelId: "1x1x1",
+		Asset:     "btc",
+		AmountSat: 100000,
+		RunAt:     now.Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour).Unix(), schedule.NextRunAt)
+
+	s.runDue()
+	assert.Empty(t, swaps.started)
+
+	now = now.Add(time.Hour)
+	s.runDue()
+	s.runDue()
+	assert.Equal(t, []startedSwap{{swap.SWAPTYPE_IN, "peer", "1x1x1", 100000}}, swaps.started)
+	assert.Equal(t, "our-node", swaps.initiator)
+
+	schedules, err := s.List()
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.True(t, schedules[0].Done)
+	require.Len(t, schedules[0].Runs, 1)
+	assert.NotEmpty(t, schedules[0].Runs[0].SwapId)
+}
+
+func Test_Scheduler_Interval(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	swaps := &dummySwapService{maxSat: 42000}
+	s := setupScheduler(t, swaps, &now)
+
+	schedule, err := s.Create(&Schedule{
+		Type:        swap.SWAPTYPE_OUT,
+		PeerId:      "peer",
+		ChannelId:   "1x1x1",
+		Asset:       "lbtc",
+		Max:         true,
+		IntervalSec: 3600,
+	})
+	require.NoError(t, err)
+
+	// Missed runs are only run once and do not shift the interval.
+	now = now.Add(3*time.Hour + 10*time.Minute)
+	s.runDue()
+	require.Len(t, swaps.started, 1)
+	assert.Equal(t, uint64(42000), swaps.started[0].amtSat)
+
+	schedule, err = s.store.Get(schedule.Id)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 15, 14, 0, 0, 0, time.UTC).Unix(), schedule.NextRunAt)
+
+	// Paused schedules do not run.
+	_, err = s.SetPaused(schedule.Id, true)
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	s.runDue()
+	assert.Len(t, swaps.started, 1)
+
+	schedule, err = s.SetPaused(schedule.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 15, 15, 0, 0, 0, time.UTC).Unix(), schedule.NextRunAt)
+
+	require.NoError(t, s.Delete(schedule.Id))
+	schedules, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+}
+
+func Test_Scheduler_SkipAndErrorRuns(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	swaps := &dummySwapService{localSat: 500000}
+	s := setupScheduler(t, swaps, &now)
+
+	schedule, err := s.Create(&Schedule{
+		Type:                swap.SWAPTYPE_IN,
+		PeerId:              "peer",
+		ChannelId:           "1x1x1",
+		Asset:               "btc",
+		AmountSat:           100000,
+		Cron:                "0 * * * *",
+		OnlyIfLocalBelowSat: 200000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC).Unix(), schedule.NextRunAt)
+
+	now = now.Add(time.Hour)
+	s.runDue()
+
+	swaps.localSat = 100000
+	swaps.swapErr = errors.New("peer offline")
+	now = now.Add(time.Hour)
+	s.runDue()
+
+	swaps.swapErr = nil
+	now = now.Add(time.Hour)
+	s.runDue()
+
+	schedule, err = s.store.Get(schedule.Id)
+	require.NoError(t, err)
+	require.Len(t, schedule.Runs, 3)
+	assert.True(t, schedule.Runs[0].Skipped)
+	assert.Contains(t, schedule.Runs[0].Reason, "not below")
+	assert.Equal(t, "peer offline", schedule.Runs[1].Error)
+	assert.NotEmpty(t, schedule.Runs[2].SwapId)
+	assert.Len(t, swaps.started, 1)
+}
+
+func Test_Scheduler_CreateInvalid(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	s := setupScheduler(t, &dummySwapService{}, &now)
+
+	valid := func() *Schedule {
+		return &Schedule{
+			Type:        swap.SWAPTYPE_OUT,
+			PeerId:      "peer",
+			ChannelId:   "1x1x1",
+			Asset:       "btc",
+			AmountSat:   100000,
+			IntervalSec: 3600,
+		}
+	}
+	tests := map[string]func(*Schedule){
+		"no timing":      func(s *Schedule) { s.IntervalSec = 0 },
+		"two timings":    func(s *Schedule) { s.Cron = "0 0 * * *" },
+		"short interval": func(s *Schedule) { s.IntervalSec = 60 },
+		"past run time":  func(s *Schedule) { s.IntervalSec = 0; s.RunAt = now.Unix() },
+		"invalid cron":   func(s *Schedule) { s.IntervalSec = 0; s.Cron = "0 0 * *" },
+		"never matches":  func(s *Schedule) { s.IntervalSec = 0; s.Cron = "0 0 30 2 *" },
+		"no amount":      func(s *Schedule) { s.AmountSat = 0 },
+		"amount and max": func(s *Schedule) { s.Max = true },
+		"no channel":     func(s *Schedule) { s.ChannelId = "" },
+		"no type":        func(s *Schedule) { s.Type = 0 },
+	}
+	for name, modify := range tests {
+		schedule := valid()
+		modify(schedule)
+		_, err := s.Create(schedule)
+		assert.Error(t, err, name)
+	}
+}
+
+func Test_Scheduler_ChangeDuringRun(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	swaps := &dummySwapService{}
+	s := setupScheduler(t, swaps, &now)
+
+	schedule, err := s.Create(&Schedule{
+		Type:        swap.SWAPTYPE_IN,
+		PeerId:      "peer",
+		ChannelId:   "1x1x1",
+		Asset:       "btc",
+		AmountSat:   100000,
+		IntervalSec: 3600,
+	})
+	require.NoError(t, err)
+
+	// The scheduler does not hold its lock while a swap is started.
+	swaps.onStart = func() {
+		_, err := s.SetPaused(schedule.Id, true)
+		assert.NoError(t, err)
+	}
+	now = now.Add(time.Hour)
+	s.runDue()
+	assert.Len(t, swaps.started, 1)
+
+	schedules, err := s.List()
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.True(t, schedules[0].Paused)
+	assert.Len(t, schedules[0].Runs, 1)
+
+	// Schedules that are deleted while another schedule runs are not run.
+	require.NoError(t, s.Delete(schedule.Id))
+	var ids []string
+	for _, channelId := range []string{"1x1x1", "2x2x2"} {
+		created, err := s.Create(&Schedule{
+			Type:      swap.SWAPTYPE_IN,
+			PeerId:    "peer",
+			ChannelId: channelId,
+			Asset:     "btc",
+			AmountSat: 100000,
+			RunAt:     now.Add(time.Hour).Unix(),
+		})
+		require.NoError(t, err)
+		ids = append(ids, created.Id)
+	}
+	swaps.started = nil
+	swaps.onStart = func() {
+		for _, id := range ids {
+			err := s.Delete(id)
+			if !errors.Is(err, ErrScheduleNotFound) {
+				assert.NoError(t, err)
+			}
+		}
+	}
+	now = now.Add(time.Hour)
+	s.runDue()
+	assert.Len(t, swaps.started, 1)
+
+	schedules, err = s.List()
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+}
+
+func Test_Scheduler_StopTwice(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	s := setupScheduler(t, &dummySwapService{}, &now)
+	s.Start()
+	s.Stop()
+	assert.NotPanics(t, s.Stop)
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.etcd.io/bbolt"
+)
+
+var schedulesBucket = []byte("swap-schedules")
+
+// ErrScheduleNotFound is returned when a schedule is not found in the
+// database.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ScheduleStore persists the schedules.
+type ScheduleStore interface {
+	// Put creates or updates the schedule.
+	Put(schedule *Schedule) error
+	// Get returns the schedule or ErrScheduleNotFound.
+	Get(id string) (*Schedule, error)
+	// Delete removes the schedule or returns ErrScheduleNotFound.
+	Delete(id string) error
+	ListAll() ([]*Schedule, error)
+}
+
+type BBoltScheduleStore struct {
+	db *bbolt.DB
+}
+
+func NewBBoltScheduleStore(db *bbolt.DB) (*BBoltScheduleStore, error) {
+	tx, err := db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	_, err = tx.CreateBucketIfNotExists(schedulesBucket)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &BBoltScheduleStore{db: db}, nil
+}
+
+// Put creates or updates the schedule.
+func (s *BBoltScheduleStore) Put(schedule *Schedule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		buf, err := json.Marshal(schedule)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(schedulesBucket).Put([]byte(schedule.Id), buf)
+	})
+}
+
+func (s *BBoltScheduleStore) Get(id string) (*Schedule, error) {
+	schedule := &Schedule{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		buf := tx.Bucket(schedulesBucket).Get([]byte(id))
+		if buf == nil {
+			return ErrScheduleNotFound
+		}
+		return json.Unmarshal(buf, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *BBoltScheduleStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(schedulesBucket)
+		if b.Get([]byte(id)) == nil {
+			return ErrScheduleNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+func (s *BBoltScheduleStore) ListAll() ([]*Schedule, error) {
+	var schedules []*Schedule
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(schedulesBucket).ForEach(func(k, v []byte) error {
+			schedule := &Schedule{}
+			if err := json.Unmarshal(v, schedule); err != nil {
+				return err
+			}
+			schedules = append(schedules, schedule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
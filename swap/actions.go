@@ -24,7 +24,7 @@ type CheckRequestWrapperAction struct {
 }
 
 func (a CheckRequestWrapperAction) Execute(services *SwapServices, swap *SwapData) EventType {
-	if !services.policy.NewSwapsAllowed() {
+	if !services.policy.NewSwapsAllowed() || services.draining.Load() {
 		swap.LastErr = errors.New("swaps are disabled")
 		swap.CancelMessage = "swaps are disabled"
 		services.requestedSwapsStore.Add(swap.PeerNodeId, RequestedSwap{
@@ -182,6 +182,7 @@ func (s *SwapInReceiverInitAction) Execute(services *SwapServices, swap *SwapDat
 		SwapId:          swap.GetId(),
 		Pubkey:          hex.EncodeToString(swap.GetPrivkey().PubKey().SerializeCompressed()),
 		Premium:         premiumValue,
+		Confirmations:   swap.RequiredConfirmations,
 	}
 	swap.SwapInAgreement = agreementMessage
 
@@ -221,6 +222,14 @@ func (s *ClaimSwapTransactionWithPreimageAction) Execute(services *SwapServices,
 	}
 
 	if swap.ClaimTxId == "" {
+		if queueClaim(services, swap, ClaimKindPreimage, nil) {
+			return NoOp
+		}
+		err = resolveClaimAddress(services, swap)
+		if err != nil {
+			log.Infof("Error deriving claim address %v", err)
+			return Event_OnRetry
+		}
 		txId, _, address, err := wallet.CreatePreimageSpendingTransaction(swap.GetOpeningParams(), swap.GetClaimParams())
 		if err != nil {
 			log.Infof("Error claiming tx with preimage %v", err)
@@ -270,12 +279,34 @@ func (c *CreateAndBroadcastOpeningTransaction) Execute(services *SwapServices, s
 		return Event_ActionSucceeded
 	}
 
-	// Generate Preimage
-	preimage, err := lightning.GetPreimage()
-	if err != nil {
-		return swap.HandleError(err)
+	// A batched swap-in joins its batch first. The batch creates the opening
+	// transaction once all of its swaps joined.
+	if swap.batch != nil && swap.BatchFunding == nil {
+		return joinOpeningBatch(swap)
+	}
+
+	// An externally funded swap-in hands out the opening transaction as a
+	// PSBT first and continues once the signed transaction was submitted.
+	if swap.ExternalFunding != nil && swap.ExternalFunding.TxHex == "" {
+		return requestExternalFunding(services, swap)
+	}
+
+	var preimage lightning.Preimage
+	if swap.BatchFunding != nil || swap.ExternalFunding != nil {
+		// The preimage was generated when the swap joined its batch or
+		// requested its funding.
+		preimage, err = lightning.MakePreimageFromStr(swap.ClaimPreimage)
+		if err != nil {
+			return swap.HandleError(err)
+		}
+	} else {
+		// Generate Preimage
+		preimage, err = lightning.GetPreimage()
+		if err != nil {
+			return swap.HandleError(err)
+		}
+		swap.ClaimPreimage = hex.EncodeToString(preimage[:])
 	}
-	swap.ClaimPreimage = hex.EncodeToString(preimage[:])
 
 	policy, err := swap.getTimelockPolicy()
 	if err != nil {
@@ -296,17 +327,36 @@ func (c *CreateAndBroadcastOpeningTransaction) Execute(services *SwapServices, s
 		blindingKeyHex = hex.EncodeToString(blindingKey.Serialize())
 	}
 
-	// Create the opening transaction
-	txHex, address, txId, _, vout, err := wallet.CreateOpeningTransaction(&OpeningParams{
-		TakerPubkey:      swap.GetTakerPubkey(),
-		MakerPubkey:      swap.GetMakerPubkey(),
-		ClaimPaymentHash: preimage.Hash().String(),
-		Amount:           swap.GetOpeningTXAmount(),
-		CSV:              policy.CSV,
-		BlindingKey:      blindingKey,
-	})
-	if err != nil {
-		return swap.HandleError(err)
+	var txHex, address, txId string
+	var vout uint32
+	if funding := swap.BatchFunding; funding != nil {
+		txHex, address, txId, vout = funding.TxHex, funding.Address, funding.TxId, funding.Vout
+	} else if funding := swap.ExternalFunding; funding != nil {
+		txId, err = broadcastExternalFunding(services, swap)
+		if err != nil {
+			// The swap waits for another funding transaction until the
+			// funding expires.
+			swap.LastErr = fmt.Errorf("broadcasting the funding transaction: %w", err)
+			swap.LastErrString = swap.LastErr.Error()
+			funding.TxHex = ""
+			return Event_SwapInSender_OnExternalFundingRequested
+		}
+		txHex, address, vout = funding.TxHex, funding.Address, funding.Vout
+	} else {
+		// Create the opening transaction
+		txHex, address, txId, _, vout, err = wallet.CreateOpeningTransaction(&OpeningParams{
+			TakerPubkey:      swap.GetTakerPubkey(),
+			MakerPubkey:      swap.GetMakerPubkey(),
+			ClaimPaymentHash: preimage.Hash().String(),
+			Amount:           swap.GetOpeningTXAmount(),
+			CSV:              policy.CSV,
+			BlindingKey:      blindingKey,
+			FeeRate:          swap.FeeRate,
+			CoinSelection:    swap.CoinSelection,
+		})
+		if err != nil {
+			return swap.HandleError(err)
+		}
 	}
 
 	err = wallet.SetLabel(txId, address, labels.Opening(swap.GetId().Short()))
@@ -502,6 +552,7 @@ func (c *CreateSwapOutFromRequestAction) Execute(services *SwapServices, swap *S
 		Pubkey:          hex.EncodeToString(swap.GetPrivkey().PubKey().SerializeCompressed()),
 		Payreq:          feeInvoice,
 		Premium:         premiumValue,
+		Confirmations:   swap.RequiredConfirmations,
 	}
 	swap.SwapOutAgreement = message
 
@@ -530,6 +581,14 @@ func (c *ClaimSwapTransactionWithCsv) Execute(services *SwapServices, swap *Swap
 	}
 
 	if swap.ClaimTxId == "" {
+		if queueClaim(services, swap, ClaimKindCsv, nil) {
+			return NoOp
+		}
+		err = resolveClaimAddress(services, swap)
+		if err != nil {
+			swap.HandleError(err)
+			return Event_OnRetry
+		}
 		txId, _, address, err := wallet.CreateCsvSpendingTransaction(swap.GetOpeningParams(), swap.GetClaimParams())
 		if err != nil {
 			swap.HandleError(err)
@@ -562,6 +621,13 @@ func (c *ClaimSwapTransactionCoop) Execute(services *SwapServices, swap *SwapDat
 	takerKey, _ := btcec.PrivKeyFromBytes(takerKeyBytes)
 
 	if swap.ClaimTxId == "" {
+		if queueClaim(services, swap, ClaimKindCoop, &Secp256k1Signer{key: takerKey}) {
+			return NoOp
+		}
+		err = resolveClaimAddress(services, swap)
+		if err != nil {
+			return swap.HandleError(err)
+		}
 		txId, _, address, err := wallet.CreateCoopSpendingTransaction(swap.GetOpeningParams(), swap.GetClaimParams(), &Secp256k1Signer{key: takerKey})
 		if err != nil {
 			return swap.HandleError(err)
@@ -658,6 +724,10 @@ func (s *SendMessageAction) Execute(services *SwapServices, swap *SwapData) Even
 	return Event_ActionSucceeded
 }
 
+// maxMessageRetryTime caps the time between retries of a message that is
+// resent until the peer acks it.
+const maxMessageRetryTime = 10 * time.Minute
+
 type SendMessageWithRetryAction struct{}
 
 func (s *SendMessageWithRetryAction) Execute(services *SwapServices, swap *SwapData) EventType {
@@ -671,7 +741,14 @@ func (s *SendMessageWithRetryAction) Execute(services *SwapServices, swap *SwapD
     if isdev.FastTests() {
         retryDur = 1 * time.Second
     }
-    rm := messages.NewRedundantMessenger(services.messenger, retryDur)
+	// Peers that ack the message let us back off and stop once it was
+	// processed, others get the message until the swap ends.
+	var rm messages.StoppableMessenger
+	if services.peerSupportsMessageAck(swap.PeerNodeId) {
+		rm = messages.NewBackoffMessenger(services.messenger, retryDur, maxMessageRetryTime)
+	} else {
+		rm = messages.NewRedundantMessenger(services.messenger, retryDur)
+	}
 	err := services.messengerManager.AddSender(swap.GetId().String(), rm)
 	if err != nil {
 		return swap.HandleError(err)
@@ -697,28 +774,38 @@ func (r *PayFeeInvoiceAction) Execute(services *SwapServices, swap *SwapData) Ev
 		return swap.HandleError(err)
 	}
 
-	sp, err := ll.SpendableMsat(swap.SwapOutRequest.Scid)
-	if err != nil {
-		return swap.HandleError(err)
-	}
-
 	// Calculate the total required balance
 	// This includes the swap amount (multiplied by 1000 to convert from sat to msat)
 	// plus the fee in millisatoshis
 	requiredBalance := swap.SwapOutRequest.Amount*1000 + msatAmt
 
-	// Check if the spendable balance (sp) is sufficient
-	if sp < requiredBalance {
-		return swap.HandleError(fmt.Errorf("not enough spendable msat: %d, expected: %d", sp, requiredBalance))
+	// A swap-out across several channels splits the required balance over
+	// its channels.
+	scids := swap.GetScids()
+	spendable := make([]uint64, len(scids))
+	for i, scid := range scids {
+		spendable[i], err = ll.SpendableMsat(scid)
+		if err != nil {
+			return swap.HandleError(err)
+		}
 	}
-
-	// Probe the payment to check if it's possible
-	success, failureReason, err := ll.ProbePayment(swap.SwapOutRequest.Scid, requiredBalance)
+	parts, err := SplitAmountMsat(requiredBalance, spendable)
 	if err != nil {
 		return swap.HandleError(err)
 	}
-	if !success {
-		return swap.HandleError(fmt.Errorf("the prepayment probe was unsuccessful: %s", failureReason))
+
+	// Probe the payment to check if it's possible
+	for i, part := range parts {
+		if part == 0 {
+			continue
+		}
+		success, failureReason, err := ll.ProbePayment(scids[i], part)
+		if err != nil {
+			return swap.HandleError(err)
+		}
+		if !success {
+			return swap.HandleError(fmt.Errorf("the prepayment probe was unsuccessful: %s", failureReason))
+		}
 	}
 
 	swap.OpeningTxFee = msatAmt / 1000
@@ -886,6 +973,7 @@ func (t *AwaitTxConfirmationAction) Execute(services *SwapServices, swap *SwapDa
 		swap.OpeningTxBroadcasted.ScriptOut,
 		swap.StartingBlockHeight,
 		policy.PaymentWindow,
+		swap.GetRequiredConfirmations(),
 		wantScript,
 	)
 	log.Debugf("Await confirmation for tx with id: %s on swap %s", swap.OpeningTxBroadcasted.TxId, swap.GetId().String())
@@ -955,6 +1043,12 @@ func (p *ValidateTxAndPayClaimInvoiceAction) Execute(services *SwapServices, swa
 		case <-ctx.Done():
 			return swap.HandleError(fmt.Errorf("could not pay invoice: timeout, last err: %v", payErr))
 		case <-ticker.C:
+			if services.isTxUnconfirmed(swap.GetId().String()) {
+				// A reorg unconfirmed the opening tx, wait until it is
+				// confirmed again before we pay.
+				log.Infof("[Swap:%s] opening tx was unconfirmed, pausing claim payment", swap.GetId())
+				return Event_OnTxUnconfirmed
+			}
 			now, err := onchain.GetBlockHeight()
 			if err != nil {
 				return swap.HandleError(err)
@@ -972,7 +1066,7 @@ func (p *ValidateTxAndPayClaimInvoiceAction) Execute(services *SwapServices, swa
 			}
 			preimage, err = lc.RebalancePayment(
 				swap.OpeningTxBroadcasted.Payreq,
-				swap.GetScid(),
+				swap.GetScids(),
 				policy.MaxTotalCLTVDelta,
 			)
 			if err != nil {
@@ -0,0 +1,468 @@
+package swap
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/log"
+)
+
+var ErrBatchNotSupported = errors.New("wallet does not support batched opening transactions")
+
+// BatchWallet is implemented by wallets that can fund the opening outputs of
+// several swap-ins with a single transaction.
+type BatchWallet interface {
+	// CreateBatchOpeningTransaction creates a transaction with an opening
+	// output for every swap without broadcasting it. The outputs are
+	// returned in the order of swapParams.
+	CreateBatchOpeningTransaction(swapParams []*OpeningParams) (txHex, txId string, fee uint64, outputs []*BatchOutput, err error)
+	// BroadcastBatchOpeningTransaction broadcasts a transaction that was
+	// created by CreateBatchOpeningTransaction and returns the transaction
+	// as it was broadcast.
+	BroadcastBatchOpeningTransaction(txHex, txId string) (broadcastTxHex string, err error)
+}
+
+// BatchOutput is the opening output of a swap in a batched opening
+// transaction.
+type BatchOutput struct {
+	Address string
+	Vout    uint32
+}
+
+// BatchFunding is the output of a swap in the opening transaction of its
+// batch.
+type BatchFunding struct {
+	TxId    string `json:"tx_id"`
+	TxHex   string `json:"tx_hex"`
+	Address string `json:"address"`
+	Vout    uint32 `json:"vout"`
+	// FeeShare is the part of the transaction fee that falls on the swap. The
+	// fee is split evenly between the swaps of the batch.
+	FeeShare uint64 `json:"fee_share"`
+	// StartingBlockHeight is the block height at which the transaction was
+	// created.
+	StartingBlockHeight uint32 `json:"starting_block_height"`
+}
+
+// BatchSwapIn is a swap-in of a batch.
+type BatchSwapIn struct {
+	PeerId              string
+	ChannelId           string
+	AmountSat           uint64
+	PremiumLimitRatePpm int64
+}
+
+// BatchSwapInResult is the outcome of starting a swap-in of a batch. Swap is
+// nil if the swap-in could not be started.
+type BatchSwapInResult struct {
+	Swap *SwapStateMachine
+	Err  error
+}
+
+// SwapInBatch starts a swap-in for every entry and funds all of them with a
+// single opening transaction. The transaction is created once every peer
+// agreed to its swap. Swaps that fail or get canceled before that leave the
+// batch, the remaining swaps are funded without them. Returns the id of the
+// batch and the result for every entry in order.
+func (s *SwapService) SwapInBatch(chain string, initiator string, swaps []*BatchSwapIn) (string, []*BatchSwapInResult, error) {
+	if len(swaps) < 2 {
+		return "", nil, errors.New("a batch needs at least two swaps")
+	}
+
+	channels := map[string]bool{}
+	var total uint64
+	for _, sw := range swaps {
+		if channels[sw.ChannelId] {
+			return "", nil, fmt.Errorf("more than one swap on channel %s", sw.ChannelId)
+		}
+		channels[sw.ChannelId] = true
+		total += sw.AmountSat
+	}
+
+	_, wallet, _, err := s.swapServices.getOnChainServices(chain)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, ok := wallet.(BatchWallet); !ok {
+		return "", nil, ErrBatchNotSupported
+	}
+
+	maximumSwapAmountSat, err := s.estimateMaximumSwapAmountSat(chain)
+	if err != nil {
+		return "", nil, err
+	}
+	if total > maximumSwapAmountSat {
+		return "", nil, fmt.Errorf("exceeding maximum swap amount: %d", maximumSwapAmountSat)
+	}
+
+	batch := newOpeningBatch(s, chain)
+	results := make([]*BatchSwapInResult, len(swaps))
+	for i, sw := range swaps {
+		swap, err := s.SwapIn(sw.PeerId, chain, sw.ChannelId, initiator, sw.AmountSat, sw.PremiumLimitRatePpm, withOpeningBatch(batch))
+		if err != nil {
+			results[i] = &BatchSwapInResult{Err: err}
+			continue
+		}
+		batch.add(swap.SwapId.String())
+		results[i] = &BatchSwapInResult{Swap: swap}
+	}
+	batch.seal()
+
+	return batch.id, results, nil
+}
+
+// withOpeningBatch adds the swap-in to the opening batch.
+func withOpeningBatch(batch *openingBatch) SwapOption {
+	return func(data *SwapData) {
+		data.batch = batch
+		data.BatchId = batch.id
+	}
+}
+
+// openingBatch collects the opening outputs of the swap-ins of a batch. It
+// funds them once the batch is sealed and every remaining swap joined.
+type openingBatch struct {
+	id      string
+	chain   string
+	service *SwapService
+
+	sync.Mutex
+	members []string
+	params  map[string]*OpeningParams
+	left    map[string]bool
+	sealed  bool
+	funding bool
+}
+
+func newOpeningBatch(service *SwapService, chain string) *openingBatch {
+	return &openingBatch{
+		id:      NewSwapId().String(),
+		chain:   chain,
+		service: service,
+		params:  map[string]*OpeningParams{},
+		left:    map[string]bool{},
+	}
+}
+
+// add adds a swap to the batch.
+func (b *openingBatch) add(swapId string) {
+	b.Lock()
+	defer b.Unlock()
+	b.members = append(b.members, swapId)
+}
+
+// seal marks that all swaps were added to the batch.
+func (b *openingBatch) seal() {
+	b.Lock()
+	defer b.Unlock()
+	b.sealed = true
+	b.fundIfReady()
+}
+
+// join registers the opening output of a swap that is ready to be funded.
+func (b *openingBatch) join(swapId string, params *OpeningParams) error {
+	b.Lock()
+	defer b.Unlock()
+	if b.funding {
+		return fmt.Errorf("batch %s is already funded", b.id)
+	}
+	b.params[swapId] = params
+	b.fundIfReady()
+	return nil
+}
+
+// leave removes a swap that ended from the batch. A swap that leaves while
+// the batch is funded makes the funding fail before the transaction is
+// broadcast.
+func (b *openingBatch) leave(swapId string) {
+	b.Lock()
+	defer b.Unlock()
+	b.left[swapId] = true
+	if b.funding {
+		log.Infof("[Batch %s] swap %s ended while the batch is funded", b.id, swapId)
+		return
+	}
+	delete(b.params, swapId)
+	b.fundIfReady()
+}
+
+// fundIfReady funds the batch if it is sealed and every remaining swap
+// joined. The caller must hold the lock.
+func (b *openingBatch) fundIfReady() {
+	if !b.sealed || b.funding {
+		return
+	}
+	var swapIds []string
+	var params []*OpeningParams
+	for _, id := range b.members {
+		if b.left[id] {
+			continue
+		}
+		p, ok := b.params[id]
+		if !ok {
+			return
+		}
+		swapIds = append(swapIds, id)
+		params = append(params, p)
+	}
+	if len(swapIds) == 0 {
+		return
+	}
+	b.funding = true
+	// The swaps are waiting for the funding in their own state machines, so
+	// the funding has to happen outside of the call that joined the last swap.
+	go b.fund(swapIds, params)
+}
+
+// fund creates the opening transaction and hands every swap its output. Every
+// swap persists its output before the transaction is broadcast, so that the
+// output can be refunded after a restart. The transaction is not broadcast if
+// one of the swaps ended in the meantime.
+func (b *openingBatch) fund(swapIds []string, params []*OpeningParams) {
+	fundings, err := b.createFundings(params)
+	if err != nil {
+		log.Infof("[Batch %s] error creating the opening transaction: %v", b.id, err)
+	}
+	if err == nil {
+		err = b.persistFundings(swapIds, fundings)
+		if err != nil {
+			log.Infof("[Batch %s] not broadcasting the opening transaction: %v", b.id, err)
+		}
+	}
+	if err == nil {
+		err = b.broadcast(fundings)
+		if err != nil {
+			log.Infof("[Batch %s] error broadcasting the opening transaction: %v", b.id, err)
+		}
+	}
+	if err == nil {
+		log.Infof("[Batch %s] opening transaction %s funds %d swaps", b.id, fundings[0].TxId, len(swapIds))
+	}
+
+	for i, swapId := range swapIds {
+		swap, getErr := b.service.GetActiveSwap(swapId)
+		if getErr != nil {
+			continue
+		}
+
+		event := Event_SwapInSender_OnBatchFunded
+		result := &batchFundingResult{err: err}
+		if err == nil {
+			result.funding = fundings[i]
+		} else {
+			event = Event_ActionFailed
+		}
+		done, sendErr := swap.SendEvent(event, result)
+		if sendErr != nil {
+			log.Infof("[Batch %s] error sending the funding to swap %s: %v", b.id, swapId, sendErr)
+		}
+		if done {
+			b.service.RemoveActiveSwap(swapId)
+		}
+	}
+}
+
+// createFundings creates the opening transaction and returns the output of
+// every swap in the order of params.
+func (b *openingBatch) createFundings(params []*OpeningParams) ([]*BatchFunding, error) {
+	txWatcher, _, _, err := b.service.swapServices.getOnChainServices(b.chain)
+	if err != nil {
+		return nil, err
+	}
+	startingHeight, err := txWatcher.GetBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	batchWallet, err := b.wallet()
+	if err != nil {
+		return nil, err
+	}
+	txHex, txId, fee, outputs, err := batchWallet.CreateBatchOpeningTransaction(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) != len(params) {
+		return nil, fmt.Errorf("expected %d opening outputs, got %d", len(params), len(outputs))
+	}
+
+	fundings := make([]*BatchFunding, len(outputs))
+	for i, output := range outputs {
+		feeShare := fee / uint64(len(outputs))
+		if i == 0 {
+			feeShare += fee % uint64(len(outputs))
+		}
+		fundings[i] = &BatchFunding{
+			TxId:                txId,
+			TxHex:               txHex,
+			Address:             output.Address,
+			Vout:                output.Vout,
+			FeeShare:            feeShare,
+			StartingBlockHeight: startingHeight,
+		}
+	}
+	return fundings, nil
+}
+
+// persistFundings hands every swap its output before the transaction is
+// broadcast. Returns an error if one of the swaps did not take its output.
+func (b *openingBatch) persistFundings(swapIds []string, fundings []*BatchFunding) error {
+	for i, swapId := range swapIds {
+		b.Lock()
+		left := b.left[swapId]
+		b.Unlock()
+		if left {
+			return fmt.Errorf("swap %s ended", swapId)
+		}
+		swap, err := b.service.GetActiveSwap(swapId)
+		if err != nil {
+			return fmt.Errorf("swap %s ended", swapId)
+		}
+		_, err = swap.SendEvent(Event_SwapInSender_OnBatchFundingCreated, &batchFundingResult{funding: fundings[i]})
+		if err != nil {
+			return fmt.Errorf("swap %s did not take its output: %w", swapId, err)
+		}
+	}
+	return nil
+}
+
+// broadcast broadcasts the opening transaction and updates the fundings with
+// the transaction as it was broadcast.
+func (b *openingBatch) broadcast(fundings []*BatchFunding) error {
+	batchWallet, err := b.wallet()
+	if err != nil {
+		return err
+	}
+	txHex, err := batchWallet.BroadcastBatchOpeningTransaction(fundings[0].TxHex, fundings[0].TxId)
+	if err != nil {
+		return err
+	}
+	for _, funding := range fundings {
+		funding.TxHex = txHex
+	}
+	return nil
+}
+
+func (b *openingBatch) wallet() (BatchWallet, error) {
+	return batchWallet(b.service.swapServices, b.chain)
+}
+
+func batchWallet(services *SwapServices, chain string) (BatchWallet, error) {
+	_, wallet, _, err := services.getOnChainServices(chain)
+	if err != nil {
+		return nil, err
+	}
+	batchWallet, ok := wallet.(BatchWallet)
+	if !ok {
+		return nil, ErrBatchNotSupported
+	}
+	return batchWallet, nil
+}
+
+// batchFundingResult hands the outcome of the batch funding to a swap.
+type batchFundingResult struct {
+	funding *BatchFunding
+	err     error
+}
+
+func (r *batchFundingResult) ApplyToSwapData(data *SwapData) error {
+	if r.err != nil {
+		data.HandleError(r.err)
+		return nil
+	}
+	if r.funding != nil {
+		data.BatchFunding = r.funding
+	}
+	return nil
+}
+
+func (r *batchFundingResult) Validate(data *SwapData) error {
+	return nil
+}
+
+// joinOpeningBatch adds the opening output of a batched swap-in to its batch.
+// The preimage is generated here already as its hash is part of the output.
+func joinOpeningBatch(swap *SwapData) EventType {
+	preimage, err := lightning.GetPreimage()
+	if err != nil {
+		return swap.HandleError(err)
+	}
+	swap.ClaimPreimage = hex.EncodeToString(preimage[:])
+
+	policy, err := swap.getTimelockPolicy()
+	if err != nil {
+		return swap.HandleError(err)
+	}
+
+	params := &OpeningParams{
+		TakerPubkey:      swap.GetTakerPubkey(),
+		MakerPubkey:      swap.GetMakerPubkey(),
+		ClaimPaymentHash: preimage.Hash().String(),
+		Amount:           swap.GetOpeningTXAmount(),
+		CSV:              policy.CSV,
+		FeeRate:          swap.FeeRate,
+	}
+	if swap.GetChain() == l_btc_chain {
+		params.BlindingKey = swap.GetOpeningParams().BlindingKey
+	}
+
+	err = swap.batch.join(swap.GetId().String(), params)
+	if err != nil {
+		return swap.HandleError(err)
+	}
+	return Event_SwapInSender_OnBatchJoined
+}
+
+// AwaitBatchBroadcastAction waits for the batch to broadcast the opening
+// transaction of a swap that persisted its output. The batch is gone after a
+// restart and may not have broadcast the transaction yet, so a recovered swap
+// broadcasts it again.
+type AwaitBatchBroadcastAction struct{}
+
+func (a *AwaitBatchBroadcastAction) Execute(services *SwapServices, swap *SwapData) EventType {
+	if swap.batch != nil {
+		return NoOp
+	}
+	funding := swap.BatchFunding
+	batchWallet, err := batchWallet(services, swap.GetChain())
+	if err == nil {
+		var txHex string
+		txHex, err = batchWallet.BroadcastBatchOpeningTransaction(funding.TxHex, funding.TxId)
+		if err == nil {
+			funding.TxHex = txHex
+		}
+	}
+	if err != nil {
+		// The transaction was probably broadcast before the restart. The
+		// output is refunded after the csv in any case.
+		log.Infof("[Swap:%s] error broadcasting the batch opening transaction %s again: %v",
+			swap.GetId(), funding.TxId, err)
+	}
+	return Event_SwapInSender_OnBatchFunded
+}
+
+// RefundBatchFundingOnFailureWrapper waits for the csv instead of canceling a
+// swap whose output was funded by its batch.
+type RefundBatchFundingOnFailureWrapper struct {
+	next Action
+}
+
+func (a *RefundBatchFundingOnFailureWrapper) Execute(services *SwapServices, swap *SwapData) EventType {
+	event := a.next.Execute(services, swap)
+	funding := swap.BatchFunding
+	if event != Event_ActionFailed || funding == nil {
+		return event
+	}
+	log.Infof("[Swap:%s] refunding the batch output %s:%d after the csv: %v",
+		swap.GetId(), funding.TxId, funding.Vout, swap.LastErr)
+	swap.OpeningTxHex = funding.TxHex
+	swap.StartingBlockHeight = funding.StartingBlockHeight
+	swap.OpeningTxBroadcasted = &OpeningTxBroadcastedMessage{
+		SwapId:    swap.GetId(),
+		TxId:      funding.TxId,
+		ScriptOut: funding.Vout,
+	}
+	return Event_SwapInSender_OnFundedSwapFailed
+}
@@ -0,0 +1,146 @@
+package swap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SwapInBatch(t *testing.T) {
+	amount := uint64(200000)
+	initiator, bob, _, _, bobChannelId := getTestParams()
+	carol := "carol"
+	carolChannelId := "100x2x4"
+
+	aliceSwapService := getTestSetup(t, initiator)
+	bobSwapService := getTestSetup(t, bob)
+	carolSwapService := getTestSetup(t, carol)
+	aliceMessenger := aliceSwapService.swapServices.messenger.(*ConnectedMessenger)
+	bobMessenger := bobSwapService.swapServices.messenger.(*ConnectedMessenger)
+	carolMessenger := carolSwapService.swapServices.messenger.(*ConnectedMessenger)
+	aliceMessenger.peers = map[string]*ConnectedMessenger{bob: bobMessenger, carol: carolMessenger}
+	bobMessenger.other = aliceMessenger
+	carolMessenger.other = aliceMessenger
+
+	aliceMessenger.msgReceivedChan = make(chan messages.MessageType)
+	aliceMsgChan := aliceMessenger.msgReceivedChan
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+	require.NoError(t, carolSwapService.Start())
+
+	_, _, err := aliceSwapService.SwapInBatch(btc_chain, initiator, []*BatchSwapIn{
+		{PeerId: bob, ChannelId: bobChannelId, AmountSat: amount, PremiumLimitRatePpm: 100000},
+	})
+	assert.EqualError(t, err, "a batch needs at least two swaps")
+
+	_, _, err = aliceSwapService.SwapInBatch(btc_chain, initiator, []*BatchSwapIn{
+		{PeerId: bob, ChannelId: bobChannelId, AmountSat: amount, PremiumLimitRatePpm: 100000},
+		{PeerId: carol, ChannelId: bobChannelId, AmountSat: amount, PremiumLimitRatePpm: 100000},
+	})
+	assert.EqualError(t, err, "more than one swap on channel "+bobChannelId)
+
+	batchId, results, err := aliceSwapService.SwapInBatch(btc_chain, initiator, []*BatchSwapIn{
+		{PeerId: bob, ChannelId: bobChannelId, AmountSat: amount, PremiumLimitRatePpm: 100000},
+		{PeerId: carol, ChannelId: carolChannelId, AmountSat: amount, PremiumLimitRatePpm: 100000},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		require.NoError(t, res.Err)
+		assert.Equal(t, batchId, res.Swap.Data.BatchId)
+	}
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPINAGREEMENT, <-aliceMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPINAGREEMENT, <-aliceMsgChan)
+
+	// Both swaps are funded by the same transaction.
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+	require.Eventually(t, func() bool {
+		for _, res := range results {
+			swap, err := aliceSwapService.GetActiveSwap(res.Swap.SwapId.String())
+			if err != nil || swap.Data.OpeningTxBroadcasted == nil {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{2}, aliceChain.getBatchOpenings())
+
+	bobSwap, carolSwap := results[0].Swap.Data, results[1].Swap.Data
+	assert.Equal(t, bobSwap.OpeningTxBroadcasted.TxId, carolSwap.OpeningTxBroadcasted.TxId)
+	assert.Equal(t, []string{bobSwap.OpeningTxBroadcasted.TxId}, aliceChain.getBatchBroadcasts())
+	assert.Equal(t, uint32(0), bobSwap.OpeningTxBroadcasted.ScriptOut)
+	assert.Equal(t, uint32(1), carolSwap.OpeningTxBroadcasted.ScriptOut)
+	assert.NotEqual(t, bobSwap.ClaimPreimage, carolSwap.ClaimPreimage)
+}
+
+func Test_OpeningBatchFundsRemainingSwaps(t *testing.T) {
+	swapService := getTestSetup(t, "alice")
+	chain := swapService.swapServices.bitcoinWallet.(*dummyChain)
+
+	batch := newOpeningBatch(swapService, btc_chain)
+	batch.add("swap1")
+	batch.add("swap2")
+	require.NoError(t, batch.join("swap1", &OpeningParams{Amount: 100000}))
+
+	// The batch is funded once it is sealed.
+	batch.leave("swap2")
+	assert.Empty(t, chain.getBatchOpenings())
+
+	batch.seal()
+	require.Eventually(t, func() bool {
+		return len(chain.getBatchOpenings()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{1}, chain.getBatchOpenings())
+	// swap1 is not an active swap that could persist its output.
+	assert.Empty(t, chain.getBatchBroadcasts())
+
+	assert.Error(t, batch.join("swap2", &OpeningParams{Amount: 100000}))
+}
+
+func Test_BatchFundingRefund(t *testing.T) {
+	swapService := getTestSetup(t, "alice")
+	services := swapService.swapServices
+	chain := services.bitcoinWallet.(*dummyChain)
+
+	funding := &BatchFunding{TxId: "txid", TxHex: "txhex", Vout: 1, StartingBlockHeight: 100}
+	swap := &SwapData{
+		SwapInRequest: &SwapInRequestMessage{Network: "mainnet"},
+		BatchFunding:  funding,
+		batch:         newOpeningBatch(swapService, btc_chain),
+	}
+
+	// The batch broadcasts the transaction.
+	assert.Equal(t, NoOp, (&AwaitBatchBroadcastAction{}).Execute(services, swap))
+	assert.Empty(t, chain.getBatchBroadcasts())
+
+	// The batch is gone after a restart, the swap broadcasts the
+	// transaction again.
+	swap.batch = nil
+	assert.Equal(t, Event_SwapInSender_OnBatchFunded, (&AwaitBatchBroadcastAction{}).Execute(services, swap))
+	assert.Equal(t, []string{"txid"}, chain.getBatchBroadcasts())
+
+	// A funded swap that fails waits for the csv instead of canceling.
+	action := &RefundBatchFundingOnFailureWrapper{next: &failingAction{}}
+	assert.Equal(t, Event_SwapInSender_OnFundedSwapFailed, action.Execute(services, swap))
+	assert.Equal(t, "txid", swap.OpeningTxBroadcasted.TxId)
+	assert.Equal(t, uint32(1), swap.OpeningTxBroadcasted.ScriptOut)
+	assert.Equal(t, "txhex", swap.OpeningTxHex)
+	assert.Equal(t, uint32(100), swap.StartingBlockHeight)
+
+	// Nothing is funded before the swap joined its batch.
+	swap = &SwapData{SwapInRequest: &SwapInRequestMessage{Network: "mainnet"}}
+	assert.Equal(t, Event_ActionFailed, action.Execute(services, swap))
+	assert.Nil(t, swap.OpeningTxBroadcasted)
+}
+
+type failingAction struct{}
+
+func (a *failingAction) Execute(services *SwapServices, swap *SwapData) EventType {
+	return swap.HandleError(errors.New("failed"))
+}
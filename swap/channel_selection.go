@@ -0,0 +1,131 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ChannelCandidate is a channel to a peer that may be used for a swap.
+type ChannelCandidate struct {
+	ChannelId string
+	Active    bool
+}
+
+// ChannelChoice explains why a channel was or was not chosen for a swap.
+type ChannelChoice struct {
+	ChannelId string `json:"channel_id"`
+	// BalanceMsat is the spendable balance for a swap-out and the receivable
+	// balance for a swap-in.
+	BalanceMsat uint64 `json:"balance_msat"`
+	Chosen      bool   `json:"chosen"`
+	Reason      string `json:"reason"`
+}
+
+var ErrNoSuitableChannel = errors.New("no suitable channel to the peer")
+
+// SelectSwapOutChannel picks the active channel with the highest spendable
+// balance that can carry the swap-out. If the payment probe fails on a channel
+// the next best channel is tried. An amtSat of 0 selects the channel with the
+// highest spendable balance without checking the amount, as used for swapping
+// the maximum amount.
+func (s *SwapService) SelectSwapOutChannel(chain string, candidates []ChannelCandidate, amtSat uint64) (string, []*ChannelChoice, error) {
+	var requiredMsat uint64
+	if amtSat > 0 {
+		wallet, err := s.enabledWallet(chain)
+		if err != nil {
+			return "", nil, err
+		}
+		openingFee, err := wallet.GetFlatOpeningTXFee()
+		if err != nil {
+			return "", nil, err
+		}
+		// The fee invoice is paid over the same channel.
+		requiredMsat = (amtSat + openingFee) * 1000
+	}
+
+	choices := s.rankChannels(candidates, s.swapServices.lightning.SpendableMsat)
+	for i, c := range choices {
+		if c.Reason != "" {
+			continue
+		}
+		if c.BalanceMsat < requiredMsat {
+			c.Reason = fmt.Sprintf("not enough spendable msat, expected: %d", requiredMsat)
+			continue
+		}
+		if requiredMsat > 0 {
+			success, failureReason, err := s.swapServices.lightning.ProbePayment(c.ChannelId, requiredMsat)
+			if err != nil {
+				c.Reason = fmt.Sprintf("probe failed: %v", err)
+				continue
+			}
+			if !success {
+				c.Reason = fmt.Sprintf("probe failed: %s", failureReason)
+				continue
+			}
+		}
+		c.Chosen = true
+		c.Reason = choiceReason(i, "spendable")
+		return c.ChannelId, choices, nil
+	}
+	return "", choices, ErrNoSuitableChannel
+}
+
+// SelectSwapInChannel picks the active channel with the highest receivable
+// balance that can carry the swap-in. An amtSat of 0 selects the channel with
+// the highest receivable balance without checking the amount.
+func (s *SwapService) SelectSwapInChannel(candidates []ChannelCandidate, amtSat uint64) (string, []*ChannelChoice, error) {
+	choices := s.rankChannels(candidates, s.swapServices.lightning.ReceivableMsat)
+	for i, c := range choices {
+		if c.Reason != "" {
+			continue
+		}
+		if c.BalanceMsat < amtSat*1000 {
+			c.Reason = fmt.Sprintf("not enough receivable msat, expected: %d", amtSat*1000)
+			continue
+		}
+		c.Chosen = true
+		c.Reason = choiceReason(i, "receivable")
+		return c.ChannelId, choices, nil
+	}
+	return "", choices, ErrNoSuitableChannel
+}
+
+func choiceReason(rank int, balance string) string {
+	if rank == 0 {
+		return fmt.Sprintf("highest %s balance", balance)
+	}
+	return fmt.Sprintf("highest %s balance of the remaining channels", balance)
+}
+
+// rankChannels returns the candidates ordered by their balance, highest
+// first. Inactive channels, channels with an active swap and channels whose
+// balance can not be determined are ranked last with a reason set.
+func (s *SwapService) rankChannels(candidates []ChannelCandidate, balanceMsat func(scid string) (uint64, error)) []*ChannelChoice {
+	choices := make([]*ChannelChoice, 0, len(candidates))
+	for _, candidate := range candidates {
+		c := &ChannelChoice{ChannelId: candidate.ChannelId}
+		choices = append(choices, c)
+		if !candidate.Active {
+			c.Reason = "channel is not active"
+			continue
+		}
+		if err := s.checkNoActiveSwap(candidate.ChannelId); err != nil {
+			c.Reason = err.Error()
+			continue
+		}
+		balance, err := balanceMsat(candidate.ChannelId)
+		if err != nil {
+			c.Reason = err.Error()
+			continue
+		}
+		c.BalanceMsat = balance
+	}
+	sort.SliceStable(choices, func(i, j int) bool {
+		if (choices[i].Reason == "") != (choices[j].Reason == "") {
+			return choices[i].Reason == ""
+		}
+		return choices[i].BalanceMsat > choices[j].BalanceMsat
+	})
+	return choices
+}
@@ -0,0 +1,81 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SelectSwapOutChannel(t *testing.T) {
+	initiator, _, _, _, _ := getTestParams()
+	swapService := getTestSetup(t, initiator)
+	lc := swapService.swapServices.lightning.(*dummyLightningClient)
+	lc.channelBalanceMsat = map[string]uint64{
+		"1x1x1": 50000 * 1000,
+		"2x2x2": 900000 * 1000,
+		"3x3x3": 500000 * 1000,
+		"4x4x4": 2000000 * 1000,
+	}
+	candidates := []ChannelCandidate{
+		{ChannelId: "1x1x1", Active: true},
+		{ChannelId: "2x2x2", Active: true},
+		{ChannelId: "3x3x3", Active: true},
+		{ChannelId: "4x4x4", Active: false},
+	}
+
+	chosen, choices, err := swapService.SelectSwapOutChannel(btc_chain, candidates, 100000)
+	require.NoError(t, err)
+	assert.Equal(t, "2x2x2", chosen)
+	assert.Len(t, choices, 4)
+	assert.True(t, choices[0].Chosen)
+	assert.Equal(t, "highest spendable balance", choices[0].Reason)
+	assert.Equal(t, "4x4x4", choices[3].ChannelId)
+	assert.Equal(t, "channel is not active", choices[3].Reason)
+
+	// A failing probe falls back to the next best channel.
+	lc.probeFailures = map[string]string{"2x2x2": "no route"}
+	chosen, choices, err = swapService.SelectSwapOutChannel(btc_chain, candidates, 100000)
+	require.NoError(t, err)
+	assert.Equal(t, "3x3x3", chosen)
+	assert.Equal(t, "probe failed: no route", choices[0].Reason)
+	assert.False(t, choices[0].Chosen)
+	assert.True(t, choices[1].Chosen)
+
+	// No channel is able to carry the amount.
+	_, choices, err = swapService.SelectSwapOutChannel(btc_chain, candidates, 600000)
+	assert.ErrorIs(t, err, ErrNoSuitableChannel)
+	for _, c := range choices {
+		assert.False(t, c.Chosen)
+		assert.NotEmpty(t, c.Reason)
+	}
+}
+
+func Test_SelectSwapInChannel(t *testing.T) {
+	initiator, _, _, _, _ := getTestParams()
+	swapService := getTestSetup(t, initiator)
+	lc := swapService.swapServices.lightning.(*dummyLightningClient)
+	lc.channelBalanceMsat = map[string]uint64{
+		"1x1x1": 300000 * 1000,
+		"2x2x2": 100000 * 1000,
+	}
+	candidates := []ChannelCandidate{
+		{ChannelId: "1x1x1", Active: true},
+		{ChannelId: "2x2x2", Active: true},
+	}
+
+	chosen, _, err := swapService.SelectSwapInChannel(candidates, 200000)
+	require.NoError(t, err)
+	assert.Equal(t, "1x1x1", chosen)
+
+	// Channels with an active swap are skipped.
+	swapService.activeSwaps["swap"] = &SwapStateMachine{Data: &SwapData{SwapInRequest: &SwapInRequestMessage{Scid: "1x1x1"}}}
+	_, choices, err := swapService.SelectSwapInChannel(candidates, 200000)
+	assert.ErrorIs(t, err, ErrNoSuitableChannel)
+	assert.Equal(t, "2x2x2", choices[0].ChannelId)
+	assert.Contains(t, choices[0].Reason, "not enough receivable msat")
+
+	chosen, _, err = swapService.SelectSwapInChannel(candidates, 50000)
+	require.NoError(t, err)
+	assert.Equal(t, "2x2x2", chosen)
+}
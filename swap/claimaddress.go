@@ -0,0 +1,83 @@
+package swap
+
+import (
+	"fmt"
+)
+
+// ClaimAddressDeriver derives fresh addresses that swap outputs are claimed
+// to, e.g. from the xpub of a cold storage wallet.
+type ClaimAddressDeriver interface {
+	// DeriveClaimAddress returns the address at the derivation index.
+	DeriveClaimAddress(index uint32) (string, error)
+}
+
+// ClaimAddressIndexStore keeps track of the derivation indexes that were
+// used for claim addresses.
+type ClaimAddressIndexStore interface {
+	// NextIndex returns the next unused derivation index of the chain and
+	// marks it as used.
+	NextIndex(chain string) (uint32, error)
+}
+
+// WithClaimAddress claims the swap output to the address instead of to the
+// wallet.
+func WithClaimAddress(address string) SwapOption {
+	return func(data *SwapData) {
+		data.ClaimAddress = address
+	}
+}
+
+// SetClaimAddressDeriver claims the outputs of all swaps on the chain that
+// have no claim address of their own to addresses of the deriver. The
+// derivation index of the last used address is kept in the index store.
+func (s *SwapServices) SetClaimAddressDeriver(chain string, deriver ClaimAddressDeriver, indexStore ClaimAddressIndexStore) {
+	if s.claimAddressDerivers == nil {
+		s.claimAddressDerivers = map[string]ClaimAddressDeriver{}
+	}
+	s.claimAddressDerivers[chain] = deriver
+	s.claimAddressIndexStore = indexStore
+}
+
+// validateClaimAddress returns an error if the address can not be paid to on
+// the chain.
+func (s *SwapServices) validateClaimAddress(chain, address string) error {
+	_, _, validator, err := s.getOnChainServices(chain)
+	if err != nil {
+		return err
+	}
+	if err := validator.ValidateAddress(address); err != nil {
+		return fmt.Errorf("invalid claim address %s: %w", address, err)
+	}
+	return nil
+}
+
+// deriveClaimAddress returns a fresh address of the deriver of the chain, or
+// an empty string if the chain has no deriver.
+func (s *SwapServices) deriveClaimAddress(chain string) (string, error) {
+	deriver, ok := s.claimAddressDerivers[chain]
+	if !ok {
+		return "", nil
+	}
+	index, err := s.claimAddressIndexStore.NextIndex(chain)
+	if err != nil {
+		return "", err
+	}
+	return deriver.DeriveClaimAddress(index)
+}
+
+// resolveClaimAddress sets the claim address of a swap that has none to a
+// fresh address of the deriver of its chain. The address is only derived
+// once the output is claimed so that swaps that never claim do not leave
+// gaps in the derivation indexes.
+func resolveClaimAddress(services *SwapServices, swap *SwapData) error {
+	if swap.ClaimAddress != "" {
+		return nil
+	}
+	address, err := services.deriveClaimAddress(swap.GetChain())
+	if err != nil {
+		return err
+	}
+	swap.ClaimAddress = address
+	swap.ClaimAddressDerived = address != ""
+	return nil
+}
@@ -0,0 +1,64 @@
+package swap
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func Test_ClaimAddress(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	_, err := swapService.SwapOut(peer, btc_chain, channelId, initiator, 100000, 100000, WithClaimAddress("invalid"))
+	assert.ErrorContains(t, err, "invalid claim address")
+
+	db, err := bbolt.Open(path.Join(t.TempDir(), "swaps"), os.ModePerm, nil)
+	require.NoError(t, err)
+	indexStore, err := NewClaimAddressIndexStore(db)
+	require.NoError(t, err)
+	services := swapService.swapServices
+	services.SetClaimAddressDeriver(btc_chain, &claimAddressDeriverStub{prefix: "btc"}, indexStore)
+
+	// Swaps without a claim address of their own get fresh addresses.
+	first := &SwapData{SwapOutRequest: &SwapOutRequestMessage{Network: "mainnet"}}
+	second := &SwapData{SwapOutRequest: &SwapOutRequestMessage{Network: "mainnet"}}
+	require.NoError(t, resolveClaimAddress(services, first))
+	require.NoError(t, resolveClaimAddress(services, second))
+	assert.Equal(t, "btc0", first.ClaimAddress)
+	assert.Equal(t, "btc1", second.ClaimAddress)
+	assert.Equal(t, "btc0", first.GetClaimParams().ClaimAddress)
+
+	// A resolved address is kept on retries.
+	require.NoError(t, resolveClaimAddress(services, first))
+	assert.Equal(t, "btc0", first.ClaimAddress)
+
+	external := &SwapData{SwapOutRequest: &SwapOutRequestMessage{Network: "mainnet"}, ClaimAddress: "external"}
+	require.NoError(t, resolveClaimAddress(services, external))
+	assert.Equal(t, "external", external.ClaimAddress)
+
+	// Chains without a deriver claim to the wallet.
+	liquid := &SwapData{SwapOutRequest: &SwapOutRequestMessage{Asset: "lbtc"}}
+	require.NoError(t, resolveClaimAddress(services, liquid))
+	assert.Empty(t, liquid.ClaimAddress)
+
+	// The index survives a restart.
+	indexStore, err = NewClaimAddressIndexStore(db)
+	require.NoError(t, err)
+	index, err := indexStore.NextIndex(btc_chain)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), index)
+}
+
+type claimAddressDeriverStub struct {
+	prefix string
+}
+
+func (d *claimAddressDeriverStub) DeriveClaimAddress(index uint32) (string, error) {
+	return fmt.Sprintf("%s%d", d.prefix, index), nil
+}
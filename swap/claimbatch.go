@@ -0,0 +1,291 @@
+package swap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elementsproject/peerswap/labels"
+	"github.com/elementsproject/peerswap/log"
+)
+
+// DefaultClaimBatchSafetyMarginBlocks is the default number of blocks before
+// the csv of a swap passes at which a claim by preimage is no longer held
+// back to be batched.
+const DefaultClaimBatchSafetyMarginBlocks = 6
+
+// claimDeadlineCheckInterval is the interval at which the block height is
+// checked against the deadlines of the claims by preimage that are held back.
+const claimDeadlineCheckInterval = time.Minute
+
+// ClaimKind is the spending path that a claim uses.
+type ClaimKind int
+
+const (
+	ClaimKindPreimage ClaimKind = iota
+	ClaimKindCsv
+	ClaimKindCoop
+)
+
+// BatchClaim is a swap output that is claimed together with the outputs of
+// other swaps.
+type BatchClaim struct {
+	Kind        ClaimKind
+	SwapParams  *OpeningParams
+	ClaimParams *ClaimParams
+	// TakerSigner signs for the taker on a cooperative claim.
+	TakerSigner Signer
+}
+
+// ClaimBatchWallet is implemented by wallets that can claim the outputs of
+// several swaps with a single transaction.
+type ClaimBatchWallet interface {
+	// CreateBatchSpendingTransaction creates and broadcasts a transaction
+	// that spends the outputs of all claims to claimAddress, or to the wallet
+	// if claimAddress is empty.
+	CreateBatchSpendingTransaction(claims []*BatchClaim, claimAddress string) (txId, txHex, address string, err error)
+}
+
+// EnableClaimBatching holds back claims for up to window so that the claims
+// of swaps that complete at about the same time are done in one transaction
+// per chain. A claim by preimage is done right away once the csv of its swap
+// passes within safetyMarginBlocks. Claims on wallets that do not implement
+// ClaimBatchWallet are done on their own.
+func (s *SwapService) EnableClaimBatching(window time.Duration, safetyMarginBlocks uint32) {
+	s.swapServices.claimBatcher = &claimBatcher{
+		service:               s,
+		window:                window,
+		safetyMarginBlocks:    safetyMarginBlocks,
+		deadlineCheckInterval: claimDeadlineCheckInterval,
+		pending:               map[string]*pendingClaims{},
+	}
+}
+
+// claimBatcher collects the claims of a chain until its window is over.
+type claimBatcher struct {
+	service               *SwapService
+	window                time.Duration
+	safetyMarginBlocks    uint32
+	deadlineCheckInterval time.Duration
+
+	sync.Mutex
+	pending map[string]*pendingClaims
+}
+
+type pendingClaims struct {
+	wallet    Wallet
+	txWatcher TxWatcher
+	claims    []*queuedClaim
+	timer     *time.Timer
+	// done stops the check of the deadlines once the claims are claimed.
+	done chan struct{}
+}
+
+type queuedClaim struct {
+	swapId string
+	// state is the state of the swap that queued the claim. The claim is
+	// dropped if the swap moved on until the batch is claimed.
+	state StateType
+	// deadline is the block height at which the csv of the swap passes. It
+	// is only set for claims by preimage.
+	deadline uint32
+	claim    *BatchClaim
+}
+
+// queueClaim adds the claim of the swap output to the batch of its chain.
+// Returns false if the output has to be claimed on its own.
+func queueClaim(services *SwapServices, swap *SwapData, kind ClaimKind, takerSigner Signer) bool {
+	b := services.claimBatcher
+	// Swaps with a claim address of their own are claimed on their own.
+	if b == nil || swap.claimBatchFailed || swap.ClaimAddress != "" {
+		return false
+	}
+	txWatcher, wallet, validator, err := services.getOnChainServices(swap.GetChain())
+	if err != nil {
+		return false
+	}
+	if _, ok := wallet.(ClaimBatchWallet); !ok {
+		return false
+	}
+
+	// A claim by preimage has to confirm before the maker can claim the
+	// output by csv.
+	urgent := false
+	var deadline uint32
+	if kind == ClaimKindPreimage {
+		height, err := txWatcher.GetBlockHeight()
+		if err != nil {
+			log.Infof("[Swap:%s]: error getting block height, claiming right away: %v", swap.GetId().Short(), err)
+			return false
+		}
+		deadline = swap.StartingBlockHeight + validator.GetCSVHeight()
+		urgent = b.isUrgent(height, deadline)
+
+		// The swap must not time out while its claim is held back, the
+		// timeout would hand out the private key.
+		if swap.toCancel != nil {
+			swap.toCancel()
+			swap.toCancel = nil
+		}
+		swap.claimQueued = true
+	}
+
+	b.add(swap.GetChain(), wallet, txWatcher, &queuedClaim{
+		swapId:   swap.GetId().String(),
+		state:    swap.GetCurrentState(),
+		deadline: deadline,
+		claim: &BatchClaim{
+			Kind:        kind,
+			SwapParams:  swap.GetOpeningParams(),
+			ClaimParams: swap.GetClaimParams(),
+			TakerSigner: takerSigner,
+		},
+	}, urgent)
+	return true
+}
+
+// isUrgent returns true if a claim by preimage with the deadline has to be
+// claimed at the block height.
+func (b *claimBatcher) isUrgent(height, deadline uint32) bool {
+	return height+b.safetyMarginBlocks >= deadline
+}
+
+func (b *claimBatcher) add(chain string, wallet Wallet, txWatcher TxWatcher, claim *queuedClaim, urgent bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	p, ok := b.pending[chain]
+	if !ok {
+		p = &pendingClaims{wallet: wallet, txWatcher: txWatcher, done: make(chan struct{})}
+		p.timer = time.AfterFunc(b.window, func() { b.claim(chain) })
+		b.pending[chain] = p
+		go b.checkDeadlines(chain, p)
+	}
+	replaced := false
+	for i, c := range p.claims {
+		if c.swapId == claim.swapId {
+			p.claims[i] = claim
+			replaced = true
+		}
+	}
+	if !replaced {
+		p.claims = append(p.claims, claim)
+	}
+
+	if urgent && p.timer.Stop() {
+		// The swap that queued the claim holds its lock until it returns.
+		go b.claim(chain)
+	}
+}
+
+// checkDeadlines claims the pending claims of the chain before their window
+// is over if the csv of one of the claims by preimage is about to pass.
+func (b *claimBatcher) checkDeadlines(chain string, p *pendingClaims) {
+	ticker := time.NewTicker(b.deadlineCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		height, err := p.txWatcher.GetBlockHeight()
+		if err != nil {
+			log.Infof("[ClaimBatch] error getting block height: %v", err)
+			continue
+		}
+		b.Lock()
+		urgent := false
+		for _, c := range p.claims {
+			if c.deadline > 0 && b.isUrgent(height, c.deadline) {
+				urgent = true
+			}
+		}
+		if urgent && p.timer.Stop() {
+			b.Unlock()
+			b.claim(chain)
+			return
+		}
+		b.Unlock()
+	}
+}
+
+// claim claims the pending outputs of the chain and hands the claim
+// transaction to their swaps.
+func (b *claimBatcher) claim(chain string) {
+	b.Lock()
+	p, ok := b.pending[chain]
+	delete(b.pending, chain)
+	b.Unlock()
+	if !ok {
+		return
+	}
+	close(p.done)
+
+	var swaps []*SwapStateMachine
+	var claims []*BatchClaim
+	for _, c := range p.claims {
+		swap, err := b.service.GetActiveSwap(c.swapId)
+		if err != nil || swap.Data.GetCurrentState() != c.state {
+			continue
+		}
+		swaps = append(swaps, swap)
+		claims = append(claims, c.claim)
+	}
+	if len(claims) == 0 {
+		return
+	}
+
+	claimAddress, err := b.service.swapServices.deriveClaimAddress(chain)
+	var txId, address string
+	if err == nil {
+		txId, _, address, err = p.wallet.(ClaimBatchWallet).CreateBatchSpendingTransaction(claims, claimAddress)
+	}
+	if err != nil {
+		log.Infof("[ClaimBatch] error claiming %d swaps in one transaction, claiming them on their own: %v", len(claims), err)
+	} else {
+		var swapIds []string
+		for _, swap := range swaps {
+			swapIds = append(swapIds, swap.SwapId.String())
+		}
+		log.Infof("[ClaimBatch] claimed %d swaps in %s", len(claims), txId)
+		err := p.wallet.SetLabel(txId, address, labels.ClaimBatch(swapIds))
+		if err != nil {
+			log.Infof("Error labeling transaction. txid: %s, label: %s, error: %v",
+				txId, labels.ClaimBatch(swapIds), err)
+		}
+	}
+
+	for _, swap := range swaps {
+		done, sendErr := swap.SendEvent(Event_OnClaimBatched, &claimBatchResult{txId: txId, claimAddress: claimAddress, err: err})
+		if sendErr != nil {
+			log.Infof("[ClaimBatch] error handing the claim to swap %s: %v", swap.SwapId.String(), sendErr)
+		}
+		if done {
+			b.service.RemoveActiveSwap(swap.SwapId.String())
+		}
+	}
+}
+
+// claimBatchResult hands the claim transaction of a batch to a swap.
+type claimBatchResult struct {
+	txId string
+	// claimAddress is the derived address that the batch was claimed to.
+	claimAddress string
+	err          error
+}
+
+func (r *claimBatchResult) ApplyToSwapData(data *SwapData) error {
+	data.claimQueued = false
+	if r.err != nil {
+		data.claimBatchFailed = true
+		return nil
+	}
+	data.ClaimTxId = r.txId
+	data.ClaimAddress = r.claimAddress
+	return nil
+}
+
+func (r *claimBatchResult) Validate(data *SwapData) error {
+	return nil
+}
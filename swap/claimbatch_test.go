@@ -0,0 +1,154 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClaimBatch(t *testing.T) {
+	aliceSwapService, swaps := getClaimBatchTestSetup(t, time.Hour)
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+
+	// The claims are held back until the window is over.
+	for _, swap := range swaps {
+		require.NoError(t, aliceChain.txConfirmedFunc(swap.SwapId.String(), swap.Data.OpeningTxHex, nil))
+		assert.Equal(t, State_SwapOutSender_ClaimSwap, swap.Current)
+		assert.Empty(t, swap.Data.ClaimTxId)
+	}
+	assert.Empty(t, aliceChain.getBatchClaims())
+
+	aliceSwapService.swapServices.claimBatcher.claim(btc_chain)
+
+	assert.Equal(t, []int{2}, aliceChain.getBatchClaims())
+	for _, swap := range swaps {
+		assert.Equal(t, State_ClaimedPreimage, swap.Current)
+	}
+	assert.NotEmpty(t, swaps[0].Data.ClaimTxId)
+	assert.Equal(t, swaps[0].Data.ClaimTxId, swaps[1].Data.ClaimTxId)
+}
+
+func Test_ClaimBatchFailed(t *testing.T) {
+	aliceSwapService, swaps := getClaimBatchTestSetup(t, time.Hour)
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+	aliceChain.failBatchClaims = true
+
+	for _, swap := range swaps {
+		require.NoError(t, aliceChain.txConfirmedFunc(swap.SwapId.String(), swap.Data.OpeningTxHex, nil))
+	}
+	aliceSwapService.swapServices.claimBatcher.claim(btc_chain)
+
+	// Every swap claims its output on its own.
+	for _, swap := range swaps {
+		assert.Equal(t, State_ClaimedPreimage, swap.Current)
+		assert.NotEmpty(t, swap.Data.ClaimTxId)
+	}
+	assert.NotEqual(t, swaps[0].Data.ClaimTxId, swaps[1].Data.ClaimTxId)
+}
+
+func Test_ClaimBatchDeadline(t *testing.T) {
+	aliceSwapService, swaps := getClaimBatchTestSetup(t, time.Hour)
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+
+	require.NoError(t, aliceChain.txConfirmedFunc(swaps[0].SwapId.String(), swaps[0].Data.OpeningTxHex, nil))
+
+	// The csv passes within the safety margin, the batch is claimed right
+	// away.
+	aliceChain.returnGetCSVHeight = DefaultClaimBatchSafetyMarginBlocks
+	require.NoError(t, aliceChain.txConfirmedFunc(swaps[1].SwapId.String(), swaps[1].Data.OpeningTxHex, nil))
+
+	require.Eventually(t, func() bool {
+		return len(aliceChain.getBatchClaims()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{2}, aliceChain.getBatchClaims())
+}
+
+func Test_ClaimBatchDeadlineRecheck(t *testing.T) {
+	aliceSwapService, swaps := getClaimBatchTestSetup(t, time.Hour)
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+	aliceSwapService.swapServices.claimBatcher.deadlineCheckInterval = 10 * time.Millisecond
+
+	require.NoError(t, aliceChain.txConfirmedFunc(swaps[0].SwapId.String(), swaps[0].Data.OpeningTxHex, nil))
+	assert.Empty(t, aliceChain.getBatchClaims())
+
+	// The csv comes within the safety margin while the claim is held back.
+	aliceChain.setBlockHeight(swaps[0].Data.StartingBlockHeight + aliceChain.returnGetCSVHeight - DefaultClaimBatchSafetyMarginBlocks)
+
+	require.Eventually(t, func() bool {
+		return len(aliceChain.getBatchClaims()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{1}, aliceChain.getBatchClaims())
+	assert.True(t, swaps[0].WaitForStateChange(func(st StateType) bool {
+		return st == State_ClaimedPreimage
+	}, 5*time.Second))
+}
+
+func Test_ClaimBatchTimeout(t *testing.T) {
+	aliceSwapService, swaps := getClaimBatchTestSetup(t, time.Hour)
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+
+	require.NoError(t, aliceChain.txConfirmedFunc(swaps[0].SwapId.String(), swaps[0].Data.OpeningTxHex, nil))
+	assert.Nil(t, swaps[0].Data.toCancel)
+
+	// A timeout that fires while the claim is held back does not hand out
+	// the private key.
+	aliceSwapService.createTimeoutCallback(swaps[0].SwapId.String())()
+	assert.Equal(t, State_SwapOutSender_ClaimSwap, swaps[0].Current)
+
+	aliceSwapService.swapServices.claimBatcher.claim(btc_chain)
+	assert.Equal(t, State_ClaimedPreimage, swaps[0].Current)
+}
+
+// getClaimBatchTestSetup returns alice with claim batching enabled and two
+// swap-outs to bob and carol that await the confirmation of their opening
+// transactions.
+func getClaimBatchTestSetup(t *testing.T, window time.Duration) (*SwapService, []*SwapStateMachine) {
+	amount := uint64(100000)
+	initiator, bob, _, _, bobChannelId := getTestParams()
+	carol := "carol"
+	carolChannelId := "100x2x4"
+
+	aliceSwapService := getTestSetup(t, initiator)
+	bobSwapService := getTestSetup(t, bob)
+	carolSwapService := getTestSetup(t, carol)
+	aliceMessenger := aliceSwapService.swapServices.messenger.(*ConnectedMessenger)
+	bobMessenger := bobSwapService.swapServices.messenger.(*ConnectedMessenger)
+	carolMessenger := carolSwapService.swapServices.messenger.(*ConnectedMessenger)
+	aliceMessenger.peers = map[string]*ConnectedMessenger{bob: bobMessenger, carol: carolMessenger}
+	bobMessenger.other = aliceMessenger
+	carolMessenger.other = aliceMessenger
+
+	aliceMessenger.msgReceivedChan = make(chan messages.MessageType)
+	bobMessenger.msgReceivedChan = make(chan messages.MessageType)
+	carolMessenger.msgReceivedChan = make(chan messages.MessageType)
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+	require.NoError(t, carolSwapService.Start())
+	aliceSwapService.EnableClaimBatching(window, DefaultClaimBatchSafetyMarginBlocks)
+
+	var swaps []*SwapStateMachine
+	for _, peer := range []struct {
+		id        string
+		channelId string
+		service   *SwapService
+		messenger *ConnectedMessenger
+	}{
+		{bob, bobChannelId, bobSwapService, bobMessenger},
+		{carol, carolChannelId, carolSwapService, carolMessenger},
+	} {
+		swap, err := aliceSwapService.SwapOut(peer.id, btc_chain, peer.channelId, initiator, amount, 100000)
+		require.NoError(t, err)
+
+		assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-peer.messenger.msgReceivedChan)
+		assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMessenger.msgReceivedChan)
+
+		peer.service.swapServices.lightning.(*dummyLightningClient).TriggerPayment(swap.SwapId.String(), INVOICE_FEE)
+		assert.Equal(t, messages.MESSAGETYPE_OPENINGTXBROADCASTED, <-aliceMessenger.msgReceivedChan)
+		swaps = append(swaps, swap)
+	}
+	return aliceSwapService, swaps
+}
@@ -0,0 +1,148 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrCoinSelectionNotSupported is returned if a swap-in with a coin selection
+// is started on a chain whose wallet can not choose the funding utxos.
+var ErrCoinSelectionNotSupported = errors.New("the wallet does not support coin selection")
+
+// ErrCoinSelectionSwapOut is returned if a swap-out is started with a coin
+// selection. Only the maker of a swap-in funds an opening transaction.
+var ErrCoinSelectionSwapOut = errors.New("coin selection only applies to swap-ins")
+
+// CoinSelectionWallet is a wallet that funds the opening transaction of a
+// swap-in from chosen utxos, see WithCoinSelection.
+type CoinSelectionWallet interface {
+	// ValidateCoinSelection returns an error if one of the selected utxos
+	// is not an unspent output of the wallet that can fund the opening
+	// transaction.
+	ValidateCoinSelection(selection *CoinSelection) error
+}
+
+// CoinSelection restricts the utxos that fund the opening transaction of a
+// swap-in. Outpoints are of the form txid:vout.
+type CoinSelection struct {
+	// Utxos are the only utxos that may fund the opening transaction.
+	Utxos []string `json:"utxos,omitempty"`
+
+	// ExcludeUtxos are utxos that must not fund the opening transaction.
+	ExcludeUtxos []string `json:"exclude_utxos,omitempty"`
+}
+
+// NewCoinSelection returns the coin selection of the outpoints, or nil if
+// none is set. An outpoint can not be both used and excluded.
+func NewCoinSelection(utxos, excludeUtxos []string) (*CoinSelection, error) {
+	if len(utxos) == 0 && len(excludeUtxos) == 0 {
+		return nil, nil
+	}
+	selection := &CoinSelection{}
+	seen := map[string]bool{}
+	for _, u := range utxos {
+		outpoint, err := ParseOutpoint(u)
+		if err != nil {
+			return nil, err
+		}
+		if seen[outpoint] {
+			return nil, fmt.Errorf("duplicate utxo %s", outpoint)
+		}
+		seen[outpoint] = true
+		selection.Utxos = append(selection.Utxos, outpoint)
+	}
+	for _, u := range excludeUtxos {
+		outpoint, err := ParseOutpoint(u)
+		if err != nil {
+			return nil, err
+		}
+		if seen[outpoint] {
+			return nil, fmt.Errorf("utxo %s is both used and excluded", outpoint)
+		}
+		seen[outpoint] = true
+		selection.ExcludeUtxos = append(selection.ExcludeUtxos, outpoint)
+	}
+	return selection, nil
+}
+
+// ParseOutpoint returns the normalized form txid:vout of the outpoint.
+func ParseOutpoint(outpoint string) (string, error) {
+	op, err := wire.NewOutPointFromString(outpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid utxo %q, expected txid:vout: %w", outpoint, err)
+	}
+	return op.String(), nil
+}
+
+// Allows returns true if the outpoint may fund the opening transaction.
+func (c *CoinSelection) Allows(outpoint string) bool {
+	if c == nil {
+		return true
+	}
+	for _, u := range c.ExcludeUtxos {
+		if u == outpoint {
+			return false
+		}
+	}
+	if len(c.Utxos) == 0 {
+		return true
+	}
+	for _, u := range c.Utxos {
+		if u == outpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckInputs returns an error if one of the outpoints that fund an opening
+// transaction is not allowed by the coin selection.
+func (c *CoinSelection) CheckInputs(outpoints []string) error {
+	for _, outpoint := range outpoints {
+		if c.Allows(outpoint) {
+			continue
+		}
+		if len(c.Utxos) > 0 {
+			return fmt.Errorf("the selected utxos do not cover the opening transaction, "+
+				"the wallet added utxo %s", outpoint)
+		}
+		return fmt.Errorf("the wallet funded the opening transaction with excluded utxo %s", outpoint)
+	}
+	return nil
+}
+
+// OpeningCoinSelection returns the coin selection of the opening params.
+// Batched openings have no coin selection.
+func OpeningCoinSelection(params []*OpeningParams) *CoinSelection {
+	if len(params) != 1 {
+		return nil
+	}
+	return params[0].CoinSelection
+}
+
+// WithCoinSelection funds the opening transaction of a swap-in only from the
+// utxos that the coin selection allows.
+func WithCoinSelection(selection *CoinSelection) SwapOption {
+	return func(data *SwapData) {
+		data.CoinSelection = selection
+	}
+}
+
+// validateCoinSelection returns an error if the wallet of the chain can not
+// fund the opening transaction from the selected utxos.
+func (s *SwapServices) validateCoinSelection(chain string, selection *CoinSelection) error {
+	_, wallet, _, err := s.getOnChainServices(chain)
+	if err != nil {
+		return err
+	}
+	if (chain == btc_chain && !s.bitcoinEnabled) || (chain == l_btc_chain && !s.liquidEnabled) {
+		return fmt.Errorf("%s swaps are not enabled", chain)
+	}
+	csWallet, ok := wallet.(CoinSelectionWallet)
+	if !ok {
+		return ErrCoinSelectionNotSupported
+	}
+	return csWallet.ValidateCoinSelection(selection)
+}
@@ -0,0 +1,68 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCoinSelection(t *testing.T) {
+	const (
+		txid = "0000000000000000000000000000000000000000000000000000000000000001"
+		utxo = txid + ":0"
+	)
+
+	selection, err := NewCoinSelection(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, selection)
+
+	selection, err = NewCoinSelection([]string{utxo}, []string{txid + ":1"})
+	require.NoError(t, err)
+	assert.Equal(t, &CoinSelection{Utxos: []string{utxo}, ExcludeUtxos: []string{txid + ":1"}}, selection)
+
+	for _, tt := range []struct {
+		utxos, excludeUtxos []string
+	}{
+		{utxos: []string{txid}},
+		{utxos: []string{"abc:0"}},
+		{utxos: []string{txid + ":x"}},
+		{utxos: []string{utxo, utxo}},
+		{utxos: []string{utxo}, excludeUtxos: []string{utxo}},
+	} {
+		_, err := NewCoinSelection(tt.utxos, tt.excludeUtxos)
+		assert.Error(t, err, tt)
+	}
+}
+
+func TestCoinSelection_CheckInputs(t *testing.T) {
+	const (
+		a = "0000000000000000000000000000000000000000000000000000000000000001:0"
+		b = "0000000000000000000000000000000000000000000000000000000000000001:1"
+		c = "0000000000000000000000000000000000000000000000000000000000000002:0"
+	)
+
+	var none *CoinSelection
+	assert.NoError(t, none.CheckInputs([]string{a, b, c}))
+
+	use := &CoinSelection{Utxos: []string{a, b}}
+	assert.NoError(t, use.CheckInputs([]string{a, b}))
+	assert.ErrorContains(t, use.CheckInputs([]string{a, c}), "do not cover")
+
+	exclude := &CoinSelection{ExcludeUtxos: []string{c}}
+	assert.NoError(t, exclude.CheckInputs([]string{a, b}))
+	assert.ErrorContains(t, exclude.CheckInputs([]string{a, c}), "excluded utxo")
+}
+
+func Test_CoinSelection(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	selection := &CoinSelection{Utxos: []string{dummyUtxo}}
+	_, err := swapService.SwapOut(peer, btc_chain, channelId, initiator, 100000, 100000, WithCoinSelection(selection))
+	assert.ErrorIs(t, err, ErrCoinSelectionSwapOut)
+
+	unknown := &CoinSelection{Utxos: []string{"0000000000000000000000000000000000000000000000000000000000000002:0"}}
+	_, err = swapService.SwapIn(peer, btc_chain, channelId, initiator, 100000, 100000, WithCoinSelection(unknown))
+	assert.ErrorContains(t, err, "unknown utxo")
+}
@@ -0,0 +1,241 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elementsproject/peerswap/log"
+)
+
+// DefaultDeferredSwapExpiry is used if no expiry is given for a deferred swap.
+const DefaultDeferredSwapExpiry = 24 * time.Hour
+
+// DeferSwapOut persists a swap out with a peer that is currently offline. The
+// swap out is started once the peer comes online, or dropped if the peer does
+// not come online before the expiry.
+func (s *SwapService) DeferSwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, expiry time.Duration, opts ...SwapOption) (*QueuedSwap, error) {
+	return s.deferSwap(&QueuedSwap{
+		Type:                SWAPTYPE_OUT,
+		PeerNodeId:          peer,
+		InitiatorNodeId:     initiator,
+		Asset:               chain,
+		ChannelId:           channelId,
+		AmountSat:           amtSat,
+		PremiumLimitRatePpm: premiumLimitRatePpm,
+	}, expiry, opts)
+}
+
+// DeferSwapIn persists a swap in with a peer that is currently offline. The
+// swap in is started once the peer comes online, or dropped if the peer does
+// not come online before the expiry.
+func (s *SwapService) DeferSwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, expiry time.Duration, opts ...SwapOption) (*QueuedSwap, error) {
+	return s.deferSwap(&QueuedSwap{
+		Type:                SWAPTYPE_IN,
+		PeerNodeId:          peer,
+		InitiatorNodeId:     initiator,
+		Asset:               chain,
+		ChannelId:           channelId,
+		AmountSat:           amtSat,
+		PremiumLimitRatePpm: premiumLimitRatePpm,
+	}, expiry, opts)
+}
+
+// ListDeferredSwaps returns the deferred swaps that wait for their peer to
+// come online.
+func (s *SwapService) ListDeferredSwaps() ([]*QueuedSwap, error) {
+	queue, err := s.ListQueuedSwaps()
+	if err != nil {
+		return nil, err
+	}
+	var deferred []*QueuedSwap
+	for _, queued := range queue {
+		if queued.WaitForPeer {
+			deferred = append(deferred, queued)
+		}
+	}
+	return deferred, nil
+}
+
+// OnPeerOnline starts the deferred swaps with the peer. Expired deferred swaps
+// are dropped.
+func (s *SwapService) OnPeerOnline(peerId string) {
+	if s.swapServices.queuedSwapsStore == nil {
+		return
+	}
+	deferred, err := s.ListDeferredSwaps()
+	if err != nil {
+		log.Infof("error listing deferred swaps: %v", err)
+		return
+	}
+	for _, queued := range deferred {
+		if queued.PeerNodeId != peerId {
+			continue
+		}
+		id := queued.Id
+		time.AfterFunc(s.queueStartDelay, func() {
+			s.startDeferredSwap(id)
+		})
+	}
+}
+
+func (s *SwapService) deferSwap(queued *QueuedSwap, expiry time.Duration, opts []SwapOption) (*QueuedSwap, error) {
+	if s.swapServices.queuedSwapsStore == nil {
+		return nil, ErrSwapQueueDisabled
+	}
+	if !s.swapServices.policy.NewSwapsAllowed() {
+		return nil, fmt.Errorf("swaps are disabled")
+	}
+	if s.swapServices.draining.Load() {
+		return nil, ErrDraining
+	}
+	if s.swapServices.policy.IsPeerSuspicious(queued.PeerNodeId) {
+		return nil, PeerIsSuspiciousError(queued.PeerNodeId)
+	}
+	if queued.AmountSat*1000 < s.swapServices.policy.GetMinSwapAmountMsat() {
+		return nil, ErrMinimumSwapSize(s.swapServices.policy.GetMinSwapAmountMsat())
+	}
+	if _, err := s.enabledWallet(queued.Asset); err != nil {
+		return nil, err
+	}
+	if expiry == 0 {
+		expiry = DefaultDeferredSwapExpiry
+	}
+
+	queuedFromOptions(queued, opts)
+	if queued.ClaimAddress != "" {
+		err := s.swapServices.validateClaimAddress(queued.Asset, queued.ClaimAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if queued.FeeRate != nil {
+		err := s.swapServices.validateFeeRate(queued.Asset, queued.FeeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if queued.CoinSelection != nil {
+		if queued.Type != SWAPTYPE_IN {
+			return nil, ErrCoinSelectionSwapOut
+		}
+		if queued.ExternalFunding {
+			return nil, ErrCoinSelectionExternalFunding
+		}
+		err := s.swapServices.validateCoinSelection(queued.Asset, queued.CoinSelection)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if queued.ExternalFunding {
+		if queued.Type != SWAPTYPE_IN {
+			return nil, ErrExternalFundingSwapOut
+		}
+		err := s.swapServices.validateExternalFunding(queued.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	queued.Id = NewSwapId().String()
+	queued.CreatedAt = now.Unix()
+	queued.WaitForPeer = true
+	queued.ExpiresAt = now.Add(expiry).Unix()
+	err := s.swapServices.queuedSwapsStore.Add(queued)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("[%s]: deferred swap until peer %s comes online", queued.Id, queued.PeerNodeId)
+
+	s.scheduleDeferredExpiry(queued)
+	return queued, nil
+}
+
+// startDeferredSwap starts the deferred swap with the id. If the channel is
+// busy the swap is moved to the queue of the channel.
+func (s *SwapService) startDeferredSwap(id string) {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	queued, err := s.getDeferredSwap(id)
+	if err != nil || queued == nil {
+		return
+	}
+	if s.swapServices.draining.Load() {
+		// Keep the swap, it is started when the peer comes online again
+		// after the drain.
+		log.Infof("[%s]: not starting deferred swap while draining", queued.Id)
+		return
+	}
+	if queued.IdempotencyKey != "" {
+		// A request with the same key must either find the deferred swap
+		// or the swap that was started from it.
+		defer s.LockIdempotencyKey(queued.IdempotencyKey)()
+	}
+	err = s.swapServices.queuedSwapsStore.Delete(queued.Id)
+	if err != nil {
+		log.Infof("[%s]: error removing deferred swap: %v", queued.Id, err)
+		return
+	}
+	if time.Now().Unix() >= queued.ExpiresAt {
+		log.Infof("[%s]: dropping expired deferred swap", queued.Id)
+		return
+	}
+
+	swap, err := s.startQueuedSwap(queued)
+	var activeErr ActiveSwapError
+	if errors.As(err, &activeErr) {
+		queued.WaitForPeer = false
+		queued.ExpiresAt = 0
+		if err := s.swapServices.queuedSwapsStore.Add(queued); err != nil {
+			log.Infof("[%s]: error queueing deferred swap: %v", queued.Id, err)
+			return
+		}
+		log.Infof("[%s]: queued deferred swap behind active swap %s", queued.Id, activeErr.swapId)
+
+		// The active swap may have finished in the meantime.
+		go s.startNextQueuedSwap(queued.ChannelId)
+		return
+	}
+	if err != nil {
+		log.Infof("[%s]: dropping deferred swap, could not start: %v", queued.Id, err)
+		return
+	}
+	log.Infof("[%s]: started deferred swap as %s", queued.Id, swap.SwapId.String())
+}
+
+// scheduleDeferredExpiry drops the deferred swap once it expired.
+func (s *SwapService) scheduleDeferredExpiry(queued *QueuedSwap) {
+	id := queued.Id
+	time.AfterFunc(time.Until(time.Unix(queued.ExpiresAt, 0)), func() {
+		s.queueLock.Lock()
+		defer s.queueLock.Unlock()
+
+		deferred, err := s.getDeferredSwap(id)
+		if err != nil || deferred == nil {
+			return
+		}
+		err = s.swapServices.queuedSwapsStore.Delete(id)
+		if err != nil {
+			log.Infof("[%s]: error removing expired deferred swap: %v", id, err)
+			return
+		}
+		log.Infof("[%s]: dropped deferred swap, peer %s did not come online in time", id, deferred.PeerNodeId)
+	})
+}
+
+// getDeferredSwap returns the deferred swap with the id or nil if there is
+// none. The caller must hold the queue lock.
+func (s *SwapService) getDeferredSwap(id string) (*QueuedSwap, error) {
+	deferred, err := s.ListDeferredSwaps()
+	if err != nil {
+		return nil, err
+	}
+	for _, queued := range deferred {
+		if queued.Id == id {
+			return queued, nil
+		}
+	}
+	return nil, nil
+}
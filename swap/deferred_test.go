@@ -0,0 +1,69 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeferSwap(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, _, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	aliceSwapService.queueStartDelay = 10 * time.Millisecond
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	deferred, err := aliceSwapService.DeferSwapOut(peer, btc_chain, channelId, initiator, amount, 100000, 0)
+	require.NoError(t, err)
+	assert.True(t, deferred.WaitForPeer)
+	assert.InDelta(t, time.Now().Add(DefaultDeferredSwapExpiry).Unix(), deferred.ExpiresAt, 5)
+
+	list, err := aliceSwapService.ListDeferredSwaps()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, deferred.Id, list[0].Id)
+
+	// Deferred swaps do not wait for the channel.
+	aliceSwapService.startNextQueuedSwap(channelId)
+	list, err = aliceSwapService.ListDeferredSwaps()
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// Other peers coming online do not start the swap.
+	aliceSwapService.OnPeerOnline("other")
+	time.Sleep(50 * time.Millisecond)
+	list, err = aliceSwapService.ListDeferredSwaps()
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	aliceSwapService.OnPeerOnline(peer)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+
+	list, err = aliceSwapService.ListDeferredSwaps()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func Test_DeferSwapExpiry(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	_, err := swapService.DeferSwapIn(peer, l_btc_chain, channelId, initiator, 100000, 0, time.Second)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		list, err := swapService.ListDeferredSwaps()
+		return err == nil && len(list) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Invalid assets are rejected right away.
+	_, err = swapService.DeferSwapIn(peer, "doge", channelId, initiator, 100000, 0, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package swap
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/elementsproject/peerswap/log"
+)
+
+var ErrDraining = errors.New("node is draining, no new swaps are started")
+
+// DrainingSwap is an active swap that a drain waits for.
+type DrainingSwap struct {
+	Swap *SwapStateMachine
+	// CurrentHeight is the block height of the chain of the swap. It is 0 if
+	// the height is not available.
+	CurrentHeight uint32
+	// ExpectedCompletionHeight is the height at which the swap is done at the
+	// latest, which is when the opening transaction can be claimed by csv. It
+	// is 0 as long as the opening transaction is not known.
+	ExpectedCompletionHeight uint32
+}
+
+// StartDrain blocks incoming swap requests and new outgoing swaps so that
+// the active swaps can finish, for example before an upgrade. Queued and
+// deferred swaps are kept. Starting a drain twice has no effect.
+func (s *SwapService) StartDrain() {
+	s.Lock()
+	defer s.Unlock()
+	if s.swapServices.draining.Swap(true) {
+		return
+	}
+	log.Infof("draining: no new swaps are started until the drain is stopped")
+	s.drained = make(chan struct{})
+	s.checkDrained()
+}
+
+// StopDrain ends a drain and starts the queued swaps again. Deferred swaps
+// are started once their peer comes online.
+func (s *SwapService) StopDrain() {
+	s.Lock()
+	if !s.swapServices.draining.Swap(false) {
+		s.Unlock()
+		return
+	}
+	// Release everyone that waits for the drain.
+	select {
+	case <-s.drained:
+	default:
+		close(s.drained)
+	}
+	s.drained = nil
+	s.Unlock()
+
+	log.Infof("drain stopped")
+	s.startQueuedSwaps()
+}
+
+// IsDraining returns true while a drain is in progress.
+func (s *SwapService) IsDraining() bool {
+	return s.swapServices.draining.Load()
+}
+
+// Drained returns a channel that is closed once the drain is done or
+// stopped. It returns nil if no drain is in progress.
+func (s *SwapService) Drained() <-chan struct{} {
+	s.RLock()
+	defer s.RUnlock()
+	return s.drained
+}
+
+// DrainStatus returns the active swaps that are left, ordered by their
+// expected completion height. Swaps without a known completion height come
+// last.
+func (s *SwapService) DrainStatus() []*DrainingSwap {
+	s.RLock()
+	swaps := make([]*SwapStateMachine, 0, len(s.activeSwaps))
+	for _, swap := range s.activeSwaps {
+		swaps = append(swaps, swap)
+	}
+	s.RUnlock()
+
+	heights := map[string]uint32{}
+	var left []*DrainingSwap
+	for _, swap := range swaps {
+		draining := &DrainingSwap{Swap: swap}
+		chain := swap.Data.GetChain()
+		txWatcher, _, validator, err := s.swapServices.getOnChainServices(chain)
+		if err == nil && txWatcher != nil {
+			if _, ok := heights[chain]; !ok {
+				height, err := txWatcher.GetBlockHeight()
+				if err != nil {
+					log.Debugf("could not get block height of %s: %v", chain, err)
+				}
+				heights[chain] = height
+			}
+			draining.CurrentHeight = heights[chain]
+			if swap.Data.StartingBlockHeight > 0 {
+				draining.ExpectedCompletionHeight = swap.Data.StartingBlockHeight + validator.GetCSVHeight()
+			}
+		}
+		left = append(left, draining)
+	}
+
+	sort.Slice(left, func(i, j int) bool {
+		hi, hj := left[i].ExpectedCompletionHeight, left[j].ExpectedCompletionHeight
+		if hi == 0 || hj == 0 {
+			return hi != 0
+		}
+		return hi < hj
+	})
+	return left
+}
+
+// checkDrained closes the drained channel if a drain is in progress and no
+// active swap is left. The caller must hold the lock.
+func (s *SwapService) checkDrained() {
+	if s.drained == nil || len(s.activeSwaps) > 0 {
+		return
+	}
+	select {
+	case <-s.drained:
+	default:
+		log.Infof("draining: all active swaps are done")
+		close(s.drained)
+	}
+}
@@ -0,0 +1,65 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Drain(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	// set lightning to fail so that the swaps get canceled
+	aliceSwapService.swapServices.lightning.(*dummyLightningClient).failpayment = true
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	assert.Nil(t, aliceSwapService.Drained())
+
+	_, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+
+	aliceSwapService.StartDrain()
+	assert.True(t, aliceSwapService.IsDraining())
+	drained := aliceSwapService.Drained()
+	require.NotNil(t, drained)
+
+	// New swaps are blocked while the active swap is left.
+	_, err = aliceSwapService.SwapIn(peer, btc_chain, channelId, initiator, amount, 100000)
+	assert.ErrorIs(t, err, ErrDraining)
+	left := aliceSwapService.DrainStatus()
+	require.Len(t, left, 1)
+	assert.Zero(t, left[0].ExpectedCompletionHeight)
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_CANCELED, <-bobMsgChan)
+
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not finish")
+	}
+	assert.Empty(t, aliceSwapService.DrainStatus())
+
+	// A draining peer rejects our requests.
+	aliceSwapService.StopDrain()
+	assert.False(t, aliceSwapService.IsDraining())
+	assert.Nil(t, aliceSwapService.Drained())
+	bobSwapService.StartDrain()
+
+	swap, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_CANCELED, <-aliceMsgChan)
+	assert.True(t, swap.WaitForStateChange(func(st StateType) bool {
+		return st == State_SwapCanceled
+	}, 5*time.Second))
+}
@@ -0,0 +1,266 @@
+package swap
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elementsproject/peerswap/lightning"
+	"github.com/elementsproject/peerswap/log"
+)
+
+// ExternalFundingTimeout is the time that an externally funded swap-in waits
+// for the signed opening transaction. The peer cancels a swap-in that has no
+// opening transaction 10 minutes after it agreed, so the timeout leaves some
+// time to broadcast the transaction and send it to the peer.
+const ExternalFundingTimeout = 5 * time.Minute
+
+var (
+	// ErrExternalFundingNotSupported is returned if an externally funded
+	// swap-in is started on a chain whose wallet can not create the opening
+	// transaction as a PSBT.
+	ErrExternalFundingNotSupported = errors.New("the wallet does not support external funding")
+
+	// ErrExternalFundingSwapOut is returned if a swap-out is started with
+	// external funding. Only the maker of a swap-in funds an opening
+	// transaction.
+	ErrExternalFundingSwapOut = errors.New("external funding only applies to swap-ins")
+
+	// ErrCoinSelectionExternalFunding is returned if an externally funded
+	// swap-in is started with a coin selection. The wallet that funds the
+	// opening transaction chooses the utxos.
+	ErrCoinSelectionExternalFunding = errors.New("coin selection does not apply to externally funded swap-ins")
+
+	// ErrNotAwaitingFunding is returned if the funding of a swap is submitted
+	// while the swap does not wait for it.
+	ErrNotAwaitingFunding = errors.New("the swap is not awaiting external funding")
+)
+
+// ExternalFundingWallet is a wallet that hands out the opening transaction of
+// a swap-in as an unsigned PSBT (bitcoin) or PSET (liquid) that is funded and
+// signed outside of the node, see WithExternalFunding.
+type ExternalFundingWallet interface {
+	// CreateFundingPsbt returns a base64 PSBT or PSET whose only output is
+	// the opening output of the swap, and the address of the output. The
+	// inputs and the change are added by the wallet that funds it.
+	CreateFundingPsbt(params *OpeningParams) (psbt, address string, err error)
+
+	// ExtractFundingTx returns the transaction of a signed and finalized
+	// PSBT or PSET, or of a raw transaction in hex, and the vout of the
+	// opening output. Returns an error if the transaction does not pay the
+	// opening output.
+	ExtractFundingTx(signed string, params *OpeningParams) (txHex string, vout uint32, err error)
+
+	// BroadcastFundingTx broadcasts the opening transaction.
+	BroadcastFundingTx(txHex string) (txId string, err error)
+}
+
+// TxLookup is implemented by the tx watchers that can tell whether a
+// transaction is known to the chain backend, in the mempool or in a block.
+type TxLookup interface {
+	// IsTxKnown returns true if the output of the transaction is known.
+	IsTxKnown(txId string, vout uint32) (bool, error)
+}
+
+// ExternalFunding is the opening transaction of an externally funded swap-in.
+type ExternalFunding struct {
+	// Psbt is the unsigned PSBT or PSET that pays the opening output.
+	Psbt string `json:"psbt,omitempty"`
+	// Address is the address of the opening output.
+	Address string `json:"address,omitempty"`
+	// ExpiresAt is the unix time after which the swap is canceled if the
+	// signed transaction was not submitted.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// TxHex is the signed opening transaction once it was submitted.
+	TxHex string `json:"tx_hex,omitempty"`
+	// Vout is the opening output of the signed transaction.
+	Vout uint32 `json:"vout,omitempty"`
+}
+
+// WithExternalFunding hands out the opening transaction of a swap-in as an
+// unsigned PSBT or PSET once the peer agreed, instead of funding it from the
+// node wallet. The swap continues once the signed transaction is submitted
+// with FinalizeSwapFunding.
+func WithExternalFunding() SwapOption {
+	return func(data *SwapData) {
+		data.ExternalFunding = &ExternalFunding{}
+	}
+}
+
+// validateExternalFunding returns an error if the wallet of the chain can not
+// hand out the opening transaction as a PSBT.
+func (s *SwapServices) validateExternalFunding(chain string) error {
+	_, wallet, _, err := s.getOnChainServices(chain)
+	if err != nil {
+		return err
+	}
+	if (chain == btc_chain && !s.bitcoinEnabled) || (chain == l_btc_chain && !s.liquidEnabled) {
+		return fmt.Errorf("%s swaps are not enabled", chain)
+	}
+	if _, ok := wallet.(ExternalFundingWallet); !ok {
+		return ErrExternalFundingNotSupported
+	}
+	return nil
+}
+
+// FinalizeSwapFunding submits the signed opening transaction of a swap-in
+// that awaits external funding. The signed transaction is a finalized PSBT or
+// PSET in base64, or a raw transaction in hex. It is validated against the
+// opening params of the swap and broadcast. The swap keeps waiting for the
+// funding if the broadcast fails.
+func (s *SwapService) FinalizeSwapFunding(swapId, signed string) (*SwapStateMachine, error) {
+	swap, err := s.GetActiveSwap(swapId)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Data.ExternalFunding == nil || swap.Data.GetCurrentState() != State_SwapInSender_AwaitExternalFunding {
+		return nil, ErrNotAwaitingFunding
+	}
+
+	_, wallet, validator, err := s.swapServices.getOnChainServices(swap.Data.GetChain())
+	if err != nil {
+		return nil, err
+	}
+	fundingWallet, ok := wallet.(ExternalFundingWallet)
+	if !ok {
+		return nil, ErrExternalFundingNotSupported
+	}
+	params := swap.Data.GetOpeningParams()
+	txHex, vout, err := fundingWallet.ExtractFundingTx(signed, params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid funding transaction: %w", err)
+	}
+	ok, err = validator.ValidateTx(params, txHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid funding transaction: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("invalid funding transaction: the opening output does not match the swap")
+	}
+
+	done, err := swap.SendEvent(Event_SwapInSender_OnExternalFundingReceived, &externalFundingResult{txHex: txHex, vout: vout})
+	if done {
+		s.RemoveActiveSwap(swap.SwapId.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch swap.Data.GetCurrentState() {
+	case State_SwapInSender_AwaitExternalFunding:
+		return nil, fmt.Errorf("the funding transaction was not broadcast: %s", swap.Data.LastErrString)
+	case State_SwapCanceled:
+		return nil, fmt.Errorf("swap canceled: %s", swap.Data.GetCancelMessage())
+	}
+	return swap, nil
+}
+
+// requestExternalFunding creates the unsigned opening transaction of an
+// externally funded swap-in. The preimage is generated here already as its
+// hash is part of the opening output.
+func requestExternalFunding(services *SwapServices, swap *SwapData) EventType {
+	_, wallet, _, err := services.getOnChainServices(swap.GetChain())
+	if err != nil {
+		return swap.HandleError(err)
+	}
+	fundingWallet, ok := wallet.(ExternalFundingWallet)
+	if !ok {
+		return swap.HandleError(ErrExternalFundingNotSupported)
+	}
+
+	preimage, err := lightning.GetPreimage()
+	if err != nil {
+		return swap.HandleError(err)
+	}
+	swap.ClaimPreimage = hex.EncodeToString(preimage[:])
+
+	psbt, address, err := fundingWallet.CreateFundingPsbt(swap.GetOpeningParams())
+	if err != nil {
+		return swap.HandleError(err)
+	}
+	swap.ExternalFunding.Psbt = psbt
+	swap.ExternalFunding.Address = address
+	swap.ExternalFunding.ExpiresAt = time.Now().Add(ExternalFundingTimeout).Unix()
+	return Event_SwapInSender_OnExternalFundingRequested
+}
+
+// broadcastExternalFunding broadcasts the submitted opening transaction of an
+// externally funded swap-in. A failed broadcast is ignored if the transaction
+// is already known to the chain backend, e.g. because the wallet that funded
+// it broadcast it as well.
+func broadcastExternalFunding(services *SwapServices, swap *SwapData) (string, error) {
+	txWatcher, wallet, validator, err := services.getOnChainServices(swap.GetChain())
+	if err != nil {
+		return "", err
+	}
+	fundingWallet, ok := wallet.(ExternalFundingWallet)
+	if !ok {
+		return "", ErrExternalFundingNotSupported
+	}
+	txId, err := fundingWallet.BroadcastFundingTx(swap.ExternalFunding.TxHex)
+	if err == nil {
+		return txId, nil
+	}
+	lookup, ok := txWatcher.(TxLookup)
+	if !ok {
+		return "", err
+	}
+	knownTxId, idErr := validator.TxIdFromHex(swap.ExternalFunding.TxHex)
+	if idErr != nil {
+		return "", err
+	}
+	known, lookupErr := lookup.IsTxKnown(knownTxId, swap.ExternalFunding.Vout)
+	if lookupErr != nil || !known {
+		return "", err
+	}
+	log.Infof("[Swap:%s]: funding transaction %s is already broadcast: %v",
+		swap.GetId(), knownTxId, err)
+	return knownTxId, nil
+}
+
+// AwaitExternalFundingAction waits for the signed opening transaction of an
+// externally funded swap-in. The swap times out at the expiry of the funding,
+// also if it was recovered after a restart.
+type AwaitExternalFundingAction struct{}
+
+func (a *AwaitExternalFundingAction) Execute(services *SwapServices, swap *SwapData) EventType {
+	remaining := time.Until(time.Unix(swap.ExternalFunding.ExpiresAt, 0))
+	if remaining <= 0 {
+		return Event_OnTimeout
+	}
+	if swap.toCancel != nil {
+		swap.toCancel()
+	}
+	toCtx, cancel := context.WithCancel(context.Background())
+	swap.toCancel = cancel
+	services.toService.addNewTimeOut(toCtx, remaining, swap.GetId().String())
+	log.Infof("[Swap:%s]: awaiting the signed opening transaction until %s",
+		swap.GetId(), time.Unix(swap.ExternalFunding.ExpiresAt, 0).Format(time.RFC3339))
+	return NoOp
+}
+
+// externalFundingResult hands the submitted opening transaction to a swap.
+type externalFundingResult struct {
+	txHex string
+	vout  uint32
+}
+
+func (r *externalFundingResult) ApplyToSwapData(data *SwapData) error {
+	if data.GetCurrentState() != State_SwapInSender_AwaitExternalFunding {
+		return ErrNotAwaitingFunding
+	}
+	if data.toCancel != nil {
+		data.toCancel()
+		data.toCancel = nil
+	}
+	data.ExternalFunding.TxHex = r.txHex
+	data.ExternalFunding.Vout = r.vout
+	data.LastErr = nil
+	data.LastErrString = ""
+	return nil
+}
+
+func (r *externalFundingResult) Validate(data *SwapData) error {
+	return nil
+}
@@ -0,0 +1,116 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExternalFunding(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService := getTestSetup(t, initiator)
+	bobSwapService := getTestSetup(t, peer)
+	aliceMessenger := aliceSwapService.swapServices.messenger.(*ConnectedMessenger)
+	bobMessenger := bobSwapService.swapServices.messenger.(*ConnectedMessenger)
+	aliceMessenger.other = bobMessenger
+	bobMessenger.other = aliceMessenger
+	aliceMessenger.msgReceivedChan = make(chan messages.MessageType)
+	aliceMsgChan := aliceMessenger.msgReceivedChan
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	_, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000, WithExternalFunding())
+	assert.ErrorIs(t, err, ErrExternalFundingSwapOut)
+	_, err = aliceSwapService.SwapIn(peer, btc_chain, channelId, initiator, amount, 100000,
+		WithExternalFunding(), WithCoinSelection(&CoinSelection{Utxos: []string{dummyUtxo}}))
+	assert.ErrorIs(t, err, ErrCoinSelectionExternalFunding)
+
+	// The node wallet does not need to cover an externally funded swap-in.
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+	aliceChain.SetBalance(0)
+
+	swap, err := aliceSwapService.SwapIn(peer, btc_chain, channelId, initiator, amount, 100000, WithExternalFunding())
+	require.NoError(t, err)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPINAGREEMENT, <-aliceMsgChan)
+
+	swapId := swap.SwapId.String()
+	require.Eventually(t, func() bool {
+		return swap.Data.GetCurrentState() == State_SwapInSender_AwaitExternalFunding
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "psbt", swap.Data.ExternalFunding.Psbt)
+	assert.Greater(t, swap.Data.ExternalFunding.ExpiresAt, time.Now().Unix())
+	assert.NotEmpty(t, swap.Data.ClaimPreimage)
+
+	_, err = aliceSwapService.FinalizeSwapFunding(swapId, "unsigned")
+	assert.ErrorContains(t, err, "invalid funding transaction")
+
+	// The swap keeps waiting for its funding if the broadcast fails.
+	aliceChain.setFailFundingBroadcast(true)
+	_, err = aliceSwapService.FinalizeSwapFunding(swapId, "signed")
+	assert.ErrorContains(t, err, "insufficient fee")
+	assert.Equal(t, State_SwapInSender_AwaitExternalFunding, swap.Data.GetCurrentState())
+	assert.Empty(t, swap.Data.ExternalFunding.TxHex)
+
+	aliceChain.setFailFundingBroadcast(false)
+	swap, err = aliceSwapService.FinalizeSwapFunding(swapId, "signed")
+	require.NoError(t, err)
+	require.NotNil(t, swap.Data.OpeningTxBroadcasted)
+	assert.Equal(t, uint32(1), swap.Data.OpeningTxBroadcasted.ScriptOut)
+	assert.Equal(t, "txhex", swap.Data.OpeningTxHex)
+
+	_, err = aliceSwapService.FinalizeSwapFunding(swapId, "signed")
+	assert.ErrorIs(t, err, ErrNotAwaitingFunding)
+}
+
+func Test_AwaitExternalFundingAction(t *testing.T) {
+	timeOut := &timeOutDummy{}
+	services := &SwapServices{toService: timeOut}
+	data := NewSwapData(NewSwapId(), "alice", "bob")
+
+	data.ExternalFunding = &ExternalFunding{ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	assert.Equal(t, EventType(NoOp), (&AwaitExternalFundingAction{}).Execute(services, data))
+	assert.Equal(t, 1, timeOut.getCalled())
+
+	// A swap that is recovered after its funding expired times out at once.
+	data.ExternalFunding.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	assert.Equal(t, EventType(Event_OnTimeout), (&AwaitExternalFundingAction{}).Execute(services, data))
+	assert.Equal(t, 1, timeOut.getCalled())
+}
+
+func Test_ExternalFundingAlreadyBroadcast(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService := getTestSetup(t, initiator)
+	bobSwapService := getTestSetup(t, peer)
+	aliceMessenger := aliceSwapService.swapServices.messenger.(*ConnectedMessenger)
+	bobMessenger := bobSwapService.swapServices.messenger.(*ConnectedMessenger)
+	aliceMessenger.other = bobMessenger
+	bobMessenger.other = aliceMessenger
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	swap, err := aliceSwapService.SwapIn(peer, btc_chain, channelId, initiator, amount, 100000, WithExternalFunding())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return swap.Data.GetCurrentState() == State_SwapInSender_AwaitExternalFunding
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The broadcast fails as the funding wallet already broadcast the
+	// transaction, the swap continues with it.
+	aliceChain := aliceSwapService.swapServices.bitcoinWallet.(*dummyChain)
+	aliceChain.setFailFundingBroadcast(true)
+	aliceChain.setKnownFundingTx(true)
+	swap, err = aliceSwapService.FinalizeSwapFunding(swap.SwapId.String(), "signed")
+	require.NoError(t, err)
+	require.NotNil(t, swap.Data.OpeningTxBroadcasted)
+	assert.NotEmpty(t, swap.Data.OpeningTxBroadcasted.TxId)
+	assert.Equal(t, "txhex", swap.Data.OpeningTxHex)
+}
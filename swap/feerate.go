@@ -0,0 +1,158 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FeeRate is the fee rate of the transactions that a swap creates itself:
+// the opening transaction of the maker and the claim transactions. It
+// overrides the estimate of the wallet. Either SatPerVByte or TargetConf is
+// set.
+type FeeRate struct {
+	// SatPerVByte is an explicit fee rate in sat/vb.
+	SatPerVByte float64 `json:"sat_per_vbyte,omitempty"`
+
+	// TargetConf is the confirmation target in blocks that the fee rate
+	// is estimated for.
+	TargetConf uint32 `json:"target_conf,omitempty"`
+}
+
+// NewFeeRate returns the fee rate for the sat/vb fee rate or the
+// confirmation target, or nil if none is set.
+func NewFeeRate(satPerVByte float64, targetConf uint32) (*FeeRate, error) {
+	feeRate := &FeeRate{SatPerVByte: satPerVByte, TargetConf: targetConf}
+	if satPerVByte == 0 && targetConf == 0 {
+		return nil, nil
+	}
+	if err := feeRate.Validate(); err != nil {
+		return nil, err
+	}
+	return feeRate, nil
+}
+
+// ParseFeeRate parses a fee rate of the form "<rate>satvb" or
+// "<blocks>blocks", e.g. "12.5satvb" or "6blocks". An empty string is no
+// fee rate.
+func ParseFeeRate(s string) (*FeeRate, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return nil, nil
+	case strings.HasSuffix(s, "satvb"):
+		rate, err := strconv.ParseFloat(strings.TrimSuffix(s, "satvb"), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid fee rate %q", s)
+		}
+		return NewFeeRate(rate, 0)
+	case strings.HasSuffix(s, "blocks"):
+		target, err := strconv.ParseUint(strings.TrimSuffix(s, "blocks"), 10, 32)
+		if err != nil || target == 0 {
+			return nil, fmt.Errorf("invalid confirmation target %q", s)
+		}
+		return NewFeeRate(0, uint32(target))
+	}
+	return nil, fmt.Errorf("invalid fee rate %q, expected e.g. 12.5satvb or 6blocks", s)
+}
+
+// Validate returns an error if not exactly one of the fee rate and the
+// confirmation target is set.
+func (f *FeeRate) Validate() error {
+	switch {
+	case f.SatPerVByte < 0:
+		return fmt.Errorf("negative fee rate %v sat/vb", f.SatPerVByte)
+	case f.SatPerVByte > 0 && f.TargetConf > 0:
+		return errors.New("either a fee rate or a confirmation target can be set, not both")
+	case f.SatPerVByte == 0 && f.TargetConf == 0:
+		return errors.New("missing fee rate or confirmation target")
+	}
+	return nil
+}
+
+func (f *FeeRate) String() string {
+	if f == nil {
+		return ""
+	}
+	if f.TargetConf > 0 {
+		return fmt.Sprintf("%dblocks", f.TargetConf)
+	}
+	return strconv.FormatFloat(f.SatPerVByte, 'f', -1, 64) + "satvb"
+}
+
+// CommonFeeRate returns the fee rate if all fee rates are the same, and nil
+// otherwise. Batched transactions use the wallet estimate unless all swaps
+// of the batch have the same fee rate.
+func CommonFeeRate(feeRates ...*FeeRate) *FeeRate {
+	if len(feeRates) == 0 || feeRates[0] == nil {
+		return nil
+	}
+	for _, f := range feeRates[1:] {
+		if f == nil || *f != *feeRates[0] {
+			return nil
+		}
+	}
+	return feeRates[0]
+}
+
+// OpeningFeeRate returns the common fee rate of the opening params, see
+// CommonFeeRate.
+func OpeningFeeRate(params []*OpeningParams) *FeeRate {
+	feeRates := make([]*FeeRate, len(params))
+	for i, p := range params {
+		feeRates[i] = p.FeeRate
+	}
+	return CommonFeeRate(feeRates...)
+}
+
+// WithFeeRate uses the fee rate for the opening and claim transactions of
+// the swap instead of the default fee rate.
+func WithFeeRate(feeRate *FeeRate) SwapOption {
+	return func(data *SwapData) {
+		data.FeeRate = feeRate
+	}
+}
+
+// SetDefaultFeeRate sets the fee rate of all swaps on the chain that are
+// started without a fee rate of their own. The fee rate must be valid for
+// the chain.
+func (s *SwapServices) SetDefaultFeeRate(chain string, feeRate *FeeRate) error {
+	if feeRate == nil {
+		delete(s.defaultFeeRates, chain)
+		return nil
+	}
+	if err := s.validateFeeRate(chain, feeRate); err != nil {
+		return err
+	}
+	if s.defaultFeeRates == nil {
+		s.defaultFeeRates = map[string]*FeeRate{}
+	}
+	s.defaultFeeRates[chain] = feeRate
+	return nil
+}
+
+// defaultFeeRate returns the default fee rate of the chain, or nil if the
+// wallet estimate is used.
+func (s *SwapServices) defaultFeeRate(chain string) *FeeRate {
+	return s.defaultFeeRates[chain]
+}
+
+// validateFeeRate returns an error if the fee rate is invalid or below the
+// fee floor of the chain.
+func (s *SwapServices) validateFeeRate(chain string, feeRate *FeeRate) error {
+	if err := feeRate.Validate(); err != nil {
+		return err
+	}
+	_, _, validator, err := s.getOnChainServices(chain)
+	if err != nil {
+		return err
+	}
+	if (chain == btc_chain && !s.bitcoinEnabled) || (chain == l_btc_chain && !s.liquidEnabled) {
+		return fmt.Errorf("%s swaps are not enabled", chain)
+	}
+	if err := validator.ValidateFeeRate(feeRate); err != nil {
+		return fmt.Errorf("invalid fee rate %s: %w", feeRate, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeeRate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *FeeRate
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "12.5satvb", want: &FeeRate{SatPerVByte: 12.5}},
+		{in: " 6blocks ", want: &FeeRate{TargetConf: 6}},
+		{in: "0satvb", wantErr: true},
+		{in: "0blocks", wantErr: true},
+		{in: "-1satvb", wantErr: true},
+		{in: "fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		feeRate, err := ParseFeeRate(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, feeRate, tt.in)
+		if feeRate != nil {
+			// The string form parses to the same fee rate.
+			parsed, err := ParseFeeRate(feeRate.String())
+			require.NoError(t, err)
+			assert.Equal(t, feeRate, parsed)
+		}
+	}
+
+	_, err := NewFeeRate(2, 6)
+	assert.Error(t, err)
+}
+
+func TestCommonFeeRate(t *testing.T) {
+	assert.Nil(t, CommonFeeRate())
+	assert.Nil(t, CommonFeeRate(nil, &FeeRate{TargetConf: 6}))
+	assert.Nil(t, CommonFeeRate(&FeeRate{TargetConf: 6}, &FeeRate{TargetConf: 3}))
+	assert.Equal(t, &FeeRate{SatPerVByte: 5}, CommonFeeRate(&FeeRate{SatPerVByte: 5}, &FeeRate{SatPerVByte: 5}))
+}
+
+func Test_FeeRate(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+	services := swapService.swapServices
+
+	// The dummy chain has a fee floor of 1 sat/vb.
+	_, err := swapService.SwapOut(peer, btc_chain, channelId, initiator, 100000, 100000, WithFeeRate(&FeeRate{SatPerVByte: 0.5}))
+	assert.ErrorContains(t, err, "invalid fee rate")
+	_, err = swapService.SwapIn(peer, btc_chain, channelId, initiator, 100000, 100000, WithFeeRate(&FeeRate{SatPerVByte: 2, TargetConf: 6}))
+	assert.Error(t, err)
+
+	assert.Error(t, services.SetDefaultFeeRate(btc_chain, &FeeRate{SatPerVByte: 0.5}))
+	require.NoError(t, services.SetDefaultFeeRate(btc_chain, &FeeRate{TargetConf: 6}))
+	assert.Equal(t, &FeeRate{TargetConf: 6}, services.defaultFeeRate(btc_chain))
+	assert.Nil(t, services.defaultFeeRate(l_btc_chain))
+
+	services.bitcoinEnabled = false
+	assert.Error(t, services.SetDefaultFeeRate(btc_chain, &FeeRate{TargetConf: 6}))
+}
@@ -33,6 +33,9 @@ const (
 	exponentialBackoffBase int = 1000
 	// exponentialBackoffCap is the maximum value for the exponential backoff as milliseconds
 	exponentialBackoffCap int = 20000
+
+	// maxHistory is the number of state transitions that are kept per swap.
+	maxHistory = 100
 )
 
 // StateType represents an extensible state type in the state machine.
@@ -67,6 +70,28 @@ type Store interface {
 	GetData(id string) (*SwapStateMachine, error)
 	ListAll() ([]*SwapStateMachine, error)
 	ListAllByPeer(peer string) ([]*SwapStateMachine, error)
+	// GetByIdempotencyKey returns the swap that we initiated with the
+	// idempotency key, or ErrDataNotAvailable if there is none.
+	GetByIdempotencyKey(key string) (*SwapStateMachine, error)
+}
+
+// StateTransition represents a single transition of the state machine.
+type StateTransition struct {
+	// Timestamp is the unix time at which the transition happened.
+	Timestamp int64 `json:"timestamp"`
+	// From is the state the machine was in before the transition.
+	From StateType `json:"from"`
+	// Event is the event that triggered the transition.
+	Event EventType `json:"event"`
+	// To is the state the machine transitioned to.
+	To StateType `json:"to"`
+	// Error holds the error that caused the transition, if any.
+	Error string `json:"error,omitempty"`
+	// TxId is the claim transaction id if known, the opening transaction id
+	// otherwise.
+	TxId string `json:"txid,omitempty"`
+	// PaymentHash is the payment hash of the claim invoice if known.
+	PaymentHash string `json:"payment_hash,omitempty"`
 }
 
 // States represents a mapping of states and their implementations.
@@ -92,6 +117,9 @@ type SwapStateMachine struct {
 	// Current represents the current state.
 	Current StateType `json:"current"`
 
+	// History holds every state transition the state machine went through.
+	History []*StateTransition `json:"history,omitempty"`
+
 	// States holds the configuration of states and events handled by the state machine.
 	States States `json:"-"`
 
@@ -192,6 +220,7 @@ func (s *SwapStateMachine) SendEvent(event EventType, eventCtx EventContext) (bo
 	if eventCtx != nil {
 		err = eventCtx.Validate(s.Data)
 		if err != nil {
+			s.Data.LastErr = err
 			s.mutex.Unlock()
 			log.Infof("Message validation error: %v on msg %v", err, eventCtx)
 			res, err := s.SendEvent(Event_OnInvalid_Message, nil)
@@ -228,6 +257,7 @@ func (s *SwapStateMachine) SendEvent(event EventType, eventCtx EventContext) (bo
 		}
 
 		// Transition over to the next state.
+		s.recordTransition(s.Current, event, nextState)
 		s.Previous = s.Current
 		s.setState(nextState)
 		s.Data.SetState(nextState)
@@ -265,6 +295,48 @@ func (s *SwapStateMachine) SendEvent(event EventType, eventCtx EventContext) (bo
 	}
 }
 
+// recordTransition appends a state transition to the history of the state
+// machine. The history is persisted together with the swap. A retry of the
+// same transition replaces the previous one, and only the latest maxHistory
+// transitions are kept.
+func (s *SwapStateMachine) recordTransition(from StateType, event EventType, to StateType) {
+	transition := &StateTransition{
+		Timestamp:   time.Now().Unix(),
+		From:        from,
+		Event:       event,
+		To:          to,
+		PaymentHash: s.Data.GetPaymentHash(),
+	}
+
+	switch event {
+	case Event_ActionFailed, Event_OnRetry, Event_OnInvalid_Message:
+		transition.Error = s.Data.LastErrString
+		if s.Data.LastErr != nil {
+			transition.Error = s.Data.LastErr.Error()
+		}
+	case Event_OnCancelReceived:
+		transition.Error = s.Data.GetCancelMessage()
+	}
+
+	if s.Data.ClaimTxId != "" {
+		transition.TxId = s.Data.ClaimTxId
+	} else {
+		transition.TxId = s.Data.GetOpeningTxId()
+	}
+
+	if n := len(s.History); n > 0 && event == Event_OnRetry {
+		last := s.History[n-1]
+		if last.Event == event && last.From == from && last.To == to {
+			s.History[n-1] = transition
+			return
+		}
+	}
+	s.History = append(s.History, transition)
+	if len(s.History) > maxHistory {
+		s.History = s.History[len(s.History)-maxHistory:]
+	}
+}
+
 // exponentialBackoffAndJitter is function to wait for
 // exponential backoff and jitter.
 func (s *SwapStateMachine) exponentialBackoffAndJitter() {
@@ -0,0 +1,98 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrIdempotencyKeyReused is returned if an idempotency key is used again for
+// a swap of another type.
+func ErrIdempotencyKeyReused(key string, swapType SwapType) error {
+	return fmt.Errorf("idempotency key %s was already used for a %s", key, swapType)
+}
+
+// WithIdempotencyKey persists the client supplied idempotency key with the
+// swap.
+func WithIdempotencyKey(key string) SwapOption {
+	return func(data *SwapData) {
+		data.IdempotencyKey = key
+	}
+}
+
+// LockIdempotencyKey blocks until no other request with the same idempotency
+// key is in progress. The returned function must be called once the request
+// is done.
+func (s *SwapService) LockIdempotencyKey(key string) (unlock func()) {
+	return s.idempotencyLocks.lock(key)
+}
+
+// FindByIdempotencyKey returns the swap or the queued swap of the swap type
+// that was started with the idempotency key. Both are nil if there is none.
+func (s *SwapService) FindByIdempotencyKey(key string, swapType SwapType) (*SwapStateMachine, *QueuedSwap, error) {
+	swap, err := s.swapServices.swapStore.GetByIdempotencyKey(key)
+	if err == nil {
+		if swap.Type != swapType {
+			return nil, nil, ErrIdempotencyKeyReused(key, swap.Type)
+		}
+		return swap, nil, nil
+	}
+	if !errors.Is(err, ErrDataNotAvailable) {
+		return nil, nil, err
+	}
+
+	if s.swapServices.queuedSwapsStore == nil {
+		return nil, nil, nil
+	}
+	queue, err := s.swapServices.queuedSwapsStore.ListAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, queued := range queue {
+		if queued.IdempotencyKey != key {
+			continue
+		}
+		if queued.Type != swapType {
+			return nil, nil, ErrIdempotencyKeyReused(key, queued.Type)
+		}
+		return nil, queued, nil
+	}
+	return nil, nil, nil
+}
+
+// idempotencyLocks holds a mutex for every idempotency key that is in use.
+type idempotencyLocks struct {
+	sync.Mutex
+	locks map[string]*idempotencyLock
+}
+
+type idempotencyLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newIdempotencyLocks() *idempotencyLocks {
+	return &idempotencyLocks{locks: map[string]*idempotencyLock{}}
+}
+
+func (l *idempotencyLocks) lock(key string) func() {
+	l.Lock()
+	keyLock, ok := l.locks[key]
+	if !ok {
+		keyLock = &idempotencyLock{}
+		l.locks[key] = keyLock
+	}
+	keyLock.refs++
+	l.Unlock()
+
+	keyLock.Lock()
+	return func() {
+		keyLock.Unlock()
+		l.Lock()
+		defer l.Unlock()
+		keyLock.refs--
+		if keyLock.refs == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package swap
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func Test_FindByIdempotencyKey(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	swap, queued, err := aliceSwapService.FindByIdempotencyKey("first", SWAPTYPE_OUT)
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+	assert.Nil(t, queued)
+
+	first, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000,
+		WithIdempotencyKey("first"))
+	require.NoError(t, err)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+
+	swap, queued, err = aliceSwapService.FindByIdempotencyKey("first", SWAPTYPE_OUT)
+	require.NoError(t, err)
+	require.NotNil(t, swap)
+	assert.Nil(t, queued)
+	assert.Equal(t, first.SwapId.String(), swap.SwapId.String())
+
+	// The key of the swap out can not be used for a swap in.
+	_, _, err = aliceSwapService.FindByIdempotencyKey("first", SWAPTYPE_IN)
+	assert.Error(t, err)
+
+	// The peer does not know our key.
+	swap, _, err = bobSwapService.FindByIdempotencyKey("first", SWAPTYPE_OUT)
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+
+	// The channel is busy, the key is stored with the queued swap.
+	_, second, err := aliceSwapService.QueueSwapOut(peer, btc_chain, channelId, initiator, amount, 100000,
+		WithIdempotencyKey("second"))
+	require.NoError(t, err)
+	require.NotNil(t, second)
+
+	swap, queued, err = aliceSwapService.FindByIdempotencyKey("second", SWAPTYPE_OUT)
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+	require.NotNil(t, queued)
+	assert.Equal(t, second.Id, queued.Id)
+}
+
+func Test_LockIdempotencyKey(t *testing.T) {
+	locks := newIdempotencyLocks()
+
+	unlock := locks.lock("key")
+	unlockOther := locks.lock("other")
+
+	locked := make(chan struct{})
+	go func() {
+		defer close(locked)
+		locks.lock("key")()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("second lock on the same key did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("second lock on the same key was not released")
+	}
+	unlockOther()
+
+	locks.Lock()
+	defer locks.Unlock()
+	assert.Empty(t, locks.locks)
+}
+
+func Test_IdempotencyKeyIndex(t *testing.T) {
+	db, err := bbolt.Open(path.Join(t.TempDir(), "swaps"), os.ModePerm, nil)
+	require.NoError(t, err)
+	store, err := NewBboltStore(db)
+	require.NoError(t, err)
+
+	first := &SwapStateMachine{SwapId: NewSwapId(), Type: SWAPTYPE_OUT, Role: SWAPROLE_SENDER,
+		Data: &SwapData{IdempotencyKey: "key", CreatedAt: 1}}
+	second := &SwapStateMachine{SwapId: NewSwapId(), Type: SWAPTYPE_OUT, Role: SWAPROLE_SENDER,
+		Data: &SwapData{IdempotencyKey: "key", CreatedAt: 2}}
+	received := &SwapStateMachine{SwapId: NewSwapId(), Type: SWAPTYPE_OUT, Role: SWAPROLE_RECEIVER,
+		Data: &SwapData{IdempotencyKey: "other"}}
+	require.NoError(t, store.Create(first))
+	require.NoError(t, store.Create(second))
+	require.NoError(t, store.Create(received))
+
+	// The key keeps pointing to the first swap.
+	swap, err := store.GetByIdempotencyKey("key")
+	require.NoError(t, err)
+	assert.Equal(t, first.SwapId.String(), swap.SwapId.String())
+
+	// Only swaps that we initiated are indexed.
+	_, err = store.GetByIdempotencyKey("other")
+	assert.ErrorIs(t, err, ErrDataNotAvailable)
+
+	// Swaps stored before the index existed are indexed on start.
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return tx.DeleteBucket(idempotencyKeyBucket)
+	}))
+	store, err = NewBboltStore(db)
+	require.NoError(t, err)
+	swap, err = store.GetByIdempotencyKey("key")
+	require.NoError(t, err)
+	assert.Equal(t, first.SwapId.String(), swap.SwapId.String())
+}
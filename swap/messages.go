@@ -46,6 +46,10 @@ type SwapInRequestMessage struct {
 	Amount       uint64 `json:"amount"`
 	Pubkey       string `json:"pubkey"`
 	PremiumLimit int64  `json:"acceptable_premium"`
+	// Confirmations is the number of confirmations that the sender wants the
+	// opening tx to have before the claim invoice is paid. It is left out by
+	// peers that do not scale the confirmations.
+	Confirmations uint32 `json:"confirmations,omitempty"`
 }
 
 func (s SwapInRequestMessage) MessageType() messages.MessageType {
@@ -57,6 +61,10 @@ func (s SwapInRequestMessage) Validate(swap *SwapData) error {
 	if err != nil {
 		return err
 	}
+	err = validateConfirmations(s.Confirmations)
+	if err != nil {
+		return err
+	}
 	err = validateAssetAndNetwork(s.Asset, s.Network)
 	if err != nil {
 		return err
@@ -137,6 +145,30 @@ func validateNetwork(network string) error {
 	}
 	return InvalidNetworkError
 }
+
+// validateConfirmations checks that the confirmations of a request or an
+// agreement stay within the bound of the protocol.
+func validateConfirmations(confirmations uint32) error {
+	if confirmations > maxRequiredConfirmations {
+		return fmt.Errorf("confirmations %d exceed the maximum of %d", confirmations, maxRequiredConfirmations)
+	}
+	return nil
+}
+
+// validateAgreedConfirmations checks that the peer does not agree to fewer
+// confirmations than requested. A peer that leaves them out does not scale
+// the confirmations, the requested ones are kept then.
+func validateAgreedConfirmations(confirmations uint32, swap *SwapData) error {
+	err := validateConfirmations(confirmations)
+	if err != nil {
+		return err
+	}
+	if confirmations > 0 && confirmations < swap.GetRequiredConfirmations() {
+		return fmt.Errorf("agreed confirmations %d are below the requested %d", confirmations, swap.GetRequiredConfirmations())
+	}
+	return nil
+}
+
 func validateHexString(paramName, hexString string, expectedLength int) error {
 	data, err := hex.DecodeString(hexString)
 	if err != nil {
@@ -172,6 +204,10 @@ type SwapInAgreementMessage struct {
 	// Premium is a compensation in Sats that the swap partner wants to be paid
 	// in order to participate in the swap.
 	Premium int64 `json:"premium"`
+	// Confirmations is the number of confirmations that both peers wait for
+	// before the claim invoice is paid, the higher one of the request and
+	// the policy of the responder.
+	Confirmations uint32 `json:"confirmations,omitempty"`
 }
 
 func (s SwapInAgreementMessage) Validate(swap *SwapData) error {
@@ -179,6 +215,10 @@ func (s SwapInAgreementMessage) Validate(swap *SwapData) error {
 	if err != nil {
 		return err
 	}
+	err = validateAgreedConfirmations(s.Confirmations, swap)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -192,6 +232,9 @@ func (s SwapInAgreementMessage) ApplyToSwapData(swap *SwapData) error {
 		return AlreadyExistsError
 	}
 	swap.SwapInAgreement = &s
+	if s.Confirmations > 0 {
+		swap.RequiredConfirmations = s.Confirmations
+	}
 	return nil
 }
 
@@ -221,6 +264,14 @@ type SwapOutRequestMessage struct {
 	// the opening_transaction.
 	Pubkey       string `json:"pubkey"`
 	PremiumLimit int64  `json:"acceptable_premium"`
+	// ExtraScids are further channels to the same peer that the claim
+	// payment may use in addition to Scid. It is only set if the peer
+	// advertised the multi_channel_swap_out feature.
+	ExtraScids []string `json:"extra_scids,omitempty"`
+	// Confirmations is the number of confirmations that the sender wants the
+	// opening tx to have before the claim invoice is paid. It is left out by
+	// peers that do not scale the confirmations.
+	Confirmations uint32 `json:"confirmations,omitempty"`
 }
 
 func (s SwapOutRequestMessage) Validate(swap *SwapData) error {
@@ -236,6 +287,22 @@ func (s SwapOutRequestMessage) Validate(swap *SwapData) error {
 	if err != nil {
 		return err
 	}
+	err = validateConfirmations(s.Confirmations)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{strings.ReplaceAll(s.Scid, ":", "x"): true}
+	for _, scid := range s.ExtraScids {
+		err = validateScid(scid)
+		if err != nil {
+			return err
+		}
+		scid = strings.ReplaceAll(scid, ":", "x")
+		if seen[scid] {
+			return fmt.Errorf("duplicate channel %s", scid)
+		}
+		seen[scid] = true
+	}
 	return nil
 }
 
@@ -270,6 +337,10 @@ type SwapOutAgreementMessage struct {
 	// Premium is a compensation in Sats that the swap partner wants to be paid
 	// in order to participate in the swap.
 	Premium int64 `json:"premium"`
+	// Confirmations is the number of confirmations that both peers wait for
+	// before the claim invoice is paid, the higher one of the request and
+	// the policy of the responder.
+	Confirmations uint32 `json:"confirmations,omitempty"`
 }
 
 func (s SwapOutAgreementMessage) Validate(swap *SwapData) error {
@@ -277,6 +348,10 @@ func (s SwapOutAgreementMessage) Validate(swap *SwapData) error {
 	if err != nil {
 		return err
 	}
+	err = validateAgreedConfirmations(s.Confirmations, swap)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -289,6 +364,9 @@ func (s SwapOutAgreementMessage) ApplyToSwapData(swap *SwapData) error {
 		return AlreadyExistsError
 	}
 	swap.SwapOutAgreement = &s
+	if s.Confirmations > 0 {
+		swap.RequiredConfirmations = s.Confirmations
+	}
 	return nil
 }
 
@@ -392,6 +470,20 @@ func (m CoopCloseMessage) ApplyToSwapData(swap *SwapData) error {
 	return nil
 }
 
+// AckMessage is sent by a node that processed a swap message. It is only sent
+// to peers that advertise support for acks and lets the sender stop resending
+// the message.
+type AckMessage struct {
+	// SwapId is the unique identifier of the swap.
+	SwapId *SwapId `json:"swap_id"`
+	// AckedMessageType is the type of the processed message.
+	AckedMessageType messages.MessageType `json:"acked_message_type"`
+}
+
+func (a AckMessage) MessageType() messages.MessageType {
+	return messages.MESSAGETYPE_ACK
+}
+
 func MarshalPeerswapMessage(msg PeerMessage) ([]byte, int, error) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
@@ -0,0 +1,94 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMultiChannelSwapOutNotSupported = errors.New("peer does not support swap-outs across several channels")
+
+// WithExtraChannels lets a swap-out span the given channels to the same peer
+// in addition to the swap channel. The claim invoice is then paid as a
+// multi-part payment over all of these channels.
+func WithExtraChannels(scids ...string) SwapOption {
+	return func(data *SwapData) {
+		data.extraScids = append([]string(nil), scids...)
+	}
+}
+
+// GetScids returns the swap channel followed by the extra channels of a
+// swap-out that spans several channels.
+func (s *SwapData) GetScids() []string {
+	scid := s.GetScid()
+	if scid == "" {
+		return nil
+	}
+	return append([]string{scid}, s.getExtraScids()...)
+}
+
+// getExtraScids returns the extra channels of a swap-out that spans several
+// channels.
+func (s *SwapData) getExtraScids() []string {
+	if s.SwapOutRequest == nil {
+		return nil
+	}
+	return s.SwapOutRequest.ExtraScids
+}
+
+// SplitAmountMsat splits amountMsat into parts that fit into the given
+// spendable amounts. The channels are filled in order, so the first channel
+// carries the largest share. The returned parts have the same length as
+// spendableMsat and may contain zero parts at the end.
+func SplitAmountMsat(amountMsat uint64, spendableMsat []uint64) ([]uint64, error) {
+	parts := make([]uint64, len(spendableMsat))
+	left := amountMsat
+	for i, sp := range spendableMsat {
+		part := min(sp, left)
+		parts[i] = part
+		left -= part
+	}
+	if left > 0 {
+		return nil, fmt.Errorf("not enough spendable msat: %d, expected: %d", amountMsat-left, amountMsat)
+	}
+	return parts, nil
+}
+
+// spendableMsat returns the sum of the spendable amounts of the channels.
+func (s *SwapServices) spendableMsat(scids []string) (uint64, error) {
+	var total uint64
+	for _, scid := range scids {
+		sp, err := s.lightning.SpendableMsat(scid)
+		if err != nil {
+			return 0, err
+		}
+		total += sp
+	}
+	return total, nil
+}
+
+// checkChannelPeers fails if one of the channels does not exist to the peer.
+func (s *SwapServices) checkChannelPeers(peerId string, scids []string) error {
+	for _, scid := range scids {
+		channelPeer, err := s.lightning.ChannelPeer(scid)
+		if err != nil {
+			return err
+		}
+		if channelPeer != peerId {
+			return fmt.Errorf("channel %s does not exist to the peer", scid)
+		}
+	}
+	return nil
+}
+
+// receivableMsat returns the sum of the receivable amounts of the channels.
+func (s *SwapServices) receivableMsat(scids []string) (uint64, error) {
+	var total uint64
+	for _, scid := range scids {
+		rs, err := s.lightning.ReceivableMsat(scid)
+		if err != nil {
+			return 0, err
+		}
+		total += rs
+	}
+	return total, nil
+}
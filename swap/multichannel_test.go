@@ -0,0 +1,121 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SplitAmountMsat(t *testing.T) {
+	parts, err := SplitAmountMsat(150, []uint64{100, 100})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{100, 50}, parts)
+
+	parts, err = SplitAmountMsat(50, []uint64{100, 100})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{50, 0}, parts)
+
+	_, err = SplitAmountMsat(250, []uint64{100, 100})
+	assert.EqualError(t, err, "not enough spendable msat: 200, expected: 250")
+}
+
+func Test_SwapOutRequestExtraScids(t *testing.T) {
+	msg := SwapOutRequestMessage{
+		Pubkey:  getRandom33ByteHexString(),
+		Network: "regtest",
+		Scid:    "100x2x3",
+	}
+	msg.ExtraScids = []string{"100x2x4"}
+	assert.NoError(t, msg.Validate(nil))
+
+	msg.ExtraScids = []string{"100:2:3"}
+	assert.Error(t, msg.Validate(nil))
+
+	msg.ExtraScids = []string{"100x2x4", "100x2x4"}
+	assert.Error(t, msg.Validate(nil))
+
+	msg.ExtraScids = []string{"invalid"}
+	assert.ErrorIs(t, msg.Validate(nil), InvalidScidError)
+}
+
+func Test_MultiChannelSwapOut(t *testing.T) {
+	amount := uint64(200000)
+	initiator, peer, _, _, channelId := getTestParams()
+	extraChannelId := "100x2x4"
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	// Neither channel can carry the swap on its own.
+	balances := map[string]uint64{channelId: amount * 600, extraChannelId: amount * 600}
+	aliceLightning := aliceSwapService.swapServices.lightning.(*dummyLightningClient)
+	aliceLightning.channelBalanceMsat = balances
+	// The claim invoice includes the premium of 10000 ppm.
+	aliceLightning.decodeAmount = (amount + amount/100) * 1000
+	aliceLightning.decodeFinalCLTV = 10
+	bobLightning := bobSwapService.swapServices.lightning.(*dummyLightningClient)
+	bobLightning.channelBalanceMsat = balances
+	bobLightning.channelPeers = map[string]string{extraChannelId: "other-peer"}
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	// The peer has to support swap-outs across several channels.
+	_, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000, WithExtraChannels(extraChannelId))
+	assert.ErrorIs(t, err, ErrMultiChannelSwapOutNotSupported)
+
+	aliceSwapService.swapServices.SetMultiChannelSwapOutSupport(&multiChannelSupportStub{peers: map[string]bool{peer: true}})
+
+	// A single channel is not enough.
+	_, err = aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	assert.ErrorContains(t, err, "exceeding spendable amount_msat")
+
+	// The peer rejects channels that are not channels to us.
+	rejected, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000, WithExtraChannels(extraChannelId))
+	require.NoError(t, err)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_CANCELED, <-aliceMsgChan)
+	require.True(t, rejected.WaitForStateChange(func(st StateType) bool {
+		return st == State_SwapCanceled
+	}, time.Second))
+	assert.Contains(t, rejected.Data.Cancel.Message, "does not exist to the peer")
+
+	bobLightning.channelPeers = map[string]string{extraChannelId: initiator}
+	aliceSwap, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000, WithExtraChannels(extraChannelId))
+	require.NoError(t, err)
+	assert.Equal(t, []string{channelId, extraChannelId}, aliceSwap.Data.GetScids())
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	bobSwap, err := bobSwapService.GetActiveSwap(aliceSwap.SwapId.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{channelId, extraChannelId}, bobSwap.Data.GetScids())
+
+	// Both channels are locked on both sides.
+	_, err = aliceSwapService.SwapIn(peer, btc_chain, extraChannelId, initiator, amount/2, 100000)
+	assert.ErrorIs(t, err, ActiveSwapError{channelId: extraChannelId, swapId: aliceSwap.SwapId.String()})
+	_, err = bobSwapService.SwapIn(initiator, btc_chain, extraChannelId, peer, amount/2, 100000)
+	assert.ErrorIs(t, err, ActiveSwapError{channelId: extraChannelId, swapId: aliceSwap.SwapId.String()})
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+	assert.Equal(t, State_SwapOutSender_AwaitTxBroadcastedMessage, aliceSwap.Current)
+	bobSwapService.swapServices.lightning.(*dummyLightningClient).TriggerPayment(bobSwap.SwapId.String(), INVOICE_FEE)
+	assert.Equal(t, State_SwapOutReceiver_AwaitClaimInvoicePayment, bobSwap.Current)
+
+	assert.Equal(t, messages.MESSAGETYPE_OPENINGTXBROADCASTED, <-aliceMsgChan)
+
+	// The claim invoice is paid over both channels.
+	err = aliceSwapService.swapServices.bitcoinTxWatcher.(*dummyChain).txConfirmedFunc(aliceSwap.SwapId.String(), aliceSwap.Data.OpeningTxHex, nil)
+	require.NoError(t, err)
+	assert.Equal(t, State_ClaimedPreimage, aliceSwap.Current)
+	assert.Equal(t, []string{channelId, extraChannelId}, aliceLightning.rebalanceChannels)
+}
+
+type multiChannelSupportStub struct {
+	peers map[string]bool
+}
+
+func (s *multiChannelSupportStub) SupportsMultiChannelSwapOut(peerId string) bool {
+	return s.peers[peerId]
+}
@@ -0,0 +1,187 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elementsproject/peerswap/premium"
+)
+
+// PreflightCheck is the outcome of a single check that is run before a swap
+// is started.
+type PreflightCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PreflightReport summarizes the local checks and the estimated costs of a
+// swap without starting it.
+type PreflightReport struct {
+	Type            SwapType `json:"type"`
+	Asset           string   `json:"asset"`
+	ChannelId       string   `json:"channel_id"`
+	AmountSat       uint64   `json:"amount_sat"`
+	PremiumLimitSat int64    `json:"premium_limit_sat"`
+
+	SpendableMsat        uint64 `json:"spendable_msat,omitempty"`
+	ReceivableMsat       uint64 `json:"receivable_msat,omitempty"`
+	MaximumSwapAmountSat uint64 `json:"maximum_swap_amount_sat,omitempty"`
+
+	// EstimatedOpeningFeeSat is the fee of the opening transaction at the
+	// current feerate. On a swap-out this is what the peer charges with the
+	// fee invoice.
+	EstimatedOpeningFeeSat uint64 `json:"estimated_opening_fee_sat"`
+	// EstimatedClaimFeeSat is the fee of the claim transaction at the current
+	// feerate.
+	EstimatedClaimFeeSat uint64 `json:"estimated_claim_fee_sat"`
+
+	Checks []*PreflightCheck `json:"checks"`
+}
+
+// AddCheck records the result of a check. A nil error marks the check as
+// passed.
+func (r *PreflightReport) AddCheck(name string, err error) {
+	check := &PreflightCheck{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Reason = err.Error()
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// Passed returns true if all checks of the report passed.
+func (r *PreflightReport) Passed() bool {
+	for _, c := range r.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// PreflightSwapOut runs the local checks of a swap-out and estimates its fees.
+// It neither starts a swap nor sends a message to the peer.
+func (s *SwapService) PreflightSwapOut(peer, chain, channelId string, amtSat uint64, premiumLimitRatePpm int64) *PreflightReport {
+	report := &PreflightReport{
+		Type:            SWAPTYPE_OUT,
+		Asset:           chain,
+		ChannelId:       channelId,
+		AmountSat:       amtSat,
+		PremiumLimitSat: premium.NewPPM(premiumLimitRatePpm).Compute(amtSat),
+	}
+	s.preflightCommon(report, peer)
+
+	// The peer charges us the opening fee with the fee invoice, so it has to
+	// be spendable on top of the swap amount.
+	requiredMsat := (amtSat + report.EstimatedOpeningFeeSat) * 1000
+
+	report.AddCheck("can_spend", s.swapServices.lightning.CanSpend(amtSat*1000))
+
+	sp, err := s.swapServices.lightning.SpendableMsat(channelId)
+	if err == nil && sp < requiredMsat {
+		err = fmt.Errorf("not enough spendable msat: %d, expected: %d", sp, requiredMsat)
+	}
+	report.SpendableMsat = sp
+	report.AddCheck("spendable_balance", err)
+
+	success, failureReason, err := s.swapServices.lightning.ProbePayment(channelId, requiredMsat)
+	if err == nil && !success {
+		err = fmt.Errorf("the prepayment probe was unsuccessful: %s", failureReason)
+	}
+	report.AddCheck("probe_payment", err)
+
+	return report
+}
+
+// PreflightSwapIn runs the local checks of a swap-in and estimates its fees.
+// It neither starts a swap nor sends a message to the peer.
+func (s *SwapService) PreflightSwapIn(peer, chain, channelId string, amtSat uint64, premiumLimitRatePpm int64) *PreflightReport {
+	report := &PreflightReport{
+		Type:            SWAPTYPE_IN,
+		Asset:           chain,
+		ChannelId:       channelId,
+		AmountSat:       amtSat,
+		PremiumLimitSat: premium.NewPPM(premiumLimitRatePpm).Compute(amtSat),
+	}
+	assetAvailable := s.preflightCommon(report, peer)
+
+	report.AddCheck("can_spend", s.swapServices.lightning.CanSpend(amtSat*1000))
+
+	rs, err := s.swapServices.lightning.ReceivableMsat(channelId)
+	if err == nil && rs < amtSat*1000 {
+		err = fmt.Errorf("exceeding receivable amount_msat: %d", rs)
+	}
+	report.ReceivableMsat = rs
+	report.AddCheck("receivable_balance", err)
+
+	if assetAvailable {
+		maximumSwapAmountSat, err := s.estimateMaximumSwapAmountSat(chain)
+		if err == nil && amtSat > maximumSwapAmountSat {
+			err = fmt.Errorf("exceeding maximum swap amount: %d", maximumSwapAmountSat)
+		}
+		report.MaximumSwapAmountSat = maximumSwapAmountSat
+		report.AddCheck("wallet_balance", err)
+	}
+
+	return report
+}
+
+// preflightCommon runs the checks that are shared by swap-outs and swap-ins
+// and fills in the fee estimates. It returns false if the on-chain services of
+// the asset are not available.
+func (s *SwapService) preflightCommon(report *PreflightReport, peer string) bool {
+	var err error
+	if !s.swapServices.policy.NewSwapsAllowed() {
+		err = errors.New("swaps are disabled")
+	}
+	report.AddCheck("swaps_allowed", err)
+
+	err = nil
+	if s.swapServices.draining.Load() {
+		err = ErrDraining
+	}
+	report.AddCheck("not_draining", err)
+
+	err = nil
+	if s.swapServices.policy.IsPeerSuspicious(peer) {
+		err = PeerIsSuspiciousError(peer)
+	}
+	report.AddCheck("peer_not_suspicious", err)
+
+	err = nil
+	if report.AmountSat*1000 < s.swapServices.policy.GetMinSwapAmountMsat() {
+		err = ErrMinimumSwapSize(s.swapServices.policy.GetMinSwapAmountMsat())
+	}
+	report.AddCheck("minimum_swap_amount", err)
+
+	report.AddCheck("no_active_swap_on_channel", s.checkNoActiveSwap(report.ChannelId))
+
+	wallet, err := s.enabledWallet(report.Asset)
+	report.AddCheck("asset_enabled", err)
+	if err != nil {
+		return false
+	}
+
+	openingFee, err := wallet.GetFlatOpeningTXFee()
+	if err != nil {
+		report.AddCheck("fee_estimation", err)
+		return true
+	}
+	claimFee, err := wallet.GetRefundFee()
+	if err != nil {
+		report.AddCheck("fee_estimation", err)
+		return true
+	}
+	report.EstimatedOpeningFeeSat = openingFee
+	report.EstimatedClaimFeeSat = claimFee
+	report.AddCheck("fee_estimation", nil)
+	return true
+}
+
+// checkNoActiveSwap returns an ActiveSwapError if there is already an active
+// swap on the channel.
+func (s *SwapService) checkNoActiveSwap(channelId string) error {
+	s.RLock()
+	defer s.RUnlock()
+	return s.activeSwapOnChannel(channelId)
+}
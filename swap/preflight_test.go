@@ -0,0 +1,47 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PreflightSwapOut(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	report := swapService.PreflightSwapOut(peer, btc_chain, channelId, 100000, 10000)
+	assert.True(t, report.Passed())
+	assert.Equal(t, uint64(100), report.EstimatedOpeningFeeSat)
+	assert.Equal(t, uint64(100), report.EstimatedClaimFeeSat)
+	assert.Equal(t, int64(1000), report.PremiumLimitSat)
+
+	// No swap was started.
+	assert.Empty(t, swapService.activeSwaps)
+	assert.Empty(t, swapService.swapServices.swapStore.(*dummyStore).dataMap)
+}
+
+func Test_PreflightSwapIn(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	report := swapService.PreflightSwapIn(peer, l_btc_chain, channelId, 100000, 0)
+	assert.True(t, report.Passed())
+	assert.Equal(t, uint64(10000000-100), report.MaximumSwapAmountSat)
+
+	// Exceeding the wallet balance fails the wallet check only.
+	report = swapService.PreflightSwapIn(peer, l_btc_chain, channelId, 20000000, 0)
+	assert.False(t, report.Passed())
+	for _, c := range report.Checks {
+		if c.Name == "wallet_balance" {
+			assert.False(t, c.Passed)
+			assert.NotEmpty(t, c.Reason)
+		} else {
+			assert.True(t, c.Passed, c.Name)
+		}
+	}
+
+	// Unknown assets fail without touching the wallets.
+	report = swapService.PreflightSwapIn(peer, "doge", channelId, 100000, 0)
+	assert.False(t, report.Passed())
+}
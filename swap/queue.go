@@ -0,0 +1,347 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elementsproject/peerswap/log"
+)
+
+var ErrSwapQueueDisabled = errors.New("swap queue is not available")
+
+// defaultQueueStartDelay gives the peer time to finish its side of the
+// previous swap before the next queued swap on the channel is requested, and
+// time to start up before a deferred swap is requested.
+const defaultQueueStartDelay = 10 * time.Second
+
+// QueuedSwap is a swap that we initiated while its channel was busy with
+// another swap. It is started as soon as the channel has no active swap
+// anymore.
+type QueuedSwap struct {
+	Id string `json:"id"`
+	// Seq is the position of the swap in the queue. Queued swaps on the same
+	// channel are started in ascending order.
+	Seq             uint64   `json:"seq"`
+	Type            SwapType `json:"type"`
+	PeerNodeId      string   `json:"peer_node_id"`
+	InitiatorNodeId string   `json:"initiator_node_id"`
+	Asset           string   `json:"asset"`
+	ChannelId       string   `json:"channel_id"`
+	// ExtraChannelIds are the extra channels of a swap-out that spans
+	// several channels, see WithExtraChannels.
+	ExtraChannelIds     []string `json:"extra_channel_ids,omitempty"`
+	AmountSat           uint64   `json:"amount_sat"`
+	PremiumLimitRatePpm int64    `json:"premium_limit_rate_ppm"`
+	RetryOf             string   `json:"retry_of,omitempty"`
+	IdempotencyKey      string   `json:"idempotency_key,omitempty"`
+	ClaimAddress        string   `json:"claim_address,omitempty"`
+	FeeRate             *FeeRate `json:"fee_rate,omitempty"`
+	// CoinSelection restricts the utxos that fund the opening tx of a
+	// swap-in, see WithCoinSelection.
+	CoinSelection *CoinSelection `json:"coin_selection,omitempty"`
+	// ExternalFunding is set on swap-ins whose opening transaction is
+	// funded outside of the node, see WithExternalFunding.
+	ExternalFunding bool  `json:"external_funding,omitempty"`
+	CreatedAt       int64 `json:"created_at"`
+	// WaitForPeer is set on deferred swaps. They are started once the peer
+	// comes online instead of once the channel is free.
+	WaitForPeer bool `json:"wait_for_peer,omitempty"`
+	// ExpiresAt is the unix time after which a deferred swap is dropped.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+// QueueSwapOut starts a swap out like SwapOut. If the channel already has an
+// active swap the swap out is queued instead and started once the channel is
+// free. Either the started swap or the queued swap is returned.
+func (s *SwapService) QueueSwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...SwapOption) (*SwapStateMachine, *QueuedSwap, error) {
+	return s.swapOrQueue(&QueuedSwap{
+		Type:                SWAPTYPE_OUT,
+		PeerNodeId:          peer,
+		InitiatorNodeId:     initiator,
+		Asset:               chain,
+		ChannelId:           channelId,
+		AmountSat:           amtSat,
+		PremiumLimitRatePpm: premiumLimitRatePpm,
+	}, opts)
+}
+
+// QueueSwapIn starts a swap in like SwapIn. If the channel already has an
+// active swap the swap in is queued instead and started once the channel is
+// free. Either the started swap or the queued swap is returned.
+func (s *SwapService) QueueSwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...SwapOption) (*SwapStateMachine, *QueuedSwap, error) {
+	return s.swapOrQueue(&QueuedSwap{
+		Type:                SWAPTYPE_IN,
+		PeerNodeId:          peer,
+		InitiatorNodeId:     initiator,
+		Asset:               chain,
+		ChannelId:           channelId,
+		AmountSat:           amtSat,
+		PremiumLimitRatePpm: premiumLimitRatePpm,
+	}, opts)
+}
+
+// ListQueuedSwaps returns all queued swaps in the order they are started.
+func (s *SwapService) ListQueuedSwaps() ([]*QueuedSwap, error) {
+	if s.swapServices.queuedSwapsStore == nil {
+		return nil, ErrSwapQueueDisabled
+	}
+	queue, err := s.swapServices.queuedSwapsStore.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].Seq < queue[j].Seq
+	})
+	return queue, nil
+}
+
+// RemoveQueuedSwap removes a swap from the queue before it was started.
+func (s *SwapService) RemoveQueuedSwap(id string) error {
+	if s.swapServices.queuedSwapsStore == nil {
+		return ErrSwapQueueDisabled
+	}
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+	err := s.swapServices.queuedSwapsStore.Delete(id)
+	if errors.Is(err, ErrDoesNotExist) {
+		return fmt.Errorf("queued swap %s does not exist", id)
+	}
+	return err
+}
+
+// startQueuedSwaps starts the first queued swap of every channel that has no
+// active swap.
+func (s *SwapService) startQueuedSwaps() {
+	if s.swapServices.queuedSwapsStore == nil {
+		return
+	}
+	queue, err := s.ListQueuedSwaps()
+	if err != nil {
+		log.Infof("error listing queued swaps: %v", err)
+		return
+	}
+	channels := map[string]struct{}{}
+	for _, queued := range queue {
+		if queued.WaitForPeer {
+			s.scheduleDeferredExpiry(queued)
+			continue
+		}
+		if _, ok := channels[queued.ChannelId]; ok {
+			continue
+		}
+		channels[queued.ChannelId] = struct{}{}
+		go s.startNextQueuedSwap(queued.ChannelId)
+	}
+}
+
+func (s *SwapService) swapOrQueue(queued *QueuedSwap, opts []SwapOption) (*SwapStateMachine, *QueuedSwap, error) {
+	if s.swapServices.queuedSwapsStore == nil {
+		return nil, nil, ErrSwapQueueDisabled
+	}
+
+	queuedFromOptions(queued, opts)
+
+	// A swap that was queued before on one of the channels is started
+	// first, also while it waits for the start delay of a freed channel.
+	ahead, err := s.queuedSwapAhead(queued)
+	if err != nil {
+		return nil, nil, err
+	}
+	var behind string
+	if ahead != nil {
+		behind = "queued swap " + ahead.Id
+	} else {
+		swap, err := s.startQueuedSwap(queued)
+		var activeErr ActiveSwapError
+		if !errors.As(err, &activeErr) {
+			return swap, nil, err
+		}
+		behind = "active swap " + activeErr.swapId
+	}
+
+	queued.Id = NewSwapId().String()
+	queued.CreatedAt = time.Now().Unix()
+	err = s.swapServices.queuedSwapsStore.Add(queued)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Infof("[%s]: queued swap on channel %s behind %s",
+		queued.Id, queued.ChannelId, behind)
+
+	if ahead == nil {
+		// The active swap may have finished in the meantime.
+		go s.startNextQueuedSwap(queued.ChannelId)
+	}
+	return nil, queued, nil
+}
+
+// queuedFromOptions keeps the values that the options set on the queued
+// swap, as options can not be persisted.
+func queuedFromOptions(queued *QueuedSwap, opts []SwapOption) {
+	data := &SwapData{}
+	for _, opt := range opts {
+		opt(data)
+	}
+	queued.RetryOf = data.RetryOf
+	queued.IdempotencyKey = data.IdempotencyKey
+	queued.ExtraChannelIds = data.extraScids
+	queued.ClaimAddress = data.ClaimAddress
+	queued.FeeRate = data.FeeRate
+	queued.CoinSelection = data.CoinSelection
+	queued.ExternalFunding = data.ExternalFunding != nil
+}
+
+// optionsFromQueued returns the options that set the values kept on the
+// queued swap, see queuedFromOptions.
+func optionsFromQueued(queued *QueuedSwap) []SwapOption {
+	var opts []SwapOption
+	if queued.RetryOf != "" {
+		opts = append(opts, WithRetryOf(queued.RetryOf))
+	}
+	if queued.IdempotencyKey != "" {
+		opts = append(opts, WithIdempotencyKey(queued.IdempotencyKey))
+	}
+	if len(queued.ExtraChannelIds) > 0 {
+		opts = append(opts, WithExtraChannels(queued.ExtraChannelIds...))
+	}
+	if queued.ClaimAddress != "" {
+		opts = append(opts, WithClaimAddress(queued.ClaimAddress))
+	}
+	if queued.FeeRate != nil {
+		opts = append(opts, WithFeeRate(queued.FeeRate))
+	}
+	if queued.CoinSelection != nil {
+		opts = append(opts, WithCoinSelection(queued.CoinSelection))
+	}
+	if queued.ExternalFunding {
+		opts = append(opts, WithExternalFunding())
+	}
+	return opts
+}
+
+func (s *SwapService) startQueuedSwap(queued *QueuedSwap) (*SwapStateMachine, error) {
+	opts := optionsFromQueued(queued)
+	switch queued.Type {
+	case SWAPTYPE_OUT:
+		return s.SwapOut(queued.PeerNodeId, queued.Asset, queued.ChannelId, queued.InitiatorNodeId,
+			queued.AmountSat, queued.PremiumLimitRatePpm, opts...)
+	case SWAPTYPE_IN:
+		return s.SwapIn(queued.PeerNodeId, queued.Asset, queued.ChannelId, queued.InitiatorNodeId,
+			queued.AmountSat, queued.PremiumLimitRatePpm, opts...)
+	default:
+		return nil, fmt.Errorf("unknown swap type %d", queued.Type)
+	}
+}
+
+// startNextQueuedSwap starts the oldest queued swap of the channel if the
+// channel has no active swap. Queued swaps that can not be started anymore
+// are dropped and the next one is tried.
+func (s *SwapService) startNextQueuedSwap(channelId string) {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	for {
+		queued, err := s.nextQueuedSwap(channelId)
+		if err != nil {
+			log.Infof("error reading queued swaps of channel %s: %v", channelId, err)
+			return
+		}
+		if queued == nil {
+			return
+		}
+
+		swap, startErr := s.startAndDeleteQueuedSwap(queued)
+		var activeErr ActiveSwapError
+		if errors.As(startErr, &activeErr) {
+			// Still busy, we try again when the active swap is done.
+			return
+		}
+		if errors.Is(startErr, ErrDraining) {
+			// Kept until the drain is stopped.
+			return
+		}
+		if startErr != nil {
+			log.Infof("[%s]: dropping queued swap, could not start: %v", queued.Id, startErr)
+			continue
+		}
+		log.Infof("[%s]: started queued swap as %s", queued.Id, swap.SwapId.String())
+		return
+	}
+}
+
+// startAndDeleteQueuedSwap starts the queued swap and removes it from the
+// queue unless it has to wait for an active swap or the drain to end. The
+// idempotency key is locked so that a request with the same key finds either
+// the queued or the started swap.
+func (s *SwapService) startAndDeleteQueuedSwap(queued *QueuedSwap) (*SwapStateMachine, error) {
+	if queued.IdempotencyKey != "" {
+		defer s.LockIdempotencyKey(queued.IdempotencyKey)()
+	}
+	swap, err := s.startQueuedSwap(queued)
+	var activeErr ActiveSwapError
+	if errors.As(err, &activeErr) || errors.Is(err, ErrDraining) {
+		return nil, err
+	}
+	if err := s.swapServices.queuedSwapsStore.Delete(queued.Id); err != nil {
+		log.Infof("[%s]: error removing queued swap: %v", queued.Id, err)
+	}
+	return swap, err
+}
+
+// nextQueuedSwap returns the oldest queued swap of the channel or nil if
+// there is none.
+func (s *SwapService) nextQueuedSwap(channelId string) (*QueuedSwap, error) {
+	queue, err := s.ListQueuedSwaps()
+	if err != nil {
+		return nil, err
+	}
+	for _, queued := range queue {
+		if queued.usesChannel(channelId) && !queued.WaitForPeer {
+			return queued, nil
+		}
+	}
+	return nil, nil
+}
+
+// queuedSwapAhead returns a queued swap that spans one of the channels of the
+// swap, or nil if there is none.
+func (s *SwapService) queuedSwapAhead(queued *QueuedSwap) (*QueuedSwap, error) {
+	channelIds := append([]string{queued.ChannelId}, queued.ExtraChannelIds...)
+	for _, channelId := range channelIds {
+		ahead, err := s.nextQueuedSwap(channelId)
+		if err != nil || ahead != nil {
+			return ahead, err
+		}
+	}
+	return nil, nil
+}
+
+// usesChannel returns true if the queued swap spans the channel.
+func (q *QueuedSwap) usesChannel(channelId string) bool {
+	if q.ChannelId == channelId {
+		return true
+	}
+	for _, id := range q.ExtraChannelIds {
+		if id == channelId {
+			return true
+		}
+	}
+	return false
+}
+
+// onChannelFree starts the next queued swap of a channel whose active swap
+// was removed.
+func (s *SwapService) onChannelFree(channelId string) {
+	if s.swapServices.queuedSwapsStore == nil {
+		return
+	}
+	queued, err := s.nextQueuedSwap(channelId)
+	if err != nil || queued == nil {
+		return
+	}
+	time.AfterFunc(s.queueStartDelay, func() {
+		s.startNextQueuedSwap(channelId)
+	})
+}
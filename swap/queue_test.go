@@ -0,0 +1,123 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elementsproject/peerswap/messages"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QueueSwap(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	// set lightning to fail so that the swaps get canceled
+	aliceSwapService.swapServices.lightning.(*dummyLightningClient).failpayment = true
+
+	aliceSwapService.queueStartDelay = 100 * time.Millisecond
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	first, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+
+	// The channel is busy, so further swaps are queued.
+	swap, queued, err := aliceSwapService.QueueSwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+	require.NotNil(t, queued)
+	assert.Equal(t, channelId, queued.ChannelId)
+
+	_, removed, err := aliceSwapService.QueueSwapIn(peer, l_btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+	require.NotNil(t, removed)
+
+	queue, err := aliceSwapService.ListQueuedSwaps()
+	require.NoError(t, err)
+	require.Len(t, queue, 2)
+	assert.Equal(t, queued.Id, queue[0].Id)
+	assert.Equal(t, removed.Id, queue[1].Id)
+
+	require.NoError(t, aliceSwapService.RemoveQueuedSwap(removed.Id))
+	assert.Error(t, aliceSwapService.RemoveQueuedSwap(removed.Id))
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_CANCELED, <-bobMsgChan)
+
+	// The queued swap is started once the first swap is done.
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, State_SwapCanceled, first.Current)
+
+	queue, err = aliceSwapService.ListQueuedSwaps()
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+}
+
+func Test_QueueSwapBehindQueued(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	// A queued swap on a freed channel waits for the start delay, a new
+	// swap on the channel is queued behind it.
+	first := &QueuedSwap{
+		Id:              NewSwapId().String(),
+		Type:            SWAPTYPE_OUT,
+		PeerNodeId:      peer,
+		InitiatorNodeId: initiator,
+		Asset:           btc_chain,
+		ChannelId:       "1x2x3",
+		ExtraChannelIds: []string{channelId},
+		AmountSat:       amount,
+	}
+	require.NoError(t, swapService.swapServices.queuedSwapsStore.Add(first))
+
+	swap, queued, err := swapService.QueueSwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+	require.NotNil(t, queued)
+
+	queue, err := swapService.ListQueuedSwaps()
+	require.NoError(t, err)
+	require.Len(t, queue, 2)
+	assert.Equal(t, first.Id, queue[0].Id)
+	assert.Equal(t, queued.Id, queue[1].Id)
+}
+
+func Test_QueuedSwapOptions(t *testing.T) {
+	feeRate := &FeeRate{TargetConf: 6}
+	selection := &CoinSelection{ExcludeUtxos: []string{dummyUtxo}}
+	opts := []SwapOption{
+		WithRetryOf("retried"),
+		WithIdempotencyKey("key"),
+		WithExtraChannels("1x2x3"),
+		WithClaimAddress("address"),
+		WithFeeRate(feeRate),
+		WithCoinSelection(selection),
+		WithExternalFunding(),
+	}
+	want := &SwapData{}
+	for _, opt := range opts {
+		opt(want)
+	}
+
+	queued := &QueuedSwap{}
+	queuedFromOptions(queued, opts)
+	got := &SwapData{}
+	for _, opt := range optionsFromQueued(queued) {
+		opt(got)
+	}
+	assert.Equal(t, want, got)
+
+	// No options set nothing.
+	queued = &QueuedSwap{}
+	queuedFromOptions(queued, nil)
+	assert.Equal(t, &QueuedSwap{}, queued)
+	assert.Empty(t, optionsFromQueued(queued))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/elementsproject/peerswap/log"
 	"github.com/elementsproject/peerswap/premium"
@@ -61,15 +62,26 @@ type SwapService struct {
 	sync.RWMutex
 
 	lastMsgLog map[string]string
+
+	// queueLock serializes starting queued swaps.
+	queueLock       sync.Mutex
+	queueStartDelay time.Duration
+
+	idempotencyLocks *idempotencyLocks
+
+	// drained is closed once the last active swap of a drain is done.
+	drained chan struct{}
 }
 
 func NewSwapService(services *SwapServices) *SwapService {
 	return &SwapService{
-		swapServices:   services,
-		activeSwaps:    map[string]*SwapStateMachine{},
-		LiquidEnabled:  services.liquidEnabled,
-		BitcoinEnabled: services.bitcoinEnabled,
-		lastMsgLog:     map[string]string{},
+		swapServices:     services,
+		activeSwaps:      map[string]*SwapStateMachine{},
+		LiquidEnabled:    services.liquidEnabled,
+		BitcoinEnabled:   services.bitcoinEnabled,
+		lastMsgLog:       map[string]string{},
+		queueStartDelay:  defaultQueueStartDelay,
+		idempotencyLocks: newIdempotencyLocks(),
 	}
 }
 
@@ -81,10 +93,12 @@ func (s *SwapService) Start() error {
 	if s.LiquidEnabled {
 		s.swapServices.liquidTxWatcher.AddConfirmationCallback(s.OnTxConfirmed)
 		s.swapServices.liquidTxWatcher.AddCsvCallback(s.OnCsvPassed)
+		s.swapServices.liquidTxWatcher.AddUnconfirmedCallback(s.OnTxUnconfirmed)
 	}
 	if s.BitcoinEnabled {
 		s.swapServices.bitcoinTxWatcher.AddConfirmationCallback(s.OnTxConfirmed)
 		s.swapServices.bitcoinTxWatcher.AddCsvCallback(s.OnCsvPassed)
+		s.swapServices.bitcoinTxWatcher.AddUnconfirmedCallback(s.OnTxUnconfirmed)
 	}
 
 	s.swapServices.lightning.AddPaymentCallback(s.OnPayment)
@@ -133,7 +147,7 @@ func (s *SwapService) RecoverSwaps() error {
 			}
 			swap.stateChange = sync.NewCond(&swap.stateMutex)
 
-			err := s.lockSwap(swap.SwapId.String(), swap.Data.GetScid(), swap)
+			err := s.lockSwap(swap.SwapId.String(), swap.Data.GetScid(), swap, swap.Data.getExtraScids()...)
 			if err != nil {
 				log.Infof("[%s]: error recovering swap: %v", swap.SwapId.String(), err)
 				return
@@ -152,6 +166,8 @@ func (s *SwapService) RecoverSwaps() error {
 	}
 	log.Debugf("Waiting for all pending swaps to recover.")
 	wg.Wait()
+
+	s.startQueuedSwaps()
 	return nil
 }
 
@@ -190,6 +206,8 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		return err
 	}
 	msgBytes := []byte(payload)
+	// ackSwapId is set if the message was processed and should be acked.
+	var ackSwapId *SwapId
 	switch msgType {
 	default:
 		// Do nothing here, as it will spam the cln log.
@@ -206,6 +224,7 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
 	case messages.MESSAGETYPE_SWAPOUTAGREEMENT:
 		var msg *SwapOutAgreementMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -226,6 +245,7 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
 	case messages.MESSAGETYPE_OPENINGTXBROADCASTED:
 		var msg *OpeningTxBroadcastedMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -243,9 +263,15 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		}
 
 		err = s.OnTxOpenedMessage(msg)
+		if errors.Is(err, ErrEventRejected) && s.hasOpeningTxMessage(msg.SwapId) {
+			// A resent message that we processed before, our ack may have
+			// been lost.
+			s.sendAck(peerId, msg.SwapId, msgType)
+		}
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
 	case messages.MESSAGETYPE_CANCELED:
 		var msg *CancelMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -266,6 +292,7 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
 	case messages.MESSAGETYPE_SWAPINREQUEST:
 		var msg *SwapInRequestMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -277,6 +304,7 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
 	case messages.MESSAGETYPE_SWAPINAGREEMENT:
 		var msg *SwapInAgreementMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -297,6 +325,26 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
+	case messages.MESSAGETYPE_ACK:
+		var msg *AckMessage
+		err := json.Unmarshal(msgBytes, &msg)
+		if err != nil {
+			return err
+		}
+		// Check if sender is expected swap partner peer.
+		ok, err := s.isMessageSenderExpectedPeer(peerId, msg.SwapId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrReceivedMessageFromUnexpectedPeer(peerId, msg.SwapId)
+		}
+
+		if s.swapServices.messengerManager.Ack(msg.SwapId.String(), int(msg.AckedMessageType)) {
+			log.Debugf("[Messenger] From: %s got ack of msgtype: %s for swap: %s", peerId,
+				messages.MessageTypeToHexString(msg.AckedMessageType), msg.SwapId.String())
+		}
 	case messages.MESSAGETYPE_COOPCLOSE:
 		var msg *CoopCloseMessage
 		err := json.Unmarshal(msgBytes, &msg)
@@ -317,6 +365,10 @@ func (s *SwapService) OnMessageReceived(peerId string, msgTypeString string, pay
 		if err != nil {
 			return err
 		}
+		ackSwapId = msg.SwapId
+	}
+	if ackSwapId != nil {
+		s.sendAck(peerId, ackSwapId, msgType)
 	}
 	return nil
 }
@@ -338,6 +390,8 @@ func (s *SwapService) OnTxConfirmed(swapId string, txHex string, gotErr error) e
 		}
 	}
 
+	s.swapServices.setTxUnconfirmed(swapId, false)
+
 	// todo move to eventctx
 	swap.Data.OpeningTxHex = txHex
 	done, err := swap.SendEvent(Event_OnTxConfirmed, nil)
@@ -352,6 +406,34 @@ func (s *SwapService) OnTxConfirmed(swapId string, txHex string, gotErr error) e
 	return nil
 }
 
+// OnTxUnconfirmed is called if a reorg unconfirmed the opening tx of a swap
+// after it was reported as confirmed. A running claim payment is paused and
+// the swap waits for the tx to confirm again.
+func (s *SwapService) OnTxUnconfirmed(swapId string) error {
+	swap, err := s.GetActiveSwap(swapId)
+	if errors.Is(err, ErrSwapDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("[%s]: opening tx was unconfirmed by a reorg", swapId)
+
+	// The claim payment holds the lock of the swap, mark the swap first so
+	// that the payment stops before the next attempt.
+	s.swapServices.setTxUnconfirmed(swapId, true)
+	done, err := swap.SendEvent(Event_OnTxUnconfirmed, nil)
+	if err == ErrEventRejected {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if done {
+		s.RemoveActiveSwap(swap.SwapId.String())
+	}
+	return nil
+}
+
 // OnCsvPassed sends the csvpassed event to the corresponding swap
 func (s *SwapService) OnCsvPassed(swapId string) error {
 	swap, err := s.GetActiveSwap(swapId)
@@ -373,13 +455,27 @@ func (s *SwapService) OnCsvPassed(swapId string) error {
 	return nil
 }
 
+// SwapOption sets optional parameters on a swap started by SwapOut or SwapIn.
+type SwapOption func(*SwapData)
+
+// WithRetryOf links the new swap to the swap that it retries.
+func WithRetryOf(swapId string) SwapOption {
+	return func(data *SwapData) {
+		data.RetryOf = swapId
+	}
+}
+
 // todo move wallet and chain / channel validation logic here
 // SwapOut starts a new swap out process
-func (s *SwapService) SwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64) (*SwapStateMachine, error) {
+func (s *SwapService) SwapOut(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePpm int64, opts ...SwapOption) (*SwapStateMachine, error) {
 	if !s.swapServices.policy.NewSwapsAllowed() {
 		return nil, fmt.Errorf("swaps are disabled")
 	}
 
+	if s.swapServices.draining.Load() {
+		return nil, ErrDraining
+	}
+
 	if s.swapServices.policy.IsPeerSuspicious(peer) {
 		return nil, PeerIsSuspiciousError(peer)
 	}
@@ -393,7 +489,37 @@ func (s *SwapService) SwapOut(peer string, chain string, channelId string, initi
 		return nil, err
 	}
 
-	sp, err := s.swapServices.lightning.SpendableMsat(channelId)
+	swap := newSwapOutSenderFSM(s.swapServices, initiator, peer)
+	swap.Data.PremiumLimitRatePpm = premiumLimitRatePpm
+	swap.Data.RequiredConfirmations = s.swapServices.requiredConfirmations(chain, amtSat)
+	swap.Data.FeeRate = s.swapServices.defaultFeeRate(chain)
+	for _, opt := range opts {
+		opt(swap.Data)
+	}
+	extraScids := swap.Data.extraScids
+	if len(extraScids) > 0 && !s.swapServices.peerSupportsMultiChannelSwapOut(peer) {
+		return nil, ErrMultiChannelSwapOutNotSupported
+	}
+	if swap.Data.ClaimAddress != "" {
+		err = s.swapServices.validateClaimAddress(chain, swap.Data.ClaimAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if swap.Data.FeeRate != nil {
+		err = s.swapServices.validateFeeRate(chain, swap.Data.FeeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if swap.Data.CoinSelection != nil {
+		return nil, ErrCoinSelectionSwapOut
+	}
+	if swap.Data.ExternalFunding != nil {
+		return nil, ErrExternalFundingSwapOut
+	}
+
+	sp, err := s.swapServices.spendableMsat(append([]string{channelId}, extraScids...))
 	if err != nil {
 		return nil, err
 	}
@@ -401,8 +527,7 @@ func (s *SwapService) SwapOut(peer string, chain string, channelId string, initi
 		return nil, fmt.Errorf("exceeding spendable amount_msat: %d", sp)
 	}
 
-	swap := newSwapOutSenderFSM(s.swapServices, initiator, peer)
-	err = s.lockSwap(swap.SwapId.String(), channelId, swap)
+	err = s.lockSwap(swap.SwapId.String(), channelId, swap, extraScids...)
 	if err != nil {
 		return nil, err
 	}
@@ -425,6 +550,8 @@ func (s *SwapService) SwapOut(peer string, chain string, channelId string, initi
 		Amount:          amtSat,
 		Pubkey:          hex.EncodeToString(swap.Data.GetPrivkey().PubKey().SerializeCompressed()),
 		PremiumLimit:    premium.NewPPM(premiumLimitRatePpm).Compute(amtSat),
+		ExtraScids:      extraScids,
+		Confirmations:   swap.Data.RequiredConfirmations,
 	}
 
 	done, err := swap.SendEvent(Event_OnSwapOutStarted, request)
@@ -440,11 +567,15 @@ func (s *SwapService) SwapOut(peer string, chain string, channelId string, initi
 
 // todo check prerequisites
 // SwapIn starts a new swap in process
-func (s *SwapService) SwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePPM int64) (*SwapStateMachine, error) {
+func (s *SwapService) SwapIn(peer string, chain string, channelId string, initiator string, amtSat uint64, premiumLimitRatePPM int64, opts ...SwapOption) (*SwapStateMachine, error) {
 	if !s.swapServices.policy.NewSwapsAllowed() {
 		return nil, fmt.Errorf("swaps are disabled")
 	}
 
+	if s.swapServices.draining.Load() {
+		return nil, ErrDraining
+	}
+
 	if s.swapServices.policy.IsPeerSuspicious(peer) {
 		return nil, PeerIsSuspiciousError(peer)
 	}
@@ -464,13 +595,6 @@ func (s *SwapService) SwapIn(peer string, chain string, channelId string, initia
 	if rs < amtSat*1000 {
 		return nil, fmt.Errorf("exceeding receivable amount_msat: %d", rs)
 	}
-	maximumSwapAmountSat, err := s.estimateMaximumSwapAmountSat(chain)
-	if err != nil {
-		return nil, err
-	}
-	if amtSat > maximumSwapAmountSat {
-		return nil, fmt.Errorf("exceeding maximum swap amount: %d", maximumSwapAmountSat)
-	}
 	var bitcoinNetwork string
 	var elementsAsset string
 	if chain == l_btc_chain {
@@ -481,6 +605,53 @@ func (s *SwapService) SwapIn(peer string, chain string, channelId string, initia
 		return nil, errors.New("invalid chain")
 	}
 	swap := newSwapInSenderFSM(s.swapServices, initiator, peer)
+	swap.Data.PremiumLimitRatePpm = premiumLimitRatePPM
+	swap.Data.RequiredConfirmations = s.swapServices.requiredConfirmations(chain, amtSat)
+	swap.Data.FeeRate = s.swapServices.defaultFeeRate(chain)
+	for _, opt := range opts {
+		opt(swap.Data)
+	}
+	if swap.Data.ExternalFunding == nil {
+		// An externally funded swap-in does not spend from the node
+		// wallet.
+		maximumSwapAmountSat, err := s.estimateMaximumSwapAmountSat(chain)
+		if err != nil {
+			return nil, err
+		}
+		if amtSat > maximumSwapAmountSat {
+			return nil, fmt.Errorf("exceeding maximum swap amount: %d", maximumSwapAmountSat)
+		}
+	}
+	if swap.Data.ClaimAddress != "" {
+		err = s.swapServices.validateClaimAddress(chain, swap.Data.ClaimAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if swap.Data.FeeRate != nil {
+		err = s.swapServices.validateFeeRate(chain, swap.Data.FeeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if swap.Data.CoinSelection != nil {
+		if swap.Data.ExternalFunding != nil {
+			return nil, ErrCoinSelectionExternalFunding
+		}
+		err = s.swapServices.validateCoinSelection(chain, swap.Data.CoinSelection)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if swap.Data.ExternalFunding != nil {
+		if swap.Data.batch != nil {
+			return nil, errors.New("a batched swap-in can not be funded externally")
+		}
+		err = s.swapServices.validateExternalFunding(chain)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err = s.lockSwap(swap.SwapId.String(), channelId, swap)
 	if err != nil {
 		return nil, err
@@ -495,6 +666,7 @@ func (s *SwapService) SwapIn(peer string, chain string, channelId string, initia
 		Amount:          amtSat,
 		Pubkey:          hex.EncodeToString(swap.Data.GetPrivkey().PubKey().SerializeCompressed()),
 		PremiumLimit:    premium.NewPPM(premiumLimitRatePPM).Compute(amtSat),
+		Confirmations:   swap.Data.RequiredConfirmations,
 	}
 
 	done, err := swap.SendEvent(Event_SwapInSender_OnSwapInRequested, request)
@@ -541,6 +713,86 @@ func (s *SwapService) estimateMaximumSwapAmountSat(chain string) (uint64, error)
 	return 0, errors.New("invalid chain")
 }
 
+// swapOutRoutingHeadroomSat is kept free on the channel when the maximum
+// swap-out amount is determined so that the payments still find a route.
+const swapOutRoutingHeadroomSat = 5000
+
+// MaxSwapOutAmountSat returns the maximum amount in satoshis that can be
+// swapped out over the channel. It leaves room for the fee invoice, a routing
+// headroom and leaveLocalSat on our side of the channel.
+func (s *SwapService) MaxSwapOutAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error) {
+	wallet, err := s.enabledWallet(chain)
+	if err != nil {
+		return 0, err
+	}
+	openingFee, err := wallet.GetFlatOpeningTXFee()
+	if err != nil {
+		return 0, err
+	}
+	sp, err := s.swapServices.lightning.SpendableMsat(channelId)
+	if err != nil {
+		return 0, err
+	}
+
+	spendableSat := sp / 1000
+	keep := openingFee + swapOutRoutingHeadroomSat + leaveLocalSat
+	if spendableSat <= keep {
+		return 0, fmt.Errorf("spendable amount of %d sat does not cover fees, headroom and local target of %d sat", spendableSat, keep)
+	}
+	return spendableSat - keep, nil
+}
+
+// MaxSwapInAmountSat returns the maximum amount in satoshis that can be
+// swapped in over the channel. It leaves the opening fee, the onchain reserve
+// of the policy and leaveLocalSat in the wallet and does not exceed the
+// receivable amount of the channel.
+func (s *SwapService) MaxSwapInAmountSat(chain, channelId string, leaveLocalSat uint64) (uint64, error) {
+	if _, err := s.enabledWallet(chain); err != nil {
+		return 0, err
+	}
+	maximumSwapAmountSat, err := s.estimateMaximumSwapAmountSat(chain)
+	if err != nil {
+		return 0, err
+	}
+	rs, err := s.swapServices.lightning.ReceivableMsat(channelId)
+	if err != nil {
+		return 0, err
+	}
+
+	keep := s.swapServices.policy.GetReserveOnchainMsat()/1000 + leaveLocalSat
+	if maximumSwapAmountSat <= keep {
+		return 0, fmt.Errorf("wallet balance of %d sat does not cover reserve and local target of %d sat", maximumSwapAmountSat, keep)
+	}
+	amount := maximumSwapAmountSat - keep
+	if rs/1000 < amount {
+		amount = rs / 1000
+	}
+	return amount, nil
+}
+
+// LocalBalanceSat returns the spendable balance in satoshis on our side of the
+// channel.
+func (s *SwapService) LocalBalanceSat(channelId string) (uint64, error) {
+	sp, err := s.swapServices.lightning.SpendableMsat(channelId)
+	if err != nil {
+		return 0, err
+	}
+	return sp / 1000, nil
+}
+
+// enabledWallet returns the wallet of the chain if swaps on the chain are
+// enabled.
+func (s *SwapService) enabledWallet(chain string) (Wallet, error) {
+	_, wallet, _, err := s.swapServices.getOnChainServices(chain)
+	if err != nil {
+		return nil, err
+	}
+	if (chain == btc_chain && !s.BitcoinEnabled) || (chain == l_btc_chain && !s.LiquidEnabled) {
+		return nil, fmt.Errorf("%s swaps are not enabled", chain)
+	}
+	return wallet, nil
+}
+
 // OnSwapInRequestReceived creates a new swap-in process and sends the event to the swap statemachine
 func (s *SwapService) OnSwapInRequestReceived(swapId *SwapId, peerId string, message *SwapInRequestMessage) error {
 	var (
@@ -632,6 +884,10 @@ func (s *SwapService) OnSwapInRequestReceived(swapId *SwapId, peerId string, mes
 	}
 
 	swap := newSwapInReceiverFSM(swapId, s.swapServices, peerId)
+	swap.Data.RequiredConfirmations = s.swapServices.agreedConfirmations(
+		getChain(message.Asset, message.Network), message.Amount, message.Confirmations,
+	)
+	swap.Data.FeeRate = s.swapServices.defaultFeeRate(getChain(message.Asset, message.Network))
 
 	err = s.lockSwap(swap.SwapId.String(), message.Scid, swap)
 	if err != nil {
@@ -683,7 +939,18 @@ func (s *SwapService) OnSwapOutRequestReceived(swapId *SwapId, peerId string, me
 		s.swapServices.messenger.SendMessage(peerId, msgBytes, msgType)
 		return err
 	}
-	rs, err := s.swapServices.lightning.ReceivableMsat(message.Scid)
+	err = s.swapServices.checkChannelPeers(peerId, message.ExtraScids)
+	if err != nil {
+		msg := fmt.Sprintf("from the %s peer: %s", s.swapServices.lightning.Implementation(), err.Error())
+		// We want to tell our peer why we can not do this swap.
+		msgBytes, msgType, err := MarshalPeerswapMessage(&CancelMessage{
+			SwapId:  swapId,
+			Message: msg,
+		})
+		s.swapServices.messenger.SendMessage(peerId, msgBytes, msgType)
+		return err
+	}
+	rs, err := s.swapServices.receivableMsat(append([]string{message.Scid}, message.ExtraScids...))
 	if err != nil {
 		msg := fmt.Sprintf("from the %s peer: %s", s.swapServices.lightning.Implementation(), err.Error())
 		// We want to tell our peer why we can not do this swap.
@@ -708,7 +975,11 @@ func (s *SwapService) OnSwapOutRequestReceived(swapId *SwapId, peerId string, me
 	}
 
 	swap := newSwapOutReceiverFSM(swapId, s.swapServices, peerId)
-	err = s.lockSwap(swap.SwapId.String(), message.Scid, swap)
+	swap.Data.RequiredConfirmations = s.swapServices.agreedConfirmations(
+		getChain(message.Asset, message.Network), message.Amount, message.Confirmations,
+	)
+	swap.Data.FeeRate = s.swapServices.defaultFeeRate(getChain(message.Asset, message.Network))
+	err = s.lockSwap(swap.SwapId.String(), message.Scid, swap, message.ExtraScids...)
 	if err != nil {
 		// If we already have an active swap on the same channel or can not lock
 		// in a new swap we want to tell it our peer.
@@ -907,6 +1178,117 @@ func (s *SwapService) GetSwap(swapId string) (*SwapStateMachine, error) {
 	return s.swapServices.swapStore.GetData(swapId)
 }
 
+// GetRetryableSwap returns the swap with the given id if it can be retried.
+// Only finished swaps that were initiated by us and did not succeed can be
+// retried.
+func (s *SwapService) GetRetryableSwap(swapId string) (*SwapStateMachine, error) {
+	swap, err := s.swapServices.swapStore.GetData(swapId)
+	if err != nil {
+		return nil, err
+	}
+	if !swap.IsFinished() {
+		return nil, fmt.Errorf("swap %s is not finished yet", swapId)
+	}
+	if swap.Role != SWAPROLE_SENDER {
+		return nil, fmt.Errorf("swap %s was not initiated by us", swapId)
+	}
+	if swap.Current == State_ClaimedPreimage {
+		return nil, fmt.Errorf("swap %s succeeded", swapId)
+	}
+	return swap, nil
+}
+
+// RetryParams are the parameters of a swap that retries a finished swap.
+type RetryParams struct {
+	Type                SwapType
+	Chain               string
+	Scid                string
+	Amount              uint64
+	PremiumLimitRatePpm int64
+	RetryOf             string
+	// ExternalFunding is set if the opening tx of the swap-in was funded
+	// outside of the node, the retry is funded the same way.
+	ExternalFunding bool
+	// ClaimAddress is the address that the original swap was started with.
+	ClaimAddress string
+	// ExtraScids are the extra channels of a swap-out that spans several
+	// channels.
+	ExtraScids []string
+	// FeeRate is the fee rate of the original swap, nil if it used the
+	// wallet estimate.
+	FeeRate *FeeRate
+	// CoinSelection is the coin selection of the original swap-in.
+	CoinSelection *CoinSelection
+}
+
+// Options returns the options that start the retry with the settings of the
+// original swap.
+func (p *RetryParams) Options() []SwapOption {
+	opts := []SwapOption{WithRetryOf(p.RetryOf)}
+	if p.ExternalFunding {
+		opts = append(opts, WithExternalFunding())
+	}
+	if p.ClaimAddress != "" {
+		opts = append(opts, WithClaimAddress(p.ClaimAddress))
+	}
+	if p.FeeRate != nil {
+		opts = append(opts, WithFeeRate(p.FeeRate))
+	}
+	if p.CoinSelection != nil {
+		opts = append(opts, WithCoinSelection(p.CoinSelection))
+	}
+	return opts
+}
+
+// GetRetryParams returns the parameters of a swap that retries the swap with
+// the given id, see GetRetryableSwap. A non-zero amount or premium limit
+// overrides the one of the original swap.
+func (s *SwapService) GetRetryParams(swapId string, amount uint64, premiumLimitRatePpm int64) (*RetryParams, error) {
+	orig, err := s.GetRetryableSwap(swapId)
+	if err != nil {
+		return nil, err
+	}
+	if orig.Type != SWAPTYPE_OUT && orig.Type != SWAPTYPE_IN {
+		return nil, fmt.Errorf("unknown swap type %s", orig.Type.String())
+	}
+	params := &RetryParams{
+		Type:                orig.Type,
+		Chain:               orig.Data.GetChain(),
+		Scid:                orig.Data.GetScid(),
+		Amount:              orig.Data.GetAmount(),
+		PremiumLimitRatePpm: orig.Data.GetPremiumLimitRatePpm(),
+		RetryOf:             orig.SwapId.String(),
+		ExternalFunding:     orig.Data.ExternalFunding != nil,
+		ExtraScids:          orig.Data.getExtraScids(),
+	}
+	if orig.Data.FeeRate != nil {
+		feeRate := *orig.Data.FeeRate
+		params.FeeRate = &feeRate
+	}
+	if sel := orig.Data.CoinSelection; sel != nil {
+		// The selected utxos may have been spent since.
+		err = s.swapServices.validateCoinSelection(params.Chain, sel)
+		if err != nil {
+			return nil, fmt.Errorf("the coin selection of swap %s is no longer valid: %w", swapId, err)
+		}
+		params.CoinSelection = &CoinSelection{
+			Utxos:        append([]string(nil), sel.Utxos...),
+			ExcludeUtxos: append([]string(nil), sel.ExcludeUtxos...),
+		}
+	}
+	// A derived address is not reused, the retry derives its own.
+	if !orig.Data.ClaimAddressDerived {
+		params.ClaimAddress = orig.Data.ClaimAddress
+	}
+	if amount != 0 {
+		params.Amount = amount
+	}
+	if premiumLimitRatePpm != 0 {
+		params.PremiumLimitRatePpm = premiumLimitRatePpm
+	}
+	return params, nil
+}
+
 func (s *SwapService) ResendLastMessage(swapId string) error {
 	swap, err := s.GetActiveSwap(swapId)
 	if err != nil {
@@ -948,24 +1330,41 @@ func (s *SwapService) GetActiveSwap(swapId string) (*SwapStateMachine, error) {
 }
 
 // RemoveActiveSwap removes a swap from the active swap map
+// and starts the next queued swap of its channel.
 func (s *SwapService) RemoveActiveSwap(swapId string) {
 	s.Lock()
-	defer s.Unlock()
+	var channelIds []string
+	var batch *openingBatch
+	if swap, ok := s.activeSwaps[swapId]; ok && swap.Data != nil {
+		channelIds = swap.Data.GetScids()
+		batch = swap.Data.batch
+	}
 	delete(s.lastMsgLog, swapId)
 	delete(s.activeSwaps, swapId)
+	s.swapServices.setTxUnconfirmed(swapId, false)
+	s.checkDrained()
+	s.Unlock()
+
+	if batch != nil {
+		batch.leave(swapId)
+	}
+	for _, channelId := range channelIds {
+		s.onChannelFree(channelId)
+	}
 }
 
 // lockSwap locks in a swap. This function ensures that we only have one active
-// swap on a channel as required by the protocol.
+// swap on a channel as required by the protocol. A swap-out that spans several
+// channels locks all of them.
 // Returns an error if the swap is already locked.
-func (s *SwapService) lockSwap(swapId, channelId string, fsm *SwapStateMachine) error {
+func (s *SwapService) lockSwap(swapId, channelId string, fsm *SwapStateMachine, extraChannelIds ...string) error {
 	s.Lock()
 	defer s.Unlock()
 
-	// Check if we already have an active swap on the same channel
-	for id, swap := range s.activeSwaps {
-		if swap.Data.GetScid() == channelId {
-			return ActiveSwapError{channelId: channelId, swapId: id}
+	// Check if we already have an active swap on the same channels
+	for _, id := range append([]string{channelId}, extraChannelIds...) {
+		if err := s.activeSwapOnChannel(id); err != nil {
+			return err
 		}
 	}
 
@@ -974,6 +1373,19 @@ func (s *SwapService) lockSwap(swapId, channelId string, fsm *SwapStateMachine)
 	return nil
 }
 
+// activeSwapOnChannel returns an ActiveSwapError if there is an active swap on
+// the channel. The caller must hold the lock.
+func (s *SwapService) activeSwapOnChannel(channelId string) error {
+	for id, swap := range s.activeSwaps {
+		for _, scid := range swap.Data.GetScids() {
+			if scid == channelId {
+				return ActiveSwapError{channelId: channelId, swapId: id}
+			}
+		}
+	}
+	return nil
+}
+
 type ActiveSwapError struct {
 	channelId string
 	swapId    string
@@ -989,6 +1401,32 @@ func (e WrongAssetError) Error() string {
 	return fmt.Sprintf("unallowed asset: %s", string(e))
 }
 
+// sendAck acknowledges a processed swap message to peers that support acks.
+func (s *SwapService) sendAck(peerId string, swapId *SwapId, msgType messages.MessageType) {
+	if !s.swapServices.peerSupportsMessageAck(peerId) {
+		return
+	}
+	msg, msgTypeInt, err := MarshalPeerswapMessage(&AckMessage{SwapId: swapId, AckedMessageType: msgType})
+	if err != nil {
+		log.Infof("[Messenger] could not marshal ack for swap %s: %v", swapId.String(), err)
+		return
+	}
+	err = s.swapServices.messenger.SendMessage(peerId, msg, msgTypeInt)
+	if err != nil {
+		log.Debugf("[Messenger] could not send ack for swap %s: %v", swapId.String(), err)
+	}
+}
+
+// hasOpeningTxMessage returns true if the active swap already received the
+// opening tx broadcasted message.
+func (s *SwapService) hasOpeningTxMessage(swapId *SwapId) bool {
+	swap, err := s.GetActiveSwap(swapId.String())
+	if err != nil {
+		return false
+	}
+	return swap.Data.OpeningTxBroadcasted != nil
+}
+
 // isMessageSenderExpectedPeer returns true if the senderId matches the
 // PeerNodeId of the swap, false if not.
 func (s *SwapService) isMessageSenderExpectedPeer(senderId string, swapId *SwapId) (bool, error) {
@@ -1015,8 +1453,8 @@ func (s *SwapService) createTimeoutCallback(swapId string) func() {
 			swap.Data.toCancel = nil
 		}
 
-		done, err := swap.SendEvent(Event_OnTimeout, nil)
-		if err == ErrEventRejected {
+		done, err := swap.SendEvent(Event_OnTimeout, &timeoutEvent{})
+		if err == ErrEventRejected || errors.Is(err, errClaimQueued) {
 			return
 		}
 		if err != nil {
@@ -1029,3 +1467,23 @@ func (s *SwapService) createTimeoutCallback(swapId string) func() {
 		}
 	}
 }
+
+// errClaimQueued is returned if a swap times out while its claim by preimage
+// is held back to be batched.
+var errClaimQueued = errors.New("claim is queued")
+
+// timeoutEvent keeps a swap from timing out while its claim by preimage is
+// held back to be batched. The check runs under the lock of the swap, so a
+// timeout that fired right before the claim was queued is dropped as well.
+type timeoutEvent struct{}
+
+func (t *timeoutEvent) ApplyToSwapData(data *SwapData) error {
+	if data.claimQueued {
+		return errClaimQueued
+	}
+	return nil
+}
+
+func (t *timeoutEvent) Validate(data *SwapData) error {
+	return nil
+}
@@ -3,6 +3,7 @@ package swap
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"log"
 	"os"
 	"path"
@@ -75,6 +76,81 @@ func Test_GoodCase(t *testing.T) {
 	assert.Equal(t, State_ClaimedPreimage, bobSwap.Current)
 }
 
+func Test_OpeningTxUnconfirmed(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+	aliceSwap, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	bobSwap, err := bobSwapService.GetActiveSwap(aliceSwap.SwapId.String())
+	require.NoError(t, err)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+	bobSwapService.swapServices.lightning.(*dummyLightningClient).TriggerPayment(bobSwap.SwapId.String(), INVOICE_FEE)
+	assert.Equal(t, messages.MESSAGETYPE_OPENINGTXBROADCASTED, <-aliceMsgChan)
+
+	// A reorg unconfirms the opening tx while the claim invoice is paid.
+	aliceChain := aliceSwapService.swapServices.bitcoinTxWatcher.(*dummyChain)
+	aliceChain.onValidateTx = func() {
+		go func() {
+			assert.NoError(t, aliceChain.unconfirmedFunc(aliceSwap.SwapId.String()))
+		}()
+	}
+	require.NoError(t, aliceChain.txConfirmedFunc(aliceSwap.SwapId.String(), aliceSwap.Data.OpeningTxHex, nil))
+	assert.Equal(t, State_SwapOutSender_AwaitTxConfirmation, aliceSwap.Current)
+	assert.Equal(t, 0, aliceSwapService.swapServices.lightning.(*dummyLightningClient).rebalanceCalled)
+
+	// The tx is confirmed again.
+	aliceChain.onValidateTx = nil
+	require.NoError(t, aliceChain.txConfirmedFunc(aliceSwap.SwapId.String(), aliceSwap.Data.OpeningTxHex, nil))
+	assert.Equal(t, State_ClaimedPreimage, aliceSwap.Current)
+}
+
+func Test_RequiredConfirmations(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	for _, tc := range []struct {
+		name       string
+		aliceConfs uint32
+		bobConfs   uint32
+		want       uint32
+	}{
+		// Bob takes over the confirmations that alice requests.
+		{"requester", 6, 0, 6},
+		// Alice takes over the confirmations that bob agrees to.
+		{"responder", 0, 8, 8},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+			aliceSwapService.swapServices.policy.(*dummyPolicy).getConfirmationsReturn = tc.aliceConfs
+			bobSwapService.swapServices.policy.(*dummyPolicy).getConfirmationsReturn = tc.bobConfs
+
+			require.NoError(t, aliceSwapService.Start())
+			require.NoError(t, bobSwapService.Start())
+			aliceSwap, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+			require.NoError(t, err)
+
+			assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+			bobSwap, err := bobSwapService.GetActiveSwap(aliceSwap.SwapId.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, bobSwap.Data.RequiredConfirmations)
+			assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+			assert.Equal(t, tc.want, aliceSwap.Data.RequiredConfirmations)
+			bobSwapService.swapServices.lightning.(*dummyLightningClient).TriggerPayment(bobSwap.SwapId.String(), INVOICE_FEE)
+			assert.Equal(t, messages.MESSAGETYPE_OPENINGTXBROADCASTED, <-aliceMsgChan)
+
+			aliceChain := aliceSwapService.swapServices.bitcoinTxWatcher.(*dummyChain)
+			assert.Equal(t, tc.want, aliceChain.waitConfirmationsParam)
+		})
+	}
+}
+
 func Test_FeePaymentFailed(t *testing.T) {
 	amount := uint64(100000)
 	initiator, peer, _, _, channelId := getTestParams()
@@ -329,6 +405,43 @@ func TestMessageFromUnexpectedPeer(t *testing.T) {
 	}
 }
 
+func TestMessageAcks(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	// Only bob knows that alice supports acks.
+	bobSwapService.swapServices.SetMessageAckSupport(&ackSupportStub{peers: map[string]bool{initiator: true}})
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	aliceSwap, err := aliceSwapService.SwapOut(peer, "btc", channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+
+	// Alice receives the agreement and the ack of her request in any order.
+	received := map[messages.MessageType]bool{}
+	received[<-aliceMsgChan] = true
+	received[<-aliceMsgChan] = true
+	assert.True(t, received[messages.MESSAGETYPE_SWAPOUTAGREEMENT])
+	assert.True(t, received[messages.MESSAGETYPE_ACK])
+
+	aliceMmgr := aliceSwapService.swapServices.messengerManager.(*MessengerManagerStub)
+	aliceMmgr.Lock()
+	assert.Equal(t, []string{fmt.Sprintf("%s:%d", aliceSwap.SwapId.String(), messages.MESSAGETYPE_SWAPOUTREQUEST)}, aliceMmgr.acked)
+	aliceMmgr.Unlock()
+
+	// Bob does not get acks from alice as she does not know that bob
+	// supports them.
+	bobMmgr := bobSwapService.swapServices.messengerManager.(*MessengerManagerStub)
+	bobMmgr.Lock()
+	assert.Empty(t, bobMmgr.acked)
+	bobMmgr.Unlock()
+}
+
 func TestTimeout(t *testing.T) {
 	t.Parallel()
 	sws := getTestSetup(t, "alice")
@@ -397,6 +510,167 @@ func Test_SwapOut_PeerIsSuspicious(t *testing.T) {
 	assert.ErrorIs(t, err, PeerIsSuspiciousError(peer))
 }
 
+func Test_RetrySwap(t *testing.T) {
+	amount := uint64(100000)
+	initiator, peer, _, _, channelId := getTestParams()
+
+	aliceSwapService, bobSwapService, aliceMsgChan, bobMsgChan := connectedTestSetup(t, initiator, peer)
+
+	// set lightning to fail so that the swap gets canceled
+	aliceSwapService.swapServices.lightning.(*dummyLightningClient).failpayment = true
+
+	require.NoError(t, aliceSwapService.Start())
+	require.NoError(t, bobSwapService.Start())
+
+	aliceSwap, err := aliceSwapService.SwapOut(peer, btc_chain, channelId, initiator, amount, 100000)
+	require.NoError(t, err)
+
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTREQUEST, <-bobMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_SWAPOUTAGREEMENT, <-aliceMsgChan)
+	assert.Equal(t, messages.MESSAGETYPE_CANCELED, <-bobMsgChan)
+	assert.Equal(t, State_SwapCanceled, aliceSwap.Current)
+
+	// The receiver of a swap can not retry it.
+	_, err = bobSwapService.GetRetryParams(aliceSwap.SwapId.String(), 0, 0)
+	assert.Error(t, err)
+	_, err = aliceSwapService.GetRetryParams("unknown", 0, 0)
+	assert.Error(t, err)
+
+	params, err := aliceSwapService.GetRetryParams(aliceSwap.SwapId.String(), 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &RetryParams{
+		Type:                SWAPTYPE_OUT,
+		Chain:               btc_chain,
+		Scid:                channelId,
+		Amount:              amount,
+		PremiumLimitRatePpm: 100000,
+		RetryOf:             aliceSwap.SwapId.String(),
+	}, params)
+
+	// The amount and premium limit can be overridden.
+	params, err = aliceSwapService.GetRetryParams(aliceSwap.SwapId.String(), 2*amount, 50000)
+	require.NoError(t, err)
+	assert.Equal(t, 2*amount, params.Amount)
+	assert.Equal(t, int64(50000), params.PremiumLimitRatePpm)
+
+	aliceSwapService.swapServices.lightning.(*dummyLightningClient).failpayment = false
+	retry, err := aliceSwapService.SwapOut(peer, params.Chain, params.Scid, initiator,
+		params.Amount, params.PremiumLimitRatePpm, WithRetryOf(params.RetryOf))
+	require.NoError(t, err)
+	assert.Equal(t, aliceSwap.SwapId.String(), retry.Data.RetryOf)
+	assert.Equal(t, 2*amount, retry.Data.GetAmount())
+
+	// The swap is not finished yet and can not be retried.
+	_, err = aliceSwapService.GetRetryParams(retry.SwapId.String(), 0, 0)
+	assert.Error(t, err)
+
+	stored, err := aliceSwapService.GetSwap(retry.SwapId.String())
+	require.NoError(t, err)
+	assert.Equal(t, aliceSwap.SwapId.String(), stored.Data.RetryOf)
+}
+
+func Test_RetrySwapOptions(t *testing.T) {
+	initiator, peer, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	orig := newSwapInSenderFSM(swapService.swapServices, initiator, peer)
+	orig.Current = State_SwapCanceled
+	orig.Data.SwapInRequest = &SwapInRequestMessage{Network: "regtest", Scid: channelId, Amount: 100000}
+	orig.Data.ExternalFunding = &ExternalFunding{}
+	orig.Data.ClaimAddress = "claim-address"
+	orig.Data.FeeRate = &FeeRate{SatPerVByte: 12.5}
+	require.NoError(t, swapService.swapServices.swapStore.UpdateData(orig))
+
+	params, err := swapService.GetRetryParams(orig.SwapId.String(), 0, 0)
+	require.NoError(t, err)
+	data := &SwapData{}
+	for _, opt := range params.Options() {
+		opt(data)
+	}
+	assert.Equal(t, orig.SwapId.String(), data.RetryOf)
+	// An externally funded swap-in is not retried from the node wallet.
+	assert.NotNil(t, data.ExternalFunding)
+	assert.Equal(t, "claim-address", data.ClaimAddress)
+	assert.Equal(t, &FeeRate{SatPerVByte: 12.5}, data.FeeRate)
+
+	// The coin selection of a swap-in is validated again.
+	orig.Data.ExternalFunding = nil
+	orig.Data.CoinSelection = &CoinSelection{Utxos: []string{dummyUtxo}}
+	require.NoError(t, swapService.swapServices.swapStore.UpdateData(orig))
+	params, err = swapService.GetRetryParams(orig.SwapId.String(), 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{dummyUtxo}, params.CoinSelection.Utxos)
+	orig.Data.CoinSelection = &CoinSelection{Utxos: []string{"spent:0"}}
+	require.NoError(t, swapService.swapServices.swapStore.UpdateData(orig))
+	_, err = swapService.GetRetryParams(orig.SwapId.String(), 0, 0)
+	assert.ErrorContains(t, err, "no longer valid")
+
+	// A derived claim address is not reused.
+	orig.Data.CoinSelection = nil
+	orig.Data.ClaimAddressDerived = true
+	require.NoError(t, swapService.swapServices.swapStore.UpdateData(orig))
+	params, err = swapService.GetRetryParams(orig.SwapId.String(), 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, params.ClaimAddress)
+
+	// A swap-out that spans several channels is retried over all of them.
+	multi := newSwapOutSenderFSM(swapService.swapServices, initiator, peer)
+	multi.Current = State_SwapCanceled
+	multi.Data.SwapOutRequest = &SwapOutRequestMessage{
+		Network: "regtest", Scid: channelId, ExtraScids: []string{"1:2:3"}, Amount: 100000,
+	}
+	require.NoError(t, swapService.swapServices.swapStore.UpdateData(multi))
+	params, err = swapService.GetRetryParams(multi.SwapId.String(), 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1:2:3"}, params.ExtraScids)
+}
+
+func Test_MaxSwapOutAmountSat(t *testing.T) {
+	initiator, _, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+	swapService.swapServices.lightning.(*dummyLightningClient).spendableMsat = 1000000 * 1000
+
+	// Opening fee of the dummy wallet is 100 sat.
+	amount, err := swapService.MaxSwapOutAmountSat(btc_chain, channelId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000000-100-swapOutRoutingHeadroomSat), amount)
+
+	amount, err = swapService.MaxSwapOutAmountSat(btc_chain, channelId, 500000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(500000-100-swapOutRoutingHeadroomSat), amount)
+
+	_, err = swapService.MaxSwapOutAmountSat(btc_chain, channelId, 1000000)
+	assert.Error(t, err)
+
+	swapService.BitcoinEnabled = false
+	_, err = swapService.MaxSwapOutAmountSat(btc_chain, channelId, 0)
+	assert.Error(t, err)
+}
+
+func Test_MaxSwapInAmountSat(t *testing.T) {
+	initiator, _, _, _, channelId := getTestParams()
+	swapService := getTestSetup(t, initiator)
+
+	// Wallet balance of the dummy wallet is 10000000 sat, the opening fee is
+	// 100 sat.
+	amount, err := swapService.MaxSwapInAmountSat(l_btc_chain, channelId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10000000-100), amount)
+
+	amount, err = swapService.MaxSwapInAmountSat(l_btc_chain, channelId, 1000000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9000000-100), amount)
+
+	// The amount is capped by the receivable amount of the channel.
+	swapService.swapServices.lightning.(*dummyLightningClient).receivableMsat = 200000 * 1000
+	amount, err = swapService.MaxSwapInAmountSat(l_btc_chain, channelId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200000), amount)
+
+	_, err = swapService.MaxSwapInAmountSat(l_btc_chain, channelId, 10000000)
+	assert.Error(t, err)
+}
+
 func getTestSetup(t *testing.T, name string) *SwapService {
 	store := &dummyStore{dataMap: map[string]*SwapStateMachine{}}
 	reqSwapsStore := &requestedSwapsStoreMock{data: map[string][]RequestedSwap{}}
@@ -430,33 +704,58 @@ func getTestSetup(t *testing.T, name string) *SwapService {
 		premiumSetting.SetDefaultRate(ctx,
 			lo.Must(premium.NewPremiumRate(premium.LBTC, premium.SwapOut, premium.NewPPM(10000)))))
 
-	swapServices := NewSwapServices(store, reqSwapsStore, lc, messenger, mmgr, policy, true, chain, chain, chain, true, chain, chain, chain, premiumSetting)
+	queuedSwapsStore, err := NewQueuedSwapsStore(db)
+	require.NoError(t, err)
+
+	swapServices := NewSwapServices(store, reqSwapsStore, queuedSwapsStore, lc, messenger, mmgr, policy, true, chain, chain, chain, true, chain, chain, chain, premiumSetting)
 	swapService := NewSwapService(swapServices)
 	return swapService
 }
 
+// connectedTestSetup returns the swap services of the initiator and the peer
+// with connected messengers, and the channels that report the messages each
+// of them received.
+func connectedTestSetup(t *testing.T, initiator, peer string) (alice, bob *SwapService, aliceMsgChan, bobMsgChan chan messages.MessageType) {
+	alice = getTestSetup(t, initiator)
+	bob = getTestSetup(t, peer)
+
+	aliceMessenger := alice.swapServices.messenger.(*ConnectedMessenger)
+	bobMessenger := bob.swapServices.messenger.(*ConnectedMessenger)
+	aliceMessenger.other = bobMessenger
+	bobMessenger.other = aliceMessenger
+	aliceMessenger.msgReceivedChan = make(chan messages.MessageType)
+	bobMessenger.msgReceivedChan = make(chan messages.MessageType)
+	return alice, bob, aliceMessenger.msgReceivedChan, bobMessenger.msgReceivedChan
+}
+
 type ConnectedMessenger struct {
 	sync.Mutex
-	thisPeerId      string
-	OnMessage       func(peerId string, msgType string, msgBytes []byte) error
-	other           *ConnectedMessenger
+	thisPeerId string
+	OnMessage  func(peerId string, msgType string, msgBytes []byte) error
+	other      *ConnectedMessenger
+	// peers routes messages by peer id instead of sending them to other.
+	peers           map[string]*ConnectedMessenger
 	msgReceivedChan chan messages.MessageType
 	lastErr         error
 }
 
 func (c *ConnectedMessenger) SendMessage(peerId string, msg []byte, msgType int) error {
+	other := c.other
+	if c.peers != nil {
+		other = c.peers[peerId]
+	}
 	go func() {
 		time.Sleep(time.Millisecond * 10)
 		msgString := messages.MessageTypeToHexString(messages.MessageType(msgType))
-		err := c.other.OnMessage(c.thisPeerId, msgString, msg)
+		err := other.OnMessage(c.thisPeerId, msgString, msg)
 		if err != nil {
 			log.Printf("error on message send %v", err)
-			c.other.Lock()
-			c.other.lastErr = err
-			c.other.Unlock()
+			other.Lock()
+			other.lastErr = err
+			other.Unlock()
 		}
-		if c.other.msgReceivedChan != nil {
-			c.other.msgReceivedChan <- messages.MessageType(msgType)
+		if other.msgReceivedChan != nil {
+			other.msgReceivedChan <- messages.MessageType(msgType)
 		}
 	}()
 
@@ -472,6 +771,7 @@ type MessengerManagerStub struct {
 	called  int
 	added   int
 	removed int
+	acked   []string
 }
 
 func (s *MessengerManagerStub) AddSender(id string, messenger messages.StoppableMessenger) error {
@@ -482,6 +782,13 @@ func (s *MessengerManagerStub) AddSender(id string, messenger messages.Stoppable
 	return nil
 }
 
+func (s *MessengerManagerStub) Ack(id string, messageType int) bool {
+	s.Lock()
+	defer s.Unlock()
+	s.acked = append(s.acked, fmt.Sprintf("%s:%d", id, messageType))
+	return true
+}
+
 func (s *MessengerManagerStub) RemoveSender(id string) {
 	s.Lock()
 	defer s.Unlock()
@@ -489,6 +796,14 @@ func (s *MessengerManagerStub) RemoveSender(id string) {
 	s.removed++
 }
 
+type ackSupportStub struct {
+	peers map[string]bool
+}
+
+func (s *ackSupportStub) SupportsMessageAck(peerId string) bool {
+	return s.peers[peerId]
+}
+
 type noopMessenger struct {
 }
 
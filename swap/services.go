@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elementsproject/peerswap/messages"
@@ -26,7 +28,20 @@ type Messenger interface {
 type MessengerManager interface {
 	AddSender(id string, messenger messages.StoppableMessenger) error
 	RemoveSender(id string)
+	Ack(id string, messageType int) bool
 }
+
+// MessageAckSupport tells whether a peer acknowledges processed swap messages.
+type MessageAckSupport interface {
+	SupportsMessageAck(peerId string) bool
+}
+
+// MultiChannelSwapOutSupport tells whether a peer accepts swap-outs that span
+// several channels.
+type MultiChannelSwapOutSupport interface {
+	SupportsMultiChannelSwapOut(peerId string) bool
+}
+
 type PeerMessage interface {
 	MessageType() messages.MessageType
 }
@@ -38,6 +53,9 @@ type Policy interface {
 	GetReserveOnchainMsat() uint64
 	GetMinSwapAmountMsat() uint64
 	NewSwapsAllowed() bool
+	// GetConfirmations returns the confirmations that an opening tx of the
+	// asset needs for a swap of the amount, 0 for the default of the chain.
+	GetConfirmations(asset string, amountSat uint64) uint32
 }
 
 type LightningClient interface {
@@ -47,20 +65,27 @@ type LightningClient interface {
 	PayInvoiceViaChannel(payreq string, channel string) (preimage string, err error)
 	AddPaymentCallback(f func(swapId string, invoiceType InvoiceType))
 	AddPaymentNotifier(swapId string, payreq string, invoiceType InvoiceType)
-	RebalancePayment(payreq string, channel string, maxTotalCLTVDelta uint32) (preimage string, err error)
+	RebalancePayment(payreq string, channels []string, maxTotalCLTVDelta uint32) (preimage string, err error)
 	RecoverClaimPayment(payreq string) (preimage string, err error)
 	CanSpend(amountMsat uint64) error
 	Implementation() string
 	SpendableMsat(scid string) (uint64, error)
 	ReceivableMsat(scid string) (uint64, error)
+	// ChannelPeer returns the node id of the remote peer of the channel.
+	ChannelPeer(scid string) (string, error)
 	ProbePayment(scid string, amountMsat uint64) (bool, string, error)
 }
 
 type TxWatcher interface {
-	AddWaitForConfirmationTx(swapID, txID string, vout, startingHeight, paymentWindow uint32, scriptpubkey []byte)
+	// AddWaitForConfirmationTx calls the confirmation callback once the tx
+	// has the given number of confirmations.
+	AddWaitForConfirmationTx(swapID, txID string, vout, startingHeight, paymentWindow, confirmations uint32, scriptpubkey []byte)
 	AddWaitForCsvTx(swapID, txID string, vout, startingHeight, csv uint32, scriptpubkey []byte)
 	AddConfirmationCallback(func(swapId string, txHex string, err error) error)
 	AddCsvCallback(func(swapId string) error)
+	// AddUnconfirmedCallback sets the callback that is called if a reorg
+	// unconfirms a tx that was reported as confirmed before.
+	AddUnconfirmedCallback(func(swapId string) error)
 	GetBlockHeight() (uint32, error)
 	StartWatchingTxs() error
 }
@@ -69,6 +94,12 @@ type Validator interface {
 	TxIdFromHex(txHex string) (string, error)
 	ValidateTx(swapParams *OpeningParams, txHex string) (bool, error)
 	GetCSVHeight() uint32
+	// ValidateAddress returns an error if the address can not be paid to on
+	// the network of the wallet.
+	ValidateAddress(address string) error
+	// ValidateFeeRate returns an error if the fee rate is below the fee
+	// floor of the chain.
+	ValidateFeeRate(feeRate *FeeRate) error
 }
 
 type Wallet interface {
@@ -94,6 +125,12 @@ type OpeningParams struct {
 	CSV              uint32
 	BlindingKey      *btcec.PrivateKey
 	OpeningAddress   string
+	// FeeRate is the fee rate of the opening transaction. The wallet
+	// estimate is used if it is nil.
+	FeeRate *FeeRate
+	// CoinSelection restricts the utxos that fund the opening transaction.
+	// The wallet chooses them freely if it is nil.
+	CoinSelection *CoinSelection
 }
 
 func (o *OpeningParams) String() string {
@@ -108,6 +145,12 @@ type ClaimParams struct {
 	Preimage     string
 	Signer       Signer
 	OpeningTxHex string
+	// ClaimAddress is the address to claim the output to. A new wallet
+	// address is used if it is empty.
+	ClaimAddress string
+	// FeeRate is the fee rate of the claim transaction. The wallet estimate
+	// is used if it is nil.
+	FeeRate *FeeRate
 
 	// blinded tx stuff
 	BlindingSeed              []byte
@@ -130,6 +173,7 @@ type TimeOutService interface {
 type SwapServices struct {
 	swapStore           Store
 	requestedSwapsStore RequestedSwapsStore
+	queuedSwapsStore    QueuedSwapsStore
 	lightning           LightningClient
 	messenger           Messenger
 	messengerManager    MessengerManager
@@ -144,11 +188,38 @@ type SwapServices struct {
 	liquidEnabled       bool
 	toService           TimeOutService
 	ps                  *premium.Setting
+
+	peerFeaturesLock sync.RWMutex
+	messageAcks      MessageAckSupport
+	multiChannel     MultiChannelSwapOutSupport
+
+	// draining is set while the node drains its active swaps, see
+	// SwapService.StartDrain.
+	draining atomic.Bool
+
+	// claimBatcher is set if claims are batched, see
+	// SwapService.EnableClaimBatching.
+	claimBatcher *claimBatcher
+
+	// claimAddressDerivers derive the claim addresses of a chain, see
+	// SetClaimAddressDeriver.
+	claimAddressDerivers   map[string]ClaimAddressDeriver
+	claimAddressIndexStore ClaimAddressIndexStore
+
+	// defaultFeeRates are the fee rates of swaps without a fee rate of
+	// their own, see SetDefaultFeeRate.
+	defaultFeeRates map[string]*FeeRate
+
+	// unconfirmedSwaps holds the swaps whose opening tx was unconfirmed by
+	// a reorg after it was reported as confirmed.
+	unconfirmedSwapsLock sync.Mutex
+	unconfirmedSwaps     map[string]bool
 }
 
 func NewSwapServices(
 	swapStore Store,
 	requestedSwapsStore RequestedSwapsStore,
+	queuedSwapsStore QueuedSwapsStore,
 	lightning LightningClient,
 	messenger Messenger,
 	messengerManager MessengerManager,
@@ -166,6 +237,7 @@ func NewSwapServices(
 	return &SwapServices{
 		swapStore:           swapStore,
 		requestedSwapsStore: requestedSwapsStore,
+		queuedSwapsStore:    queuedSwapsStore,
 		lightning:           lightning,
 		messenger:           messenger,
 		messengerManager:    messengerManager,
@@ -182,6 +254,34 @@ func NewSwapServices(
 	}
 }
 
+// SetMessageAckSupport enables acks with the peers that support them. Without
+// it no acks are sent and retried messages are resent until the swap ends.
+func (s *SwapServices) SetMessageAckSupport(acks MessageAckSupport) {
+	s.peerFeaturesLock.Lock()
+	defer s.peerFeaturesLock.Unlock()
+	s.messageAcks = acks
+}
+
+func (s *SwapServices) peerSupportsMessageAck(peerId string) bool {
+	s.peerFeaturesLock.RLock()
+	defer s.peerFeaturesLock.RUnlock()
+	return s.messageAcks != nil && s.messageAcks.SupportsMessageAck(peerId)
+}
+
+// SetMultiChannelSwapOutSupport allows swap-outs across several channels with
+// the peers that support them. Without it such swap-outs are rejected.
+func (s *SwapServices) SetMultiChannelSwapOutSupport(multiChannel MultiChannelSwapOutSupport) {
+	s.peerFeaturesLock.Lock()
+	defer s.peerFeaturesLock.Unlock()
+	s.multiChannel = multiChannel
+}
+
+func (s *SwapServices) peerSupportsMultiChannelSwapOut(peerId string) bool {
+	s.peerFeaturesLock.RLock()
+	defer s.peerFeaturesLock.RUnlock()
+	return s.multiChannel != nil && s.multiChannel.SupportsMultiChannelSwapOut(peerId)
+}
+
 func (s *SwapServices) getOnChainServices(asset string) (TxWatcher, Wallet, Validator, error) {
 	if asset == "" {
 		return nil, nil, nil, fmt.Errorf("missing asset")
@@ -194,3 +294,42 @@ func (s *SwapServices) getOnChainServices(asset string) (TxWatcher, Wallet, Vali
 	}
 	return nil, nil, nil, WrongAssetError(asset)
 }
+
+// setTxUnconfirmed marks the opening tx of the swap as unconfirmed by a
+// reorg, or clears the mark.
+func (s *SwapServices) setTxUnconfirmed(swapId string, unconfirmed bool) {
+	s.unconfirmedSwapsLock.Lock()
+	defer s.unconfirmedSwapsLock.Unlock()
+	if !unconfirmed {
+		delete(s.unconfirmedSwaps, swapId)
+		return
+	}
+	if s.unconfirmedSwaps == nil {
+		s.unconfirmedSwaps = map[string]bool{}
+	}
+	s.unconfirmedSwaps[swapId] = true
+}
+
+// isTxUnconfirmed returns true if the opening tx of the swap was unconfirmed
+// by a reorg and did not confirm again yet.
+func (s *SwapServices) isTxUnconfirmed(swapId string) bool {
+	s.unconfirmedSwapsLock.Lock()
+	defer s.unconfirmedSwapsLock.Unlock()
+	return s.unconfirmedSwaps[swapId]
+}
+
+// requiredConfirmations returns the confirmations that the opening tx of a
+// swap of the amount on the chain needs before the claim invoice is paid.
+func (s *SwapServices) requiredConfirmations(chain string, amountSat uint64) uint32 {
+	if confs := s.policy.GetConfirmations(chain, amountSat); confs > 0 {
+		return confs
+	}
+	return defaultConfirmations(chain)
+}
+
+// agreedConfirmations returns the confirmations that the opening tx of a
+// requested swap needs, the higher one of the request and the policy. Both
+// peers use them once they are sent with the agreement.
+func (s *SwapServices) agreedConfirmations(chain string, amountSat uint64, requested uint32) uint32 {
+	return max(requested, s.requiredConfirmations(chain, amountSat))
+}
@@ -42,6 +42,9 @@ const (
 	State_SwapInSender_SendRequest              StateType = "State_SwapInSender_SendRequest"
 	State_SwapInSender_AwaitAgreement           StateType = "State_SwapInSender_AwaitAgreement"
 	State_SwapInSender_BroadcastOpeningTx       StateType = "State_SwapInSender_BroadcastOpeningTx"
+	State_SwapInSender_AwaitBatch               StateType = "State_SwapInSender_AwaitBatch"
+	State_SwapInSender_AwaitBatchBroadcast      StateType = "State_SwapInSender_AwaitBatchBroadcast"
+	State_SwapInSender_AwaitExternalFunding     StateType = "State_SwapInSender_AwaitExternalFunding"
 	State_SwapInSender_SendTxBroadcastedMessage StateType = "State_SwapInSender_SendTxBroadcastedMessage"
 	State_SwapInSender_AwaitClaimPayment        StateType = "State_SwapInSender_AwaitClaimPayment"
 	State_SwapInSender_ClaimSwapCsv             StateType = "State_SwapInSender_ClaimSwapCsv"
@@ -67,6 +70,7 @@ const (
 
 	Event_OnTxOpenedMessage EventType = "Event_OnTxOpenedMessage"
 	Event_OnTxConfirmed     EventType = "Event_OnTxConfirmed"
+	Event_OnTxUnconfirmed   EventType = "Event_OnTxUnconfirmed"
 
 	// todo retrystate? failstate? refundstate?
 	Event_OnRetry      EventType = "Event_OnRetry"
@@ -82,12 +86,19 @@ const (
 
 	Event_OnTimeout = "Event_OnTimeout"
 
-	Event_ActionSucceeded                  EventType = "Event_ActionSucceeded"
-	Event_SwapInSender_OnSwapInRequested   EventType = "Event_SwapInSender_OnSwapInRequested"
-	Event_SwapInSender_OnAgreementReceived EventType = "Event_SwapInSender_OnAgreementReceived"
-	Event_ActionFailed                     EventType = "Event_ActionFailed"
-	Event_SwapInReceiver_OnRequestReceived EventType = "Event_SwapInReceiver_OnRequestReceived"
-	Event_Done                             EventType = "Event_Done"
+	Event_ActionSucceeded                         EventType = "Event_ActionSucceeded"
+	Event_SwapInSender_OnSwapInRequested          EventType = "Event_SwapInSender_OnSwapInRequested"
+	Event_SwapInSender_OnAgreementReceived        EventType = "Event_SwapInSender_OnAgreementReceived"
+	Event_SwapInSender_OnBatchJoined              EventType = "Event_SwapInSender_OnBatchJoined"
+	Event_SwapInSender_OnBatchFundingCreated      EventType = "Event_SwapInSender_OnBatchFundingCreated"
+	Event_SwapInSender_OnBatchFunded              EventType = "Event_SwapInSender_OnBatchFunded"
+	Event_SwapInSender_OnFundedSwapFailed         EventType = "Event_SwapInSender_OnFundedSwapFailed"
+	Event_SwapInSender_OnExternalFundingRequested EventType = "Event_SwapInSender_OnExternalFundingRequested"
+	Event_SwapInSender_OnExternalFundingReceived  EventType = "Event_SwapInSender_OnExternalFundingReceived"
+	Event_OnClaimBatched                          EventType = "Event_OnClaimBatched"
+	Event_ActionFailed                            EventType = "Event_ActionFailed"
+	Event_SwapInReceiver_OnRequestReceived        EventType = "Event_SwapInReceiver_OnRequestReceived"
+	Event_Done                                    EventType = "Event_Done"
 
 	Event_OnInvalid_Message EventType = "Event_Invalid_Message"
 )
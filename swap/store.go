@@ -1,9 +1,11 @@
 package swap
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"go.etcd.io/bbolt"
 )
@@ -11,6 +13,9 @@ import (
 var (
 	swapBuckets          = []byte("swaps")
 	requestedSwapsBucket = []byte("requested-swaps")
+	queuedSwapsBucket    = []byte("queued-swaps")
+	claimAddressBucket   = []byte("claim-address-index")
+	idempotencyKeyBucket = []byte("idempotency-key-index")
 
 	ErrDoesNotExist  = fmt.Errorf("does not exist")
 	ErrAlreadyExists = fmt.Errorf("swap already exist")
@@ -26,10 +31,38 @@ func NewBboltStore(db *bbolt.DB) (*bboltStore, error) {
 		return nil, err
 	}
 	defer tx.Rollback()
-	_, err = tx.CreateBucketIfNotExists(swapBuckets)
+	swaps, err := tx.CreateBucketIfNotExists(swapBuckets)
 	if err != nil {
 		return nil, err
 	}
+	if tx.Bucket(idempotencyKeyBucket) == nil {
+		index, err := tx.CreateBucket(idempotencyKeyBucket)
+		if err != nil {
+			return nil, err
+		}
+		// Index the swaps that were created before the index existed,
+		// oldest first.
+		var existing []*SwapStateMachine
+		err = swaps.ForEach(func(k, v []byte) error {
+			swap := &SwapStateMachine{}
+			if err := json.Unmarshal(v, swap); err != nil {
+				return err
+			}
+			existing = append(existing, swap)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(existing, func(i, j int) bool {
+			return existing[i].Data.CreatedAt < existing[j].Data.CreatedAt
+		})
+		for _, swap := range existing {
+			if err := indexIdempotencyKey(index, swap); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -89,6 +122,9 @@ func (p *bboltStore) Create(swap *SwapStateMachine) error {
 	if err := b.Put(h2b(swap.SwapId.String()), jData); err != nil {
 		return err
 	}
+	if err := indexIdempotencyKey(tx.Bucket(idempotencyKeyBucket), swap); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -119,6 +155,9 @@ func (p *bboltStore) Update(swap *SwapStateMachine) error {
 	if err := b.Put(h2b(swap.SwapId.String()), jData); err != nil {
 		return err
 	}
+	if err := indexIdempotencyKey(tx.Bucket(idempotencyKeyBucket), swap); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -134,6 +173,20 @@ func (p *bboltStore) DeleteById(s string) error {
 		return fmt.Errorf("bucket nil")
 	}
 
+	if jData := b.Get(h2b(s)); jData != nil {
+		swap := &SwapStateMachine{}
+		if err := json.Unmarshal(jData, swap); err != nil {
+			return err
+		}
+		index := tx.Bucket(idempotencyKeyBucket)
+		key := []byte(swap.Data.IdempotencyKey)
+		if swap.Data.IdempotencyKey != "" && hex.EncodeToString(index.Get(key)) == s {
+			if err := index.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := b.Delete(h2b(s)); err != nil {
 		return err
 	}
@@ -141,6 +194,36 @@ func (p *bboltStore) DeleteById(s string) error {
 	return tx.Commit()
 }
 
+// GetByIdempotencyKey returns the swap that we initiated with the
+// idempotency key, or ErrDataNotAvailable if there is none.
+func (p *bboltStore) GetByIdempotencyKey(key string) (*SwapStateMachine, error) {
+	var id string
+	err := p.db.View(func(tx *bbolt.Tx) error {
+		id = hex.EncodeToString(tx.Bucket(idempotencyKeyBucket).Get([]byte(key)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, ErrDataNotAvailable
+	}
+	return p.GetData(id)
+}
+
+// indexIdempotencyKey maps the idempotency key of a swap that we initiated
+// to the swap id. The key keeps pointing to the first swap that used it.
+func indexIdempotencyKey(index *bbolt.Bucket, swap *SwapStateMachine) error {
+	if swap.Role != SWAPROLE_SENDER || swap.Data == nil || swap.Data.IdempotencyKey == "" {
+		return nil
+	}
+	key := []byte(swap.Data.IdempotencyKey)
+	if index.Get(key) != nil {
+		return nil
+	}
+	return index.Put(key, h2b(swap.SwapId.String()))
+}
+
 func (p *bboltStore) GetById(s string) (*SwapStateMachine, error) {
 	tx, err := p.db.Begin(false)
 	if err != nil {
@@ -330,3 +413,117 @@ func (s *requestedSwapsStore) Get(id string) ([]RequestedSwap, error) {
 
 	return reqswaps, nil
 }
+
+type QueuedSwapsStore interface {
+	Add(queued *QueuedSwap) error
+	Delete(id string) error
+	ListAll() ([]*QueuedSwap, error)
+}
+
+type queuedSwapsStore struct {
+	db *bbolt.DB
+}
+
+func NewQueuedSwapsStore(db *bbolt.DB) (*queuedSwapsStore, error) {
+	tx, err := db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	_, err = tx.CreateBucketIfNotExists(queuedSwapsBucket)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &queuedSwapsStore{db: db}, nil
+}
+
+// Add persists the queued swap and assigns its position in the queue.
+func (s *queuedSwapsStore) Add(queued *QueuedSwap) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(queuedSwapsBucket)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		queued.Seq = seq
+
+		buf, err := json.Marshal(queued)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(queued.Id), buf)
+	})
+}
+
+func (s *queuedSwapsStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(queuedSwapsBucket)
+		if b.Get([]byte(id)) == nil {
+			return ErrDoesNotExist
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+func (s *queuedSwapsStore) ListAll() ([]*QueuedSwap, error) {
+	var queue []*QueuedSwap
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(queuedSwapsBucket)
+		return b.ForEach(func(k, v []byte) error {
+			queued := &QueuedSwap{}
+			if err := json.Unmarshal(v, queued); err != nil {
+				return err
+			}
+			queue = append(queue, queued)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+type claimAddressIndexStore struct {
+	db *bbolt.DB
+}
+
+func NewClaimAddressIndexStore(db *bbolt.DB) (*claimAddressIndexStore, error) {
+	tx, err := db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	_, err = tx.CreateBucketIfNotExists(claimAddressBucket)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &claimAddressIndexStore{db: db}, nil
+}
+
+// NextIndex returns the next unused derivation index of the chain and marks
+// it as used.
+func (s *claimAddressIndexStore) NextIndex(chain string) (uint32, error) {
+	var index uint32
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(claimAddressBucket)
+		if v := b.Get([]byte(chain)); v != nil {
+			index = binary.BigEndian.Uint32(v)
+		}
+		next := make([]byte, 4)
+		binary.BigEndian.PutUint32(next, index+1)
+		return b.Put([]byte(chain), next)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return index, nil
+}
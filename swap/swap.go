@@ -120,6 +120,60 @@ type SwapData struct {
 
 	StartingBlockHeightSet bool `json:"opening_block_height_set,omitempty"`
 
+	// PremiumLimitRatePpm is the premium limit rate the initiator started the
+	// swap with.
+	PremiumLimitRatePpm int64 `json:"premium_limit_rate_ppm,omitempty"`
+
+	// RetryOf is the id of the swap that this swap is a retry of.
+	RetryOf string `json:"retry_of,omitempty"`
+
+	// IdempotencyKey is the key the client started the swap with. Requests
+	// with the same key return this swap instead of starting a new one.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	// ClaimAddress is the address that the swap output is claimed to. The
+	// output is claimed to the wallet if it is empty.
+	ClaimAddress string `json:"claim_address,omitempty"`
+	// ClaimAddressDerived is set if the claim address was derived by the
+	// node instead of being given with the swap.
+	ClaimAddressDerived bool `json:"claim_address_derived,omitempty"`
+
+	// RequiredConfirmations is the number of confirmations that the opening
+	// tx needs before the claim invoice is paid. It is resolved from the
+	// policy when the swap is created, 0 means the default of the chain.
+	RequiredConfirmations uint32 `json:"required_confirmations,omitempty"`
+
+	// FeeRate is the fee rate of the opening and claim transactions that
+	// the swap creates. The wallet estimate is used if it is nil.
+	FeeRate *FeeRate `json:"fee_rate,omitempty"`
+
+	// CoinSelection restricts the utxos that fund the opening tx of a
+	// swap-in, see WithCoinSelection.
+	CoinSelection *CoinSelection `json:"coin_selection,omitempty"`
+
+	// extraScids are the extra channels of a swap-out until they are sent
+	// with the swap-out request, see WithExtraChannels.
+	extraScids []string
+
+	// ExternalFunding is the opening transaction of a swap-in that is funded
+	// outside of the node, see WithExternalFunding.
+	ExternalFunding *ExternalFunding `json:"external_funding,omitempty"`
+
+	// BatchId is the id of the opening batch of a batched swap-in.
+	BatchId string `json:"batch_id,omitempty"`
+	// BatchFunding is the output of the swap in the opening transaction of
+	// its batch once the batch is funded.
+	BatchFunding *BatchFunding `json:"batch_funding,omitempty"`
+	// batch is the opening batch that a batched swap-in joins once the peer
+	// agreed.
+	batch *openingBatch
+	// claimBatchFailed is set if the batched claim of the swap failed, the
+	// swap is then claimed on its own.
+	claimBatchFailed bool
+	// claimQueued is set while the claim by preimage of the swap is held
+	// back to be batched.
+	claimQueued bool
+
 	BlindingKeyHex string `json:"blinding_key"`
 
 	LastMessage EventContext `json:"last_message"`
@@ -207,6 +261,26 @@ func (s *SwapData) GetAmount() uint64 {
 	return 0
 }
 
+// GetPremiumLimitRatePpm returns the premium limit rate of the swap. Swaps
+// that were created before the rate was stored only know the absolute premium
+// limit, so the rate is derived from it.
+func (s *SwapData) GetPremiumLimitRatePpm() int64 {
+	if s.PremiumLimitRatePpm != 0 {
+		return s.PremiumLimitRatePpm
+	}
+	var premiumLimit int64
+	if s.SwapInRequest != nil {
+		premiumLimit = s.SwapInRequest.PremiumLimit
+	} else if s.SwapOutRequest != nil {
+		premiumLimit = s.SwapOutRequest.PremiumLimit
+	}
+	amount := s.GetAmount()
+	if amount == 0 {
+		return 0
+	}
+	return premiumLimit * 1e6 / int64(amount)
+}
+
 func (s *SwapData) GetClaimAmount() uint64 {
 	if s.SwapInRequest != nil {
 		return s.SwapInRequest.Amount
@@ -256,7 +330,9 @@ func (s *SwapData) GetInvoiceExpiry() uint64 {
 	default:
 		expiry = 0
 	}
-	return expiry
+	// The invoice has to outlast the wait for confirmations beyond the
+	// default.
+	return expiry + uint64(s.getExtraConfirmations())*blockIntervalSeconds(s.GetChain())
 }
 
 func (s *SwapData) GetInvoiceCltv() uint64 {
@@ -278,14 +354,18 @@ func (s *SwapData) GetNetwork() string {
 }
 
 func (s *SwapData) GetChain() string {
-	if s.GetAsset() != "" && s.GetNetwork() == "" {
+	return getChain(s.GetAsset(), s.GetNetwork())
+}
+
+// getChain returns the chain of a swap request with the asset and network.
+func getChain(asset, network string) string {
+	if asset != "" && network == "" {
 		return l_btc_chain
-	} else if s.GetAsset() == "" && s.GetNetwork() != "" {
+	} else if asset == "" && network != "" {
 		return btc_chain
 	} else {
 		return ""
 	}
-
 }
 
 func (s *SwapData) GetMakerPubkey() string {
@@ -359,6 +439,8 @@ func (s *SwapData) GetOpeningParams() *OpeningParams {
 		Amount:           s.GetOpeningTXAmount(),
 		CSV:              policy.CSV,
 		BlindingKey:      blindingKey,
+		FeeRate:          s.FeeRate,
+		CoinSelection:    s.CoinSelection,
 	}
 }
 
@@ -369,6 +451,8 @@ func (s *SwapData) GetClaimParams() *ClaimParams {
 		Preimage:     s.ClaimPreimage,
 		Signer:       &Secp256k1Signer{key},
 		OpeningTxHex: s.OpeningTxHex,
+		ClaimAddress: s.ClaimAddress,
+		FeeRate:      s.FeeRate,
 	}
 
 	return claimParams
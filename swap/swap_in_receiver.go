@@ -72,6 +72,7 @@ func getSwapInReceiverStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_SwapInReceiver_ClaimSwap,
 				Event_ActionFailed:    State_SwapInReceiver_SendPrivkey,
+				Event_OnTxUnconfirmed: State_SwapInReceiver_AwaitTxConfirmation,
 			},
 		},
 		State_SwapInReceiver_SendPrivkey: {
@@ -93,6 +94,7 @@ func getSwapInReceiverStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedPreimage,
 				Event_OnRetry:         State_SwapInReceiver_ClaimSwap,
+				Event_OnClaimBatched:  State_SwapInReceiver_ClaimSwap,
 			},
 		},
 		State_ClaimedPreimage: {
@@ -57,10 +57,46 @@ func getSwapInSenderStates() States {
 			},
 		},
 		State_SwapInSender_BroadcastOpeningTx: {
-			Action: &CheckPremiumAmount{next: &CreateAndBroadcastOpeningTransaction{}},
+			Action: &RefundBatchFundingOnFailureWrapper{next: &CheckPremiumAmount{next: &CreateAndBroadcastOpeningTransaction{}}},
 			Events: Events{
-				Event_ActionSucceeded: State_SwapInSender_SendTxBroadcastedMessage,
-				Event_ActionFailed:    State_SendCancel,
+				Event_ActionSucceeded:                         State_SwapInSender_SendTxBroadcastedMessage,
+				Event_ActionFailed:                            State_SendCancel,
+				Event_SwapInSender_OnFundedSwapFailed:         State_WaitCsv,
+				Event_SwapInSender_OnBatchJoined:              State_SwapInSender_AwaitBatch,
+				Event_SwapInSender_OnExternalFundingRequested: State_SwapInSender_AwaitExternalFunding,
+			},
+		},
+		State_SwapInSender_AwaitBatch: {
+			Action: &NoOpAction{},
+			Events: Events{
+				Event_SwapInSender_OnBatchFundingCreated: State_SwapInSender_AwaitBatchBroadcast,
+				Event_ActionFailed:                       State_SendCancel,
+				Event_OnCancelReceived:                   State_SwapCanceled,
+				Event_OnTimeout:                          State_SendCancel,
+				Event_OnInvalid_Message:                  State_SendCancel,
+			},
+			// The batch is gone after a restart. Nothing is funded yet, the
+			// transaction is only broadcast once every swap of the batch
+			// moved on.
+			FailOnrecover: true,
+		},
+		State_SwapInSender_AwaitBatchBroadcast: {
+			// The output of the swap may be funded, so the swap can not be
+			// canceled anymore. It waits for the csv once the transaction
+			// is broadcast if the peer is gone.
+			Action: &AwaitBatchBroadcastAction{},
+			Events: Events{
+				Event_SwapInSender_OnBatchFunded: State_SwapInSender_BroadcastOpeningTx,
+				Event_ActionFailed:               State_SendCancel,
+			},
+		},
+		State_SwapInSender_AwaitExternalFunding: {
+			Action: &AwaitExternalFundingAction{},
+			Events: Events{
+				Event_SwapInSender_OnExternalFundingReceived: State_SwapInSender_BroadcastOpeningTx,
+				Event_OnCancelReceived:                       State_SwapCanceled,
+				Event_OnTimeout:                              State_SendCancel,
+				Event_OnInvalid_Message:                      State_SendCancel,
 			},
 		},
 		State_SwapInSender_SendTxBroadcastedMessage: {
@@ -85,6 +121,7 @@ func getSwapInSenderStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedCsv,
 				Event_OnRetry:         State_SwapInSender_ClaimSwapCsv,
+				Event_OnClaimBatched:  State_SwapInSender_ClaimSwapCsv,
 			},
 		},
 		State_SwapInSender_ClaimSwapCoop: {
@@ -92,6 +129,7 @@ func getSwapInSenderStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedCoop,
 				Event_ActionFailed:    State_WaitCsv,
+				Event_OnClaimBatched:  State_SwapInSender_ClaimSwapCoop,
 			},
 		},
 		State_WaitCsv: {
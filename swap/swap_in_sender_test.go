@@ -149,7 +149,7 @@ func getSwapServices(t *testing.T, msgChan chan PeerMessage) *SwapServices {
 	require.NoError(t, err)
 	premium, err := premium.NewSetting(db)
 	require.NoError(t, err)
-	swapServices := NewSwapServices(store, reqSwapsStore, lc, messenger, mmgr, policy, true, chain, chain, chain, true, chain, chain, chain, premium)
+	swapServices := NewSwapServices(store, reqSwapsStore, nil, lc, messenger, mmgr, policy, true, chain, chain, chain, true, chain, chain, chain, premium)
 	swapServices.toService = &timeOutDummy{}
 	return swapServices
 }
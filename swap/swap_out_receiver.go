@@ -89,6 +89,7 @@ func getSwapOutReceiverStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedCoop,
 				Event_ActionFailed:    State_WaitCsv,
+				Event_OnClaimBatched:  State_SwapOutReceiver_ClaimSwapCoop,
 			},
 		},
 		State_WaitCsv: {
@@ -103,6 +104,7 @@ func getSwapOutReceiverStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedCsv,
 				Event_OnRetry:         State_SwapOutReceiver_ClaimSwapCsv,
+				Event_OnClaimBatched:  State_SwapOutReceiver_ClaimSwapCsv,
 			},
 		},
 		State_SendCancel: {
@@ -99,6 +99,7 @@ func getSwapOutSenderStates() States {
 			Events: Events{
 				Event_ActionFailed:    State_SwapOutSender_SendPrivkey,
 				Event_ActionSucceeded: State_SwapOutSender_ClaimSwap,
+				Event_OnTxUnconfirmed: State_SwapOutSender_AwaitTxConfirmation,
 			},
 		},
 		State_SwapOutSender_ClaimSwap: {
@@ -106,6 +107,7 @@ func getSwapOutSenderStates() States {
 			Events: Events{
 				Event_ActionSucceeded: State_ClaimedPreimage,
 				Event_OnRetry:         State_SwapOutSender_ClaimSwap,
+				Event_OnClaimBatched:  State_SwapOutSender_ClaimSwap,
 				Event_OnTimeout:       State_SwapOutSender_SendPrivkey,
 			},
 		},
@@ -3,7 +3,9 @@ package swap
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
+	"sync"
 	"testing"
 
 	"github.com/elementsproject/peerswap/lightning"
@@ -209,11 +211,18 @@ func Test_AbortCsvClaim(t *testing.T) {
 }
 
 type dummyStore struct {
+	sync.Mutex
 	dataMap map[string]*SwapStateMachine
 }
 
 func (d *dummyStore) ListAll() ([]*SwapStateMachine, error) {
-	panic("implement me")
+	d.Lock()
+	defer d.Unlock()
+	var swaps []*SwapStateMachine
+	for _, swap := range d.dataMap {
+		swaps = append(swaps, swap)
+	}
+	return swaps, nil
 }
 
 func (d *dummyStore) ListAllByPeer(peer string) ([]*SwapStateMachine, error) {
@@ -221,11 +230,26 @@ func (d *dummyStore) ListAllByPeer(peer string) ([]*SwapStateMachine, error) {
 }
 
 func (d *dummyStore) UpdateData(data *SwapStateMachine) error {
+	d.Lock()
+	defer d.Unlock()
 	d.dataMap[data.SwapId.String()] = data
 	return nil
 }
 
+func (d *dummyStore) GetByIdempotencyKey(key string) (*SwapStateMachine, error) {
+	d.Lock()
+	defer d.Unlock()
+	for _, swap := range d.dataMap {
+		if swap.Role == SWAPROLE_SENDER && swap.Data != nil && swap.Data.IdempotencyKey == key {
+			return swap, nil
+		}
+	}
+	return nil, ErrDataNotAvailable
+}
+
 func (d *dummyStore) GetData(id string) (*SwapStateMachine, error) {
+	d.Lock()
+	defer d.Unlock()
 	if _, ok := d.dataMap[id]; !ok {
 		return nil, ErrDataNotAvailable
 	}
@@ -259,6 +283,7 @@ type dummyLightningClient struct {
 	decodeAmount           uint64
 	decodeFinalCLTV        int64
 	rebalanceCalled        int
+	rebalanceChannels      []string
 	rebalanceMaxTotalCLTV  uint32
 	recoverPaymentPreimage string
 	recoverPaymentError    error
@@ -269,6 +294,16 @@ type dummyLightningClient struct {
 
 	spendableMsatCalled  int
 	receivableMsatCalled int
+	// spendableMsat and receivableMsat are returned if set, otherwise the
+	// balances are unlimited.
+	spendableMsat  uint64
+	receivableMsat uint64
+	// channelBalanceMsat overrides both balances per channel.
+	channelBalanceMsat map[string]uint64
+	// probeFailures makes the probe fail on the channel with the reason.
+	probeFailures map[string]string
+	// channelPeers maps channels to their remote peer.
+	channelPeers map[string]string
 }
 
 func (d *dummyLightningClient) Implementation() string {
@@ -277,14 +312,33 @@ func (d *dummyLightningClient) Implementation() string {
 
 func (d *dummyLightningClient) SpendableMsat(scid string) (uint64, error) {
 	d.spendableMsatCalled++
+	if b, ok := d.channelBalanceMsat[scid]; ok {
+		return b, nil
+	}
+	if d.spendableMsat != 0 {
+		return d.spendableMsat, nil
+	}
 	return math.MaxUint64, nil
 }
 
 func (d *dummyLightningClient) ReceivableMsat(scid string) (uint64, error) {
 	d.receivableMsatCalled++
+	if b, ok := d.channelBalanceMsat[scid]; ok {
+		return b, nil
+	}
+	if d.receivableMsat != 0 {
+		return d.receivableMsat, nil
+	}
 	return math.MaxUint64, nil
 }
 
+func (d *dummyLightningClient) ChannelPeer(scid string) (string, error) {
+	if peer, ok := d.channelPeers[scid]; ok {
+		return peer, nil
+	}
+	return "", fmt.Errorf("could not find a channel with scid: %s", scid)
+}
+
 func (d *dummyLightningClient) CanSpend(amtMsat uint64) error {
 	d.canSpendCalled++
 	return d.canSpendError
@@ -295,10 +349,11 @@ func (d *dummyLightningClient) AddPaymentNotifier(swapId string, payreq string,
 
 func (d *dummyLightningClient) RebalancePayment(
 	payreq string,
-	channel string,
+	channels []string,
 	maxTotalCLTVDelta uint32,
 ) (preimage string, err error) {
 	d.rebalanceCalled++
+	d.rebalanceChannels = channels
 	d.rebalanceMaxTotalCLTV = maxTotalCLTVDelta
 	if d.failpayment {
 		return "", errors.New("payment failed")
@@ -381,6 +436,9 @@ func (d *dummyLightningClient) PayInvoiceViaChannel(payreq, scid string) (preima
 }
 
 func (d *dummyLightningClient) ProbePayment(scid string, amountMsat uint64) (bool, string, error) {
+	if reason, ok := d.probeFailures[scid]; ok {
+		return false, reason, nil
+	}
 	return true, "", nil
 }
 
@@ -393,6 +451,8 @@ type dummyPolicy struct {
 
 	newSwapsAllowedCalled int
 	newSwapsAllowedReturn bool
+
+	getConfirmationsReturn uint32
 }
 
 func (d *dummyPolicy) NewSwapsAllowed() bool {
@@ -430,13 +490,33 @@ func (d *dummyPolicy) ShouldPayFee(swapAmount, feeAmount uint64, peerId, channel
 	return true
 }
 
+func (d *dummyPolicy) GetConfirmations(asset string, amountSat uint64) uint32 {
+	return d.getConfirmationsReturn
+}
+
 type dummyChain struct {
 	txConfirmedFunc func(swapId string, txHex string, err error) error
 	csvPassedFunc   func(swapId string) error
+	unconfirmedFunc func(swapId string) error
 	balance         uint64
+	// onValidateTx is called when the opening tx is validated.
+	onValidateTx func()
 
 	calledGetCSVHeight int64
 	returnGetCSVHeight uint32
+
+	sync.Mutex
+	batchOpenings   []int
+	batchBroadcasts []string
+	batchClaims     []int
+	failBatchClaims bool
+	// blockHeight is the block height that is returned if it is set.
+	blockHeight uint32
+
+	waitConfirmationsParam uint32
+
+	failFundingBroadcast bool
+	knownFundingTx       bool
 }
 
 func (d *dummyChain) StartWatchingTxs() error {
@@ -486,8 +566,8 @@ func (d *dummyChain) CreateCoopSpendingTransaction(swapParams *OpeningParams, cl
 	return getRandom32ByteHexString(), "txhex", "addr", nil
 }
 
-func (d *dummyChain) AddWaitForConfirmationTx(swapID, txID string, vout, startingHeight, paymentWindow uint32, wantscript []byte) {
-
+func (d *dummyChain) AddWaitForConfirmationTx(swapID, txID string, vout, startingHeight, paymentWindow, confirmations uint32, wantscript []byte) {
+	d.waitConfirmationsParam = confirmations
 }
 
 func (d *dummyChain) SetLabel(txID, address, label string) error {
@@ -499,6 +579,11 @@ func (d *dummyChain) AddWaitForCsvTx(swapID, txID string, vout, startingHeight,
 }
 
 func (d *dummyChain) GetBlockHeight() (uint32, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.blockHeight > 0 {
+		return d.blockHeight, nil
+	}
 	return 1, nil
 }
 
@@ -514,10 +599,65 @@ func (d *dummyChain) CreateOpeningTransaction(swapParams *OpeningParams) (unprep
 	return "txhex", "address", getRandom32ByteHexString(), 0, 0, nil
 }
 
+func (d *dummyChain) CreateBatchOpeningTransaction(swapParams []*OpeningParams) (txHex, txId string, fee uint64, outputs []*BatchOutput, err error) {
+	d.Lock()
+	d.batchOpenings = append(d.batchOpenings, len(swapParams))
+	d.Unlock()
+	for i := range swapParams {
+		outputs = append(outputs, &BatchOutput{Address: fmt.Sprintf("address%d", i), Vout: uint32(i)})
+	}
+	return "txhex", getRandom32ByteHexString(), 0, outputs, nil
+}
+
+func (d *dummyChain) BroadcastBatchOpeningTransaction(txHex, txId string) (string, error) {
+	d.Lock()
+	defer d.Unlock()
+	d.batchBroadcasts = append(d.batchBroadcasts, txId)
+	return txHex, nil
+}
+
+func (d *dummyChain) getBatchBroadcasts() []string {
+	d.Lock()
+	defer d.Unlock()
+	return append([]string(nil), d.batchBroadcasts...)
+}
+
+func (d *dummyChain) getBatchOpenings() []int {
+	d.Lock()
+	defer d.Unlock()
+	return append([]int(nil), d.batchOpenings...)
+}
+
+func (d *dummyChain) CreateBatchSpendingTransaction(claims []*BatchClaim, claimAddress string) (txId, txHex, address string, err error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.failBatchClaims {
+		return "", "", "", errors.New("batch claim failed")
+	}
+	d.batchClaims = append(d.batchClaims, len(claims))
+	return getRandom32ByteHexString(), "txhex", "addr", nil
+}
+
+func (d *dummyChain) setBlockHeight(height uint32) {
+	d.Lock()
+	defer d.Unlock()
+	d.blockHeight = height
+}
+
+func (d *dummyChain) getBatchClaims() []int {
+	d.Lock()
+	defer d.Unlock()
+	return append([]int(nil), d.batchClaims...)
+}
+
 func (d *dummyChain) AddCsvCallback(f func(swapId string) error) {
 	d.csvPassedFunc = f
 }
 
+func (d *dummyChain) AddUnconfirmedCallback(f func(swapId string) error) {
+	d.unconfirmedFunc = f
+}
+
 func (d *dummyChain) NewAddress() (string, error) {
 	return "addr", nil
 }
@@ -526,6 +666,143 @@ func (d *dummyChain) AddConfirmationCallback(f func(swapId string, txHex string,
 	d.txConfirmedFunc = f
 }
 
+func (d *dummyChain) ValidateAddress(address string) error {
+	if address == "invalid" {
+		return errors.New("invalid address")
+	}
+	return nil
+}
+
+func (d *dummyChain) CreateFundingPsbt(params *OpeningParams) (string, string, error) {
+	return "psbt", "address", nil
+}
+
+func (d *dummyChain) ExtractFundingTx(signed string, params *OpeningParams) (string, uint32, error) {
+	if signed != "signed" {
+		return "", 0, errors.New("not signed")
+	}
+	return "txhex", 1, nil
+}
+
+func (d *dummyChain) BroadcastFundingTx(txHex string) (string, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.failFundingBroadcast {
+		return "", errors.New("insufficient fee")
+	}
+	return getRandom32ByteHexString(), nil
+}
+
+func (d *dummyChain) setFailFundingBroadcast(fail bool) {
+	d.Lock()
+	defer d.Unlock()
+	d.failFundingBroadcast = fail
+}
+
+func (d *dummyChain) IsTxKnown(txId string, vout uint32) (bool, error) {
+	d.Lock()
+	defer d.Unlock()
+	return d.knownFundingTx, nil
+}
+
+func (d *dummyChain) setKnownFundingTx(known bool) {
+	d.Lock()
+	defer d.Unlock()
+	d.knownFundingTx = known
+}
+
+// dummyUtxo is the only utxo of the dummy chain wallet.
+const dummyUtxo = "0000000000000000000000000000000000000000000000000000000000000001:0"
+
+func (d *dummyChain) ValidateCoinSelection(selection *CoinSelection) error {
+	for _, u := range selection.Utxos {
+		if u != dummyUtxo {
+			return fmt.Errorf("unknown utxo %s", u)
+		}
+	}
+	return nil
+}
+
+func (d *dummyChain) ValidateFeeRate(feeRate *FeeRate) error {
+	if feeRate.SatPerVByte > 0 && feeRate.SatPerVByte < 1 {
+		return errors.New("below fee floor")
+	}
+	return nil
+}
+
 func (d *dummyChain) ValidateTx(swapParams *OpeningParams, openingTxId string) (bool, error) {
+	if d.onValidateTx != nil {
+		d.onValidateTx()
+	}
 	return true, nil
 }
+
+func Test_History(t *testing.T) {
+	swapAmount := uint64(100000)
+	initiator, peer, takerpubkeyhash, _, chanId := getTestParams()
+	FeeInvoice := "err"
+	msgChan := make(chan PeerMessage)
+
+	swapServices := getSwapServices(t, msgChan)
+	swapFSM := newSwapOutSenderFSM(swapServices, initiator, peer)
+
+	_, err := swapFSM.SendEvent(Event_OnSwapOutStarted, &SwapOutRequestMessage{
+		Amount:          swapAmount,
+		Scid:            chanId,
+		SwapId:          swapFSM.SwapId,
+		Pubkey:          takerpubkeyhash,
+		Network:         "mainnet",
+		ProtocolVersion: PEERSWAP_PROTOCOL_VERSION,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-msgChan
+	_, err = swapFSM.SendEvent(Event_OnFeeInvoiceReceived, &SwapOutAgreementMessage{Payreq: FeeInvoice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-msgChan
+
+	expected := []StateTransition{
+		{From: "", Event: Event_OnSwapOutStarted, To: State_SwapOutSender_CreateSwap},
+		{From: State_SwapOutSender_CreateSwap, Event: Event_ActionSucceeded, To: State_SwapOutSender_SendRequest},
+		{From: State_SwapOutSender_SendRequest, Event: Event_ActionSucceeded, To: State_SwapOutSender_AwaitAgreement},
+		{From: State_SwapOutSender_AwaitAgreement, Event: Event_OnInvalid_Message, To: State_SendCancel},
+		{From: State_SendCancel, Event: Event_ActionSucceeded, To: State_SwapCanceled},
+	}
+	assert.Len(t, swapFSM.History, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, e.From, swapFSM.History[i].From)
+		assert.Equal(t, e.Event, swapFSM.History[i].Event)
+		assert.Equal(t, e.To, swapFSM.History[i].To)
+		assert.NotZero(t, swapFSM.History[i].Timestamp)
+	}
+	assert.NotEmpty(t, swapFSM.History[3].Error)
+
+	// The history is persisted together with the swap.
+	stored, err := swapServices.swapStore.GetData(swapFSM.SwapId.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, swapFSM.History, stored.History)
+}
+
+func Test_HistoryRetries(t *testing.T) {
+	swapFSM := &SwapStateMachine{Data: &SwapData{}}
+
+	swapFSM.recordTransition(State_SwapOutSender_AwaitAgreement, Event_ActionSucceeded, State_SwapOutSender_PayFeeInvoice)
+	for i := 0; i < 20; i++ {
+		swapFSM.recordTransition(State_SwapOutSender_PayFeeInvoice, Event_OnRetry, State_SwapOutSender_PayFeeInvoice)
+	}
+	// Consecutive retries of the same transition are collapsed.
+	assert.Len(t, swapFSM.History, 2)
+	assert.Equal(t, Event_OnRetry, swapFSM.History[1].Event)
+
+	for i := 0; i < 2*maxHistory; i++ {
+		swapFSM.recordTransition(State_SwapOutSender_PayFeeInvoice, Event_ActionFailed, State_SendCancel)
+	}
+	// Only the latest transitions are kept.
+	assert.Len(t, swapFSM.History, maxHistory)
+	assert.Equal(t, Event_ActionFailed, swapFSM.History[0].Event)
+}
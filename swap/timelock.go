@@ -15,11 +15,21 @@ const (
 	liquidPaymentWindow     uint32 = 60
 	liquidInvoiceFinalCLTV  uint64 = 29
 	liquidMaxTotalCLTVDelta uint32 = 32
+
+	// bitcoinConfirmations and liquidConfirmations are the confirmations
+	// that an opening tx needs if the policy does not set any.
+	bitcoinConfirmations uint32 = 3
+	liquidConfirmations  uint32 = 2
+	// maxRequiredConfirmations bounds the confirmations that peers agree
+	// on for a swap.
+	maxRequiredConfirmations uint32 = 12
 )
 
 // timelockPolicy contains the chain-specific limits derived from the swap's
 // protocol version. Values are kept internal so they cannot become a second
-// negotiation surface.
+// negotiation surface. The only input from the peers are the agreed
+// confirmations, which extend the payment window by at most
+// maxRequiredConfirmations blocks.
 type timelockPolicy struct {
 	CSV                  uint32
 	PaymentWindow        uint32
@@ -67,8 +77,10 @@ func (s *SwapData) getTimelockPolicy() (timelockPolicy, error) {
 			}, nil
 		case PEERSWAP_PROTOCOL_VERSION:
 			return timelockPolicy{
-				CSV:                  liquidSwapCSV,
-				PaymentWindow:        liquidPaymentWindow,
+				CSV: liquidSwapCSV,
+				// The window is extended by the blocks that the swap
+				// waits for confirmations beyond the default.
+				PaymentWindow:        liquidPaymentWindow + s.getExtraConfirmations(),
 				InvoiceFinalCLTV:     liquidInvoiceFinalCLTV,
 				MaxTotalCLTVDelta:    liquidMaxTotalCLTVDelta,
 				AllowNewClaimPayment: true,
@@ -80,3 +92,40 @@ func (s *SwapData) getTimelockPolicy() (timelockPolicy, error) {
 		return timelockPolicy{}, fmt.Errorf("unsupported chain: %s", s.GetChain())
 	}
 }
+
+// defaultConfirmations returns the confirmations that an opening tx on the
+// chain needs if the policy does not set any.
+func defaultConfirmations(chain string) uint32 {
+	if chain == l_btc_chain {
+		return liquidConfirmations
+	}
+	return bitcoinConfirmations
+}
+
+// blockIntervalSeconds returns the expected time between two blocks of the
+// chain.
+func blockIntervalSeconds(chain string) uint64 {
+	if chain == l_btc_chain {
+		return 60
+	}
+	return 600
+}
+
+// GetRequiredConfirmations returns the confirmations that the opening tx
+// needs before the claim invoice is paid.
+func (s *SwapData) GetRequiredConfirmations() uint32 {
+	if s.RequiredConfirmations == 0 {
+		return defaultConfirmations(s.GetChain())
+	}
+	return s.RequiredConfirmations
+}
+
+// getExtraConfirmations returns the confirmations that the swap waits for
+// beyond the default of the chain.
+func (s *SwapData) getExtraConfirmations() uint32 {
+	confs, def := s.GetRequiredConfirmations(), defaultConfirmations(s.GetChain())
+	if confs <= def {
+		return 0
+	}
+	return confs - def
+}
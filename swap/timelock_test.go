@@ -99,6 +99,35 @@ func TestTimelockPolicyRejectsUnknownVersion(t *testing.T) {
 	assertError(t, err, "unsupported protocol version: 5")
 }
 
+func TestRequiredConfirmations(t *testing.T) {
+	liquid := swapDataForPolicy(l_btc_chain, PEERSWAP_PROTOCOL_VERSION)
+	assertEqual(t, liquidConfirmations, liquid.GetRequiredConfirmations())
+	assertEqual(t, uint64(3600), liquid.GetInvoiceExpiry())
+
+	// Confirmations beyond the default extend the payment window and the
+	// invoice expiry by one block each.
+	liquid.RequiredConfirmations = 12
+	policy, err := liquid.getTimelockPolicy()
+	assertNoError(t, err)
+	assertEqual(t, uint32(70), policy.PaymentWindow)
+	assertEqual(t, uint64(3600+10*60), liquid.GetInvoiceExpiry())
+
+	// Fewer confirmations than the default keep the window.
+	liquid.RequiredConfirmations = 1
+	policy, err = liquid.getTimelockPolicy()
+	assertNoError(t, err)
+	assertEqual(t, uint32(60), policy.PaymentWindow)
+	assertEqual(t, uint64(3600), liquid.GetInvoiceExpiry())
+
+	bitcoin := swapDataForPolicy(btc_chain, PEERSWAP_PROTOCOL_VERSION)
+	assertEqual(t, bitcoinConfirmations, bitcoin.GetRequiredConfirmations())
+	bitcoin.RequiredConfirmations = 6
+	policy, err = bitcoin.getTimelockPolicy()
+	assertNoError(t, err)
+	assertEqual(t, uint32(504), policy.PaymentWindow)
+	assertEqual(t, uint64(3600*24+3*600), bitcoin.GetInvoiceExpiry())
+}
+
 func TestPaymentWindowBoundary(t *testing.T) {
 	data := swapDataForPolicy(l_btc_chain, PEERSWAP_PROTOCOL_VERSION)
 	data.StartingBlockHeight = 100
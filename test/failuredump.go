@@ -1,9 +1,17 @@
 package test
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/elementsproject/glightning/glightning"
+	"github.com/elementsproject/peerswap/clightning"
+	"github.com/elementsproject/peerswap/peerswaprpc"
+	"github.com/elementsproject/peerswap/swap"
 	"github.com/elementsproject/peerswap/testframework"
 )
 
@@ -76,7 +84,11 @@ func WithPeerSwapd(p *PeerSwapd) DumpOption {
 		if p == nil || p.DaemonProcess == nil {
 			return
 		}
-		*ps = append(*ps, tailableProcess{p: p.DaemonProcess, lines: defaultLines})
+		*ps = append(*ps, tailableProcess{
+			p:       p.DaemonProcess,
+			lines:   defaultLines,
+			history: peerswapdSwapHistory(p),
+		})
 	}
 }
 
@@ -87,7 +99,11 @@ func WithPeerSwapds(peers ...*PeerSwapd) DumpOption {
 			if p == nil || p.DaemonProcess == nil {
 				continue
 			}
-			*ps = append(*ps, tailableProcess{p: p.DaemonProcess, lines: defaultLines})
+			*ps = append(*ps, tailableProcess{
+				p:       p.DaemonProcess,
+				lines:   defaultLines,
+				history: peerswapdSwapHistory(p),
+			})
 		}
 	}
 }
@@ -109,9 +125,10 @@ func WithCLightningNodes(nodes []*CLightningNodeWithLiquid, filters []string) Du
 				filter = filters[i]
 			}
 			*ps = append(*ps, tailableProcess{
-				p:      n.DaemonProcess,
-				filter: filter,
-				lines:  defaultLines,
+				p:       n.DaemonProcess,
+				filter:  filter,
+				lines:   defaultLines,
+				history: clnSwapHistory(n.Rpc),
 			})
 		}
 	}
@@ -129,20 +146,23 @@ func WithLightningNodes(lightningds []testframework.LightningNode) DumpOption {
 			switch n := node.(type) {
 			case *CLightningNodeWithLiquid:
 				*ps = append(*ps, tailableProcess{
-					p:      n.DaemonProcess,
-					filter: filter,
-					lines:  defaultLines,
+					p:       n.DaemonProcess,
+					filter:  filter,
+					lines:   defaultLines,
+					history: clnSwapHistory(n.Rpc),
 				})
 			case *LndNodeWithLiquid:
 				*ps = append(*ps, tailableProcess{
-					p:     n.DaemonProcess,
-					lines: defaultLines,
+					p:       n.DaemonProcess,
+					lines:   defaultLines,
+					history: peerswapdSwapHistory(n.ps),
 				})
 			case *testframework.CLightningNode:
 				*ps = append(*ps, tailableProcess{
-					p:      n.DaemonProcess,
-					filter: filter,
-					lines:  defaultLines,
+					p:       n.DaemonProcess,
+					filter:  filter,
+					lines:   defaultLines,
+					history: clnSwapHistory(n.Rpc),
 				})
 			case *testframework.LndNode:
 				*ps = append(*ps, tailableProcess{
@@ -167,7 +187,12 @@ func WithCLightnings(nodes []*testframework.CLightningNode) DumpOption {
 			if n == nil {
 				continue
 			}
-			*ps = append(*ps, tailableProcess{p: n.DaemonProcess, filter: filter, lines: defaultLines})
+			*ps = append(*ps, tailableProcess{
+				p:       n.DaemonProcess,
+				filter:  filter,
+				lines:   defaultLines,
+				history: clnSwapHistory(n.Rpc),
+			})
 		}
 	}
 }
@@ -197,7 +222,72 @@ func WithLndNodesWithLiquid(nodes []*LndNodeWithLiquid) DumpOption {
 			if n == nil {
 				continue
 			}
-			*ps = append(*ps, tailableProcess{p: n.DaemonProcess, lines: defaultLines})
+			*ps = append(*ps, tailableProcess{
+				p:       n.DaemonProcess,
+				lines:   defaultLines,
+				history: peerswapdSwapHistory(n.ps),
+			})
+		}
+	}
+}
+
+// peerswapdSwapHistory returns a function that fetches the state transition
+// history of all swaps known to the peerswapd.
+func peerswapdSwapHistory(p *PeerSwapd) func() string {
+	return func() string {
+		if p == nil || p.PeerswapClient == nil {
+			return "peerswapd client not available"
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		res, err := p.PeerswapClient.ListSwaps(ctx, &peerswaprpc.ListSwapsRequest{})
+		if err != nil {
+			return fmt.Sprintf("could not list swaps: %v", err)
 		}
+		var b strings.Builder
+		for _, s := range res.GetSwaps() {
+			h, err := p.PeerswapClient.GetSwapHistory(ctx, &peerswaprpc.GetSwapHistoryRequest{SwapId: s.GetId()})
+			if err != nil {
+				fmt.Fprintf(&b, "[%s] could not get history: %v\n", s.GetId(), err)
+				continue
+			}
+			fmt.Fprintf(&b, "[%s] %s %s (%s)\n", s.GetId(), s.GetType(), s.GetRole(), s.GetState())
+			for _, t := range h.GetHistory() {
+				writeTransition(&b, t.GetTimestamp(), t.GetFromState(), t.GetEvent(), t.GetToState(), t.GetError())
+			}
+		}
+		return b.String()
+	}
+}
+
+// clnSwapHistory returns a function that fetches the state transition history
+// of all swaps known to the peerswap plugin.
+func clnSwapHistory(rpc *glightning.Lightning) func() string {
+	return func() string {
+		if rpc == nil {
+			return "lightning rpc not available"
+		}
+		var swaps []*swap.SwapStateMachine
+		err := rpc.Request(&clightning.ListSwaps{DetailedPrint: true}, &swaps)
+		if err != nil {
+			return fmt.Sprintf("could not list swaps: %v", err)
+		}
+		var b strings.Builder
+		for _, s := range swaps {
+			fmt.Fprintf(&b, "[%s] %s %s (%s)\n", s.SwapId.String(), s.Type.String(), s.Role.String(), s.Current)
+			for _, t := range s.History {
+				writeTransition(&b, t.Timestamp, string(t.From), string(t.Event), string(t.To), t.Error)
+			}
+		}
+		return b.String()
+	}
+}
+
+func writeTransition(b *strings.Builder, timestamp int64, from, event, to, errMsg string) {
+	fmt.Fprintf(b, "  %s %s --%s--> %s", time.Unix(timestamp, 0).UTC().Format(time.RFC3339), from, event, to)
+	if errMsg != "" {
+		fmt.Fprintf(b, " error: %s", errMsg)
 	}
+	b.WriteString("\n")
 }
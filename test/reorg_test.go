@@ -0,0 +1,92 @@
+package test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/elementsproject/peerswap/clightning"
+	"github.com/elementsproject/peerswap/testframework"
+)
+
+// Test_ClnCln_OpeningTxReorg checks that a reorg that unconfirms the opening
+// tx pauses the claim payment of the taker. The maker node is stopped so that
+// the claim payment can not succeed before the confirming block is
+// invalidated. The taker pays the claim invoice after the tx was confirmed
+// again.
+func Test_ClnCln_OpeningTxReorg(t *testing.T) {
+	IsIntegrationTest(t)
+	t.Parallel()
+
+	require := requireNew(t)
+
+	bitcoind, lightningds, scid := clnclnSetup(t, uint64(math.Pow10(6)))
+	DumpOnFailure(t, WithBitcoin(bitcoind), WithCLightnings(lightningds))
+
+	taker, maker := lightningds[0], lightningds[1]
+	origTakerBalance, err := taker.GetChannelBalanceSat(scid)
+	require.NoError(err)
+
+	go func() {
+		// We need to run this in a go routine as the Request call is blocking and sometimes does not return.
+		var response map[string]interface{}
+		if err := taker.Rpc.Request(&clightning.SwapOut{
+			SatAmt:              origTakerBalance / 2,
+			ShortChannelId:      scid,
+			Asset:               "btc",
+			PremiumLimitRatePPM: 100000,
+		}, &response); err != nil {
+			if !isAsyncSwapOutRequestCloseError(err) {
+				t.Errorf("SwapOut request failed: %v", err)
+			}
+		}
+	}()
+
+	_, err = waitForTxInMempool(t, bitcoind.RpcProxy, testframework.TIMEOUT)
+	require.NoError(err)
+	feeBalance, err := maker.GetChannelBalanceSat(scid)
+	require.NoError(err)
+
+	// Stop the maker so that the claim payment is retried.
+	require.NoError(maker.Stop())
+
+	r, err := bitcoind.Rpc.Call("getblockcount")
+	require.NoError(err)
+	height, err := r.GetInt()
+	require.NoError(err)
+	require.NoError(bitcoind.GenerateBlocks(BitcoinConfirms))
+	require.NoError(taker.DaemonProcess.WaitForLog(
+		"Event_OnTxConfirmed on State_SwapOutSender_AwaitTxConfirmation",
+		testframework.TIMEOUT,
+	))
+
+	// Invalidate the block that confirmed the opening tx.
+	r, err = bitcoind.Rpc.Call("getblockhash", height+1)
+	require.NoError(err)
+	hash, err := r.GetString()
+	require.NoError(err)
+	_, err = bitcoind.Rpc.Call("invalidateblock", hash)
+	require.NoError(err)
+	require.NoError(taker.DaemonProcess.WaitForLog(
+		"opening tx was unconfirmed by a reorg",
+		testframework.TIMEOUT,
+	))
+	require.NoError(taker.DaemonProcess.WaitForLog(
+		"Event_OnTxUnconfirmed on State_SwapOutSender_ValidateTxAndPayClaimInvoice",
+		testframework.TIMEOUT,
+	))
+
+	// Confirm the opening tx again.
+	require.NoError(maker.Run(true, true))
+	require.NoError(bitcoind.GenerateBlocks(BitcoinConfirms))
+	testframework.AssertWaitForBalanceChange(
+		t,
+		maker,
+		scid,
+		feeBalance,
+		testframework.TIMEOUT,
+	)
+	require.NoError(taker.DaemonProcess.WaitForLog(
+		"Event_ActionSucceeded on State_SwapOutSender_ValidateTxAndPayClaimInvoice",
+		testframework.TIMEOUT,
+	))
+}
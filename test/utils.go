@@ -38,6 +38,9 @@ type tailableProcess struct {
 	p      *testframework.DaemonProcess
 	lines  int
 	filter string
+	// history optionally returns the swap state transition history of the
+	// node that is printed after the logs.
+	history func() string
 }
 
 func pprintFail(fps ...tailableProcess) {
@@ -52,6 +55,10 @@ func pprintFail(fps ...tailableProcess) {
 			fmt.Printf("+++++++++++++++++++++++++++++ %s (StdErr) +++++++++++++++++++++++++++++\n", fp.p.Prefix())
 			fmt.Printf("%s\n", fp.p.StdErr.String())
 		}
+		if fp.history != nil {
+			fmt.Printf("+++++++++++++++++++++++++++++ %s (Swap History) +++++++++++++++++++++++++++++\n", fp.p.Prefix())
+			fmt.Printf("%s\n", fp.history())
+		}
 		fmt.Printf("+++++++++++++++++++++++++++++ %s (End) +++++++++++++++++++++++++++++\n", fp.p.Prefix())
 		fmt.Printf("\n")
 	}
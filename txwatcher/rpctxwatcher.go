@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elementsproject/peerswap/log"
@@ -13,6 +14,10 @@ import (
 
 var ErrCookieAuthFailed = errors.New("Authorization failed: Incorrect user or password")
 
+// reorgWatchDepth is the number of blocks that a confirmed tx is watched for
+// reorgs.
+const reorgWatchDepth = 6
+
 type BlockchainRpc interface {
 	GetBlockHeight() (uint64, error)
 	GetTxOut(txid string, vout uint32) (*TxOutResp, error)
@@ -34,29 +39,47 @@ type SwapTxInfo struct {
 	Csv                 uint32
 }
 
-type observerInfo struct {
-	cancel    context.CancelFunc
-	blockChan chan uint32
+// confirmedTxInfo is a tx that was reported as confirmed and is watched for
+// reorgs.
+type confirmedTxInfo struct {
+	TxId        string
+	TxVout      uint32
+	BlockHeight uint32
+	BlockHash   string
 }
 
-// todo zmq notifications
+type observerInfo struct {
+	cancel     context.CancelFunc
+	blockChan  chan uint32
+	txId       string
+	txSeenChan chan struct{}
+}
 
 // BlockchainRpcTxWatcher handles notifications of confirmed and csv-passed events
 type BlockchainRpcTxWatcher struct {
 	observer   *CommonBlockchainObserver
 	blockchain BlockchainRpc
 
-	txCallback        func(swapId string, txHex string, err error) error
-	csvPassedCallback func(swapId string) error
+	txCallback          func(swapId string, txHex string, err error) error
+	csvPassedCallback   func(swapId string) error
+	unconfirmedCallback func(swapId string) error
 
-	txWatchList    map[string]*SwapTxInfo
-	csvtxWatchList map[string]*SwapTxInfo
-	newBlockChan   chan uint64
+	txWatchList        map[string]*SwapTxInfo
+	csvtxWatchList     map[string]*SwapTxInfo
+	confirmedWatchList map[string]*confirmedTxInfo
+	newBlockChan       chan uint64
 
 	observerLoopList map[string]observerInfo
 
 	requiredConfs uint32
 
+	zmqHashBlockAddr string
+	zmqRawTxAddr     string
+	txId             TxIdFunc
+	// zmqBlocksActive is set while new blocks are pushed by zmq.
+	zmqBlocksActive atomic.Bool
+	blockNotifyChan chan struct{}
+
 	ctx context.Context
 	sync.Mutex
 }
@@ -73,6 +96,21 @@ func (s *BlockchainRpcTxWatcher) GetBlockHeight() (uint32, error) {
 	return uint32(blockheight), nil
 }
 
+// IsTxKnown returns true if the output of the transaction is in the mempool
+// or in the utxo set.
+//
+// NOTE: This method is part of the swap.TxLookup interface.
+func (s *BlockchainRpcTxWatcher) IsTxKnown(txId string, vout uint32) (bool, error) {
+	if s.blockchain == nil {
+		return false, fmt.Errorf("missing blockchain rpc client")
+	}
+	res, err := s.blockchain.GetTxOut(txId, vout)
+	if err != nil {
+		return false, err
+	}
+	return res != nil, nil
+}
+
 //nolint:revive // Preserve the established exported API name.
 func NewBlockchainRpcTxWatcher(
 	ctx context.Context,
@@ -80,14 +118,16 @@ func NewBlockchainRpcTxWatcher(
 	requiredConfs uint32,
 ) *BlockchainRpcTxWatcher {
 	return &BlockchainRpcTxWatcher{
-		ctx:              ctx,
-		blockchain:       blockchain,
-		txWatchList:      make(map[string]*SwapTxInfo),
-		csvtxWatchList:   make(map[string]*SwapTxInfo),
-		newBlockChan:     make(chan uint64),
-		requiredConfs:    requiredConfs,
-		observerLoopList: make(map[string]observerInfo),
-		observer:         &CommonBlockchainObserver{blockchain: blockchain},
+		ctx:                ctx,
+		blockchain:         blockchain,
+		txWatchList:        make(map[string]*SwapTxInfo),
+		csvtxWatchList:     make(map[string]*SwapTxInfo),
+		confirmedWatchList: make(map[string]*confirmedTxInfo),
+		newBlockChan:       make(chan uint64),
+		blockNotifyChan:    make(chan struct{}, 1),
+		requiredConfs:      requiredConfs,
+		observerLoopList:   make(map[string]observerInfo),
+		observer:           &CommonBlockchainObserver{blockchain: blockchain},
 	}
 }
 
@@ -97,6 +137,7 @@ func (s *BlockchainRpcTxWatcher) StartWatchingTxs() error {
 		return fmt.Errorf("missing blockchain rpc client")
 	}
 
+	s.startZmq()
 	go s.StartBlockWatcher()
 	go func() error {
 		for {
@@ -104,69 +145,77 @@ func (s *BlockchainRpcTxWatcher) StartWatchingTxs() error {
 			case <-s.ctx.Done():
 				return nil
 			case nb := <-s.newBlockChan:
+				s.Lock()
 				for _, obs := range s.observerLoopList {
 					go func(height uint32) { obs.blockChan <- height }(uint32(nb))
 				}
+				s.Unlock()
 				// Todo: HandleCsvTx could also need a refresh.
 				err := s.HandleCsvTx(nb)
 				if err != nil {
 					return err
 				}
-			default:
-				time.Sleep(100 * time.Millisecond)
+				s.HandleReorgs(nb)
 			}
 		}
 	}()
 	return nil
 }
 
-// StartBlockWatcher starts listening for new blocks
+// StartBlockWatcher starts listening for new blocks. The block height is
+// polled unless new blocks are pushed by zmq.
 func (s *BlockchainRpcTxWatcher) StartBlockWatcher() error {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
 	var lastHeight uint64
 	var lastHash string
+	var lastPoll time.Time
 	logged := 0
 	for {
 		select {
 		case <-s.ctx.Done():
 			return nil
+		case <-s.blockNotifyChan:
 		case <-ticker.C:
-			nextHeight, err := s.blockchain.GetBlockHeight()
-			if err != nil {
-				if logged == 0 && err.Error() != ErrCookieAuthFailed.Error() {
-					log.Infof("block watcher: %v, %v", s.blockchain, err)
-					logged++
-				}
-				if err.Error() == ErrCookieAuthFailed.Error() {
-					log.Infof("block watcher: %v, %v", s.blockchain, err)
-					time.Sleep(1 * time.Second)
-					os.Exit(1)
-				}
+			if s.zmqBlocksActive.Load() && time.Since(lastPoll) < zmqSafetyPollInterval {
+				continue
 			}
-			nextHash, err := s.blockchain.GetBlockHash(uint32(nextHeight))
-			if err != nil {
-				if logged == 0 && err.Error() != ErrCookieAuthFailed.Error() {
-					log.Infof("block watcher: %v, %v", s.blockchain, err)
-					logged++
-				}
-				if err.Error() == ErrCookieAuthFailed.Error() {
-					log.Infof("block watcher: %v, %v", s.blockchain, err)
-					time.Sleep(1 * time.Second)
-					os.Exit(1)
-				}
+		}
+		lastPoll = time.Now()
+		nextHeight, err := s.blockchain.GetBlockHeight()
+		if err != nil {
+			if logged == 0 && err.Error() != ErrCookieAuthFailed.Error() {
+				log.Infof("block watcher: %v, %v", s.blockchain, err)
+				logged++
 			}
-			if err == nil && logged != 0 {
-				log.Infof("block watcher: reconnected to %v daemon", s.blockchain)
-				logged = 0
+			if err.Error() == ErrCookieAuthFailed.Error() {
+				log.Infof("block watcher: %v, %v", s.blockchain, err)
+				time.Sleep(1 * time.Second)
+				os.Exit(1)
 			}
-			if nextHeight > lastHeight || nextHash != lastHash {
-				lastHeight = nextHeight
-				lastHash = nextHash
-				s.newBlockChan <- nextHeight
+		}
+		nextHash, err := s.blockchain.GetBlockHash(uint32(nextHeight))
+		if err != nil {
+			if logged == 0 && err.Error() != ErrCookieAuthFailed.Error() {
+				log.Infof("block watcher: %v, %v", s.blockchain, err)
+				logged++
+			}
+			if err.Error() == ErrCookieAuthFailed.Error() {
+				log.Infof("block watcher: %v, %v", s.blockchain, err)
+				time.Sleep(1 * time.Second)
+				os.Exit(1)
 			}
 		}
+		if err == nil && logged != 0 {
+			log.Infof("block watcher: reconnected to %v daemon", s.blockchain)
+			logged = 0
+		}
+		if nextHeight > lastHeight || nextHash != lastHash {
+			lastHeight = nextHeight
+			lastHash = nextHash
+			s.newBlockChan <- nextHeight
+		}
 	}
 }
 
@@ -202,15 +251,94 @@ func (s *BlockchainRpcTxWatcher) HandleCsvTx(blockheight uint64) error {
 	return nil
 }
 
-func (l *BlockchainRpcTxWatcher) AddWaitForConfirmationTx(swapId, txId string, vout, startingBlockheight, paymentWindow uint32, _ []byte) {
+// HandleReorgs looks for confirmed transactions that were unconfirmed by a
+// reorg. A tx that was confirmed again in another block is watched further.
+func (s *BlockchainRpcTxWatcher) HandleReorgs(blockheight uint64) {
+	var unconfirmed []string
+	s.Lock()
+	for swapId, v := range s.confirmedWatchList {
+		if uint64(v.BlockHeight)+reorgWatchDepth <= blockheight {
+			// The tx is buried deep enough.
+			delete(s.confirmedWatchList, swapId)
+			continue
+		}
+		hash, err := s.blockchain.GetBlockHash(v.BlockHeight)
+		if err == nil && hash == v.BlockHash {
+			continue
+		}
+		res, err := s.blockchain.GetTxOut(v.TxId, v.TxVout)
+		if err != nil {
+			log.Infof("reorg watchlist fetchtx err: %v", err)
+			continue
+		}
+		if res != nil && res.Confirmations > 0 {
+			// The tx was confirmed in another block.
+			height := uint32(blockheight) + 1 - res.Confirmations
+			hash, err := s.blockchain.GetBlockHash(height)
+			if err != nil {
+				log.Infof("reorg watchlist blockhash err: %v", err)
+				continue
+			}
+			v.BlockHeight = height
+			v.BlockHash = hash
+			continue
+		}
+		log.Infof("tx %s of swap %s was unconfirmed by a reorg", v.TxId, swapId)
+		delete(s.confirmedWatchList, swapId)
+		unconfirmed = append(unconfirmed, swapId)
+	}
+	callback := s.unconfirmedCallback
+	s.Unlock()
+
+	if callback == nil {
+		return
+	}
+	for _, swapId := range unconfirmed {
+		// The callback waits for a running claim payment of the swap, do not
+		// block the watcher meanwhile.
+		go func(swapId string) {
+			if err := callback(swapId); err != nil {
+				log.Infof("[swapId=%s] error calling unconfirmed callback: %v", swapId, err)
+			}
+		}(swapId)
+	}
+}
+
+// watchForReorg watches the confirmed tx for reorgs that unconfirm it.
+func (l *BlockchainRpcTxWatcher) watchForReorg(swapId, txId string, vout, blockHeight uint32) {
+	hash, err := l.blockchain.GetBlockHash(blockHeight)
+	if err != nil {
+		log.Infof("[swapId=%s] could not watch tx for reorgs: %v", swapId, err)
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.confirmedWatchList[swapId] = &confirmedTxInfo{
+		TxId:        txId,
+		TxVout:      vout,
+		BlockHeight: blockHeight,
+		BlockHash:   hash,
+	}
+}
+
+// AddWaitForConfirmationTx calls the confirmation callback once the tx has
+// the given number of confirmations, or the confirmations the watcher was
+// created with if confirmations is 0.
+func (l *BlockchainRpcTxWatcher) AddWaitForConfirmationTx(swapId, txId string, vout, startingBlockheight, paymentWindow, confirmations uint32, _ []byte) {
 	log.Infof("adding tx watcher for %s", swapId)
+	if confirmations == 0 {
+		confirmations = l.requiredConfs
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	newBlock := make(chan uint32)
+	txSeen := make(chan struct{}, 1)
 	info := observerInfo{
-		cancel:    cancel,
-		blockChan: newBlock,
+		cancel:     cancel,
+		blockChan:  newBlock,
+		txId:       txId,
+		txSeenChan: txSeen,
 	}
-	go l.observationLoop(ctx, swapId, txId, vout, startingBlockheight, paymentWindow, newBlock)
+	go l.observationLoop(ctx, swapId, txId, vout, startingBlockheight, paymentWindow, confirmations, newBlock, txSeen)
 	l.Lock()
 	defer l.Unlock()
 	l.observerLoopList[swapId] = info
@@ -263,6 +391,7 @@ func (l *BlockchainRpcTxWatcher) TxClaimed(swaps []string) {
 	for _, v := range swaps {
 		delete(l.txWatchList, v)
 		delete(l.csvtxWatchList, v)
+		delete(l.confirmedWatchList, v)
 	}
 }
 
@@ -278,14 +407,22 @@ func (l *BlockchainRpcTxWatcher) AddCsvCallback(f func(swapId string) error) {
 	l.csvPassedCallback = f
 }
 
+func (l *BlockchainRpcTxWatcher) AddUnconfirmedCallback(f func(swapId string) error) {
+	l.Lock()
+	defer l.Unlock()
+	l.unconfirmedCallback = f
+}
+
 func (l *BlockchainRpcTxWatcher) observationLoop(
 	ctx context.Context,
 	swapId,
 	txId string,
 	vout,
 	startingHeight,
-	safetyLimit uint32,
+	safetyLimit,
+	requiredConfs uint32,
 	newBlock chan uint32,
+	txSeen <-chan struct{},
 ) {
 	// Deletes itself from the list after completion.
 	defer func() {
@@ -297,10 +434,21 @@ func (l *BlockchainRpcTxWatcher) observationLoop(
 	log.Debugf("starting chain observer for %s", swapId)
 	var lastHeight uint32
 	for {
+		var current uint32
 		select {
 		case <-ctx.Done():
 			// We got told to stop observing the chain.
 			l.callbackAndLog(swapId, "", ErrContextCanceled)
+			return
+		case <-txSeen:
+			if lastHeight == 0 {
+				// The first block is yet to come.
+				continue
+			}
+			// The tx was pushed by zmq, check it right away instead of
+			// waiting for the next block.
+			log.Debugf("tx seen for %s", swapId)
+			current = lastHeight
 		case height := <-newBlock:
 			log.Debugf(
 				"new block height=%v, starting_height=%d, safety_limit=%d for %s",
@@ -309,59 +457,60 @@ func (l *BlockchainRpcTxWatcher) observationLoop(
 				safetyLimit,
 				swapId,
 			)
-			current := height
+			current = height
 
 			if current <= lastHeight {
 				// We already got this block, wait for the next.
 				continue
 			}
 			lastHeight = current
+		}
 
-			// Check if we are outside of our safety limits. This can happen on
-			// restart. If the current block height is above our safety limits,
-			// we cancel the swap.
-			if current >= startingHeight+safetyLimit {
-				l.callbackAndLog(swapId, "", fmt.Errorf("exceeded csv limit"))
-				return
-			}
+		// Check if we are outside of our safety limits. This can happen on
+		// restart. If the current block height is above our safety limits,
+		// we cancel the swap.
+		if current >= startingHeight+safetyLimit {
+			l.callbackAndLog(swapId, "", fmt.Errorf("exceeded csv limit"))
+			return
+		}
 
-			// Check if we can find the tx
-			rawTx, firstSeen, err := l.observer.IsTxInMempoolOrRange(
-				txId, startingHeight, vout)
-			if errors.Is(err, ErrNotFound) {
-				// Tx was not found from the "Starting Blockheight" until now.
-				// Wait for the next block
-				continue
-			} else if errors.Is(err, ErrUnconfirmed) {
-				// Tx was found in mempool but is unconfirmed. Wait for the next
-				// block.
-				continue
-			} else if errors.Is(err, ErrOutOfSync) {
-				// The node can briefly report a txout best block that differs
-				// from the latest block hash during block updates. Retry on the
-				// next observation instead of canceling the swap.
-				continue
-			} else if err != nil {
-				// Something serious happened better cancel the swap.
-				l.callbackAndLog(swapId, "", err)
-				return
-			}
+		// Check if we can find the tx
+		rawTx, firstSeen, err := l.observer.IsTxInMempoolOrRange(
+			txId, startingHeight, vout)
+		if errors.Is(err, ErrNotFound) {
+			// Tx was not found from the "Starting Blockheight" until now.
+			// Wait for the next block
+			continue
+		} else if errors.Is(err, ErrUnconfirmed) {
+			// Tx was found in mempool but is unconfirmed. Wait for the next
+			// block.
+			continue
+		} else if errors.Is(err, ErrOutOfSync) {
+			// The node can briefly report a txout best block that differs
+			// from the latest block hash during block updates. Retry on the
+			// next observation instead of canceling the swap.
+			continue
+		} else if err != nil {
+			// Something serious happened better cancel the swap.
+			l.callbackAndLog(swapId, "", err)
+			return
+		}
 
-			// Check that the amount of confirmation matches with what we expect
-			// First check that we are in a safe range.
-			if firstSeen > startingHeight+safetyLimit {
-				l.callbackAndLog(swapId, "", fmt.Errorf("exceeded csv limit"))
-				return
-			}
+		// Check that the amount of confirmation matches with what we expect
+		// First check that we are in a safe range.
+		if firstSeen > startingHeight+safetyLimit {
+			l.callbackAndLog(swapId, "", fmt.Errorf("exceeded csv limit"))
+			return
+		}
 
-			// Now check if we got enough confirmations. We use first seen - 1
-			// as this is the block the tx was confirmed in the first time.
-			if current-(firstSeen-1) >= l.requiredConfs {
-				// We finally made it, enough confirmations and below the safety
-				// limit!
-				l.callbackAndLog(swapId, rawTx, nil)
-				return
-			}
+		// Now check if we got enough confirmations. We use first seen - 1
+		// as this is the block the tx was confirmed in the first time.
+		if current-(firstSeen-1) >= requiredConfs {
+			// We finally made it, enough confirmations and below the safety
+			// limit!
+			l.watchForReorg(swapId, txId, vout, firstSeen)
+			l.callbackAndLog(swapId, rawTx, nil)
+			return
 		}
 	}
 }
@@ -23,7 +23,7 @@ func Test_RpcTxWatcherConfirmations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	txWatcher.AddWaitForConfirmationTx(swapId, txId, 0, 0, 50, nil)
+	txWatcher.AddWaitForConfirmationTx(swapId, txId, 0, 0, 50, 0, nil)
 	txWatcher.AddConfirmationCallback(func(swapId, txHex string, err error) error {
 		go func() { txWatcherChan <- swapId }()
 		return nil
@@ -60,7 +60,7 @@ func Test_RpcTxWatcherOutOfSyncWaitsForNextBlock(t *testing.T) {
 	})
 
 	newBlock := make(chan uint32)
-	go txWatcher.observationLoop(context.Background(), swapId, txId, 0, 0, 100, newBlock)
+	go txWatcher.observationLoop(context.Background(), swapId, txId, 0, 0, 100, 1, newBlock, nil)
 
 	newBlock <- 1
 	select {
@@ -108,7 +108,9 @@ func Test_RpcTxWatcherRejectsAtPaymentDeadline(t *testing.T) {
 		0,
 		100,
 		60,
+		1,
 		newBlock,
+		nil,
 	)
 
 	newBlock <- 160
@@ -171,11 +173,47 @@ func Test_RpcTxWatcherStoresPerSwapCsv(t *testing.T) {
 	assert.Equal(t, uint32(10080), watcher.csvtxWatchList["current"].Csv)
 }
 
+func Test_RpcTxWatcherReorg(t *testing.T) {
+	db := &DummyBlockchain{}
+	watcher := NewBlockchainRpcTxWatcher(context.Background(), db, 2)
+	unconfirmedChan := make(chan string, 1)
+	watcher.AddUnconfirmedCallback(func(swapId string) error {
+		unconfirmedChan <- swapId
+		return nil
+	})
+
+	watcher.watchForReorg("foo", "bar", 0, 10)
+	watcher.HandleReorgs(11)
+	assert.Equal(t, uint32(10), watcher.confirmedWatchList["foo"].BlockHeight)
+
+	// The tx is confirmed again in another block.
+	db.SetBlockHash("otherhash")
+	db.SetNextTxOutResp(&TxOutResp{Confirmations: 1})
+	watcher.HandleReorgs(12)
+	assert.Equal(t, uint32(12), watcher.confirmedWatchList["foo"].BlockHeight)
+	assert.Equal(t, "otherhash", watcher.confirmedWatchList["foo"].BlockHash)
+	assert.Empty(t, unconfirmedChan)
+
+	// The tx is unconfirmed.
+	db.SetBlockHash("thirdhash")
+	db.SetNextTxOutResp(&TxOutResp{Confirmations: 0})
+	watcher.HandleReorgs(12)
+	assert.Equal(t, "foo", <-unconfirmedChan)
+	assert.NotContains(t, watcher.confirmedWatchList, "foo")
+
+	// The tx is buried deep enough.
+	watcher.watchForReorg("foo", "bar", 0, 12)
+	watcher.HandleReorgs(12 + reorgWatchDepth)
+	assert.NotContains(t, watcher.confirmedWatchList, "foo")
+}
+
 type DummyBlockchain struct {
 	sync.RWMutex
 	nextBlockheight uint64
 	nextTxOutResp   *TxOutResp
 	txOutCalls      chan struct{}
+	// blockHash is returned for every height if set.
+	blockHash string
 }
 
 func (d *DummyBlockchain) GetBlockHeightByHash(blockhash string) (uint32, error) {
@@ -183,6 +221,11 @@ func (d *DummyBlockchain) GetBlockHeightByHash(blockhash string) (uint32, error)
 }
 
 func (d *DummyBlockchain) GetBlockHash(height uint32) (string, error) {
+	d.RLock()
+	defer d.RUnlock()
+	if d.blockHash != "" {
+		return d.blockHash, nil
+	}
 	return "blockhash", nil
 }
 
@@ -196,6 +239,12 @@ func (d *DummyBlockchain) SetBlockHeight(height uint64) {
 	d.nextBlockheight = height
 }
 
+func (d *DummyBlockchain) SetBlockHash(hash string) {
+	d.Lock()
+	defer d.Unlock()
+	d.blockHash = hash
+}
+
 func (d *DummyBlockchain) SetNextTxOutResp(out *TxOutResp) {
 	d.Lock()
 	defer d.Unlock()
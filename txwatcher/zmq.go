@@ -0,0 +1,169 @@
+package txwatcher
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/log"
+	"github.com/lightninglabs/gozmq"
+	"github.com/vulpemventures/go-elements/transaction"
+)
+
+const (
+	zmqTopicHashBlock = "hashblock"
+	zmqTopicRawTx     = "rawtx"
+
+	// zmqSafetyPollInterval is the interval in which the block height is
+	// still polled while zmq notifications arrive, to catch notifications
+	// that were dropped by the daemon.
+	zmqSafetyPollInterval = 30 * time.Second
+	// zmqReconnectInterval is the time to wait before resubscribing if the
+	// daemon can not be reached.
+	zmqReconnectInterval = 10 * time.Second
+	// zmqReadTimeout is the time to wait for the remaining frames of a
+	// message.
+	zmqReadTimeout = 5 * time.Second
+)
+
+// TxIdFunc returns the txid of a raw transaction as published by the
+// zmqpubrawtx endpoint of the daemon.
+type TxIdFunc func(rawTx []byte) (string, error)
+
+// BitcoinTxId returns the txid of a raw bitcoin transaction.
+func BitcoinTxId(rawTx []byte) (string, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return "", err
+	}
+	return tx.TxHash().String(), nil
+}
+
+// ElementsTxId returns the txid of a raw elements transaction.
+func ElementsTxId(rawTx []byte) (string, error) {
+	tx, err := transaction.NewTxFromBuffer(bytes.NewBuffer(rawTx))
+	if err != nil {
+		return "", err
+	}
+	return tx.TxHash().String(), nil
+}
+
+// EnableZmq subscribes to the zmqpubhashblock and zmqpubrawtx endpoints of
+// the daemon once the txwatcher is started. New blocks are then pushed
+// instead of polled, and watched transactions are checked as soon as they
+// are seen. The block height is polled as before while the hashblock
+// endpoint can not be reached. Either address may be empty. EnableZmq must
+// be called before StartWatchingTxs.
+func (s *BlockchainRpcTxWatcher) EnableZmq(hashBlockAddr, rawTxAddr string, txId TxIdFunc) {
+	s.zmqHashBlockAddr = hashBlockAddr
+	s.zmqRawTxAddr = rawTxAddr
+	s.txId = txId
+}
+
+func (s *BlockchainRpcTxWatcher) startZmq() {
+	if s.zmqHashBlockAddr != "" {
+		go s.zmqSubscribe(s.zmqHashBlockAddr, zmqTopicHashBlock, s.handleZmqBlock)
+	}
+	if s.zmqRawTxAddr != "" && s.txId != nil {
+		go s.zmqSubscribe(s.zmqRawTxAddr, zmqTopicRawTx, s.handleZmqTx)
+	}
+}
+
+// zmqSubscribe passes every message of the topic to handle until the
+// context is done. The subscription is renewed if the daemon drops it.
+func (s *BlockchainRpcTxWatcher) zmqSubscribe(addr, topic string, handle func(body []byte)) {
+	for {
+		conn, err := gozmq.Subscribe(addr, []string{topic}, zmqReadTimeout)
+		if err != nil {
+			log.Infof("zmq: could not subscribe to %s on %s, polling instead: %v", topic, addr, err)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(zmqReconnectInterval):
+				continue
+			}
+		}
+		log.Infof("zmq: subscribed to %s on %s", topic, addr)
+
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-s.ctx.Done():
+				conn.Close()
+			case <-done:
+			}
+		}()
+		err = s.zmqReceive(conn, topic, handle)
+		close(done)
+		conn.Close()
+		if topic == zmqTopicHashBlock {
+			s.zmqBlocksActive.Store(false)
+		}
+		if err == nil {
+			return
+		}
+		log.Infof("zmq: %s subscription on %s dropped, polling instead: %v", topic, addr, err)
+	}
+}
+
+// zmqReceive reads messages until the connection is closed or fails. It
+// returns nil if the context is done.
+func (s *BlockchainRpcTxWatcher) zmqReceive(conn *gozmq.Conn, topic string, handle func(body []byte)) error {
+	for {
+		msg, err := conn.Receive(nil)
+		if err != nil {
+			if s.ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				// The connection was lost and gozmq tried to reconnect.
+				// Poll until the next message arrives.
+				if topic == zmqTopicHashBlock {
+					s.zmqBlocksActive.Store(false)
+				}
+				continue
+			}
+			return err
+		}
+		// A message consists of the topic, the body and a sequence
+		// number.
+		if len(msg) < 2 || string(msg[0]) != topic {
+			continue
+		}
+		if topic == zmqTopicHashBlock {
+			s.zmqBlocksActive.Store(true)
+		}
+		handle(msg[1])
+	}
+}
+
+func (s *BlockchainRpcTxWatcher) handleZmqBlock(_ []byte) {
+	select {
+	case s.blockNotifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (s *BlockchainRpcTxWatcher) handleZmqTx(rawTx []byte) {
+	txId, err := s.txId(rawTx)
+	if err != nil {
+		log.Debugf("zmq: could not decode tx: %v", err)
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	for swapId, obs := range s.observerLoopList {
+		if obs.txId != txId {
+			continue
+		}
+		log.Debugf("zmq: saw tx %s of swap %s", txId, swapId)
+		select {
+		case obs.txSeenChan <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package txwatcher
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RpcTxWatcherTxSeen(t *testing.T) {
+	txOutCalls := make(chan struct{}, 1)
+	db := &DummyBlockchain{
+		nextBlockheight: 5,
+		nextTxOutResp: &TxOutResp{
+			BestBlockHash: "stale-blockhash",
+		},
+		txOutCalls: txOutCalls,
+	}
+	txWatcher := NewBlockchainRpcTxWatcher(context.Background(), db, 1)
+
+	callbackErr := make(chan error, 1)
+	txWatcher.AddConfirmationCallback(func(swapId, txHex string, err error) error {
+		callbackErr <- err
+		return nil
+	})
+
+	newBlock := make(chan uint32)
+	txSeen := make(chan struct{}, 1)
+	go txWatcher.observationLoop(context.Background(), "swap", "tx", 0, 0, 100, 1, newBlock, txSeen)
+
+	newBlock <- 5
+	select {
+	case <-txOutCalls:
+	case <-time.After(time.Second):
+		t.Fatal("expected txout lookup")
+	}
+
+	// The tx is checked again when it is seen, without waiting for the
+	// next block.
+	db.SetNextTxOutResp(&TxOutResp{
+		BestBlockHash: "blockhash",
+		Confirmations: 1,
+	})
+	txSeen <- struct{}{}
+	select {
+	case err := <-callbackErr:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected confirmation callback")
+	}
+}
+
+func Test_RpcTxWatcherZmqBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := &DummyBlockchain{nextBlockheight: 1}
+	txWatcher := NewBlockchainRpcTxWatcher(ctx, db, 1)
+	txWatcher.zmqBlocksActive.Store(true)
+	go txWatcher.StartBlockWatcher()
+
+	select {
+	case height := <-txWatcher.newBlockChan:
+		assert.Equal(t, uint64(1), height)
+	case <-time.After(time.Second):
+		t.Fatal("expected initial block")
+	}
+
+	// Blocks are not polled while zmq pushes them.
+	db.SetBlockHeight(2)
+	select {
+	case height := <-txWatcher.newBlockChan:
+		t.Fatalf("unexpected block %d", height)
+	case <-time.After(time.Second):
+	}
+
+	txWatcher.handleZmqBlock(nil)
+	select {
+	case height := <-txWatcher.newBlockChan:
+		assert.Equal(t, uint64(2), height)
+	case <-time.After(time.Second):
+		t.Fatal("expected pushed block")
+	}
+
+	// Polling takes over once zmq drops.
+	txWatcher.zmqBlocksActive.Store(false)
+	db.SetBlockHeight(3)
+	select {
+	case height := <-txWatcher.newBlockChan:
+		assert.Equal(t, uint64(3), height)
+	case <-time.After(time.Second):
+		t.Fatal("expected polled block")
+	}
+}
+
+func Test_BitcoinTxId(t *testing.T) {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, [][]byte{{1}}))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+	buf := new(bytes.Buffer)
+	require.NoError(t, tx.Serialize(buf))
+
+	txId, err := BitcoinTxId(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, tx.TxHash().String(), txId)
+
+	_, err = BitcoinTxId([]byte{0x01})
+	assert.Error(t, err)
+	_, err = ElementsTxId([]byte{0x01})
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 
 	"strings"
 
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/elementsproject/glightning/gelements"
 	"github.com/elementsproject/glightning/jrpc2"
 	"github.com/elementsproject/peerswap/log"
@@ -39,6 +41,7 @@ type RpcClient interface {
 	Ping() (bool, error)
 	GetNetworkInfo() (*gelements.NetworkInfo, error)
 	DecodeRawTx(txstring string) (*gelements.Tx, error)
+	GetTxOut(txid string, vout uint32) (*gelements.TxOutResp, error)
 }
 
 // ElementsRpcWallet uses the elementsd rpc wallet
@@ -78,25 +81,53 @@ func (r *ElementsRpcWallet) FinalizeTransaction(rawTx string) (string, error) {
 // CreateAndBroadcastTransaction takes a tx with outputs and adds inputs in order to spend the tx
 func (r *ElementsRpcWallet) CreateAndBroadcastTransaction(swapParams *swap.OpeningParams,
 	asset []byte) (txid, rawTx string, fee uint64, err error) {
-	outputscript, err := address.ToOutputScript(swapParams.OpeningAddress)
+	_, rawTx, fee, err = r.CreateBatchTransaction([]*swap.OpeningParams{swapParams}, asset)
 	if err != nil {
 		return "", "", 0, err
 	}
-	sats, err := elementsutil.ValueToBytes(swapParams.Amount)
+	txid, err = r.SendRawTx(rawTx)
 	if err != nil {
 		return "", "", 0, err
 	}
-	output := transaction.NewTxOutput(asset, sats, outputscript)
-	output.Nonce = swapParams.BlindingKey.PubKey().SerializeCompressed()
+	return txid, rawTx, fee, nil
+}
 
+// CreateBatchTransaction creates a tx with an output for every swap and adds
+// inputs in order to spend the tx. The tx is signed but not broadcast.
+func (r *ElementsRpcWallet) CreateBatchTransaction(swapParams []*swap.OpeningParams,
+	asset []byte) (txid, rawTx string, fee uint64, err error) {
 	tx := transaction.NewTx(2)
-	tx.Outputs = append(tx.Outputs, output)
+	for _, params := range swapParams {
+		outputscript, err := address.ToOutputScript(params.OpeningAddress)
+		if err != nil {
+			return "", "", 0, err
+		}
+		sats, err := elementsutil.ValueToBytes(params.Amount)
+		if err != nil {
+			return "", "", 0, err
+		}
+		output := transaction.NewTxOutput(asset, sats, outputscript)
+		output.Nonce = params.BlindingKey.PubKey().SerializeCompressed()
+		tx.Outputs = append(tx.Outputs, output)
+	}
+	// elementsd keeps the preset inputs and only adds inputs if they do not
+	// cover the outputs, which is rejected below.
+	selection := swap.OpeningCoinSelection(swapParams)
+	if selection != nil {
+		for _, u := range selection.Utxos {
+			op, err := wire.NewOutPointFromString(u)
+			if err != nil {
+				return "", "", 0, err
+			}
+			tx.AddInput(transaction.NewTxInput(op.Hash[:], op.Index))
+		}
+	}
 
 	txHex, err := tx.ToHex()
 	if err != nil {
 		return "", "", 0, err
 	}
-	feerate, err := r.getFeeRate()
+	feerate, err := r.getFeeRate(swap.OpeningFeeRate(swapParams))
 	if err != nil {
 		return "", "", 0, err
 	}
@@ -109,15 +140,59 @@ func (r *ElementsRpcWallet) CreateAndBroadcastTransaction(swapParams *swap.Openi
 	if err != nil {
 		return "", "", 0, err
 	}
+	if selection != nil {
+		if err := checkFundingInputs(fundedTx.TxString, selection); err != nil {
+			return "", "", 0, err
+		}
+	}
 	finalized, err := r.FinalizeTransaction(fundedTx.TxString)
 	if err != nil {
 		return "", "", 0, err
 	}
-	txid, err = r.SendRawTx(finalized)
+	finalizedTx, err := transaction.NewTxFromHex(finalized)
 	if err != nil {
 		return "", "", 0, err
 	}
-	return txid, finalized, gelements.ConvertBtc(fundedTx.Fee), nil
+	return finalizedTx.TxHash().String(), finalized, gelements.ConvertBtc(fundedTx.Fee), nil
+}
+
+// ValidateCoinSelection returns an error if one of the selected utxos is
+// spent or unknown.
+func (r *ElementsRpcWallet) ValidateCoinSelection(selection *swap.CoinSelection) error {
+	for _, u := range selection.Utxos {
+		op, err := wire.NewOutPointFromString(u)
+		if err != nil {
+			return err
+		}
+		txOut, err := r.rpcClient.GetTxOut(op.Hash.String(), op.Index)
+		if err != nil {
+			return err
+		}
+		if txOut == nil {
+			return fmt.Errorf("utxo %s is spent or unknown", u)
+		}
+	}
+	return nil
+}
+
+// checkFundingInputs returns an error if the funded transaction spends a
+// utxo that the coin selection does not allow. elementsd can not leave
+// utxos out of its coin selection, so a transaction that spends an excluded
+// utxo is rejected instead.
+func checkFundingInputs(txHex string, selection *swap.CoinSelection) error {
+	tx, err := transaction.NewTxFromHex(txHex)
+	if err != nil {
+		return err
+	}
+	outpoints := make([]string, len(tx.Inputs))
+	for i, in := range tx.Inputs {
+		hash, err := chainhash.NewHash(in.Hash)
+		if err != nil {
+			return err
+		}
+		outpoints[i] = wire.NewOutPoint(hash, in.Index).String()
+	}
+	return selection.CheckInputs(outpoints)
 }
 
 // setupWallet checks if the swap wallet is already loaded in elementsd, if not it loads/creates it
@@ -198,9 +273,17 @@ const (
 )
 
 // getFeeRate retrieves the optimal fee rate based on the current Liquid network conditions.
-// Returns the recommended fee rate in BTC/kB
-func (r *ElementsRpcWallet) getFeeRate() (float64, error) {
-	feeRes, err := r.rpcClient.EstimateFee(LiquidTargetBlocks, "ECONOMICAL")
+// The fee rate of a swap is used instead if it is set. Returns the recommended
+// fee rate in BTC/kB
+func (r *ElementsRpcWallet) getFeeRate(swapFeeRate *swap.FeeRate) (float64, error) {
+	targetBlocks := uint32(LiquidTargetBlocks)
+	if swapFeeRate != nil {
+		if swapFeeRate.SatPerVByte > 0 {
+			return math.Max(minFeeRateBTCPerKb, swapFeeRate.SatPerVByte*kb/satsPerBTC), nil
+		}
+		targetBlocks = swapFeeRate.TargetConf
+	}
+	feeRes, err := r.rpcClient.EstimateFee(targetBlocks, "ECONOMICAL")
 	if err != nil {
 		return 0, err
 	}
@@ -219,7 +302,13 @@ const (
 )
 
 func (r *ElementsRpcWallet) GetFee(txSize int64) (uint64, error) {
-	feeRate, err := r.getFeeRate()
+	return r.GetFeeForRate(txSize, nil)
+}
+
+// GetFeeForRate returns the fee in sats for a transaction of size txSize with
+// the fee rate of a swap, or with the estimated fee rate if it is nil.
+func (r *ElementsRpcWallet) GetFeeForRate(txSize int64, swapFeeRate *swap.FeeRate) (uint64, error) {
+	feeRate, err := r.getFeeRate(swapFeeRate)
 	if err != nil {
 		return 0, fmt.Errorf("error getting fee rate: %v", err)
 	}
@@ -1,6 +1,13 @@
 package wallet
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/elementsproject/peerswap/swap"
+	"github.com/vulpemventures/go-elements/transaction"
+)
 
 func TestSatsToBTCString(t *testing.T) {
 	cases := []struct {
@@ -23,3 +30,33 @@ func TestSatsToBTCString(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckFundingInputs(t *testing.T) {
+	hash, err := chainhash.NewHashFromStr("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := transaction.NewTx(2)
+	tx.AddInput(transaction.NewTxInput(hash[:], 1))
+	txHex, err := tx.ToHex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outpoint := wire.NewOutPoint(hash, 1).String()
+
+	cases := []struct {
+		selection *swap.CoinSelection
+		wantErr   bool
+	}{
+		{nil, false},
+		{&swap.CoinSelection{Utxos: []string{outpoint}}, false},
+		{&swap.CoinSelection{Utxos: []string{wire.NewOutPoint(hash, 0).String()}}, true},
+		{&swap.CoinSelection{ExcludeUtxos: []string{outpoint}}, true},
+	}
+	for _, c := range cases {
+		err := checkFundingInputs(txHex, c.selection)
+		if (err != nil) != c.wantErr {
+			t.Errorf("checkFundingInputs(%v) = %v, wantErr %v", c.selection, err, c.wantErr)
+		}
+	}
+}
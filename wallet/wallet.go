@@ -20,8 +20,17 @@ type Wallet interface {
 	SendToAddress(string, uint64) (string, error)
 	GetBalance() (uint64, error)
 	CreateAndBroadcastTransaction(swapParams *swap.OpeningParams, asset []byte) (txid, rawTx string, fee uint64, err error)
+	// CreateBatchTransaction creates and signs a tx with an output for
+	// every swap without broadcasting it.
+	CreateBatchTransaction(swapParams []*swap.OpeningParams, asset []byte) (txid, rawTx string, fee uint64, err error)
 	SendRawTx(rawTx string) (txid string, err error)
 	GetFee(txSize int64) (uint64, error)
+	// GetFeeForRate returns the fee for a transaction of size txSize with
+	// the fee rate of a swap, or with the wallet estimate if it is nil.
+	GetFeeForRate(txSize int64, feeRate *swap.FeeRate) (uint64, error)
+	// ValidateCoinSelection returns an error if the wallet can not fund an
+	// opening transaction from the selected utxos.
+	ValidateCoinSelection(selection *swap.CoinSelection) error
 	SetLabel(txID, address, label string) error
 	Ping() (bool, error)
 }